@@ -0,0 +1,85 @@
+package client
+
+import (
+	"context"
+	"net/http"
+)
+
+type Category struct {
+	ID       uint   `json:"id"`
+	ParentID uint   `json:"parent_id,omitempty"`
+	Name     string `json:"name"`
+}
+
+// CategoryRequest nests the category under ParentID. A nil ParentID creates a top level
+// category and keeps the parent on update.
+type CategoryRequest struct {
+	Name     string `json:"name"`
+	ParentID *uint  `json:"parent_id,omitempty"`
+}
+
+// CategoryNode is a category with its subcategories.
+type CategoryNode struct {
+	ID       uint           `json:"id"`
+	Name     string         `json:"name"`
+	Children []CategoryNode `json:"children"`
+}
+
+func (c *Client) CreateCategory(ctx context.Context, req CategoryRequest) (*Category, error) {
+	var category Category
+
+	err := c.do(ctx, http.MethodPost, "/api/category", req, &category)
+	if err != nil {
+		return nil, err
+	}
+
+	return &category, nil
+}
+
+func (c *Client) GetCategory(ctx context.Context, id uint) (*Category, error) {
+	var category Category
+
+	err := c.do(ctx, http.MethodGet, pathID("/api/category", id), nil, &category)
+	if err != nil {
+		return nil, err
+	}
+
+	return &category, nil
+}
+
+func (c *Client) ListCategories(ctx context.Context) ([]Category, error) {
+	var categories []Category
+
+	err := c.do(ctx, http.MethodGet, "/api/categories", nil, &categories)
+	if err != nil {
+		return nil, err
+	}
+
+	return categories, nil
+}
+
+func (c *Client) GetCategoryTree(ctx context.Context) ([]CategoryNode, error) {
+	var tree []CategoryNode
+
+	err := c.do(ctx, http.MethodGet, "/api/categories/tree", nil, &tree)
+	if err != nil {
+		return nil, err
+	}
+
+	return tree, nil
+}
+
+func (c *Client) UpdateCategory(ctx context.Context, id uint, req CategoryRequest) (*Category, error) {
+	var category Category
+
+	err := c.do(ctx, http.MethodPut, pathID("/api/category", id), req, &category)
+	if err != nil {
+		return nil, err
+	}
+
+	return &category, nil
+}
+
+func (c *Client) DeleteCategory(ctx context.Context, id uint) error {
+	return c.do(ctx, http.MethodDelete, pathID("/api/category", id), nil, nil)
+}
@@ -0,0 +1,220 @@
+package client
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+const (
+	apiKeyHeader = "X-API-Key"
+
+	defaultTimeout = 10 * time.Second
+	defaultBackoff = 200 * time.Millisecond
+)
+
+var ErrInvalidBaseURL = errors.New("the base url is not valid")
+
+type Client struct {
+	baseURL    *url.URL
+	httpClient *http.Client
+	apiKey     string
+	token      string
+	retries    int
+	backoff    time.Duration
+}
+
+type Configuration func(c *Client) error
+
+// New creates a client for the API served at baseURL, e.g. "http://localhost:8080".
+func New(baseURL string, cfgs ...Configuration) (*Client, error) {
+	u, err := url.Parse(baseURL)
+	if err != nil || u.Scheme == "" || u.Host == "" {
+		return nil, fmt.Errorf("%w: %q", ErrInvalidBaseURL, baseURL)
+	}
+
+	c := &Client{
+		baseURL:    u,
+		httpClient: &http.Client{Timeout: defaultTimeout},
+		backoff:    defaultBackoff,
+	}
+
+	for _, cfg := range cfgs {
+		err := cfg(c)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return c, nil
+}
+
+func WithHTTPClient(hc *http.Client) Configuration {
+	return func(c *Client) error {
+		c.httpClient = hc
+		return nil
+	}
+}
+
+func WithAPIKey(key string) Configuration {
+	return func(c *Client) error {
+		c.apiKey = key
+		return nil
+	}
+}
+
+func WithBearerToken(token string) Configuration {
+	return func(c *Client) error {
+		c.token = token
+		return nil
+	}
+}
+
+// WithRetries retries idempotent requests up to n times on network errors,
+// 429 and 5xx responses, doubling the backoff after every attempt.
+func WithRetries(n int, backoff time.Duration) Configuration {
+	return func(c *Client) error {
+		c.retries = n
+		c.backoff = backoff
+		return nil
+	}
+}
+
+type response struct {
+	Status string          `json:"status"`
+	Error  string          `json:"error"`
+	Data   json.RawMessage `json:"data"`
+}
+
+func (c *Client) do(ctx context.Context, method, path string, body, out any) error {
+	var payload []byte
+
+	if body != nil {
+		var err error
+
+		payload, err = json.Marshal(body)
+		if err != nil {
+			return err
+		}
+	}
+
+	attempts := 1
+	if isIdempotent(method) {
+		attempts += c.retries
+	}
+
+	var err error
+
+	for attempt := 0; attempt < attempts; attempt++ {
+		if attempt > 0 {
+			err := sleep(ctx, c.backoff<<(attempt-1))
+			if err != nil {
+				return err
+			}
+		}
+
+		var retry bool
+
+		retry, err = c.send(ctx, method, path, payload, out)
+		if !retry {
+			return err
+		}
+	}
+
+	return err
+}
+
+func (c *Client) send(ctx context.Context, method, path string, payload []byte, out any) (bool, error) {
+	var body io.Reader
+	if payload != nil {
+		body = bytes.NewReader(payload)
+	}
+
+	// JoinPath escapes a query, so it is split off and set on the URL as is.
+	path, query, _ := strings.Cut(path, "?")
+	u := c.baseURL.JoinPath(path)
+	u.RawQuery = query
+
+	req, err := http.NewRequestWithContext(ctx, method, u.String(), body)
+	if err != nil {
+		return false, err
+	}
+
+	req.Header.Set("Accept", "application/json")
+
+	if payload != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+
+	if c.apiKey != "" {
+		req.Header.Set(apiKeyHeader, c.apiKey)
+	}
+
+	if c.token != "" {
+		req.Header.Set("Authorization", "Bearer "+c.token)
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return ctx.Err() == nil, err
+	}
+	defer resp.Body.Close()
+
+	var dto response
+
+	decodeErr := json.NewDecoder(resp.Body).Decode(&dto)
+
+	if resp.StatusCode >= http.StatusInternalServerError || resp.StatusCode == http.StatusTooManyRequests {
+		return true, newAPIError(resp.StatusCode, dto.Error)
+	}
+
+	if decodeErr != nil {
+		return false, fmt.Errorf("decode response: %w", decodeErr)
+	}
+
+	if resp.StatusCode >= http.StatusBadRequest || dto.Status == "error" {
+		return false, newAPIError(resp.StatusCode, dto.Error)
+	}
+
+	if out == nil || len(dto.Data) == 0 {
+		return false, nil
+	}
+
+	return false, json.Unmarshal(dto.Data, out)
+}
+
+func isIdempotent(method string) bool {
+	switch method {
+	case http.MethodGet, http.MethodHead, http.MethodPut, http.MethodDelete:
+		return true
+	default:
+		return false
+	}
+}
+
+func sleep(ctx context.Context, d time.Duration) error {
+	t := time.NewTimer(d)
+	defer t.Stop()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-t.C:
+		return nil
+	}
+}
+
+func pathID(prefix string, id uint) string {
+	return fmt.Sprintf("%s/%d", prefix, id)
+}
+
+func pathCode(prefix, code string) string {
+	return prefix + "/" + url.PathEscape(strings.TrimSpace(code))
+}
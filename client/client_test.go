@@ -0,0 +1,370 @@
+package client_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"git.home/alex/go-subscriptions/client"
+	"git.home/alex/go-subscriptions/internal/api"
+	"git.home/alex/go-subscriptions/internal/api/handler/subscription_handler"
+	"git.home/alex/go-subscriptions/internal/auth"
+	"git.home/alex/go-subscriptions/internal/domain/repository"
+	"git.home/alex/go-subscriptions/internal/domain/service"
+	"git.home/alex/go-subscriptions/internal/factory"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testAPIKey = "test-key"
+
+func newTestServer(t *testing.T) string {
+	t.Helper()
+
+	rf, err := factory.NewRepositoryFactory(factory.WithMemoryRepository())
+	require.NoError(t, err)
+
+	sf, err := factory.NewServiceFactory(
+		factory.WithRepositoryFactory(rf),
+		factory.WithCategoryService(),
+		factory.WithCurrencyService(),
+		factory.WithCycleService(),
+		factory.WithSubscriptionService(),
+		factory.WithTagService(),
+		factory.WithPaymentMethodService(),
+	)
+	require.NoError(t, err)
+
+	s, err := api.NewHTTPServer(
+		api.WithContext(context.Background()),
+		api.WithDefaultRouter(),
+		api.WithAuth(auth.NewAPIKeyAuthenticator(auth.APIKey{Name: "test", Key: testAPIKey, Scope: auth.ScopeAdmin})),
+		api.WithCategoryHandlers(sf.CategoryService),
+		api.WithCurrencyHandlers(sf.CurrencyService),
+		api.WithCycleHandlers(sf.CycleService),
+		api.WithSubscribeHandlers(&subscription_handler.HandlerOpts{
+			SubscriptionService: sf.SubscriptionService,
+			CategoryService:     sf.CategoryService,
+			CycleService:        sf.CycleService,
+			CurrencyService:     sf.CurrencyService,
+		}),
+		api.WithTagHandlers(sf.TagService),
+		api.WithPaymentMethodHandlers(sf.PaymentMethodService),
+	)
+	require.NoError(t, err)
+
+	ts := httptest.NewServer(s.Handler())
+	t.Cleanup(ts.Close)
+
+	return ts.URL
+}
+
+func newTestClient(t *testing.T, baseURL string) *client.Client {
+	t.Helper()
+
+	c, err := client.New(baseURL, client.WithAPIKey(testAPIKey))
+	require.NoError(t, err)
+
+	return c
+}
+
+func TestNew(t *testing.T) {
+	_, err := client.New("localhost")
+	assert.ErrorIs(t, err, client.ErrInvalidBaseURL)
+
+	_, err = client.New("http://localhost:8080")
+	assert.NoError(t, err)
+}
+
+func TestClient_Subscriptions(t *testing.T) {
+	c := newTestClient(t, newTestServer(t))
+	ctx := context.Background()
+
+	category, err := c.CreateCategory(ctx, client.CategoryRequest{Name: "Streaming"})
+	require.NoError(t, err)
+	assert.Equal(t, &client.Category{ID: 1, Name: "Streaming"}, category)
+
+	cycle, err := c.CreateCycle(ctx, client.CycleRequest{Name: "Monthly", Days: 30})
+	require.NoError(t, err)
+	assert.Equal(t, uint(30), cycle.Days)
+
+	rule := "FREQ=MONTHLY;INTERVAL=3;BYDAY=MO,TU,WE,TH,FR;BYSETPOS=1"
+	quarterly, err := c.CreateCycle(ctx, client.CycleRequest{Name: "Quarterly", Rule: rule})
+	require.NoError(t, err)
+	assert.Equal(t, rule, quarterly.Rule)
+
+	// The rule error quotes the rule text, which the error response has to escape.
+	_, err = c.CreateCycle(ctx, client.CycleRequest{Name: "Sometimes", Rule: "FREQ=SOMETIMES"})
+	assert.ErrorIs(t, err, client.ErrInvalidCycle)
+
+	currency, err := c.CreateCurrency(ctx, client.Currency{Code: "USD", Name: "US Dollar", Symbol: "$"})
+	require.NoError(t, err)
+	assert.Equal(t, "USD", currency.Code)
+
+	req := client.SubscriptionRequest{
+		Name:            "Music",
+		Price:           9.99,
+		CategoryID:      category.ID,
+		CycleID:         cycle.ID,
+		CurrencyCode:    currency.Code,
+		NextPaymentDate: client.NewDate(2024, time.May, 21),
+	}
+
+	created, err := c.CreateSubscription(ctx, req)
+	require.NoError(t, err)
+	assert.Equal(t, "2024-05-21", created.NextPaymentDate.String())
+
+	req.Price = 11.99
+
+	updated, err := c.UpdateSubscription(ctx, created.ID, req)
+	require.NoError(t, err)
+	assert.InDelta(t, 11.99, updated.Price, 0.001)
+
+	subscriptions, err := c.ListSubscriptions(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, []client.Subscription{*updated}, subscriptions)
+
+	prices, err := c.ListSubscriptionPrices(ctx, created.ID)
+	require.NoError(t, err)
+	require.Len(t, prices, 2)
+	assert.Equal(t, client.PriceChange{Price: 9.99}, prices[0])
+	assert.InDelta(t, 11.99, prices[1].Price, 0.001)
+
+	announced := client.NewDate(2999, 1, 1)
+	changed, err := c.ChangeSubscriptionPrice(ctx, created.ID, 13.99, &announced)
+	require.NoError(t, err)
+	assert.InDelta(t, 11.99, changed.Price, 0.001)
+
+	_, err = c.ChangeSubscriptionPrice(ctx, created.ID, 0, nil)
+	assert.ErrorIs(t, err, client.ErrInvalidPrice)
+
+	resume := client.NewDate(2999, 1, 1)
+	paused, err := c.PauseSubscription(ctx, created.ID, &resume)
+	require.NoError(t, err)
+	assert.Equal(t, "paused", paused.Status)
+	assert.Equal(t, &resume, paused.ResumeDate)
+
+	activated, err := c.ActivateSubscription(ctx, created.ID)
+	require.NoError(t, err)
+	assert.Equal(t, "active", activated.Status)
+
+	_, err = c.ActivateSubscription(ctx, created.ID)
+	assert.ErrorIs(t, err, client.ErrInvalidTransition)
+
+	tag, err := c.CreateTag(ctx, client.TagRequest{Name: "work"})
+	require.NoError(t, err)
+
+	tagIDs, err := c.TagSubscription(ctx, created.ID, tag.ID)
+	require.NoError(t, err)
+	assert.Equal(t, []uint{tag.ID}, tagIDs)
+
+	tagged, err := c.ListSubscriptions(ctx, tag.ID)
+	require.NoError(t, err)
+	require.Len(t, tagged, 1)
+	assert.Equal(t, []uint{tag.ID}, tagged[0].TagIDs)
+
+	tagged, err = c.ListSubscriptions(ctx, tag.ID, tag.ID+1)
+	require.NoError(t, err)
+	assert.Empty(t, tagged)
+
+	_, err = c.CreatePaymentMethod(ctx, client.PaymentMethodRequest{Label: "Visa", Type: "card", LastFour: "42"})
+	assert.ErrorIs(t, err, client.ErrInvalidPaymentMethod)
+
+	method, err := c.CreatePaymentMethod(ctx, client.PaymentMethodRequest{Label: "Visa", Type: "card", Expiry: "2020-01"})
+	require.NoError(t, err)
+	assert.True(t, method.Expired)
+
+	require.NoError(t, c.LinkSubscriptionPaymentMethod(ctx, created.ID, method.ID))
+
+	linked, err := c.GetSubscription(ctx, created.ID)
+	require.NoError(t, err)
+	assert.Equal(t, method.ID, linked.PaymentMethodID)
+
+	empty := client.Contract{StartDate: client.NewDate(2024, 1, 1), EndDate: client.NewDate(2024, 1, 1)}
+	_, err = c.SetSubscriptionContract(ctx, created.ID, empty)
+	assert.ErrorIs(t, err, client.ErrInvalidContract)
+
+	contracted, err := c.SetSubscriptionContract(ctx, created.ID, client.Contract{
+		StartDate:  client.NewDate(2024, 1, 1),
+		EndDate:    client.NewDate(2025, 1, 1),
+		NoticeDays: 30,
+		AutoRenew:  true,
+	})
+	require.NoError(t, err)
+	require.NotNil(t, contracted.Contract)
+	assert.NotNil(t, contracted.Contract.CancelBy)
+	assert.Equal(t, "active", contracted.Status)
+
+	uncontracted, err := c.RemoveSubscriptionContract(ctx, created.ID)
+	require.NoError(t, err)
+	assert.Nil(t, uncontracted.Contract)
+
+	require.NoError(t, c.DeleteSubscription(ctx, created.ID))
+
+	_, err = c.GetSubscription(ctx, created.ID)
+	assert.ErrorIs(t, err, client.ErrNotFoundSubscription)
+}
+
+func TestClient_CategoryTree(t *testing.T) {
+	baseURL := newTestServer(t)
+	c := newTestClient(t, baseURL)
+	ctx := context.Background()
+
+	parent, err := c.CreateCategory(ctx, client.CategoryRequest{Name: "Entertainment"})
+	require.NoError(t, err)
+
+	child, err := c.CreateCategory(ctx, client.CategoryRequest{Name: "Streaming", ParentID: &parent.ID})
+	require.NoError(t, err)
+	assert.Equal(t, parent.ID, child.ParentID)
+
+	_, err = c.UpdateCategory(ctx, parent.ID, client.CategoryRequest{Name: "Entertainment", ParentID: &child.ID})
+	assert.ErrorIs(t, err, client.ErrCategoryCycle)
+
+	child, err = c.UpdateCategory(ctx, child.ID, client.CategoryRequest{Name: "Video streaming"})
+	require.NoError(t, err)
+	assert.Equal(t, parent.ID, child.ParentID, "the parent is kept when it is left out")
+
+	tree, err := c.GetCategoryTree(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, []client.CategoryNode{
+		{ID: parent.ID, Name: "Entertainment", Children: []client.CategoryNode{
+			{ID: child.ID, Name: "Video streaming", Children: []client.CategoryNode{}},
+		}},
+	}, tree)
+}
+
+func TestClient_Errors(t *testing.T) {
+	baseURL := newTestServer(t)
+	c := newTestClient(t, baseURL)
+	ctx := context.Background()
+
+	_, err := c.GetCategory(ctx, 10)
+	assert.ErrorIs(t, err, client.ErrNotFoundCategory)
+
+	_, err = c.CreateCategory(ctx, client.CategoryRequest{})
+	assert.ErrorIs(t, err, client.ErrInvalidCategory)
+
+	_, err = c.GetCurrency(ctx, "EUR")
+	assert.ErrorIs(t, err, client.ErrNotFoundCurrency)
+
+	err = c.DeleteCycle(ctx, 10)
+	assert.ErrorIs(t, err, client.ErrNotFoundCycle)
+
+	unauthorized, err := client.New(baseURL)
+	require.NoError(t, err)
+
+	_, err = unauthorized.ListCategories(ctx)
+
+	var apiErr *client.APIError
+
+	require.ErrorAs(t, err, &apiErr)
+	assert.Equal(t, http.StatusUnauthorized, apiErr.StatusCode)
+	assert.ErrorIs(t, err, client.ErrNoCredentials)
+}
+
+func TestClient_Retries(t *testing.T) {
+	var calls atomic.Int32
+
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if calls.Add(1) < 3 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+
+		_, _ = w.Write([]byte(`{"status":"success","error":"","data":[{"id":1,"name":"Streaming"}]}`))
+	}))
+	defer ts.Close()
+
+	testCases := []struct {
+		name      string
+		retries   int
+		wantCalls int32
+		wantErr   bool
+	}{
+		{name: "Test no retries", retries: 0, wantCalls: 1, wantErr: true},
+		{name: "Test retries until success", retries: 3, wantCalls: 3},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			calls.Store(0)
+
+			c, err := client.New(ts.URL, client.WithRetries(tc.retries, time.Millisecond))
+			require.NoError(t, err)
+
+			categories, err := c.ListCategories(context.Background())
+			assert.Equal(t, tc.wantCalls, calls.Load())
+
+			if tc.wantErr {
+				assert.Error(t, err)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, []client.Category{{ID: 1, Name: "Streaming"}}, categories)
+		})
+	}
+}
+
+func TestClient_RetriesRespectContext(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusBadGateway)
+	}))
+	defer ts.Close()
+
+	c, err := client.New(ts.URL, client.WithRetries(5, time.Hour))
+	require.NoError(t, err)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	_, err = c.ListCycles(ctx)
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+}
+
+// The client matches errors by message, so its errors must read exactly like the server's.
+func TestClient_ErrorsMatchServer(t *testing.T) {
+	pairs := []struct{ client, server error }{
+		{client.ErrNotFoundCategory, repository.ErrNotFoundCategory},
+		{client.ErrCreateCategory, repository.ErrCreateCategory},
+		{client.ErrUpdateCategory, repository.ErrUpdateCategory},
+		{client.ErrDeleteCategory, repository.ErrDeleteCategory},
+		{client.ErrNotFoundCurrency, repository.ErrNotFoundCurrency},
+		{client.ErrCreateCurrency, repository.ErrCreateCurrency},
+		{client.ErrUpdateCurrency, repository.ErrUpdateCurrency},
+		{client.ErrDeleteCurrency, repository.ErrDeleteCurrency},
+		{client.ErrAlreadyExistsCurrency, repository.ErrAlreadyExistsCurrency},
+		{client.ErrNotFoundCycle, repository.ErrNotFoundCycle},
+		{client.ErrCreateCycle, repository.ErrCreateCycle},
+		{client.ErrUpdateCycle, repository.ErrUpdateCycle},
+		{client.ErrDeleteCycle, repository.ErrDeleteCycle},
+		{client.ErrNotFoundSubscription, repository.ErrNotFoundSubscription},
+		{client.ErrCreateSubscription, repository.ErrCreateSubscription},
+		{client.ErrUpdateSubscription, repository.ErrUpdateSubscription},
+		{client.ErrDeleteSubscription, repository.ErrDeleteSubscription},
+		{client.ErrNotFoundTag, repository.ErrNotFoundTag},
+		{client.ErrNotFoundPaymentMethod, repository.ErrNotFoundPaymentMethod},
+		{client.ErrInvalidCategory, service.ErrInvalidCategory},
+		{client.ErrCategoryCycle, service.ErrCategoryCycle},
+		{client.ErrInvalidCurrency, service.ErrInvalidCurrency},
+		{client.ErrInvalidCycle, service.ErrInvalidCycle},
+		{client.ErrInvalidSubscription, service.ErrInvalidSubscription},
+		{client.ErrInvalidPaymentDate, subscription_handler.ErrInvalidPaymentDate},
+		{client.ErrInvalidTransition, service.ErrInvalidTransition},
+		{client.ErrInvalidStatusDate, service.ErrInvalidStatusDate},
+		{client.ErrInvalidPrice, service.ErrInvalidPrice},
+		{client.ErrInvalidTag, service.ErrInvalidTag},
+		{client.ErrInvalidPaymentMethod, service.ErrInvalidPaymentMethod},
+		{client.ErrInvalidContract, service.ErrInvalidContract},
+		{client.ErrAccessDenied, service.ErrAccessDenied},
+		{client.ErrNoCredentials, auth.ErrNoCredentials},
+		{client.ErrInsufficientScope, auth.ErrInsufficientScope},
+	}
+
+	for _, pair := range pairs {
+		assert.Equal(t, pair.server.Error(), pair.client.Error())
+	}
+}
@@ -0,0 +1,65 @@
+package client
+
+import (
+	"context"
+	"net/http"
+)
+
+type Currency struct {
+	Code   string `json:"code"`
+	Name   string `json:"name"`
+	Symbol string `json:"symbol"`
+}
+
+type CurrencyUpdateRequest struct {
+	Name   string `json:"name"`
+	Symbol string `json:"symbol"`
+}
+
+func (c *Client) CreateCurrency(ctx context.Context, req Currency) (*Currency, error) {
+	var currency Currency
+
+	err := c.do(ctx, http.MethodPost, "/api/currency", req, &currency)
+	if err != nil {
+		return nil, err
+	}
+
+	return &currency, nil
+}
+
+func (c *Client) GetCurrency(ctx context.Context, code string) (*Currency, error) {
+	var currency Currency
+
+	err := c.do(ctx, http.MethodGet, pathCode("/api/currency", code), nil, &currency)
+	if err != nil {
+		return nil, err
+	}
+
+	return &currency, nil
+}
+
+func (c *Client) ListCurrencies(ctx context.Context) ([]Currency, error) {
+	var currencies []Currency
+
+	err := c.do(ctx, http.MethodGet, "/api/currencies", nil, &currencies)
+	if err != nil {
+		return nil, err
+	}
+
+	return currencies, nil
+}
+
+func (c *Client) UpdateCurrency(ctx context.Context, code string, req CurrencyUpdateRequest) (*Currency, error) {
+	var currency Currency
+
+	err := c.do(ctx, http.MethodPut, pathCode("/api/currency", code), req, &currency)
+	if err != nil {
+		return nil, err
+	}
+
+	return &currency, nil
+}
+
+func (c *Client) DeleteCurrency(ctx context.Context, code string) error {
+	return c.do(ctx, http.MethodDelete, pathCode("/api/currency", code), nil, nil)
+}
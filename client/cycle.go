@@ -0,0 +1,71 @@
+package client
+
+import (
+	"context"
+	"net/http"
+)
+
+type Cycle struct {
+	ID   uint   `json:"id"`
+	Name string `json:"name"`
+	Days uint   `json:"days"`
+	// Rule is the RFC 5545 RRULE of a cycle that doesn't repeat every Days days.
+	Rule string `json:"rule,omitempty"`
+	// Calendar cycles of 30 or 365 days repeat monthly or yearly on the same day of month.
+	Calendar bool `json:"calendar,omitempty"`
+}
+
+type CycleRequest struct {
+	Name     string `json:"name"`
+	Days     uint   `json:"days,omitempty"`
+	Rule     string `json:"rule,omitempty"`
+	Calendar bool   `json:"calendar,omitempty"`
+}
+
+func (c *Client) CreateCycle(ctx context.Context, req CycleRequest) (*Cycle, error) {
+	var cycle Cycle
+
+	err := c.do(ctx, http.MethodPost, "/api/cycle", req, &cycle)
+	if err != nil {
+		return nil, err
+	}
+
+	return &cycle, nil
+}
+
+func (c *Client) GetCycle(ctx context.Context, id uint) (*Cycle, error) {
+	var cycle Cycle
+
+	err := c.do(ctx, http.MethodGet, pathID("/api/cycle", id), nil, &cycle)
+	if err != nil {
+		return nil, err
+	}
+
+	return &cycle, nil
+}
+
+func (c *Client) ListCycles(ctx context.Context) ([]Cycle, error) {
+	var cycles []Cycle
+
+	err := c.do(ctx, http.MethodGet, "/api/cycles", nil, &cycles)
+	if err != nil {
+		return nil, err
+	}
+
+	return cycles, nil
+}
+
+func (c *Client) UpdateCycle(ctx context.Context, id uint, req CycleRequest) (*Cycle, error) {
+	var cycle Cycle
+
+	err := c.do(ctx, http.MethodPut, pathID("/api/cycle", id), req, &cycle)
+	if err != nil {
+		return nil, err
+	}
+
+	return &cycle, nil
+}
+
+func (c *Client) DeleteCycle(ctx context.Context, id uint) error {
+	return c.do(ctx, http.MethodDelete, pathID("/api/cycle", id), nil, nil)
+}
@@ -0,0 +1,101 @@
+package client
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+// The errors reported by the API, matched by their message so callers can use errors.Is.
+// The messages must stay in sync with the server's.
+var (
+	ErrNotFoundCategory      = errors.New("the category was not found in the repository")
+	ErrCreateCategory        = errors.New("failed to add the category to the repository")
+	ErrUpdateCategory        = errors.New("failed to update the category in the repository")
+	ErrDeleteCategory        = errors.New("failed to delete the category from the repository")
+	ErrNotFoundCurrency      = errors.New("the currency was not found in the repository")
+	ErrCreateCurrency        = errors.New("failed to add the currency to the repository")
+	ErrUpdateCurrency        = errors.New("failed to update the currency in the repository")
+	ErrDeleteCurrency        = errors.New("failed to delete the currency from the repository")
+	ErrAlreadyExistsCurrency = errors.New("currency already exists")
+	ErrNotFoundCycle         = errors.New("the cycle was not found in the repository")
+	ErrCreateCycle           = errors.New("failed to add the cycle to the repository")
+	ErrUpdateCycle           = errors.New("failed to update the cycle in the repository")
+	ErrDeleteCycle           = errors.New("failed to delete the cycle from the repository")
+	ErrNotFoundSubscription  = errors.New("the subscription was not found in the repository")
+	ErrCreateSubscription    = errors.New("failed to add the subscription to the repository")
+	ErrUpdateSubscription    = errors.New("failed to update the subscription in the repository")
+	ErrDeleteSubscription    = errors.New("failed to delete the subscription from the repository")
+	ErrNotFoundTag           = errors.New("the tag was not found in the repository")
+	ErrNotFoundPaymentMethod = errors.New("the payment method was not found in the repository")
+
+	ErrInvalidCategory      = errors.New("the category is not valid")
+	ErrCategoryCycle        = errors.New("the category can't be nested under itself")
+	ErrInvalidCurrency      = errors.New("the currency is not valid")
+	ErrInvalidCycle         = errors.New("the cycle is invalid")
+	ErrInvalidSubscription  = errors.New("the subscription is invalid")
+	ErrInvalidPaymentDate   = errors.New("the payment date is not valid")
+	ErrInvalidTransition    = errors.New("the subscription can't change to this state")
+	ErrInvalidStatusDate    = errors.New("the status date is not valid")
+	ErrInvalidPrice         = errors.New("the price is not valid")
+	ErrInvalidTag           = errors.New("the tag is not valid")
+	ErrInvalidPaymentMethod = errors.New("the payment method is not valid")
+	ErrInvalidContract      = errors.New("the contract is not valid")
+	ErrAccessDenied         = errors.New("access denied")
+
+	ErrNoCredentials     = errors.New("no credentials provided")
+	ErrInsufficientScope = errors.New("insufficient scope")
+)
+
+var knownErrors = func() map[string]error {
+	errs := []error{
+		ErrNotFoundCategory, ErrCreateCategory, ErrUpdateCategory, ErrDeleteCategory,
+		ErrNotFoundCurrency, ErrCreateCurrency, ErrUpdateCurrency, ErrDeleteCurrency, ErrAlreadyExistsCurrency,
+		ErrNotFoundCycle, ErrCreateCycle, ErrUpdateCycle, ErrDeleteCycle,
+		ErrNotFoundSubscription, ErrCreateSubscription, ErrUpdateSubscription, ErrDeleteSubscription,
+		ErrNotFoundTag, ErrNotFoundPaymentMethod,
+		ErrInvalidCategory, ErrInvalidCurrency, ErrInvalidCycle, ErrInvalidSubscription, ErrInvalidPaymentDate, ErrInvalidTag,
+		ErrInvalidPaymentMethod, ErrInvalidContract,
+		ErrCategoryCycle, ErrInvalidTransition, ErrInvalidStatusDate, ErrInvalidPrice, ErrAccessDenied,
+		ErrNoCredentials, ErrInsufficientScope,
+	}
+
+	m := make(map[string]error, len(errs))
+	for _, err := range errs {
+		m[err.Error()] = err
+	}
+
+	return m
+}()
+
+// APIError is returned for every error response. Err holds the matching
+// sentinel error when the message is known, so errors.Is works on it.
+type APIError struct {
+	StatusCode int
+	Message    string
+	Err        error
+}
+
+func newAPIError(status int, message string) *APIError {
+	if message == "" {
+		message = http.StatusText(status)
+	}
+
+	err, ok := knownErrors[message]
+	if !ok {
+		// Errors with details are reported as "<sentinel message>: <details>".
+		prefix, _, _ := strings.Cut(message, ": ")
+		err = knownErrors[prefix]
+	}
+
+	return &APIError{StatusCode: status, Message: message, Err: err}
+}
+
+func (e *APIError) Error() string {
+	return fmt.Sprintf("api error (%d): %s", e.StatusCode, e.Message)
+}
+
+func (e *APIError) Unwrap() error {
+	return e.Err
+}
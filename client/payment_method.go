@@ -0,0 +1,81 @@
+package client
+
+import (
+	"context"
+	"net/http"
+)
+
+type PaymentMethod struct {
+	ID       uint   `json:"id"`
+	Label    string `json:"label"`
+	Type     string `json:"type"`
+	LastFour string `json:"last_four,omitempty"`
+	// Expiry is the year and month the method expires in, formatted as 2006-01.
+	Expiry  string `json:"expiry,omitempty"`
+	Expired bool   `json:"expired"`
+}
+
+type PaymentMethodRequest struct {
+	Label    string `json:"label"`
+	Type     string `json:"type"`
+	LastFour string `json:"last_four,omitempty"`
+	Expiry   string `json:"expiry,omitempty"`
+}
+
+func (c *Client) CreatePaymentMethod(ctx context.Context, req PaymentMethodRequest) (*PaymentMethod, error) {
+	var method PaymentMethod
+
+	err := c.do(ctx, http.MethodPost, "/api/payment_method", req, &method)
+	if err != nil {
+		return nil, err
+	}
+
+	return &method, nil
+}
+
+func (c *Client) GetPaymentMethod(ctx context.Context, id uint) (*PaymentMethod, error) {
+	var method PaymentMethod
+
+	err := c.do(ctx, http.MethodGet, pathID("/api/payment_method", id), nil, &method)
+	if err != nil {
+		return nil, err
+	}
+
+	return &method, nil
+}
+
+func (c *Client) ListPaymentMethods(ctx context.Context) ([]PaymentMethod, error) {
+	var methods []PaymentMethod
+
+	err := c.do(ctx, http.MethodGet, "/api/payment_methods", nil, &methods)
+	if err != nil {
+		return nil, err
+	}
+
+	return methods, nil
+}
+
+func (c *Client) UpdatePaymentMethod(ctx context.Context, id uint, req PaymentMethodRequest) (*PaymentMethod, error) {
+	var method PaymentMethod
+
+	err := c.do(ctx, http.MethodPut, pathID("/api/payment_method", id), req, &method)
+	if err != nil {
+		return nil, err
+	}
+
+	return &method, nil
+}
+
+func (c *Client) DeletePaymentMethod(ctx context.Context, id uint) error {
+	return c.do(ctx, http.MethodDelete, pathID("/api/payment_method", id), nil, nil)
+}
+
+// PaymentMethodSubscription adds the method to the subscription and returns the subscription's method IDs.
+// LinkSubscriptionPaymentMethod charges the subscription to the payment method; a zero methodID unlinks it.
+func (c *Client) LinkSubscriptionPaymentMethod(ctx context.Context, subscriptionID, methodID uint) error {
+	req := struct {
+		PaymentMethodID uint `json:"payment_method_id"`
+	}{PaymentMethodID: methodID}
+
+	return c.do(ctx, http.MethodPut, pathID("/api/subscription", subscriptionID)+"/payment_method", req, nil)
+}
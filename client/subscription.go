@@ -0,0 +1,247 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+)
+
+const DateLayout = "2006-01-02"
+
+// Date is a calendar date encoded as "2006-01-02".
+type Date time.Time
+
+func NewDate(year int, month time.Month, day int) Date {
+	return Date(time.Date(year, month, day, 0, 0, 0, 0, time.UTC))
+}
+
+func (d Date) String() string {
+	return time.Time(d).Format(DateLayout)
+}
+
+func (d Date) MarshalJSON() ([]byte, error) {
+	return json.Marshal(d.String())
+}
+
+func (d *Date) UnmarshalJSON(data []byte) error {
+	var s string
+
+	err := json.Unmarshal(data, &s)
+	if err != nil {
+		return err
+	}
+
+	t, err := time.Parse(DateLayout, s)
+	if err != nil {
+		return err
+	}
+
+	*d = Date(t)
+
+	return nil
+}
+
+type Subscription struct {
+	ID              uint    `json:"id"`
+	Name            string  `json:"name"`
+	Note            string  `json:"note"`
+	Logo            string  `json:"logo"`
+	Price           float64 `json:"price"`
+	CategoryID      uint    `json:"category_id"`
+	CycleID         uint    `json:"cycle_id"`
+	CurrencyCode    string  `json:"currency"`
+	NextPaymentDate Date    `json:"next_payment_date"`
+	// Status is the lifecycle state in effect today: trial, active, paused, cancelled or expired.
+	Status         string `json:"status"`
+	TrialStartDate *Date  `json:"trial_start_date,omitempty"`
+	TrialEndDate   *Date  `json:"trial_end_date,omitempty"`
+	ResumeDate     *Date  `json:"resume_date,omitempty"`
+	CancelDate     *Date  `json:"cancel_date,omitempty"`
+	TagIDs         []uint `json:"tag_ids,omitempty"`
+	// PaymentMethodID is zero when the subscription isn't linked to a payment method.
+	PaymentMethodID uint `json:"payment_method_id,omitempty"`
+	// LogoAssetID is the uploaded logo image, served at /api/asset/{id}; zero without one.
+	LogoAssetID uint `json:"logo_asset_id,omitempty"`
+	// Contract is nil for a subscription without a commitment.
+	Contract *Contract `json:"contract,omitempty"`
+}
+
+// Contract is a fixed-term commitment; a term runs up to, but excluding, EndDate. TermEnd and
+// CancelBy are only set in responses.
+type Contract struct {
+	StartDate  Date  `json:"start_date"`
+	EndDate    Date  `json:"end_date"`
+	NoticeDays uint  `json:"notice_days"`
+	AutoRenew  bool  `json:"auto_renew"`
+	TermEnd    *Date `json:"term_end,omitempty"`
+	CancelBy   *Date `json:"cancel_by,omitempty"`
+}
+
+type SubscriptionRequest struct {
+	Name            string  `json:"name"`
+	Note            string  `json:"note,omitempty"`
+	Logo            string  `json:"logo,omitempty"`
+	Price           float64 `json:"price"`
+	CategoryID      uint    `json:"category_id"`
+	CycleID         uint    `json:"cycle_id"`
+	CurrencyCode    string  `json:"currency"`
+	NextPaymentDate Date    `json:"next_payment_date"`
+	// Status and the trial dates are only read on create. Price is what is charged after a trial.
+	Status         string `json:"status,omitempty"`
+	TrialStartDate *Date  `json:"trial_start_date,omitempty"`
+	TrialEndDate   *Date  `json:"trial_end_date,omitempty"`
+}
+
+func (c *Client) CreateSubscription(ctx context.Context, req SubscriptionRequest) (*Subscription, error) {
+	var subscription Subscription
+
+	err := c.do(ctx, http.MethodPost, "/api/subscription", req, &subscription)
+	if err != nil {
+		return nil, err
+	}
+
+	return &subscription, nil
+}
+
+func (c *Client) GetSubscription(ctx context.Context, id uint) (*Subscription, error) {
+	var subscription Subscription
+
+	err := c.do(ctx, http.MethodGet, pathID("/api/subscription", id), nil, &subscription)
+	if err != nil {
+		return nil, err
+	}
+
+	return &subscription, nil
+}
+
+// ListSubscriptions lists the subscriptions, only those carrying every one of tagIDs when given.
+func (c *Client) ListSubscriptions(ctx context.Context, tagIDs ...uint) ([]Subscription, error) {
+	var subscriptions []Subscription
+
+	query := url.Values{}
+	for _, id := range tagIDs {
+		query.Add("tag", strconv.FormatUint(uint64(id), 10))
+	}
+
+	path := "/api/subscriptions"
+	if len(query) > 0 {
+		path += "?" + query.Encode()
+	}
+
+	err := c.do(ctx, http.MethodGet, path, nil, &subscriptions)
+	if err != nil {
+		return nil, err
+	}
+
+	return subscriptions, nil
+}
+
+func (c *Client) UpdateSubscription(ctx context.Context, id uint, req SubscriptionRequest) (*Subscription, error) {
+	var subscription Subscription
+
+	err := c.do(ctx, http.MethodPut, pathID("/api/subscription", id), req, &subscription)
+	if err != nil {
+		return nil, err
+	}
+
+	return &subscription, nil
+}
+
+func (c *Client) DeleteSubscription(ctx context.Context, id uint) error {
+	return c.do(ctx, http.MethodDelete, pathID("/api/subscription", id), nil, nil)
+}
+
+func (c *Client) ActivateSubscription(ctx context.Context, id uint) (*Subscription, error) {
+	return c.changeStatus(ctx, id, "activate", nil)
+}
+
+// PauseSubscription pauses until resumeDate, or until activated when it is nil.
+func (c *Client) PauseSubscription(ctx context.Context, id uint, resumeDate *Date) (*Subscription, error) {
+	return c.changeStatus(ctx, id, "pause", struct {
+		ResumeDate *Date `json:"resume_date,omitempty"`
+	}{resumeDate})
+}
+
+// CancelSubscription cancels from effectiveDate on, or today when it is nil.
+func (c *Client) CancelSubscription(ctx context.Context, id uint, effectiveDate *Date) (*Subscription, error) {
+	return c.changeStatus(ctx, id, "cancel", struct {
+		EffectiveDate *Date `json:"effective_date,omitempty"`
+	}{effectiveDate})
+}
+
+func (c *Client) ExpireSubscription(ctx context.Context, id uint) (*Subscription, error) {
+	return c.changeStatus(ctx, id, "expire", nil)
+}
+
+func (c *Client) changeStatus(ctx context.Context, id uint, action string, body any) (*Subscription, error) {
+	var subscription Subscription
+
+	err := c.do(ctx, http.MethodPost, pathID("/api/subscription", id)+"/"+action, body, &subscription)
+	if err != nil {
+		return nil, err
+	}
+
+	return &subscription, nil
+}
+
+// PriceChange has no EffectiveDate for the price the subscription started with.
+type PriceChange struct {
+	Price         float64 `json:"price"`
+	EffectiveDate *Date   `json:"effective_date,omitempty"`
+}
+
+func (c *Client) ListSubscriptionPrices(ctx context.Context, id uint) ([]PriceChange, error) {
+	var prices []PriceChange
+
+	err := c.do(ctx, http.MethodGet, pathID("/api/subscription", id)+"/prices", nil, &prices)
+	if err != nil {
+		return nil, err
+	}
+
+	return prices, nil
+}
+
+// ChangeSubscriptionPrice records a price effective from effectiveDate, or today when it is nil.
+func (c *Client) ChangeSubscriptionPrice(ctx context.Context, id uint, price float64, effectiveDate *Date) (*Subscription, error) {
+	var subscription Subscription
+
+	err := c.do(ctx, http.MethodPost, pathID("/api/subscription", id)+"/prices", struct {
+		Price         float64 `json:"price"`
+		EffectiveDate *Date   `json:"effective_date,omitempty"`
+	}{price, effectiveDate}, &subscription)
+	if err != nil {
+		return nil, err
+	}
+
+	return &subscription, nil
+}
+
+func (c *Client) SetSubscriptionContract(ctx context.Context, id uint, contract Contract) (*Subscription, error) {
+	var subscription Subscription
+
+	err := c.do(ctx, http.MethodPut, pathID("/api/subscription", id)+"/contract", struct {
+		StartDate  Date `json:"start_date"`
+		EndDate    Date `json:"end_date"`
+		NoticeDays uint `json:"notice_days"`
+		AutoRenew  bool `json:"auto_renew"`
+	}{contract.StartDate, contract.EndDate, contract.NoticeDays, contract.AutoRenew}, &subscription)
+	if err != nil {
+		return nil, err
+	}
+
+	return &subscription, nil
+}
+
+func (c *Client) RemoveSubscriptionContract(ctx context.Context, id uint) (*Subscription, error) {
+	var subscription Subscription
+
+	err := c.do(ctx, http.MethodDelete, pathID("/api/subscription", id)+"/contract", nil, &subscription)
+	if err != nil {
+		return nil, err
+	}
+
+	return &subscription, nil
+}
@@ -0,0 +1,85 @@
+package client
+
+import (
+	"context"
+	"net/http"
+)
+
+type Tag struct {
+	ID   uint   `json:"id"`
+	Name string `json:"name"`
+}
+
+type TagRequest struct {
+	Name string `json:"name"`
+}
+
+func (c *Client) CreateTag(ctx context.Context, req TagRequest) (*Tag, error) {
+	var tag Tag
+
+	err := c.do(ctx, http.MethodPost, "/api/tag", req, &tag)
+	if err != nil {
+		return nil, err
+	}
+
+	return &tag, nil
+}
+
+func (c *Client) GetTag(ctx context.Context, id uint) (*Tag, error) {
+	var tag Tag
+
+	err := c.do(ctx, http.MethodGet, pathID("/api/tag", id), nil, &tag)
+	if err != nil {
+		return nil, err
+	}
+
+	return &tag, nil
+}
+
+func (c *Client) ListTags(ctx context.Context) ([]Tag, error) {
+	var tags []Tag
+
+	err := c.do(ctx, http.MethodGet, "/api/tags", nil, &tags)
+	if err != nil {
+		return nil, err
+	}
+
+	return tags, nil
+}
+
+func (c *Client) UpdateTag(ctx context.Context, id uint, req TagRequest) (*Tag, error) {
+	var tag Tag
+
+	err := c.do(ctx, http.MethodPut, pathID("/api/tag", id), req, &tag)
+	if err != nil {
+		return nil, err
+	}
+
+	return &tag, nil
+}
+
+func (c *Client) DeleteTag(ctx context.Context, id uint) error {
+	return c.do(ctx, http.MethodDelete, pathID("/api/tag", id), nil, nil)
+}
+
+// TagSubscription adds the tag to the subscription and returns the subscription's tag IDs.
+func (c *Client) TagSubscription(ctx context.Context, subscriptionID, tagID uint) ([]uint, error) {
+	return c.changeTags(ctx, http.MethodPut, subscriptionID, tagID)
+}
+
+func (c *Client) UntagSubscription(ctx context.Context, subscriptionID, tagID uint) ([]uint, error) {
+	return c.changeTags(ctx, http.MethodDelete, subscriptionID, tagID)
+}
+
+func (c *Client) changeTags(ctx context.Context, method string, subscriptionID, tagID uint) ([]uint, error) {
+	var resp struct {
+		TagIDs []uint `json:"tag_ids"`
+	}
+
+	err := c.do(ctx, method, pathID(pathID("/api/subscription", subscriptionID)+"/tag", tagID), nil, &resp)
+	if err != nil {
+		return nil, err
+	}
+
+	return resp.TagIDs, nil
+}
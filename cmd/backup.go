@@ -0,0 +1,85 @@
+package cmd
+
+import (
+	"os"
+	"sort"
+	"strconv"
+
+	"git.home/alex/go-subscriptions/internal/backup"
+	"github.com/spf13/cobra"
+)
+
+var backupCmd = &cobra.Command{
+	Use:   "backup [FILE]",
+	Short: "Write a backup of all entities to a JSON file or stdout",
+	Args:  cobra.MaximumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		application, err := loadApp()
+		if err != nil {
+			return err
+		}
+
+		archive, err := backup.Dump(application.Context, application.RepositoryFactory)
+		if err != nil {
+			return err
+		}
+
+		if len(args) == 0 {
+			return backup.Write(cmd.OutOrStdout(), archive)
+		}
+
+		file, err := os.Create(args[0])
+		if err != nil {
+			return err
+		}
+
+		err = backup.Write(file, archive)
+		if err != nil {
+			_ = file.Close()
+			return err
+		}
+
+		return file.Close()
+	},
+}
+
+var restoreCmd = &cobra.Command{
+	Use:   "restore FILE",
+	Short: "Restore a backup into the empty configured storage",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		file, err := os.Open(args[0])
+		if err != nil {
+			return err
+		}
+		defer file.Close()
+
+		archive, err := backup.Read(file)
+		if err != nil {
+			return err
+		}
+
+		application, err := loadWritableApp()
+		if err != nil {
+			return err
+		}
+
+		err = backup.Restore(application.Context, application.RepositoryFactory, archive)
+		if err != nil {
+			return err
+		}
+
+		return printCounts(cmd, archive.Counts())
+	},
+}
+
+func printCounts(cmd *cobra.Command, counts map[string]int) error {
+	t := table{header: []string{"ENTITY", "COUNT"}}
+	for name, n := range counts {
+		t.rows = append(t.rows, []string{name, strconv.Itoa(n)})
+	}
+
+	sort.Slice(t.rows, func(i, j int) bool { return t.rows[i][0] < t.rows[j][0] })
+
+	return printResult(cmd.OutOrStdout(), counts, t)
+}
@@ -0,0 +1,157 @@
+package cmd
+
+import (
+	"git.home/alex/go-subscriptions/internal/domain/entity"
+	"github.com/spf13/cobra"
+)
+
+type categoryView struct {
+	ID   uint   `json:"id"`
+	Name string `json:"name"`
+}
+
+func newCategoryCmd() *cobra.Command {
+	categoryCmd := &cobra.Command{
+		Use:   "category",
+		Short: "Manage categories",
+	}
+
+	categoryCmd.AddCommand(
+		&cobra.Command{
+			Use:   "add NAME",
+			Short: "Add a category",
+			Args:  cobra.ExactArgs(1),
+			RunE: func(cmd *cobra.Command, args []string) error {
+				application, err := loadWritableApp()
+				if err != nil {
+					return err
+				}
+
+				category, err := application.ServiceFactory.CategoryService.CreateCategory(application.Context, entity.Category{Name: args[0]})
+				if err != nil {
+					return err
+				}
+
+				return printCategories(cmd, true, *category)
+			},
+		},
+		&cobra.Command{
+			Use:   "list",
+			Short: "List categories",
+			Args:  cobra.NoArgs,
+			RunE: func(cmd *cobra.Command, _ []string) error {
+				application, err := loadApp()
+				if err != nil {
+					return err
+				}
+
+				categories, err := application.ServiceFactory.CategoryService.GetAllCategories(application.Context)
+				if err != nil {
+					return err
+				}
+
+				return printCategories(cmd, false, categories...)
+			},
+		},
+		&cobra.Command{
+			Use:   "show ID",
+			Short: "Show a category",
+			Args:  cobra.ExactArgs(1),
+			RunE: func(cmd *cobra.Command, args []string) error {
+				id, err := parseID(args[0])
+				if err != nil {
+					return err
+				}
+
+				application, err := loadApp()
+				if err != nil {
+					return err
+				}
+
+				category, err := application.ServiceFactory.CategoryService.GetCategory(application.Context, id)
+				if err != nil {
+					return err
+				}
+
+				return printCategories(cmd, true, *category)
+			},
+		},
+		newCategoryUpdateCmd(),
+		&cobra.Command{
+			Use:   "delete ID",
+			Short: "Delete a category",
+			Args:  cobra.ExactArgs(1),
+			RunE: func(cmd *cobra.Command, args []string) error {
+				id, err := parseID(args[0])
+				if err != nil {
+					return err
+				}
+
+				application, err := loadWritableApp()
+				if err != nil {
+					return err
+				}
+
+				return application.ServiceFactory.CategoryService.DeleteCategory(application.Context, id)
+			},
+		},
+	)
+
+	return categoryCmd
+}
+
+func newCategoryUpdateCmd() *cobra.Command {
+	var name string
+
+	updateCmd := &cobra.Command{
+		Use:   "update ID",
+		Short: "Update a category",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			id, err := parseID(args[0])
+			if err != nil {
+				return err
+			}
+
+			application, err := loadWritableApp()
+			if err != nil {
+				return err
+			}
+
+			cs := application.ServiceFactory.CategoryService
+
+			category, err := cs.GetCategory(application.Context, id)
+			if err != nil {
+				return err
+			}
+
+			if cmd.Flags().Changed("name") {
+				category.Name = name
+			}
+
+			category, err = cs.UpdateCategory(application.Context, *category)
+			if err != nil {
+				return err
+			}
+
+			return printCategories(cmd, true, *category)
+		},
+	}
+
+	updateCmd.Flags().StringVar(&name, "name", "", "category name")
+
+	return updateCmd
+}
+
+func (v categoryView) row() []string {
+	return []string{formatUint(v.ID), v.Name}
+}
+
+func printCategories(cmd *cobra.Command, single bool, categories ...entity.Category) error {
+	views := make([]categoryView, 0, len(categories))
+	for _, c := range categories {
+		views = append(views, categoryView{ID: c.ID, Name: c.Name})
+	}
+
+	return printItems(cmd.OutOrStdout(), []string{"ID", "NAME"}, views, single)
+}
@@ -0,0 +1,106 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+
+	"git.home/alex/go-subscriptions/internal/domain/service"
+	"github.com/spf13/cobra"
+)
+
+func newSubscriptionImportCmd() *cobra.Command {
+	var dryRun bool
+
+	importCmd := &cobra.Command{
+		Use:   "import FILE",
+		Short: "Import subscriptions from a CSV file",
+		Long: "Import subscriptions from a CSV file with a header row.\n" +
+			"Columns: name, price, currency, cycle, category, next_payment_date, note, logo,\n" +
+			"status, trial_start_date, trial_end_date, resume_date, cancel_date.\n" +
+			"Tags, payment methods, contracts, price history and shares are not part of the file.\n" +
+			"Missing categories are created, rows that fail are reported and skipped.",
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			file, err := os.Open(args[0])
+			if err != nil {
+				return err
+			}
+			defer file.Close()
+
+			application, err := loadWritableApp()
+			if err != nil {
+				return err
+			}
+
+			report, err := application.ServiceFactory.CSVService.Import(application.Context, file, dryRun)
+			if err != nil {
+				return err
+			}
+
+			return printImportReport(cmd, report)
+		},
+	}
+
+	importCmd.Flags().BoolVar(&dryRun, "dry-run", false, "validate the rows without importing them")
+
+	return importCmd
+}
+
+func newSubscriptionExportCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "export [FILE]",
+		Short: "Export subscriptions to a CSV file or stdout",
+		Args:  cobra.MaximumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			application, err := loadApp()
+			if err != nil {
+				return err
+			}
+
+			if len(args) == 0 {
+				return application.ServiceFactory.CSVService.Export(application.Context, cmd.OutOrStdout())
+			}
+
+			file, err := os.Create(args[0])
+			if err != nil {
+				return err
+			}
+
+			err = application.ServiceFactory.CSVService.Export(application.Context, file)
+			if err != nil {
+				_ = file.Close()
+				return err
+			}
+
+			return file.Close()
+		},
+	}
+}
+
+func printImportReport(cmd *cobra.Command, report *service.CSVImportReport) error {
+	t := table{header: []string{"ROW", "ERROR"}}
+	for _, rowErr := range report.Errors {
+		t.rows = append(t.rows, []string{strconv.Itoa(rowErr.Row), rowErr.Error})
+	}
+
+	if outputFormat == outputTable {
+		verb := "Imported"
+		if report.DryRun {
+			verb = "Dry run: would import"
+		}
+
+		_, _ = fmt.Fprintf(cmd.OutOrStdout(), "%s %d of %d rows\n", verb, report.Imported, report.Rows)
+
+		if len(report.CreatedCategories) > 0 {
+			_, _ = fmt.Fprintf(cmd.OutOrStdout(), "New categories: %s\n", strings.Join(report.CreatedCategories, ", "))
+		}
+
+		if len(report.Errors) == 0 {
+			return nil
+		}
+	}
+
+	return printResult(cmd.OutOrStdout(), report, t)
+}
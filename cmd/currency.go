@@ -0,0 +1,152 @@
+package cmd
+
+import (
+	"git.home/alex/go-subscriptions/internal/domain/entity"
+	"github.com/spf13/cobra"
+)
+
+type currencyView struct {
+	Code   string `json:"code"`
+	Name   string `json:"name"`
+	Symbol string `json:"symbol"`
+}
+
+func newCurrencyCmd() *cobra.Command {
+	currencyCmd := &cobra.Command{
+		Use:   "currency",
+		Short: "Manage currencies",
+	}
+
+	currencyCmd.AddCommand(
+		&cobra.Command{
+			Use:   "add CODE NAME SYMBOL",
+			Short: "Add a currency",
+			Args:  cobra.ExactArgs(3),
+			RunE: func(cmd *cobra.Command, args []string) error {
+				application, err := loadWritableApp()
+				if err != nil {
+					return err
+				}
+
+				currency, err := application.ServiceFactory.CurrencyService.CreateCurrency(application.Context, entity.Currency{
+					Code:   args[0],
+					Name:   args[1],
+					Symbol: args[2],
+				})
+				if err != nil {
+					return err
+				}
+
+				return printCurrencies(cmd, true, *currency)
+			},
+		},
+		&cobra.Command{
+			Use:   "list",
+			Short: "List currencies",
+			Args:  cobra.NoArgs,
+			RunE: func(cmd *cobra.Command, _ []string) error {
+				application, err := loadApp()
+				if err != nil {
+					return err
+				}
+
+				currencies, err := application.ServiceFactory.CurrencyService.GetAllCurrencies(application.Context)
+				if err != nil {
+					return err
+				}
+
+				return printCurrencies(cmd, false, currencies...)
+			},
+		},
+		&cobra.Command{
+			Use:   "show CODE",
+			Short: "Show a currency",
+			Args:  cobra.ExactArgs(1),
+			RunE: func(cmd *cobra.Command, args []string) error {
+				application, err := loadApp()
+				if err != nil {
+					return err
+				}
+
+				currency, err := application.ServiceFactory.CurrencyService.GetCurrency(application.Context, args[0])
+				if err != nil {
+					return err
+				}
+
+				return printCurrencies(cmd, true, *currency)
+			},
+		},
+		newCurrencyUpdateCmd(),
+		&cobra.Command{
+			Use:   "delete CODE",
+			Short: "Delete a currency",
+			Args:  cobra.ExactArgs(1),
+			RunE: func(cmd *cobra.Command, args []string) error {
+				application, err := loadWritableApp()
+				if err != nil {
+					return err
+				}
+
+				return application.ServiceFactory.CurrencyService.DeleteCurrency(application.Context, args[0])
+			},
+		},
+	)
+
+	return currencyCmd
+}
+
+func newCurrencyUpdateCmd() *cobra.Command {
+	var name, symbol string
+
+	updateCmd := &cobra.Command{
+		Use:   "update CODE",
+		Short: "Update a currency",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			application, err := loadWritableApp()
+			if err != nil {
+				return err
+			}
+
+			cs := application.ServiceFactory.CurrencyService
+
+			currency, err := cs.GetCurrency(application.Context, args[0])
+			if err != nil {
+				return err
+			}
+
+			if cmd.Flags().Changed("name") {
+				currency.Name = name
+			}
+
+			if cmd.Flags().Changed("symbol") {
+				currency.Symbol = symbol
+			}
+
+			currency, err = cs.UpdateCurrency(application.Context, *currency)
+			if err != nil {
+				return err
+			}
+
+			return printCurrencies(cmd, true, *currency)
+		},
+	}
+
+	updateCmd.Flags().StringVar(&name, "name", "", "currency name")
+	updateCmd.Flags().StringVar(&symbol, "symbol", "", "currency symbol")
+
+	return updateCmd
+}
+
+func (v currencyView) row() []string {
+	return []string{v.Code, v.Name, v.Symbol}
+}
+
+func printCurrencies(cmd *cobra.Command, single bool, currencies ...entity.Currency) error {
+	views := make([]currencyView, 0, len(currencies))
+	for _, c := range currencies {
+		views = append(views, currencyView{Code: c.Code, Name: c.Name, Symbol: c.Symbol})
+	}
+
+	return printItems(cmd.OutOrStdout(), []string{"CODE", "NAME", "SYMBOL"}, views, single)
+}
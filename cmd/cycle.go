@@ -0,0 +1,196 @@
+package cmd
+
+import (
+	"strconv"
+
+	"git.home/alex/go-subscriptions/internal/domain/entity"
+	"github.com/spf13/cobra"
+)
+
+const calendarFlagUsage = "repeat a 30 day cycle every month and a 365 day cycle every year on the same day"
+
+type cycleView struct {
+	ID       uint   `json:"id"`
+	Name     string `json:"name"`
+	Days     uint   `json:"days"`
+	Calendar bool   `json:"calendar"`
+}
+
+func newCycleCmd() *cobra.Command {
+	cycleCmd := &cobra.Command{
+		Use:   "cycle",
+		Short: "Manage billing cycles",
+	}
+
+	cycleCmd.AddCommand(
+		newCycleAddCmd(),
+		&cobra.Command{
+			Use:   "list",
+			Short: "List billing cycles",
+			Args:  cobra.NoArgs,
+			RunE: func(cmd *cobra.Command, _ []string) error {
+				application, err := loadApp()
+				if err != nil {
+					return err
+				}
+
+				cycles, err := application.ServiceFactory.CycleService.GetAllCycles(application.Context)
+				if err != nil {
+					return err
+				}
+
+				return printCycles(cmd, false, cycles...)
+			},
+		},
+		&cobra.Command{
+			Use:   "show ID",
+			Short: "Show a billing cycle",
+			Args:  cobra.ExactArgs(1),
+			RunE: func(cmd *cobra.Command, args []string) error {
+				id, err := parseID(args[0])
+				if err != nil {
+					return err
+				}
+
+				application, err := loadApp()
+				if err != nil {
+					return err
+				}
+
+				cycle, err := application.ServiceFactory.CycleService.GetCycle(application.Context, id)
+				if err != nil {
+					return err
+				}
+
+				return printCycles(cmd, true, *cycle)
+			},
+		},
+		newCycleUpdateCmd(),
+		&cobra.Command{
+			Use:   "delete ID",
+			Short: "Delete a billing cycle",
+			Args:  cobra.ExactArgs(1),
+			RunE: func(cmd *cobra.Command, args []string) error {
+				id, err := parseID(args[0])
+				if err != nil {
+					return err
+				}
+
+				application, err := loadWritableApp()
+				if err != nil {
+					return err
+				}
+
+				return application.ServiceFactory.CycleService.DeleteCycle(application.Context, id)
+			},
+		},
+	)
+
+	return cycleCmd
+}
+
+func newCycleAddCmd() *cobra.Command {
+	var calendar bool
+
+	addCmd := &cobra.Command{
+		Use:   "add NAME DAYS",
+		Short: "Add a billing cycle",
+		Args:  cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			days, err := strconv.ParseUint(args[1], 10, 0)
+			if err != nil {
+				return err
+			}
+
+			application, err := loadWritableApp()
+			if err != nil {
+				return err
+			}
+
+			cycle, err := application.ServiceFactory.CycleService.CreateCycle(application.Context, entity.Cycle{
+				Name:     args[0],
+				Days:     uint(days),
+				Calendar: calendar,
+			})
+			if err != nil {
+				return err
+			}
+
+			return printCycles(cmd, true, *cycle)
+		},
+	}
+
+	addCmd.Flags().BoolVar(&calendar, "calendar", false, calendarFlagUsage)
+
+	return addCmd
+}
+
+func newCycleUpdateCmd() *cobra.Command {
+	var (
+		name     string
+		days     uint
+		calendar bool
+	)
+
+	updateCmd := &cobra.Command{
+		Use:   "update ID",
+		Short: "Update a billing cycle",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			id, err := parseID(args[0])
+			if err != nil {
+				return err
+			}
+
+			application, err := loadWritableApp()
+			if err != nil {
+				return err
+			}
+
+			cs := application.ServiceFactory.CycleService
+
+			cycle, err := cs.GetCycle(application.Context, id)
+			if err != nil {
+				return err
+			}
+
+			if cmd.Flags().Changed("name") {
+				cycle.Name = name
+			}
+
+			if cmd.Flags().Changed("days") {
+				cycle.Days = days
+			}
+
+			if cmd.Flags().Changed("calendar") {
+				cycle.Calendar = calendar
+			}
+
+			cycle, err = cs.UpdateCycle(application.Context, *cycle)
+			if err != nil {
+				return err
+			}
+
+			return printCycles(cmd, true, *cycle)
+		},
+	}
+
+	updateCmd.Flags().StringVar(&name, "name", "", "cycle name")
+	updateCmd.Flags().UintVar(&days, "days", 0, "cycle length in days")
+	updateCmd.Flags().BoolVar(&calendar, "calendar", false, calendarFlagUsage)
+
+	return updateCmd
+}
+
+func (v cycleView) row() []string {
+	return []string{formatUint(v.ID), v.Name, formatUint(v.Days), strconv.FormatBool(v.Calendar)}
+}
+
+func printCycles(cmd *cobra.Command, single bool, cycles ...entity.Cycle) error {
+	views := make([]cycleView, 0, len(cycles))
+	for _, c := range cycles {
+		views = append(views, cycleView{ID: c.ID, Name: c.Name, Days: c.Days, Calendar: c.Calendar})
+	}
+
+	return printItems(cmd.OutOrStdout(), []string{"ID", "NAME", "DAYS", "CALENDAR"}, views, single)
+}
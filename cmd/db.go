@@ -0,0 +1,125 @@
+package cmd
+
+import (
+	"strconv"
+	"time"
+
+	"git.home/alex/go-subscriptions/internal/config"
+	"git.home/alex/go-subscriptions/internal/migration"
+	"git.home/alex/go-subscriptions/internal/repository/sqlite"
+	"github.com/spf13/cobra"
+)
+
+type migrationView struct {
+	Version   uint   `json:"version"`
+	Name      string `json:"name"`
+	Applied   bool   `json:"applied"`
+	AppliedAt string `json:"applied_at,omitempty"`
+}
+
+func (v migrationView) row() []string {
+	return []string{formatUint(v.Version), v.Name, strconv.FormatBool(v.Applied), v.AppliedAt}
+}
+
+func newDBCmd() *cobra.Command {
+	dbCmd := &cobra.Command{
+		Use:   "db",
+		Short: "Manage the schema of the SQLite database",
+	}
+
+	var steps int
+
+	rollbackCmd := &cobra.Command{
+		Use:   "rollback",
+		Short: "Roll back the last applied migrations",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			return withMigrator(func(m *migration.Migrator) error {
+				reverted, err := m.Down(cmd.Context(), steps)
+				if err != nil {
+					return err
+				}
+
+				return printMigrations(cmd, reverted, false)
+			})
+		},
+	}
+	rollbackCmd.Flags().IntVar(&steps, "steps", 1, "number of migrations to roll back")
+
+	dbCmd.AddCommand(
+		&cobra.Command{
+			Use:   "migrate",
+			Short: "Apply the pending migrations",
+			Args:  cobra.NoArgs,
+			RunE: func(cmd *cobra.Command, _ []string) error {
+				return withMigrator(func(m *migration.Migrator) error {
+					applied, err := m.Up(cmd.Context())
+					if err != nil {
+						return err
+					}
+
+					return printMigrations(cmd, applied, true)
+				})
+			},
+		},
+		rollbackCmd,
+		&cobra.Command{
+			Use:   "status",
+			Short: "List the migrations and whether they are applied",
+			Args:  cobra.NoArgs,
+			RunE: func(cmd *cobra.Command, _ []string) error {
+				return withMigrator(func(m *migration.Migrator) error {
+					statuses, err := m.Status(cmd.Context())
+					if err != nil {
+						return err
+					}
+
+					views := make([]migrationView, 0, len(statuses))
+					for _, s := range statuses {
+						view := migrationView{Version: s.Version, Name: s.Name, Applied: s.Applied}
+						if s.Applied {
+							view.AppliedAt = s.AppliedAt.Format(time.RFC3339)
+						}
+
+						views = append(views, view)
+					}
+
+					return printItems(cmd.OutOrStdout(), []string{"VERSION", "NAME", "APPLIED", "APPLIED AT"}, views, false)
+				})
+			},
+		},
+	)
+
+	return dbCmd
+}
+
+// withMigrator opens the configured SQLite database for the duration of fn.
+func withMigrator(fn func(m *migration.Migrator) error) error {
+	cfg, err := config.LoadConfig(cfgFile)
+	if err != nil {
+		return err
+	}
+
+	db, err := sqlite.Open(cfg.SQLite.Path)
+	if err != nil {
+		return err
+	}
+
+	defer db.Close()
+
+	m, err := sqlite.NewMigrator(db)
+	if err != nil {
+		return err
+	}
+
+	return fn(m)
+}
+
+func printMigrations(cmd *cobra.Command, migrations []migration.Migration, applied bool) error {
+	views := make([]migrationView, 0, len(migrations))
+	for _, m := range migrations {
+		views = append(views, migrationView{Version: m.Version, Name: m.Name, Applied: applied})
+	}
+
+	return printItems(cmd.OutOrStdout(), []string{"VERSION", "NAME", "APPLIED", "APPLIED AT"}, views, false)
+}
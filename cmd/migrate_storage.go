@@ -0,0 +1,58 @@
+package cmd
+
+import (
+	"strconv"
+
+	"git.home/alex/go-subscriptions/internal/app"
+	"git.home/alex/go-subscriptions/internal/backup"
+	"github.com/spf13/cobra"
+)
+
+func newMigrateStorageCmd() *cobra.Command {
+	var fromConfig, toConfig, statePath string
+
+	migrateCmd := &cobra.Command{
+		Use:   "migrate-storage",
+		Short: "Copy all entities from one storage backend to another",
+		Long: "Copy all entities from the storage configured in --from to the one configured in --to,\n" +
+			"keeping the IDs, and verify counts and checksums afterwards. The target must be empty.\n" +
+			"Progress is recorded in the state file; run the command again to resume an interrupted migration.",
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			from, err := app.NewApp(fromConfig)
+			if err != nil {
+				return err
+			}
+
+			to, err := app.NewApp(toConfig)
+			if err != nil {
+				return err
+			}
+
+			// A memory source is always empty and a memory target forgets the copy on exit.
+			if !from.Persistent() || !to.Persistent() {
+				return errNotPersistent
+			}
+
+			report, err := backup.Migrate(cmd.Context(), from.RepositoryFactory, to.RepositoryFactory, statePath)
+			if err != nil {
+				return err
+			}
+
+			t := table{header: []string{"SECTION", "COUNT", "CHECKSUM", "RESUMED"}}
+			for _, s := range report.Sections {
+				t.rows = append(t.rows, []string{s.Name, strconv.Itoa(s.Count), s.Checksum, strconv.FormatBool(s.Resumed)})
+			}
+
+			return printResult(cmd.OutOrStdout(), report, t)
+		},
+	}
+
+	migrateCmd.Flags().StringVar(&fromConfig, "from", "", "config file of the source storage")
+	migrateCmd.Flags().StringVar(&toConfig, "to", "", "config file of the target storage")
+	migrateCmd.Flags().StringVar(&statePath, "state", "migrate-storage.state.json", "file recording the migration progress")
+	_ = migrateCmd.MarkFlagRequired("from")
+	_ = migrateCmd.MarkFlagRequired("to")
+
+	return migrateCmd
+}
@@ -0,0 +1,117 @@
+package cmd
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+
+	"git.home/alex/go-subscriptions/internal/app"
+)
+
+const (
+	outputTable = "table"
+	outputJSON  = "json"
+)
+
+var (
+	errUnknownOutput = errors.New("unknown output format")
+	errInvalidID     = errors.New("the id is not valid")
+	errNotPersistent = errors.New("the memory storage loses every change when the command exits, configure the sqlite storage")
+)
+
+var outputFormat string
+
+type table struct {
+	header []string
+	rows   [][]string
+}
+
+// printResult writes v as JSON or t as an aligned table depending on --output.
+func printResult(w io.Writer, v any, t table) error {
+	switch outputFormat {
+	case outputJSON:
+		enc := json.NewEncoder(w)
+		enc.SetIndent("", "  ")
+
+		return enc.Encode(v)
+	case outputTable:
+		tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+
+		_, _ = fmt.Fprintln(tw, strings.Join(t.header, "\t"))
+		for _, row := range t.rows {
+			_, _ = fmt.Fprintln(tw, strings.Join(row, "\t"))
+		}
+
+		return tw.Flush()
+	default:
+		return fmt.Errorf("%w: %q", errUnknownOutput, outputFormat)
+	}
+}
+
+type tableRow interface {
+	row() []string
+}
+
+// printItems prints items one row each; in JSON mode a single item is written as an object.
+func printItems[T tableRow](w io.Writer, header []string, items []T, single bool) error {
+	t := table{header: header}
+	for _, item := range items {
+		t.rows = append(t.rows, item.row())
+	}
+
+	if single && len(items) == 1 {
+		return printResult(w, items[0], t)
+	}
+
+	return printResult(w, items, t)
+}
+
+func parseID(s string) (uint, error) {
+	id, err := strconv.ParseUint(s, 10, 0)
+	if err != nil || id == 0 {
+		return 0, fmt.Errorf("%w: %q", errInvalidID, s)
+	}
+
+	return uint(id), nil
+}
+
+func formatUint(v uint) string {
+	return strconv.FormatUint(uint64(v), 10)
+}
+
+func formatPrice(v float64) string {
+	return strconv.FormatFloat(v, 'f', 2, 64)
+}
+
+func loadApp() (*app.App, error) {
+	application, err := app.NewApp(cfgFile)
+	if err != nil {
+		return nil, err
+	}
+
+	if !application.Persistent() {
+		slog.Warn("The memory storage is not persisted, it starts empty for every command")
+	}
+
+	return application, nil
+}
+
+// loadWritableApp is loadApp for commands that change data. It refuses a storage that would drop
+// the changes on exit rather than report them as done.
+func loadWritableApp() (*app.App, error) {
+	application, err := app.NewApp(cfgFile)
+	if err != nil {
+		return nil, err
+	}
+
+	if !application.Persistent() {
+		return nil, errNotPersistent
+	}
+
+	return application, nil
+}
@@ -25,8 +25,18 @@ func Execute() {
 
 func initCommands() {
 	rootCmd.PersistentFlags().StringVar(&cfgFile, "config", "config.yaml", "config file (default is config.yaml)")
+	rootCmd.PersistentFlags().StringVarP(&outputFormat, "output", "o", outputTable, "output format: table or json")
 
 	rootCmd.AddCommand(initCmd)
 	rootCmd.AddCommand(versionCmd)
 	rootCmd.AddCommand(runCmd)
+	rootCmd.AddCommand(backupCmd)
+	rootCmd.AddCommand(restoreCmd)
+	rootCmd.AddCommand(newMigrateStorageCmd())
+	rootCmd.AddCommand(newDBCmd())
+	rootCmd.AddCommand(newCategoryCmd())
+	rootCmd.AddCommand(newCurrencyCmd())
+	rootCmd.AddCommand(newCycleCmd())
+	rootCmd.AddCommand(newSubscriptionCmd())
+	rootCmd.AddCommand(newStatementCmd())
 }
@@ -1,8 +1,9 @@
 package cmd
 
 import (
+	"log/slog"
+
 	"git.home/alex/go-subscriptions/internal/api"
-	"git.home/alex/go-subscriptions/internal/api/handler/subscription_handler"
 	"git.home/alex/go-subscriptions/internal/app"
 	"github.com/spf13/cobra"
 )
@@ -15,22 +16,11 @@ var runCmd = &cobra.Command{
 			return err
 		}
 
-		httpServer, err := api.NewHTTPServer(
-			api.WithTimeout(application.Config.Timeout),
-			api.WithListenAddr(application.Config.ListenAddr),
-			api.WithContext(application.Context),
-			api.WithDefaultRouter(),
-			api.WithHealthHandler(),
-			api.WithCategoryHandlers(application.ServiceFactory.CategoryService),
-			api.WithCurrencyHandlers(application.ServiceFactory.CurrencyService),
-			api.WithCycleHandlers(application.ServiceFactory.CycleService),
-			api.WithSubscribeHandlers(&subscription_handler.HandlerOpts{
-				SubscriptionService: application.ServiceFactory.SubscriptionService,
-				CategoryService:     application.ServiceFactory.CategoryService,
-				CycleService:        application.ServiceFactory.CycleService,
-				CurrencyService:     application.ServiceFactory.CurrencyService,
-			}),
-		)
+		if application.Authenticator == nil {
+			slog.Warn("No credentials configured, the API is not protected")
+		}
+
+		httpServer, err := api.NewHTTPServer(application.ServerConfigurations()...)
 		if err != nil {
 			return err
 		}
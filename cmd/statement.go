@@ -0,0 +1,219 @@
+package cmd
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+
+	"git.home/alex/go-subscriptions/internal/domain/service"
+	"github.com/spf13/cobra"
+)
+
+var errInvalidSelection = errors.New("the proposal selection is not valid")
+
+func newStatementCmd() *cobra.Command {
+	statementCmd := &cobra.Command{
+		Use:   "statement",
+		Short: "Find subscriptions in bank statements",
+	}
+
+	statementCmd.AddCommand(newStatementScanCmd(), newStatementConfirmCmd())
+
+	return statementCmd
+}
+
+func newStatementScanCmd() *cobra.Command {
+	var (
+		format  string
+		confirm string
+		mapping = service.DefaultStatementMapping()
+	)
+
+	scanCmd := &cobra.Command{
+		Use:   "scan FILE",
+		Short: "List the recurring charges of a CSV, OFX or QFX statement",
+		Long: "List the merchants that charge about the same amount weekly, monthly, quarterly or yearly.\n" +
+			"Review the numbered proposals, then run again with --confirm to create subscriptions from them,\n" +
+			"or save them with -o json, edit the file and pass it to \"statement confirm\".",
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			file, err := os.Open(args[0])
+			if err != nil {
+				return err
+			}
+			defer file.Close()
+
+			application, err := loadApp()
+			if err != nil {
+				return err
+			}
+
+			ss := application.ServiceFactory.StatementService
+
+			report, err := ss.Scan(application.Context, file, service.StatementFormat(format), mapping)
+			if err != nil {
+				return err
+			}
+
+			if confirm == "" {
+				return printScanReport(cmd, report)
+			}
+
+			proposals, err := selectProposals(report.Proposals, confirm)
+			if err != nil {
+				return err
+			}
+
+			confirmed, err := ss.Confirm(application.Context, proposals)
+			if err != nil {
+				return err
+			}
+
+			return printConfirmReport(cmd, proposals, confirmed)
+		},
+	}
+
+	fs := scanCmd.Flags()
+	fs.StringVar(&format, "format", "", "csv, ofx or qfx, detected from the content when not set")
+	fs.StringVar(&confirm, "confirm", "", "create subscriptions from the listed proposals, e.g. 1,3 or all")
+	fs.StringVar(&mapping.DateColumn, "date-column", mapping.DateColumn, "csv column of the booking date")
+	fs.StringVar(&mapping.DescriptionColumn, "description-column", mapping.DescriptionColumn, "csv column of the merchant")
+	fs.StringVar(&mapping.AmountColumn, "amount-column", mapping.AmountColumn, "csv column of the amount, charges are negative")
+	fs.StringVar(&mapping.CurrencyColumn, "currency-column", mapping.CurrencyColumn, "csv column of the currency code")
+	fs.StringVar(&mapping.DateLayout, "date-layout", mapping.DateLayout, "date layout of the csv, in Go reference time")
+	fs.StringVar(&mapping.Delimiter, "delimiter", mapping.Delimiter, `csv field delimiter, \t for tabs`)
+	fs.BoolVar(&mapping.DecimalComma, "decimal-comma", false, "amounts use a decimal comma, e.g. 1.234,56")
+	fs.StringVar(&mapping.Currency, "currency", "", "currency of rows or files that don't state one")
+
+	return scanCmd
+}
+
+func newStatementConfirmCmd() *cobra.Command {
+	var only string
+
+	confirmCmd := &cobra.Command{
+		Use:   "confirm FILE",
+		Short: "Create subscriptions from the JSON output of \"statement scan\"",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			data, err := os.ReadFile(args[0])
+			if err != nil {
+				return err
+			}
+
+			var report service.StatementScanReport
+
+			err = json.Unmarshal(data, &report)
+			if err != nil {
+				return err
+			}
+
+			proposals := report.Proposals
+			if only != "" {
+				proposals, err = selectProposals(proposals, only)
+				if err != nil {
+					return err
+				}
+			}
+
+			application, err := loadWritableApp()
+			if err != nil {
+				return err
+			}
+
+			confirmed, err := application.ServiceFactory.StatementService.Confirm(application.Context, proposals)
+			if err != nil {
+				return err
+			}
+
+			return printConfirmReport(cmd, proposals, confirmed)
+		},
+	}
+
+	confirmCmd.Flags().StringVar(&only, "only", "", "confirm only the listed proposals, e.g. 1,3")
+
+	return confirmCmd
+}
+
+// selectProposals picks proposals by their 1-based number in the scan listing, or all of them.
+func selectProposals(proposals []service.StatementProposal, selection string) ([]service.StatementProposal, error) {
+	if selection == "all" {
+		return proposals, nil
+	}
+
+	var selected []service.StatementProposal
+
+	for _, part := range strings.Split(selection, ",") {
+		n, err := strconv.Atoi(strings.TrimSpace(part))
+		if err != nil || n < 1 || n > len(proposals) {
+			return nil, fmt.Errorf("%w: %q", errInvalidSelection, part)
+		}
+
+		selected = append(selected, proposals[n-1])
+	}
+
+	return selected, nil
+}
+
+func printScanReport(cmd *cobra.Command, report *service.StatementScanReport) error {
+	t := table{header: []string{"#", "NAME", "PRICE", "CURRENCY", "CYCLE", "CHARGES", "LAST", "NEXT", "TRACKED"}}
+
+	for i, proposal := range report.Proposals {
+		cycle := proposal.CycleName
+		if proposal.CycleID == 0 {
+			cycle += " (new)"
+		}
+
+		tracked := ""
+		if proposal.Tracked {
+			tracked = "yes"
+		}
+
+		t.rows = append(t.rows, []string{
+			strconv.Itoa(i + 1),
+			proposal.Name,
+			formatPrice(proposal.Price),
+			proposal.CurrencyCode,
+			cycle,
+			strconv.Itoa(proposal.Occurrences),
+			proposal.LastCharge,
+			proposal.NextPaymentDate,
+			tracked,
+		})
+	}
+
+	if outputFormat == outputTable {
+		_, _ = fmt.Fprintf(cmd.OutOrStdout(), "Read %d transactions (%d skipped), found %d recurring charges\n",
+			report.Transactions, report.Skipped, len(report.Proposals))
+
+		if len(report.Proposals) == 0 {
+			return nil
+		}
+	}
+
+	return printResult(cmd.OutOrStdout(), report, t)
+}
+
+func printConfirmReport(cmd *cobra.Command, proposals []service.StatementProposal, report *service.StatementConfirmReport) error {
+	t := table{header: []string{"NAME", "ERROR"}}
+	for _, proposalErr := range report.Errors {
+		t.rows = append(t.rows, []string{proposals[proposalErr.Proposal].Name, proposalErr.Error})
+	}
+
+	if outputFormat == outputTable {
+		_, _ = fmt.Fprintf(cmd.OutOrStdout(), "Created %d subscriptions\n", report.Created)
+
+		if len(report.CreatedCycles) > 0 {
+			_, _ = fmt.Fprintf(cmd.OutOrStdout(), "New cycles: %s\n", strings.Join(report.CreatedCycles, ", "))
+		}
+
+		if len(report.Errors) == 0 {
+			return nil
+		}
+	}
+
+	return printResult(cmd.OutOrStdout(), report, t)
+}
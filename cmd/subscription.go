@@ -0,0 +1,325 @@
+package cmd
+
+import (
+	"context"
+	"time"
+
+	"git.home/alex/go-subscriptions/internal/api/handler/subscription_handler"
+	"git.home/alex/go-subscriptions/internal/app"
+	"git.home/alex/go-subscriptions/internal/clock"
+	"git.home/alex/go-subscriptions/internal/domain/entity"
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+)
+
+type subscriptionView struct {
+	ID              uint    `json:"id"`
+	Name            string  `json:"name"`
+	Note            string  `json:"note"`
+	Logo            string  `json:"logo"`
+	Price           float64 `json:"price"`
+	CategoryID      uint    `json:"category_id"`
+	CycleID         uint    `json:"cycle_id"`
+	CurrencyCode    string  `json:"currency"`
+	NextPaymentDate string  `json:"next_payment_date"`
+	Status          string  `json:"status"`
+}
+
+type subscriptionFlags struct {
+	name            string
+	note            string
+	logo            string
+	price           float64
+	categoryID      uint
+	cycleID         uint
+	currencyCode    string
+	nextPaymentDate string
+	trialStartDate  string
+	trialEndDate    string
+}
+
+func (f *subscriptionFlags) register(fs *pflag.FlagSet) {
+	fs.StringVar(&f.name, "name", "", "subscription name")
+	fs.StringVar(&f.note, "note", "", "free-form note")
+	fs.StringVar(&f.logo, "logo", "", "logo url")
+	fs.Float64Var(&f.price, "price", 0, "price per billing cycle")
+	fs.UintVar(&f.categoryID, "category", 0, "category id")
+	fs.UintVar(&f.cycleID, "cycle", 0, "billing cycle id")
+	fs.StringVar(&f.currencyCode, "currency", "", "currency code")
+	fs.StringVar(&f.nextPaymentDate, "next-payment", "", "next payment date ("+subscription_handler.PaymentDateLayout+")")
+}
+
+func (f *subscriptionFlags) registerTrial(fs *pflag.FlagSet) {
+	fs.StringVar(&f.trialStartDate, "trial-start", "", "first day of the trial ("+subscription_handler.PaymentDateLayout+")")
+	fs.StringVar(&f.trialEndDate, "trial-end", "", "start as a trial ending on this date, --price is charged after it")
+}
+
+// apply copies the flags that were set on the command line into the subscription.
+func (f *subscriptionFlags) apply(a *app.App, fs *pflag.FlagSet, s *entity.Subscription) error {
+	sf := a.ServiceFactory
+
+	if fs.Changed("name") {
+		s.Name = f.name
+	}
+
+	if fs.Changed("note") {
+		s.Note = f.note
+	}
+
+	if fs.Changed("logo") {
+		s.Logo = f.logo
+	}
+
+	if fs.Changed("price") {
+		s.Price = f.price
+	}
+
+	if fs.Changed("category") {
+		category, err := sf.CategoryService.GetCategory(a.Context, f.categoryID)
+		if err != nil {
+			return err
+		}
+
+		s.Category = *category
+	}
+
+	if fs.Changed("cycle") {
+		cycle, err := sf.CycleService.GetCycle(a.Context, f.cycleID)
+		if err != nil {
+			return err
+		}
+
+		s.Cycle = *cycle
+	}
+
+	if fs.Changed("currency") {
+		currency, err := sf.CurrencyService.GetCurrency(a.Context, f.currencyCode)
+		if err != nil {
+			return err
+		}
+
+		s.Currency = *currency
+	}
+
+	if fs.Changed("next-payment") {
+		date, err := time.Parse(subscription_handler.PaymentDateLayout, f.nextPaymentDate)
+		if err != nil {
+			return subscription_handler.ErrInvalidPaymentDate
+		}
+
+		s.NextPaymentDate = entity.PaymentDate(date)
+	}
+
+	if fs.Changed("trial-end") {
+		date, err := time.Parse(subscription_handler.PaymentDateLayout, f.trialEndDate)
+		if err != nil {
+			return subscription_handler.ErrInvalidPaymentDate
+		}
+
+		s.Status = entity.StatusTrial
+		s.TrialEndDate = entity.PaymentDate(date)
+	}
+
+	if fs.Changed("trial-start") {
+		date, err := time.Parse(subscription_handler.PaymentDateLayout, f.trialStartDate)
+		if err != nil {
+			return subscription_handler.ErrInvalidPaymentDate
+		}
+
+		s.TrialStartDate = entity.PaymentDate(date)
+	}
+
+	return nil
+}
+
+func newSubscriptionCmd() *cobra.Command {
+	subscriptionCmd := &cobra.Command{
+		Use:   "subscription",
+		Short: "Manage subscriptions",
+	}
+
+	subscriptionCmd.AddCommand(
+		newSubscriptionAddCmd(),
+		&cobra.Command{
+			Use:   "list",
+			Short: "List subscriptions",
+			Args:  cobra.NoArgs,
+			RunE: func(cmd *cobra.Command, _ []string) error {
+				application, err := loadApp()
+				if err != nil {
+					return err
+				}
+
+				subscriptions, err := application.ServiceFactory.SubscriptionService.GetAllSubscriptions(application.Context)
+				if err != nil {
+					return err
+				}
+
+				return printSubscriptions(application.Context, cmd, false, subscriptions...)
+			},
+		},
+		&cobra.Command{
+			Use:   "show ID",
+			Short: "Show a subscription",
+			Args:  cobra.ExactArgs(1),
+			RunE: func(cmd *cobra.Command, args []string) error {
+				id, err := parseID(args[0])
+				if err != nil {
+					return err
+				}
+
+				application, err := loadApp()
+				if err != nil {
+					return err
+				}
+
+				subscription, err := application.ServiceFactory.SubscriptionService.GetSubscription(application.Context, id)
+				if err != nil {
+					return err
+				}
+
+				return printSubscriptions(application.Context, cmd, true, *subscription)
+			},
+		},
+		newSubscriptionUpdateCmd(),
+		newSubscriptionActivateCmd(),
+		newSubscriptionPauseCmd(),
+		newSubscriptionCancelCmd(),
+		newSubscriptionExpireCmd(),
+		newSubscriptionImportCmd(),
+		newSubscriptionExportCmd(),
+		&cobra.Command{
+			Use:   "delete ID",
+			Short: "Delete a subscription",
+			Args:  cobra.ExactArgs(1),
+			RunE: func(cmd *cobra.Command, args []string) error {
+				id, err := parseID(args[0])
+				if err != nil {
+					return err
+				}
+
+				application, err := loadWritableApp()
+				if err != nil {
+					return err
+				}
+
+				return application.ServiceFactory.SubscriptionService.DeleteSubscription(application.Context, id)
+			},
+		},
+	)
+
+	return subscriptionCmd
+}
+
+func newSubscriptionAddCmd() *cobra.Command {
+	var flags subscriptionFlags
+
+	addCmd := &cobra.Command{
+		Use:   "add",
+		Short: "Add a subscription",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			application, err := loadWritableApp()
+			if err != nil {
+				return err
+			}
+
+			var subscription entity.Subscription
+
+			err = flags.apply(application, cmd.Flags(), &subscription)
+			if err != nil {
+				return err
+			}
+
+			created, err := application.ServiceFactory.SubscriptionService.CreateSubscription(application.Context, subscription)
+			if err != nil {
+				return err
+			}
+
+			return printSubscriptions(application.Context, cmd, true, *created)
+		},
+	}
+
+	flags.register(addCmd.Flags())
+	flags.registerTrial(addCmd.Flags())
+
+	for _, name := range []string{"name", "price", "cycle", "currency", "next-payment"} {
+		_ = addCmd.MarkFlagRequired(name)
+	}
+
+	return addCmd
+}
+
+func newSubscriptionUpdateCmd() *cobra.Command {
+	var flags subscriptionFlags
+
+	updateCmd := &cobra.Command{
+		Use:   "update ID",
+		Short: "Update a subscription",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			id, err := parseID(args[0])
+			if err != nil {
+				return err
+			}
+
+			application, err := loadWritableApp()
+			if err != nil {
+				return err
+			}
+
+			ss := application.ServiceFactory.SubscriptionService
+
+			subscription, err := ss.GetSubscription(application.Context, id)
+			if err != nil {
+				return err
+			}
+
+			err = flags.apply(application, cmd.Flags(), subscription)
+			if err != nil {
+				return err
+			}
+
+			subscription, err = ss.UpdateSubscription(application.Context, *subscription)
+			if err != nil {
+				return err
+			}
+
+			return printSubscriptions(application.Context, cmd, true, *subscription)
+		},
+	}
+
+	flags.register(updateCmd.Flags())
+
+	return updateCmd
+}
+
+func (v subscriptionView) row() []string {
+	return []string{
+		formatUint(v.ID), v.Name, formatPrice(v.Price), v.CurrencyCode,
+		formatUint(v.CategoryID), formatUint(v.CycleID), v.NextPaymentDate, v.Status,
+	}
+}
+
+func printSubscriptions(ctx context.Context, cmd *cobra.Command, single bool, subscriptions ...entity.Subscription) error {
+	today := clock.Today(ctx)
+	views := make([]subscriptionView, 0, len(subscriptions))
+	for _, s := range subscriptions {
+		views = append(views, subscriptionView{
+			ID:              s.ID,
+			Name:            s.Name,
+			Note:            s.Note,
+			Logo:            s.Logo,
+			Price:           s.Price,
+			CategoryID:      s.Category.ID,
+			CycleID:         s.Cycle.ID,
+			CurrencyCode:    s.Currency.Code,
+			NextPaymentDate: time.Time(s.NextPaymentDate).Format(subscription_handler.PaymentDateLayout),
+			Status:          string(s.StatusAt(today)),
+		})
+	}
+
+	header := []string{"ID", "NAME", "PRICE", "CURRENCY", "CATEGORY", "CYCLE", "NEXT PAYMENT", "STATUS"}
+
+	return printItems(cmd.OutOrStdout(), header, views, single)
+}
@@ -0,0 +1,104 @@
+package cmd
+
+import (
+	"context"
+	"time"
+
+	"git.home/alex/go-subscriptions/internal/api/handler/subscription_handler"
+	"git.home/alex/go-subscriptions/internal/domain/entity"
+	"git.home/alex/go-subscriptions/internal/domain/service"
+	"github.com/spf13/cobra"
+)
+
+type statusTransition func(ctx context.Context, ss *service.SubscriptionService, id uint) (*entity.Subscription, error)
+
+// newStatusCmd builds a command that applies a lifecycle transition to the subscription given by ID.
+func newStatusCmd(use, short string, transition statusTransition) *cobra.Command {
+	return &cobra.Command{
+		Use:   use + " ID",
+		Short: short,
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			id, err := parseID(args[0])
+			if err != nil {
+				return err
+			}
+
+			application, err := loadWritableApp()
+			if err != nil {
+				return err
+			}
+
+			subscription, err := transition(application.Context, application.ServiceFactory.SubscriptionService, id)
+			if err != nil {
+				return err
+			}
+
+			return printSubscriptions(application.Context, cmd, true, *subscription)
+		},
+	}
+}
+
+func newSubscriptionActivateCmd() *cobra.Command {
+	return newStatusCmd("activate", "Convert a trial, resume or reactivate a subscription",
+		func(ctx context.Context, ss *service.SubscriptionService, id uint) (*entity.Subscription, error) {
+			return ss.Activate(ctx, id)
+		})
+}
+
+func newSubscriptionPauseCmd() *cobra.Command {
+	var until string
+
+	pauseCmd := newStatusCmd("pause", "Pause a subscription",
+		func(ctx context.Context, ss *service.SubscriptionService, id uint) (*entity.Subscription, error) {
+			resume, err := parseOptionalDate(until)
+			if err != nil {
+				return nil, err
+			}
+
+			return ss.Pause(ctx, id, resume)
+		})
+
+	pauseCmd.Flags().StringVar(&until, "until", "",
+		"resume date ("+subscription_handler.PaymentDateLayout+"), paused until activated if empty")
+
+	return pauseCmd
+}
+
+func newSubscriptionCancelCmd() *cobra.Command {
+	var on string
+
+	cancelCmd := newStatusCmd("cancel", "Cancel a subscription",
+		func(ctx context.Context, ss *service.SubscriptionService, id uint) (*entity.Subscription, error) {
+			effective, err := parseOptionalDate(on)
+			if err != nil {
+				return nil, err
+			}
+
+			return ss.Cancel(ctx, id, effective)
+		})
+
+	cancelCmd.Flags().StringVar(&on, "on", "", "effective date ("+subscription_handler.PaymentDateLayout+"), today if empty")
+
+	return cancelCmd
+}
+
+func newSubscriptionExpireCmd() *cobra.Command {
+	return newStatusCmd("expire", "Mark a subscription as expired",
+		func(ctx context.Context, ss *service.SubscriptionService, id uint) (*entity.Subscription, error) {
+			return ss.Expire(ctx, id)
+		})
+}
+
+func parseOptionalDate(value string) (time.Time, error) {
+	if value == "" {
+		return time.Time{}, nil
+	}
+
+	date, err := time.Parse(subscription_handler.PaymentDateLayout, value)
+	if err != nil {
+		return time.Time{}, subscription_handler.ErrInvalidPaymentDate
+	}
+
+	return date, nil
+}
@@ -0,0 +1,118 @@
+package asset_handler_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"image"
+	"image/png"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"git.home/alex/go-subscriptions/internal/api/handler"
+	"git.home/alex/go-subscriptions/internal/api/handler/asset_handler"
+	"git.home/alex/go-subscriptions/internal/blobstore"
+	"git.home/alex/go-subscriptions/internal/domain/entity"
+	"git.home/alex/go-subscriptions/internal/domain/service"
+	"git.home/alex/go-subscriptions/internal/repository/memory"
+	"github.com/julienschmidt/httprouter"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newRouter(t *testing.T) *httprouter.Router {
+	t.Helper()
+
+	ctx := context.Background()
+	subscriptions := memory.NewSubscriptionRepository()
+
+	_, err := subscriptions.Create(ctx, entity.Subscription{Name: "Streaming", Price: 10})
+	require.NoError(t, err)
+
+	store, err := blobstore.NewFileSystem(t.TempDir())
+	require.NoError(t, err)
+
+	as := service.NewAssetService(memory.NewAssetRepository(), subscriptions, store, 0)
+
+	router := httprouter.New()
+	router.POST("/api/subscription/:id/logo", handler.Handle(asset_handler.UploadLogo(ctx, as)))
+	router.GET("/api/asset/:id", asset_handler.Serve(ctx, as, false))
+	router.GET("/api/asset/:id/thumbnail", asset_handler.Serve(ctx, as, true))
+
+	return router
+}
+
+func newUploadRequest(t *testing.T, content []byte) *http.Request {
+	t.Helper()
+
+	var body bytes.Buffer
+
+	mw := multipart.NewWriter(&body)
+	fw, err := mw.CreateFormFile(asset_handler.FileField, "logo.png")
+	require.NoError(t, err)
+	_, _ = fw.Write(content)
+	require.NoError(t, mw.Close())
+
+	r := httptest.NewRequest(http.MethodPost, "/api/subscription/1/logo", &body)
+	r.Header.Set("Content-Type", mw.FormDataContentType())
+
+	return r
+}
+
+func TestUploadAndServe(t *testing.T) {
+	router := newRouter(t)
+
+	var img bytes.Buffer
+	require.NoError(t, png.Encode(&img, image.NewGray(image.Rect(0, 0, 256, 256))))
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, newUploadRequest(t, img.Bytes()))
+
+	var uploaded struct {
+		Status string `json:"status"`
+		Data   struct {
+			ID           uint   `json:"id"`
+			ContentType  string `json:"content_type"`
+			Checksum     string `json:"checksum"`
+			URL          string `json:"url"`
+			ThumbnailURL string `json:"thumbnail_url"`
+		} `json:"data"`
+	}
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &uploaded))
+	require.Equal(t, "success", uploaded.Status, w.Body.String())
+	assert.Equal(t, "image/png", uploaded.Data.ContentType)
+	assert.Equal(t, "/api/asset/1", uploaded.Data.URL)
+
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, uploaded.Data.URL, nil))
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, img.Bytes(), w.Body.Bytes())
+	assert.Equal(t, "image/png", w.Header().Get("Content-Type"))
+	assert.Contains(t, w.Header().Get("Cache-Control"), "immutable")
+	assert.Equal(t, `"`+uploaded.Data.Checksum+`"`, w.Header().Get("ETag"))
+
+	r := httptest.NewRequest(http.MethodGet, uploaded.Data.URL, nil)
+	r.Header.Set("If-None-Match", w.Header().Get("ETag"))
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, r)
+	assert.Equal(t, http.StatusNotModified, w.Code)
+	assert.Empty(t, w.Body.Bytes())
+
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, uploaded.Data.ThumbnailURL, nil))
+	require.Equal(t, http.StatusOK, w.Code)
+
+	thumb, err := png.Decode(w.Body)
+	require.NoError(t, err)
+	assert.Equal(t, image.Rect(0, 0, 128, 128), thumb.Bounds())
+
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/api/asset/2", nil))
+	assert.JSONEq(t, `{"status":"error","data":null,"error":"the asset was not found in the repository"}`, w.Body.String())
+
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, newUploadRequest(t, []byte("not an image")))
+	assert.JSONEq(t, `{"status":"error","data":null,"error":"the file is not a PNG, JPEG or GIF image"}`, w.Body.String())
+}
@@ -0,0 +1,36 @@
+package asset_handler
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+
+	"git.home/alex/go-subscriptions/internal/api/api_response"
+	"git.home/alex/go-subscriptions/internal/auth"
+	"git.home/alex/go-subscriptions/internal/domain/service"
+	"github.com/julienschmidt/httprouter"
+)
+
+// RemoveLogo unlinks the uploaded logo from the subscription and deletes the image.
+func RemoveLogo(ctx context.Context, as *service.AssetService) api_response.Handle {
+	return func(r *http.Request, ps httprouter.Params) any {
+		ctx := auth.RequestContext(ctx, r)
+
+		id, err := strconv.Atoi(ps.ByName("id"))
+		if err != nil {
+			return err
+		}
+
+		subscription, err := as.RemoveSubscriptionLogo(ctx, uint(id))
+		if err != nil {
+			return err
+		}
+
+		type resp struct {
+			SubscriptionID uint `json:"subscription_id"`
+			LogoAssetID    uint `json:"logo_asset_id"`
+		}
+
+		return resp{SubscriptionID: subscription.ID, LogoAssetID: subscription.LogoAssetID}
+	}
+}
@@ -0,0 +1,32 @@
+package asset_handler
+
+import (
+	"strconv"
+	"time"
+
+	"git.home/alex/go-subscriptions/internal/domain/entity"
+)
+
+type assetResp struct {
+	ID           uint      `json:"id"`
+	ContentType  string    `json:"content_type"`
+	Size         int64     `json:"size"`
+	Checksum     string    `json:"checksum"`
+	CreatedAt    time.Time `json:"created_at"`
+	URL          string    `json:"url"`
+	ThumbnailURL string    `json:"thumbnail_url"`
+}
+
+func newAssetResp(asset *entity.Asset) assetResp {
+	url := "/api/asset/" + strconv.FormatUint(uint64(asset.ID), 10)
+
+	return assetResp{
+		ID:           asset.ID,
+		ContentType:  asset.ContentType,
+		Size:         asset.Size,
+		Checksum:     asset.Checksum,
+		CreatedAt:    asset.CreatedAt,
+		URL:          url,
+		ThumbnailURL: url + "/thumbnail",
+	}
+}
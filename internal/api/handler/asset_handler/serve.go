@@ -0,0 +1,64 @@
+package asset_handler
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"strconv"
+
+	"git.home/alex/go-subscriptions/internal/api/api_response"
+	"git.home/alex/go-subscriptions/internal/auth"
+	"git.home/alex/go-subscriptions/internal/domain/service"
+	"github.com/julienschmidt/httprouter"
+)
+
+// Assets never change once uploaded, so clients may keep them for a year without revalidating.
+const cacheControl = "private, max-age=31536000, immutable"
+
+// Serve writes the image, or its thumbnail, with caching headers. Conditional requests with the
+// ETag are answered with 304 Not Modified.
+func Serve(ctx context.Context, as *service.AssetService, thumbnail bool) httprouter.Handle {
+	return func(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
+		ctx := auth.RequestContext(ctx, r)
+
+		id, err := strconv.Atoi(ps.ByName("id"))
+		if err != nil {
+			writeError(w, err)
+
+			return
+		}
+
+		asset, content, err := as.OpenAsset(ctx, uint(id), thumbnail)
+		if err != nil {
+			writeError(w, err)
+
+			return
+		}
+		defer content.Close()
+
+		data, err := io.ReadAll(content)
+		if err != nil {
+			writeError(w, err)
+
+			return
+		}
+
+		contentType, etag := asset.ContentType, asset.Checksum
+		if thumbnail {
+			contentType, etag = service.ThumbnailContentType, etag+"-thumb"
+		}
+
+		w.Header().Set("Content-Type", contentType)
+		w.Header().Set("Cache-Control", cacheControl)
+		w.Header().Set("ETag", `"`+etag+`"`)
+		w.Header().Set("X-Content-Type-Options", "nosniff")
+		http.ServeContent(w, r, "", asset.CreatedAt, bytes.NewReader(data))
+	}
+}
+
+func writeError(w http.ResponseWriter, err error) {
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(api_response.Error(err))
+}
@@ -0,0 +1,62 @@
+package asset_handler
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strconv"
+
+	"git.home/alex/go-subscriptions/internal/api/api_response"
+	"git.home/alex/go-subscriptions/internal/auth"
+	"git.home/alex/go-subscriptions/internal/domain/service"
+	"github.com/julienschmidt/httprouter"
+)
+
+const (
+	FileField = "file"
+
+	// maxRequestSize caps the whole form; the service enforces the configured limit on the image itself.
+	maxRequestSize = 32 << 20
+	maxMemory      = 1 << 20
+)
+
+var (
+	ErrMissingFile = errors.New("the image file is missing from the form")
+)
+
+// UploadLogo reads the "file" field of a multipart form and makes the image the logo of the subscription.
+func UploadLogo(ctx context.Context, as *service.AssetService) api_response.Handle {
+	return func(r *http.Request, ps httprouter.Params) any {
+		ctx := auth.RequestContext(ctx, r)
+
+		id, err := strconv.Atoi(ps.ByName("id"))
+		if err != nil {
+			return err
+		}
+
+		r.Body = http.MaxBytesReader(nil, r.Body, maxRequestSize)
+
+		err = r.ParseMultipartForm(maxMemory)
+		if err != nil {
+			var tooLarge *http.MaxBytesError
+			if errors.As(err, &tooLarge) {
+				return service.ErrAssetTooLarge
+			}
+
+			return err
+		}
+
+		file, _, err := r.FormFile(FileField)
+		if err != nil {
+			return ErrMissingFile
+		}
+		defer file.Close()
+
+		asset, err := as.SetSubscriptionLogo(ctx, uint(id), file)
+		if err != nil {
+			return err
+		}
+
+		return newAssetResp(asset)
+	}
+}
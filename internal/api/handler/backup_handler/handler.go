@@ -0,0 +1,89 @@
+package backup_handler
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"git.home/alex/go-subscriptions/internal/api/api_response"
+	"git.home/alex/go-subscriptions/internal/auth"
+	"git.home/alex/go-subscriptions/internal/backup"
+	"git.home/alex/go-subscriptions/internal/domain/service"
+	"git.home/alex/go-subscriptions/internal/factory"
+	"github.com/julienschmidt/httprouter"
+)
+
+const (
+	fileName = "subscriptions-backup.json"
+
+	// maxArchiveSize caps restores through the API; the restore command reads larger archives from disk.
+	maxArchiveSize = 1 << 30
+)
+
+var (
+	ErrArchiveTooLarge = errors.New("the backup archive is larger than 1 GiB")
+)
+
+// Backup downloads the archive of all entities. Only admin callers may use it.
+func Backup(ctx context.Context, rf *factory.RepositoryFactory) httprouter.Handle {
+	return func(w http.ResponseWriter, r *http.Request, _ httprouter.Params) {
+		ctx := auth.RequestContext(ctx, r)
+
+		var buf bytes.Buffer
+
+		err := backupTo(ctx, rf, &buf)
+		if err != nil {
+			w.Header().Set("Content-Type", "application/json")
+			_ = json.NewEncoder(w).Encode(api_response.Error(err))
+
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set("Content-Disposition", `attachment; filename="`+fileName+`"`)
+		_, _ = w.Write(buf.Bytes())
+	}
+}
+
+// Restore loads an archive sent as the request body into the empty storage.
+func Restore(ctx context.Context, rf *factory.RepositoryFactory) api_response.Handle {
+	return func(r *http.Request, _ httprouter.Params) any {
+		ctx := auth.RequestContext(ctx, r)
+
+		if !auth.IsAdmin(ctx) {
+			return service.ErrAccessDenied
+		}
+
+		archive, err := backup.Read(http.MaxBytesReader(nil, r.Body, maxArchiveSize))
+		if err != nil {
+			var tooLarge *http.MaxBytesError
+			if errors.As(err, &tooLarge) {
+				return ErrArchiveTooLarge
+			}
+
+			return err
+		}
+
+		err = backup.Restore(ctx, rf, archive)
+		if err != nil {
+			return err
+		}
+
+		return archive.Counts()
+	}
+}
+
+func backupTo(ctx context.Context, rf *factory.RepositoryFactory, buf *bytes.Buffer) error {
+	if !auth.IsAdmin(ctx) {
+		return service.ErrAccessDenied
+	}
+
+	archive, err := backup.Dump(ctx, rf)
+	if err != nil {
+		return err
+	}
+
+	return backup.Write(buf, archive)
+}
@@ -0,0 +1,75 @@
+package backup_handler_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"git.home/alex/go-subscriptions/internal/api/api_response"
+	"git.home/alex/go-subscriptions/internal/api/handler/backup_handler"
+	"git.home/alex/go-subscriptions/internal/auth"
+	"git.home/alex/go-subscriptions/internal/backup"
+	"git.home/alex/go-subscriptions/internal/domain/entity"
+	"git.home/alex/go-subscriptions/internal/domain/service"
+	"git.home/alex/go-subscriptions/internal/factory"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBackupRestore(t *testing.T) {
+	ctx := context.Background()
+
+	source, err := factory.NewRepositoryFactory(factory.WithMemoryRepository())
+	require.NoError(t, err)
+	_, err = source.CategoryRepository.Create(ctx, entity.Category{Name: "Music"})
+	require.NoError(t, err)
+
+	w := httptest.NewRecorder()
+	backup_handler.Backup(ctx, source)(w, httptest.NewRequest(http.MethodGet, "/api/admin/backup", nil), nil)
+	assert.Contains(t, w.Header().Get("Content-Disposition"), "attachment")
+
+	target, err := factory.NewRepositoryFactory(factory.WithMemoryRepository())
+	require.NoError(t, err)
+
+	r := httptest.NewRequest(http.MethodPost, "/api/admin/restore", w.Body)
+	response := backup_handler.Restore(ctx, target)(r, nil)
+
+	assert.Equal(t, 1, response.(map[string]int)["categories"])
+
+	category, err := target.CategoryRepository.Get(ctx, 1)
+	require.NoError(t, err)
+	assert.Equal(t, "Music", category.Name)
+}
+
+func TestBackupRestore_AccessDenied(t *testing.T) {
+	rf, err := factory.NewRepositoryFactory(factory.WithMemoryRepository())
+	require.NoError(t, err)
+
+	userCtx := auth.WithPrincipal(context.Background(), &auth.Principal{UserID: 1, Scope: auth.ScopeReadWrite})
+
+	w := httptest.NewRecorder()
+	backup_handler.Backup(context.Background(), rf)(w, httptest.NewRequest(http.MethodGet, "/", nil).WithContext(userCtx), nil)
+
+	var dto api_response.ResponseDTO
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &dto))
+	assert.Equal(t, api_response.Error(service.ErrAccessDenied), dto)
+
+	r := httptest.NewRequest(http.MethodPost, "/", strings.NewReader(`{"version":1}`)).WithContext(userCtx)
+	response := backup_handler.Restore(context.Background(), rf)(r, nil)
+	assert.ErrorIs(t, response.(error), service.ErrAccessDenied)
+
+	unboundCtx := auth.WithPrincipal(context.Background(), &auth.Principal{Subject: "token", Scope: auth.ScopeReadWrite})
+
+	r = httptest.NewRequest(http.MethodPost, "/", strings.NewReader(`{"version":1}`)).WithContext(unboundCtx)
+	response = backup_handler.Restore(context.Background(), rf)(r, nil)
+	assert.ErrorIs(t, response.(error), service.ErrAccessDenied)
+
+	adminCtx := auth.WithPrincipal(context.Background(), &auth.Principal{Subject: "admin", Scope: auth.ScopeAdmin})
+
+	r = httptest.NewRequest(http.MethodPost, "/", strings.NewReader(`{"version":99}`)).WithContext(adminCtx)
+	response = backup_handler.Restore(context.Background(), rf)(r, nil)
+	assert.ErrorIs(t, response.(error), backup.ErrUnsupportedVersion)
+}
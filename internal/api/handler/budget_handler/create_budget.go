@@ -0,0 +1,32 @@
+package budget_handler
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+
+	"git.home/alex/go-subscriptions/internal/api/api_response"
+	"git.home/alex/go-subscriptions/internal/auth"
+	"git.home/alex/go-subscriptions/internal/domain/service"
+	"github.com/julienschmidt/httprouter"
+)
+
+func CreateBudget(ctx context.Context, bs *service.BudgetService) api_response.Handle {
+	return func(r *http.Request, _ httprouter.Params) any {
+		ctx := auth.RequestContext(ctx, r)
+
+		var req budgetReq
+
+		err := json.NewDecoder(r.Body).Decode(&req)
+		if err != nil {
+			return err
+		}
+
+		createdBudget, err := bs.CreateBudget(ctx, req.entity(0))
+		if err != nil {
+			return err
+		}
+
+		return newBudgetResp(createdBudget)
+	}
+}
@@ -0,0 +1,29 @@
+package budget_handler
+
+import (
+	"context"
+	"net/http"
+
+	"git.home/alex/go-subscriptions/internal/api/api_response"
+	"git.home/alex/go-subscriptions/internal/auth"
+	"git.home/alex/go-subscriptions/internal/domain/service"
+	"github.com/julienschmidt/httprouter"
+)
+
+func GetBudgets(ctx context.Context, bs *service.BudgetService) api_response.Handle {
+	return func(r *http.Request, _ httprouter.Params) any {
+		ctx := auth.RequestContext(ctx, r)
+
+		budgets, err := bs.GetAllBudgets(ctx)
+		if err != nil {
+			return err
+		}
+
+		budgetDTOs := make([]budgetResp, len(budgets))
+		for i := range budgets {
+			budgetDTOs[i] = newBudgetResp(&budgets[i])
+		}
+
+		return budgetDTOs
+	}
+}
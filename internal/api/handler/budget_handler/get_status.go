@@ -0,0 +1,48 @@
+package budget_handler
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+
+	"git.home/alex/go-subscriptions/internal/api/api_response"
+	"git.home/alex/go-subscriptions/internal/auth"
+	"git.home/alex/go-subscriptions/internal/domain/service"
+	"github.com/julienschmidt/httprouter"
+)
+
+func GetStatus(ctx context.Context, bs *service.BudgetService) api_response.Handle {
+	return func(r *http.Request, ps httprouter.Params) any {
+		ctx := auth.RequestContext(ctx, r)
+
+		id, err := strconv.Atoi(ps.ByName("id"))
+		if err != nil {
+			return err
+		}
+
+		status, err := bs.GetStatus(ctx, uint(id))
+		if err != nil {
+			return err
+		}
+
+		return newStatusResp(status)
+	}
+}
+
+func GetStatuses(ctx context.Context, bs *service.BudgetService) api_response.Handle {
+	return func(r *http.Request, _ httprouter.Params) any {
+		ctx := auth.RequestContext(ctx, r)
+
+		statuses, err := bs.GetStatuses(ctx)
+		if err != nil {
+			return err
+		}
+
+		statusDTOs := make([]statusResp, len(statuses))
+		for i := range statuses {
+			statusDTOs[i] = newStatusResp(&statuses[i])
+		}
+
+		return statusDTOs
+	}
+}
@@ -0,0 +1,80 @@
+package budget_handler_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"git.home/alex/go-subscriptions/internal/api/handler/budget_handler"
+	"git.home/alex/go-subscriptions/internal/domain/entity"
+	"git.home/alex/go-subscriptions/internal/domain/service"
+	"git.home/alex/go-subscriptions/internal/repository/memory"
+	"git.home/alex/go-subscriptions/tests/tests_assert"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type budgetDTO struct {
+	ID           uint    `json:"id"`
+	CategoryID   uint    `json:"category_id,omitempty"`
+	Period       string  `json:"period"`
+	Amount       float64 `json:"amount"`
+	CurrencyCode string  `json:"currency"`
+}
+
+type statusDTO struct {
+	budgetDTO
+	Spent       float64 `json:"spent"`
+	Remaining   float64 `json:"remaining"`
+	Utilization float64 `json:"utilization"`
+	OverBudget  bool    `json:"over_budget"`
+}
+
+func TestBudgetStatus(t *testing.T) {
+	ctx := context.Background()
+	subscriptions := memory.NewSubscriptionRepository()
+	bs := service.NewBudgetService(memory.NewBudgetRepository(), memory.NewCategoryRepository(), subscriptions)
+
+	_, err := subscriptions.Create(ctx, entity.Subscription{Name: "Cloud", Price: 60, Currency: entity.USD, Cycle: entity.Yearly})
+	require.NoError(t, err)
+
+	testCases := []struct {
+		name     string
+		body     string
+		expected budgetDTO
+		wantErr  error
+	}{
+		{
+			name:     "Overall budget",
+			body:     `{"period":"monthly","amount":4,"currency":"USD"}`,
+			expected: budgetDTO{ID: 1, Period: "monthly", Amount: 4, CurrencyCode: "USD"},
+		},
+		{name: "Second overall budget", body: `{"period":"yearly","amount":4,"currency":"USD"}`, wantErr: service.ErrBudgetExists},
+		{name: "Unknown category", body: `{"category_id":3,"period":"yearly","amount":4,"currency":"USD"}`, wantErr: service.ErrInvalidBudget},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			r := httptest.NewRequest(http.MethodPost, "/api/budget", strings.NewReader(tc.body))
+			response := budget_handler.CreateBudget(ctx, bs)(r, nil)
+
+			if tc.wantErr != nil {
+				assert.ErrorIs(t, response.(error), tc.wantErr)
+				return
+			}
+
+			tests_assert.EqualAsJSON(t, tc.expected, response)
+		})
+	}
+
+	response := budget_handler.GetStatuses(ctx, bs)(httptest.NewRequest(http.MethodGet, "/api/budgets/status", nil), nil)
+	tests_assert.EqualAsJSON(t, []statusDTO{{
+		budgetDTO:   budgetDTO{ID: 1, Period: "monthly", Amount: 4, CurrencyCode: "USD"},
+		Spent:       5,
+		Remaining:   -1,
+		Utilization: 125,
+		OverBudget:  true,
+	}}, response)
+}
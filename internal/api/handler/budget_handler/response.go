@@ -0,0 +1,54 @@
+package budget_handler
+
+import (
+	"git.home/alex/go-subscriptions/internal/domain/entity"
+	"git.home/alex/go-subscriptions/internal/domain/service"
+)
+
+type budgetReq struct {
+	// CategoryID is zero for the overall budget.
+	CategoryID   uint                `json:"category_id"`
+	Period       entity.BudgetPeriod `json:"period"`
+	Amount       float64             `json:"amount"`
+	CurrencyCode string              `json:"currency"`
+}
+
+func (req budgetReq) entity(id uint) entity.Budget {
+	return entity.Budget{ID: id, CategoryID: req.CategoryID, Period: req.Period, Amount: req.Amount, CurrencyCode: req.CurrencyCode}
+}
+
+type budgetResp struct {
+	ID           uint    `json:"id"`
+	CategoryID   uint    `json:"category_id,omitempty"`
+	Period       string  `json:"period"`
+	Amount       float64 `json:"amount"`
+	CurrencyCode string  `json:"currency"`
+}
+
+func newBudgetResp(budget *entity.Budget) budgetResp {
+	return budgetResp{
+		ID:           budget.ID,
+		CategoryID:   budget.CategoryID,
+		Period:       string(budget.Period),
+		Amount:       budget.Amount,
+		CurrencyCode: budget.CurrencyCode,
+	}
+}
+
+type statusResp struct {
+	budgetResp
+	Spent       float64 `json:"spent"`
+	Remaining   float64 `json:"remaining"`
+	Utilization float64 `json:"utilization"`
+	OverBudget  bool    `json:"over_budget"`
+}
+
+func newStatusResp(status *service.BudgetStatus) statusResp {
+	return statusResp{
+		budgetResp:  newBudgetResp(&status.Budget),
+		Spent:       status.Spent,
+		Remaining:   status.Remaining,
+		Utilization: status.Utilization,
+		OverBudget:  status.OverBudget(),
+	}
+}
@@ -0,0 +1,38 @@
+package budget_handler
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strconv"
+
+	"git.home/alex/go-subscriptions/internal/api/api_response"
+	"git.home/alex/go-subscriptions/internal/auth"
+	"git.home/alex/go-subscriptions/internal/domain/service"
+	"github.com/julienschmidt/httprouter"
+)
+
+func UpdateBudget(ctx context.Context, bs *service.BudgetService) api_response.Handle {
+	return func(r *http.Request, ps httprouter.Params) any {
+		ctx := auth.RequestContext(ctx, r)
+
+		id, err := strconv.Atoi(ps.ByName("id"))
+		if err != nil {
+			return err
+		}
+
+		var req budgetReq
+
+		err = json.NewDecoder(r.Body).Decode(&req)
+		if err != nil {
+			return err
+		}
+
+		updatedBudget, err := bs.UpdateBudget(ctx, req.entity(uint(id)))
+		if err != nil {
+			return err
+		}
+
+		return newBudgetResp(updatedBudget)
+	}
+}
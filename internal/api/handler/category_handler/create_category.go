@@ -6,6 +6,7 @@ import (
 	"net/http"
 
 	"git.home/alex/go-subscriptions/internal/api/api_response"
+	"git.home/alex/go-subscriptions/internal/auth"
 	"git.home/alex/go-subscriptions/internal/domain/entity"
 	"git.home/alex/go-subscriptions/internal/domain/service"
 	"github.com/julienschmidt/httprouter"
@@ -13,8 +14,11 @@ import (
 
 func CreateCategory(ctx context.Context, cs *service.CategoryService) api_response.Handle {
 	return func(r *http.Request, _ httprouter.Params) any {
+		ctx := auth.RequestContext(ctx, r)
+
 		var req struct {
-			Name string `json:"name"`
+			Name     string `json:"name"`
+			ParentID uint   `json:"parent_id"`
 		}
 
 		err := json.NewDecoder(r.Body).Decode(&req)
@@ -23,20 +27,23 @@ func CreateCategory(ctx context.Context, cs *service.CategoryService) api_respon
 		}
 
 		createdCategory, err := cs.CreateCategory(ctx, entity.Category{
-			Name: req.Name,
+			Name:     req.Name,
+			ParentID: req.ParentID,
 		})
 		if err != nil {
 			return err
 		}
 
 		type resp struct {
-			ID   uint   `json:"id"`
-			Name string `json:"name"`
+			ID       uint   `json:"id"`
+			ParentID uint   `json:"parent_id,omitempty"`
+			Name     string `json:"name"`
 		}
 
 		return resp{
-			ID:   createdCategory.ID,
-			Name: createdCategory.Name,
+			ID:       createdCategory.ID,
+			ParentID: createdCategory.ParentID,
+			Name:     createdCategory.Name,
 		}
 	}
 }
@@ -49,7 +49,7 @@ func TestCreateCategory(t *testing.T) {
 		},
 	}
 
-	cs := service.NewCategoryService(memory.NewCategoryRepository())
+	cs := service.NewCategoryService(memory.NewCategoryRepository(), memory.NewSubscriptionRepository())
 	ctx := context.Background()
 
 	for _, tc := range testCases {
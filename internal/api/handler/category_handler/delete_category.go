@@ -6,12 +6,15 @@ import (
 	"strconv"
 
 	"git.home/alex/go-subscriptions/internal/api/api_response"
+	"git.home/alex/go-subscriptions/internal/auth"
 	"git.home/alex/go-subscriptions/internal/domain/service"
 	"github.com/julienschmidt/httprouter"
 )
 
 func DeleteCategory(ctx context.Context, cs *service.CategoryService) api_response.Handle {
-	return func(_ *http.Request, ps httprouter.Params) any {
+	return func(r *http.Request, ps httprouter.Params) any {
+		ctx := auth.RequestContext(ctx, r)
+
 		id, err := strconv.Atoi(ps.ByName("id"))
 		if err != nil {
 			return err
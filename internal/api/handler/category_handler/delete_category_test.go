@@ -34,7 +34,7 @@ func TestDeleteCategory(t *testing.T) {
 		},
 	}
 
-	cs := service.NewCategoryService(memory.NewCategoryRepository())
+	cs := service.NewCategoryService(memory.NewCategoryRepository(), memory.NewSubscriptionRepository())
 	ctx := context.Background()
 
 	for _, tc := range testCases {
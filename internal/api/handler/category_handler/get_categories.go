@@ -5,27 +5,32 @@ import (
 	"net/http"
 
 	"git.home/alex/go-subscriptions/internal/api/api_response"
+	"git.home/alex/go-subscriptions/internal/auth"
 	"git.home/alex/go-subscriptions/internal/domain/service"
 	"github.com/julienschmidt/httprouter"
 )
 
 func GetCategories(ctx context.Context, cs *service.CategoryService) api_response.Handle {
-	return func(_ *http.Request, _ httprouter.Params) any {
+	return func(r *http.Request, _ httprouter.Params) any {
+		ctx := auth.RequestContext(ctx, r)
+
 		categories, err := cs.GetAllCategories(ctx)
 		if err != nil {
 			return err
 		}
 
 		type resp struct {
-			ID   uint   `json:"id"`
-			Name string `json:"name"`
+			ID       uint   `json:"id"`
+			ParentID uint   `json:"parent_id,omitempty"`
+			Name     string `json:"name"`
 		}
 
 		categoryDTOs := make([]resp, len(categories))
 		for i, category := range categories {
 			categoryDTOs[i] = resp{
-				ID:   category.ID,
-				Name: category.Name,
+				ID:       category.ID,
+				ParentID: category.ParentID,
+				Name:     category.Name,
 			}
 		}
 
@@ -54,7 +54,7 @@ func TestGetCategories(t *testing.T) {
 			mockRepo := new(mock_repository.MockCategoryRepository)
 			mockRepo.On("GetAll", ctx).Return(tc.categories, tc.mockError)
 
-			cs := service.NewCategoryService(mockRepo)
+			cs := service.NewCategoryService(mockRepo, new(mock_repository.MockSubscriptionRepository))
 
 			response := category_handler.GetCategories(ctx, cs)(nil, nil)
 
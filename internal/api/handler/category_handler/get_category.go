@@ -6,12 +6,15 @@ import (
 	"strconv"
 
 	"git.home/alex/go-subscriptions/internal/api/api_response"
+	"git.home/alex/go-subscriptions/internal/auth"
 	"git.home/alex/go-subscriptions/internal/domain/service"
 	"github.com/julienschmidt/httprouter"
 )
 
 func GetCategory(ctx context.Context, cs *service.CategoryService) api_response.Handle {
-	return func(_ *http.Request, ps httprouter.Params) any {
+	return func(r *http.Request, ps httprouter.Params) any {
+		ctx := auth.RequestContext(ctx, r)
+
 		id, err := strconv.Atoi(ps.ByName("id"))
 		if err != nil {
 			return err
@@ -23,13 +26,15 @@ func GetCategory(ctx context.Context, cs *service.CategoryService) api_response.
 		}
 
 		type resp struct {
-			ID   uint   `json:"id"`
-			Name string `json:"name"`
+			ID       uint   `json:"id"`
+			ParentID uint   `json:"parent_id,omitempty"`
+			Name     string `json:"name"`
 		}
 
 		return resp{
-			ID:   category.ID,
-			Name: category.Name,
+			ID:       category.ID,
+			ParentID: category.ParentID,
+			Name:     category.Name,
 		}
 	}
 }
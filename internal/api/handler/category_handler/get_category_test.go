@@ -44,7 +44,7 @@ func TestGetCategory(t *testing.T) {
 		},
 	}
 
-	cs := service.NewCategoryService(memory.NewCategoryRepository())
+	cs := service.NewCategoryService(memory.NewCategoryRepository(), memory.NewSubscriptionRepository())
 	ctx := context.Background()
 
 	for _, tc := range testCases {
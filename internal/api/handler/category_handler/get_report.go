@@ -0,0 +1,43 @@
+package category_handler
+
+import (
+	"context"
+	"net/http"
+
+	"git.home/alex/go-subscriptions/internal/api/api_response"
+	"git.home/alex/go-subscriptions/internal/auth"
+	"git.home/alex/go-subscriptions/internal/domain/service"
+	"github.com/julienschmidt/httprouter"
+)
+
+func GetReport(ctx context.Context, cs *service.CategoryService) api_response.Handle {
+	return func(r *http.Request, _ httprouter.Params) any {
+		ctx := auth.RequestContext(ctx, r)
+
+		reports, err := cs.GetReport(ctx)
+		if err != nil {
+			return err
+		}
+
+		type resp struct {
+			CategoryID    uint               `json:"category_id"`
+			ParentID      uint               `json:"parent_id,omitempty"`
+			Name          string             `json:"name"`
+			Monthly       map[string]float64 `json:"monthly"`
+			Subscriptions int                `json:"subscriptions"`
+		}
+
+		reportDTOs := make([]resp, len(reports))
+		for i, report := range reports {
+			reportDTOs[i] = resp{
+				CategoryID:    report.Category.ID,
+				ParentID:      report.Category.ParentID,
+				Name:          report.Category.Name,
+				Monthly:       report.Totals,
+				Subscriptions: report.Subscriptions,
+			}
+		}
+
+		return reportDTOs
+	}
+}
@@ -0,0 +1,43 @@
+package category_handler
+
+import (
+	"context"
+	"net/http"
+
+	"git.home/alex/go-subscriptions/internal/api/api_response"
+	"git.home/alex/go-subscriptions/internal/auth"
+	"git.home/alex/go-subscriptions/internal/domain/service"
+	"github.com/julienschmidt/httprouter"
+)
+
+type nodeResp struct {
+	ID       uint       `json:"id"`
+	Name     string     `json:"name"`
+	Children []nodeResp `json:"children"`
+}
+
+func GetTree(ctx context.Context, cs *service.CategoryService) api_response.Handle {
+	return func(r *http.Request, _ httprouter.Params) any {
+		ctx := auth.RequestContext(ctx, r)
+
+		tree, err := cs.GetTree(ctx)
+		if err != nil {
+			return err
+		}
+
+		return newNodeResps(tree)
+	}
+}
+
+func newNodeResps(nodes []service.CategoryNode) []nodeResp {
+	nodeDTOs := make([]nodeResp, len(nodes))
+	for i, node := range nodes {
+		nodeDTOs[i] = nodeResp{
+			ID:       node.Category.ID,
+			Name:     node.Category.Name,
+			Children: newNodeResps(node.Children),
+		}
+	}
+
+	return nodeDTOs
+}
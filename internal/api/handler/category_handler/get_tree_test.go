@@ -0,0 +1,41 @@
+package category_handler_test
+
+import (
+	"context"
+	"testing"
+
+	"git.home/alex/go-subscriptions/internal/api/handler/category_handler"
+	"git.home/alex/go-subscriptions/internal/domain/repository"
+	"git.home/alex/go-subscriptions/internal/domain/service"
+	"git.home/alex/go-subscriptions/tests/mock_repository"
+	"git.home/alex/go-subscriptions/tests/tests_assert"
+)
+
+func TestGetTree(t *testing.T) {
+	ctx := context.Background()
+
+	mockRepo := new(mock_repository.MockCategoryRepository)
+	mockRepo.On("GetAll", ctx).Return(repository.Categories{
+		{ID: 1, Name: "Entertainment"},
+		{ID: 2, ParentID: 1, Name: "Streaming"},
+		{ID: 3, ParentID: 2, Name: "Video"},
+		{ID: 4, Name: "Utilities"},
+	}, nil)
+
+	cs := service.NewCategoryService(mockRepo, new(mock_repository.MockSubscriptionRepository))
+
+	response := category_handler.GetTree(ctx, cs)(nil, nil)
+
+	type node struct {
+		ID       uint   `json:"id"`
+		Name     string `json:"name"`
+		Children []node `json:"children"`
+	}
+
+	tests_assert.EqualAsJSON(t, []node{
+		{ID: 1, Name: "Entertainment", Children: []node{
+			{ID: 2, Name: "Streaming", Children: []node{{ID: 3, Name: "Video", Children: []node{}}}},
+		}},
+		{ID: 4, Name: "Utilities", Children: []node{}},
+	}, response)
+}
@@ -7,19 +7,24 @@ import (
 	"strconv"
 
 	"git.home/alex/go-subscriptions/internal/api/api_response"
+	"git.home/alex/go-subscriptions/internal/auth"
 	"git.home/alex/go-subscriptions/internal/domain/service"
 	"github.com/julienschmidt/httprouter"
 )
 
 func UpdateCategory(ctx context.Context, cs *service.CategoryService) api_response.Handle {
 	return func(r *http.Request, ps httprouter.Params) any {
+		ctx := auth.RequestContext(ctx, r)
+
 		id, err := strconv.Atoi(ps.ByName("id"))
 		if err != nil {
 			return err
 		}
 
+		// A missing parent_id keeps the parent, zero moves the category to the top level.
 		var req struct {
-			Name string `json:"name"`
+			Name     string `json:"name"`
+			ParentID *uint  `json:"parent_id"`
 		}
 		err = json.NewDecoder(r.Body).Decode(&req)
 		if err != nil {
@@ -32,19 +37,25 @@ func UpdateCategory(ctx context.Context, cs *service.CategoryService) api_respon
 		}
 
 		category.Name = req.Name
+		if req.ParentID != nil {
+			category.ParentID = *req.ParentID
+		}
+
 		updatedCategory, err := cs.UpdateCategory(ctx, *category)
 		if err != nil {
 			return err
 		}
 
 		type resp struct {
-			ID   uint   `json:"id"`
-			Name string `json:"name"`
+			ID       uint   `json:"id"`
+			ParentID uint   `json:"parent_id,omitempty"`
+			Name     string `json:"name"`
 		}
 
 		return resp{
-			ID:   updatedCategory.ID,
-			Name: updatedCategory.Name,
+			ID:       updatedCategory.ID,
+			ParentID: updatedCategory.ParentID,
+			Name:     updatedCategory.Name,
 		}
 	}
 }
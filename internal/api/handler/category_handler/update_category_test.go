@@ -66,7 +66,7 @@ func TestUpdateCategory(t *testing.T) {
 		},
 	}
 
-	cs := service.NewCategoryService(memory.NewCategoryRepository())
+	cs := service.NewCategoryService(memory.NewCategoryRepository(), memory.NewSubscriptionRepository())
 	ctx := context.Background()
 
 	for _, tc := range testCases {
@@ -0,0 +1,36 @@
+package csv_handler
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+
+	"git.home/alex/go-subscriptions/internal/api/api_response"
+	"git.home/alex/go-subscriptions/internal/auth"
+	"git.home/alex/go-subscriptions/internal/domain/service"
+	"github.com/julienschmidt/httprouter"
+)
+
+const exportFileName = "subscriptions.csv"
+
+// Export downloads the subscriptions as a CSV file.
+func Export(ctx context.Context, cs *service.CSVService) httprouter.Handle {
+	return func(w http.ResponseWriter, r *http.Request, _ httprouter.Params) {
+		ctx := auth.RequestContext(ctx, r)
+
+		var buf bytes.Buffer
+
+		err := cs.Export(ctx, &buf)
+		if err != nil {
+			w.Header().Set("Content-Type", "application/json")
+			_ = json.NewEncoder(w).Encode(api_response.Error(err))
+
+			return
+		}
+
+		w.Header().Set("Content-Type", "text/csv; charset=utf-8")
+		w.Header().Set("Content-Disposition", `attachment; filename="`+exportFileName+`"`)
+		_, _ = w.Write(buf.Bytes())
+	}
+}
@@ -0,0 +1,129 @@
+package csv_handler_test
+
+import (
+	"bytes"
+	"context"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"git.home/alex/go-subscriptions/internal/api/handler/csv_handler"
+	"git.home/alex/go-subscriptions/internal/domain/entity"
+	"git.home/alex/go-subscriptions/internal/domain/service"
+	"git.home/alex/go-subscriptions/internal/repository/memory"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newCSVService(t *testing.T) *service.CSVService {
+	t.Helper()
+
+	ctx := context.Background()
+	currencies := service.NewCurrencyService(memory.NewCurrencyRepository())
+	cycles := service.NewCycleService(memory.NewCycleRepository())
+
+	_, err := currencies.CreateCurrency(ctx, entity.USD)
+	require.NoError(t, err)
+	_, err = cycles.CreateCycle(ctx, entity.Monthly)
+	require.NoError(t, err)
+
+	return service.NewCSVService(
+		service.NewCategoryService(memory.NewCategoryRepository(), memory.NewSubscriptionRepository()),
+		currencies,
+		cycles,
+		service.NewSubscriptionService(memory.NewSubscriptionRepository()),
+	)
+}
+
+func newUploadRequest(t *testing.T, field, content, query string) *http.Request {
+	t.Helper()
+
+	var body bytes.Buffer
+
+	mw := multipart.NewWriter(&body)
+	fw, err := mw.CreateFormFile(field, "subscriptions.csv")
+	require.NoError(t, err)
+	_, _ = fw.Write([]byte(content))
+	require.NoError(t, mw.Close())
+
+	r := httptest.NewRequest(http.MethodPost, "/api/subscriptions/import"+query, &body)
+	r.Header.Set("Content-Type", mw.FormDataContentType())
+
+	return r
+}
+
+func TestImport(t *testing.T) {
+	const content = "name,price,currency,cycle,category\nNetflix,10,USD,Monthly,Streaming\nGym,x,USD,Monthly,\n"
+
+	testCases := []struct {
+		name     string
+		field    string
+		query    string
+		content  string
+		expected *service.CSVImportReport
+		wantErr  error
+	}{
+		{
+			name:  "Test dry run",
+			field: csv_handler.FileField,
+			query: "?dry_run=true",
+			expected: &service.CSVImportReport{
+				DryRun:            true,
+				Rows:              2,
+				Imported:          1,
+				CreatedCategories: []string{"Streaming"},
+				Errors:            []service.CSVRowError{{Row: 3, Error: `the subscription is invalid: price "x"`}},
+			},
+		},
+		{
+			name:    "Test missing file error",
+			field:   "upload",
+			wantErr: csv_handler.ErrMissingFile,
+		},
+		{
+			name:    "Test file too large error",
+			field:   csv_handler.FileField,
+			content: content + strings.Repeat("Gym,5,USD,Monthly,\n", 1<<20),
+			wantErr: csv_handler.ErrFileTooLarge,
+		},
+	}
+
+	cs := newCSVService(t)
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if tc.content == "" {
+				tc.content = content
+			}
+
+			r := newUploadRequest(t, tc.field, tc.content, tc.query)
+
+			response := csv_handler.Import(context.Background(), cs)(r, nil)
+
+			if tc.wantErr != nil {
+				assert.ErrorIs(t, tc.wantErr, response.(error))
+				return
+			}
+
+			assert.Equal(t, tc.expected, response)
+		})
+	}
+}
+
+func TestExport(t *testing.T) {
+	cs := newCSVService(t)
+
+	r := newUploadRequest(t, csv_handler.FileField, "name,price,currency,cycle\nNetflix,10,USD,Monthly\n", "")
+	csv_handler.Import(context.Background(), cs)(r, nil)
+
+	w := httptest.NewRecorder()
+	csv_handler.Export(context.Background(), cs)(w, httptest.NewRequest(http.MethodGet, "/api/subscriptions/export", nil), nil)
+
+	assert.Equal(t, "text/csv; charset=utf-8", w.Header().Get("Content-Type"))
+	assert.Contains(t, w.Header().Get("Content-Disposition"), "subscriptions.csv")
+	assert.Equal(t, "name,price,currency,cycle,category,next_payment_date,note,logo,"+
+		"status,trial_start_date,trial_end_date,resume_date,cancel_date\n"+
+		"Netflix,10,USD,Monthly,,,,,,,,,\n", w.Body.String())
+}
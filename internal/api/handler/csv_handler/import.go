@@ -0,0 +1,59 @@
+package csv_handler
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strconv"
+
+	"git.home/alex/go-subscriptions/internal/api/api_response"
+	"git.home/alex/go-subscriptions/internal/auth"
+	"git.home/alex/go-subscriptions/internal/domain/service"
+	"github.com/julienschmidt/httprouter"
+)
+
+const (
+	FileField = "file"
+
+	maxUploadSize = 10 << 20
+	maxMemory     = 1 << 20
+)
+
+var (
+	ErrMissingFile  = errors.New("the csv file is missing from the form")
+	ErrFileTooLarge = errors.New("the csv file is larger than 10 MiB")
+)
+
+// Import reads the "file" field of a multipart form. Pass ?dry_run=true to only validate the rows.
+func Import(ctx context.Context, cs *service.CSVService) api_response.Handle {
+	return func(r *http.Request, _ httprouter.Params) any {
+		ctx := auth.RequestContext(ctx, r)
+
+		dryRun, _ := strconv.ParseBool(r.URL.Query().Get("dry_run"))
+
+		r.Body = http.MaxBytesReader(nil, r.Body, maxUploadSize)
+
+		err := r.ParseMultipartForm(maxMemory)
+		if err != nil {
+			var tooLarge *http.MaxBytesError
+			if errors.As(err, &tooLarge) {
+				return ErrFileTooLarge
+			}
+
+			return err
+		}
+
+		file, _, err := r.FormFile(FileField)
+		if err != nil {
+			return ErrMissingFile
+		}
+		defer file.Close()
+
+		report, err := cs.Import(ctx, file, dryRun)
+		if err != nil {
+			return err
+		}
+
+		return report
+	}
+}
@@ -6,6 +6,7 @@ import (
 	"net/http"
 
 	"git.home/alex/go-subscriptions/internal/api/api_response"
+	"git.home/alex/go-subscriptions/internal/auth"
 	"git.home/alex/go-subscriptions/internal/domain/entity"
 	"git.home/alex/go-subscriptions/internal/domain/service"
 	"github.com/julienschmidt/httprouter"
@@ -13,6 +14,8 @@ import (
 
 func CreateCurrency(ctx context.Context, cs *service.CurrencyService) api_response.Handle {
 	return func(r *http.Request, _ httprouter.Params) any {
+		ctx := auth.RequestContext(ctx, r)
+
 		var req struct {
 			Code   string `json:"code"`
 			Name   string `json:"name"`
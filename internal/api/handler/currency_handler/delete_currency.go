@@ -5,12 +5,15 @@ import (
 	"net/http"
 
 	"git.home/alex/go-subscriptions/internal/api/api_response"
+	"git.home/alex/go-subscriptions/internal/auth"
 	"git.home/alex/go-subscriptions/internal/domain/service"
 	"github.com/julienschmidt/httprouter"
 )
 
 func DeleteCurrency(ctx context.Context, cs *service.CurrencyService) api_response.Handle {
-	return func(_ *http.Request, ps httprouter.Params) any {
+	return func(r *http.Request, ps httprouter.Params) any {
+		ctx := auth.RequestContext(ctx, r)
+
 		code := ps.ByName("code")
 
 		err := cs.DeleteCurrency(ctx, code)
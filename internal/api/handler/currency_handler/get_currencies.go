@@ -5,12 +5,15 @@ import (
 	"net/http"
 
 	"git.home/alex/go-subscriptions/internal/api/api_response"
+	"git.home/alex/go-subscriptions/internal/auth"
 	"git.home/alex/go-subscriptions/internal/domain/service"
 	"github.com/julienschmidt/httprouter"
 )
 
 func GetCurrencies(ctx context.Context, cs *service.CurrencyService) api_response.Handle {
-	return func(_ *http.Request, _ httprouter.Params) any {
+	return func(r *http.Request, _ httprouter.Params) any {
+		ctx := auth.RequestContext(ctx, r)
+
 		currencies, err := cs.GetAllCurrencies(ctx)
 		if err != nil {
 			return err
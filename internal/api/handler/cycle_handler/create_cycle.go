@@ -6,6 +6,7 @@ import (
 	"net/http"
 
 	"git.home/alex/go-subscriptions/internal/api/api_response"
+	"git.home/alex/go-subscriptions/internal/auth"
 	"git.home/alex/go-subscriptions/internal/domain/entity"
 	"git.home/alex/go-subscriptions/internal/domain/service"
 	"github.com/julienschmidt/httprouter"
@@ -13,9 +14,13 @@ import (
 
 func CreateCycle(ctx context.Context, cs *service.CycleService) api_response.Handle {
 	return func(r *http.Request, _ httprouter.Params) any {
+		ctx := auth.RequestContext(ctx, r)
+
 		var req struct {
-			Name string `json:"name"`
-			Days uint   `json:"days"`
+			Name     string `json:"name"`
+			Days     uint   `json:"days"`
+			Rule     string `json:"rule"`
+			Calendar bool   `json:"calendar"`
 		}
 
 		err := json.NewDecoder(r.Body).Decode(&req)
@@ -24,23 +29,29 @@ func CreateCycle(ctx context.Context, cs *service.CycleService) api_response.Han
 		}
 
 		createdCycle, err := cs.CreateCycle(ctx, entity.Cycle{
-			Name: req.Name,
-			Days: req.Days,
+			Name:     req.Name,
+			Days:     req.Days,
+			Rule:     req.Rule,
+			Calendar: req.Calendar,
 		})
 		if err != nil {
 			return err
 		}
 
 		type resp struct {
-			ID   uint   `json:"id"`
-			Name string `json:"name"`
-			Days uint   `json:"days"`
+			ID       uint   `json:"id"`
+			Name     string `json:"name"`
+			Days     uint   `json:"days"`
+			Rule     string `json:"rule,omitempty"`
+			Calendar bool   `json:"calendar,omitempty"`
 		}
 
 		return resp{
-			ID:   createdCycle.ID,
-			Name: createdCycle.Name,
-			Days: createdCycle.Days,
+			ID:       createdCycle.ID,
+			Name:     createdCycle.Name,
+			Days:     createdCycle.Days,
+			Rule:     createdCycle.Rule,
+			Calendar: createdCycle.Calendar,
 		}
 	}
 }
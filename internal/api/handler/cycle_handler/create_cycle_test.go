@@ -19,12 +19,14 @@ func TestCreateCycle(t *testing.T) {
 	type req struct {
 		Name string `json:"name"`
 		Days uint   `json:"days"`
+		Rule string `json:"rule,omitempty"`
 	}
 
 	type resp struct {
 		ID   uint   `json:"id"`
 		Name string `json:"name"`
 		Days uint   `json:"days"`
+		Rule string `json:"rule,omitempty"`
 	}
 
 	testCases := []struct {
@@ -43,6 +45,11 @@ func TestCreateCycle(t *testing.T) {
 			requestBody: req{Name: "Test Cycle 2", Days: 30},
 			expected:    resp{ID: 2, Name: "Test Cycle 2", Days: 30},
 		},
+		{
+			name:        "Test Create Rule Cycle",
+			requestBody: req{Name: "Quarterly", Rule: "freq=monthly;interval=3;bymonthday=1"},
+			expected:    resp{ID: 3, Name: "Quarterly", Rule: "FREQ=MONTHLY;INTERVAL=3;BYMONTHDAY=1"},
+		},
 		{
 			name:        "Test validation error",
 			requestBody: req{Name: "", Days: 0},
@@ -6,12 +6,15 @@ import (
 	"strconv"
 
 	"git.home/alex/go-subscriptions/internal/api/api_response"
+	"git.home/alex/go-subscriptions/internal/auth"
 	"git.home/alex/go-subscriptions/internal/domain/service"
 	"github.com/julienschmidt/httprouter"
 )
 
 func GetCycle(ctx context.Context, cs *service.CycleService) api_response.Handle {
-	return func(_ *http.Request, ps httprouter.Params) any {
+	return func(r *http.Request, ps httprouter.Params) any {
+		ctx := auth.RequestContext(ctx, r)
+
 		id, err := strconv.Atoi(ps.ByName("id"))
 		if err != nil {
 			return err
@@ -23,15 +26,19 @@ func GetCycle(ctx context.Context, cs *service.CycleService) api_response.Handle
 		}
 
 		type resp struct {
-			ID   uint   `json:"id"`
-			Name string `json:"name"`
-			Days uint   `json:"days"`
+			ID       uint   `json:"id"`
+			Name     string `json:"name"`
+			Days     uint   `json:"days"`
+			Rule     string `json:"rule,omitempty"`
+			Calendar bool   `json:"calendar,omitempty"`
 		}
 
 		return resp{
-			ID:   cycle.ID,
-			Name: cycle.Name,
-			Days: cycle.Days,
+			ID:       cycle.ID,
+			Name:     cycle.Name,
+			Days:     cycle.Days,
+			Rule:     cycle.Rule,
+			Calendar: cycle.Calendar,
 		}
 	}
 }
@@ -5,29 +5,36 @@ import (
 	"net/http"
 
 	"git.home/alex/go-subscriptions/internal/api/api_response"
+	"git.home/alex/go-subscriptions/internal/auth"
 	"git.home/alex/go-subscriptions/internal/domain/service"
 	"github.com/julienschmidt/httprouter"
 )
 
 func GetCycles(ctx context.Context, cs *service.CycleService) api_response.Handle {
-	return func(_ *http.Request, _ httprouter.Params) any {
+	return func(r *http.Request, _ httprouter.Params) any {
+		ctx := auth.RequestContext(ctx, r)
+
 		cycles, err := cs.GetAllCycles(ctx)
 		if err != nil {
 			return err
 		}
 
 		type resp struct {
-			ID   uint   `json:"id"`
-			Name string `json:"name"`
-			Days uint   `json:"days"`
+			ID       uint   `json:"id"`
+			Name     string `json:"name"`
+			Days     uint   `json:"days"`
+			Rule     string `json:"rule,omitempty"`
+			Calendar bool   `json:"calendar,omitempty"`
 		}
 
 		cyclesResp := make([]resp, len(cycles))
 		for i, cycle := range cycles {
 			cyclesResp[i] = resp{
-				ID:   cycle.ID,
-				Name: cycle.Name,
-				Days: cycle.Days,
+				ID:       cycle.ID,
+				Name:     cycle.Name,
+				Days:     cycle.Days,
+				Rule:     cycle.Rule,
+				Calendar: cycle.Calendar,
 			}
 		}
 
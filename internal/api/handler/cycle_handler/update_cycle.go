@@ -7,20 +7,25 @@ import (
 	"strconv"
 
 	"git.home/alex/go-subscriptions/internal/api/api_response"
+	"git.home/alex/go-subscriptions/internal/auth"
 	"git.home/alex/go-subscriptions/internal/domain/service"
 	"github.com/julienschmidt/httprouter"
 )
 
 func UpdateCycle(ctx context.Context, cs *service.CycleService) api_response.Handle {
 	return func(r *http.Request, ps httprouter.Params) any {
+		ctx := auth.RequestContext(ctx, r)
+
 		id, err := strconv.Atoi(ps.ByName("id"))
 		if err != nil {
 			return err
 		}
 
 		var req struct {
-			Name string `json:"name"`
-			Days uint   `json:"days"`
+			Name     string `json:"name"`
+			Days     uint   `json:"days"`
+			Rule     string `json:"rule"`
+			Calendar bool   `json:"calendar"`
 		}
 		err = json.NewDecoder(r.Body).Decode(&req)
 		if err != nil {
@@ -34,21 +39,27 @@ func UpdateCycle(ctx context.Context, cs *service.CycleService) api_response.Han
 
 		cycle.Name = req.Name
 		cycle.Days = req.Days
+		cycle.Rule = req.Rule
+		cycle.Calendar = req.Calendar
 		updatedCycle, err := cs.UpdateCycle(ctx, *cycle)
 		if err != nil {
 			return err
 		}
 
 		type resp struct {
-			ID   uint   `json:"id"`
-			Name string `json:"name"`
-			Days uint   `json:"days"`
+			ID       uint   `json:"id"`
+			Name     string `json:"name"`
+			Days     uint   `json:"days"`
+			Rule     string `json:"rule,omitempty"`
+			Calendar bool   `json:"calendar,omitempty"`
 		}
 
 		return resp{
-			ID:   updatedCycle.ID,
-			Name: updatedCycle.Name,
-			Days: updatedCycle.Days,
+			ID:       updatedCycle.ID,
+			Name:     updatedCycle.Name,
+			Days:     updatedCycle.Days,
+			Rule:     updatedCycle.Rule,
+			Calendar: updatedCycle.Calendar,
 		}
 	}
 }
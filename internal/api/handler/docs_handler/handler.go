@@ -0,0 +1,80 @@
+package docs_handler
+
+import (
+	"crypto/sha256"
+	_ "embed"
+	"encoding/base64"
+	"encoding/json"
+	"net/http"
+	"regexp"
+	"sync"
+
+	"git.home/alex/go-subscriptions/internal/api/api_response"
+	"github.com/julienschmidt/httprouter"
+	"gopkg.in/yaml.v3"
+)
+
+const (
+	SpecPath = "/api/openapi.json"
+	DocsPath = "/api/docs"
+)
+
+var (
+	//go:embed openapi.yaml
+	specYAML []byte
+
+	//go:embed docs.html
+	docsHTML []byte
+)
+
+// SpecJSON returns the OpenAPI document. The document is maintained as YAML and converted once.
+var SpecJSON = sync.OnceValues(func() ([]byte, error) {
+	var spec any
+	if err := yaml.Unmarshal(specYAML, &spec); err != nil {
+		return nil, err
+	}
+
+	return json.Marshal(spec)
+})
+
+func Spec() httprouter.Handle {
+	return func(w http.ResponseWriter, _ *http.Request, _ httprouter.Params) {
+		spec, err := SpecJSON()
+
+		w.Header().Set("Content-Type", "application/json")
+
+		if err != nil {
+			// Unlike the API errors this is a fault of the server, so the status says so.
+			spec, _ = json.Marshal(api_response.Error(err))
+			w.WriteHeader(http.StatusInternalServerError)
+		}
+
+		_, _ = w.Write(spec)
+	}
+}
+
+// swaggerUIBundle is the Swagger UI script docs.html loads. The version is pinned: published npm
+// versions never change, so the page can't pick up a different script than the one reviewed.
+const swaggerUIBundle = "https://unpkg.com/swagger-ui-dist@5.17.14/swagger-ui-bundle.js"
+
+var inlineScript = regexp.MustCompile(`<script>([\s\S]*?)</script>`)
+
+// docsPolicy only lets the docs page run the pinned bundle and its own inline script, by hash.
+var docsPolicy = sync.OnceValue(func() string {
+	policy := "script-src " + swaggerUIBundle
+
+	for _, match := range inlineScript.FindAllSubmatch(docsHTML, -1) {
+		sum := sha256.Sum256(match[1])
+		policy += " 'sha256-" + base64.StdEncoding.EncodeToString(sum[:]) + "'"
+	}
+
+	return policy
+})
+
+func Docs() httprouter.Handle {
+	return func(w http.ResponseWriter, _ *http.Request, _ httprouter.Params) {
+		w.Header().Set("Content-Security-Policy", docsPolicy())
+		w.Header().Set("Content-Type", "text/html; charset=utf-8")
+		_, _ = w.Write(docsHTML)
+	}
+}
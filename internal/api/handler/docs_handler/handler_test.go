@@ -0,0 +1,41 @@
+package docs_handler_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"git.home/alex/go-subscriptions/internal/api/handler/docs_handler"
+	"github.com/julienschmidt/httprouter"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSpec(t *testing.T) {
+	w := httptest.NewRecorder()
+
+	docs_handler.Spec()(w, &http.Request{}, httprouter.Params{})
+
+	var spec struct {
+		OpenAPI string         `json:"openapi"`
+		Paths   map[string]any `json:"paths"`
+	}
+
+	assert.Equal(t, "application/json", w.Header().Get("Content-Type"))
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &spec))
+	assert.Equal(t, "3.0.3", spec.OpenAPI)
+	assert.NotEmpty(t, spec.Paths)
+}
+
+func TestDocs(t *testing.T) {
+	w := httptest.NewRecorder()
+
+	docs_handler.Docs()(w, &http.Request{}, httprouter.Params{})
+
+	assert.Equal(t, "text/html; charset=utf-8", w.Header().Get("Content-Type"))
+	assert.Contains(t, w.Body.String(), docs_handler.SpecPath)
+
+	policy := w.Header().Get("Content-Security-Policy")
+	assert.Contains(t, policy, "https://unpkg.com/swagger-ui-dist@5.17.14/swagger-ui-bundle.js")
+	assert.Contains(t, policy, "'sha256-")
+}
@@ -2,15 +2,12 @@ package handler
 
 import (
 	"encoding/json"
-	"fmt"
 	"net/http"
 
 	"git.home/alex/go-subscriptions/internal/api/api_response"
 	"github.com/julienschmidt/httprouter"
 )
 
-var errorMessage = `{"status":"error","error":"%s","data":null}`
-
 func Handle(h api_response.Handle) httprouter.Handle {
 	return func(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
 		result := h(r, ps)
@@ -38,7 +35,8 @@ func write(w http.ResponseWriter, data []byte) {
 }
 
 func writeError(w http.ResponseWriter, err error) {
-	response := []byte(fmt.Sprintf(errorMessage, err.Error()))
+	// The DTO only holds strings here, so marshaling can't fail; it escapes quotes in the message.
+	response, _ := json.Marshal(api_response.Error(err))
 	write(w, response)
 }
 
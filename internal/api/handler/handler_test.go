@@ -1,6 +1,7 @@
 package handler_test
 
 import (
+	"fmt"
 	"net/http"
 	"net/http/httptest"
 	"testing"
@@ -35,6 +36,14 @@ func TestHandle(t *testing.T) {
 			expectedStatus: http.StatusOK,
 			expectedBody:   `{"status":"error","error":"` + tests.ErrTest.Error() + `","data":null}`,
 		},
+		{
+			name: "error with quotes",
+			handler: func(_ *http.Request, _ httprouter.Params) any {
+				return fmt.Errorf("%w: %q", tests.ErrTest, "value")
+			},
+			expectedStatus: http.StatusOK,
+			expectedBody:   `{"status":"error","error":"` + tests.ErrTest.Error() + `: \"value\"","data":null}`,
+		},
 	}
 
 	for _, tc := range testCases {
@@ -1,8 +1,10 @@
 package health_handler
 
 import (
+	"encoding/json"
 	"net/http"
 
+	"git.home/alex/go-subscriptions/internal/health"
 	"git.home/alex/go-subscriptions/internal/version"
 	"github.com/julienschmidt/httprouter"
 )
@@ -15,3 +17,20 @@ func Handle() httprouter.Handle {
 		_, _ = w.Write([]byte(data))
 	}
 }
+
+// Ready runs the registered checks. A failing check answers 503 so load balancers stop routing to
+// the instance; /health only tells that the process is alive.
+func Ready(r *health.Registry) httprouter.Handle {
+	return func(w http.ResponseWriter, req *http.Request, _ httprouter.Params) {
+		result := r.Run(req.Context())
+
+		w.Header().Set("Content-Type", "application/health+json")
+		w.Header().Set("Cache-Control", "no-store")
+
+		if result.Status == health.StatusFail {
+			w.WriteHeader(http.StatusServiceUnavailable)
+		}
+
+		_ = json.NewEncoder(w).Encode(result)
+	}
+}
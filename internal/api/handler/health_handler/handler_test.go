@@ -1,14 +1,19 @@
 package health_handler_test
 
 import (
+	"context"
+	"encoding/json"
+	"errors"
 	"net/http"
 	"net/http/httptest"
 	"testing"
 
 	"github.com/julienschmidt/httprouter"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 
 	"git.home/alex/go-subscriptions/internal/api/handler/health_handler"
+	"git.home/alex/go-subscriptions/internal/health"
 	"git.home/alex/go-subscriptions/internal/version"
 )
 
@@ -36,3 +41,45 @@ func TestHandle(t *testing.T) {
 		})
 	}
 }
+
+func TestReady(t *testing.T) {
+	testCases := []struct {
+		name       string
+		check      health.CheckFunc
+		wantCode   int
+		wantStatus health.Status
+	}{
+		{
+			name:       "ready",
+			check:      func(context.Context) error { return nil },
+			wantCode:   http.StatusOK,
+			wantStatus: health.StatusPass,
+		},
+		{
+			name:       "storage down",
+			check:      func(context.Context) error { return errors.New("database is locked") },
+			wantCode:   http.StatusServiceUnavailable,
+			wantStatus: health.StatusFail,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			registry, err := health.NewRegistry()
+			require.NoError(t, err)
+			registry.Register("storage", "datastore", tc.check)
+
+			w := httptest.NewRecorder()
+			health_handler.Ready(registry)(w, httptest.NewRequest(http.MethodGet, "/ready", nil), httprouter.Params{})
+
+			var result health.Result
+			require.NoError(t, json.Unmarshal(w.Body.Bytes(), &result))
+
+			assert.Equal(t, tc.wantCode, w.Code)
+			assert.Equal(t, "application/health+json", w.Header().Get("Content-Type"))
+			assert.Equal(t, tc.wantStatus, result.Status)
+			assert.Equal(t, version.Version, result.Version)
+			assert.Len(t, result.Checks["storage:responseTime"], 1)
+		})
+	}
+}
@@ -0,0 +1,41 @@
+package household_handler
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strconv"
+
+	"git.home/alex/go-subscriptions/internal/api/api_response"
+	"git.home/alex/go-subscriptions/internal/auth"
+	"git.home/alex/go-subscriptions/internal/domain/entity"
+	"git.home/alex/go-subscriptions/internal/domain/service"
+	"github.com/julienschmidt/httprouter"
+)
+
+func AddMember(ctx context.Context, hs *service.HouseholdService) api_response.Handle {
+	return func(r *http.Request, ps httprouter.Params) any {
+		ctx := auth.RequestContext(ctx, r)
+
+		id, err := strconv.Atoi(ps.ByName("id"))
+		if err != nil {
+			return err
+		}
+
+		var req struct {
+			Name   string `json:"name"`
+			UserID uint   `json:"user_id"`
+		}
+		err = json.NewDecoder(r.Body).Decode(&req)
+		if err != nil {
+			return err
+		}
+
+		household, err := hs.AddMember(ctx, uint(id), entity.HouseholdMember{Name: req.Name, UserID: req.UserID})
+		if err != nil {
+			return err
+		}
+
+		return newHouseholdResp(household)
+	}
+}
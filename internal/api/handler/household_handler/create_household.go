@@ -0,0 +1,44 @@
+package household_handler
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+
+	"git.home/alex/go-subscriptions/internal/api/api_response"
+	"git.home/alex/go-subscriptions/internal/auth"
+	"git.home/alex/go-subscriptions/internal/domain/entity"
+	"git.home/alex/go-subscriptions/internal/domain/service"
+	"github.com/julienschmidt/httprouter"
+)
+
+func CreateHousehold(ctx context.Context, hs *service.HouseholdService) api_response.Handle {
+	return func(r *http.Request, _ httprouter.Params) any {
+		ctx := auth.RequestContext(ctx, r)
+
+		var req struct {
+			Name    string `json:"name"`
+			Members []struct {
+				Name   string `json:"name"`
+				UserID uint   `json:"user_id"`
+			} `json:"members"`
+		}
+
+		err := json.NewDecoder(r.Body).Decode(&req)
+		if err != nil {
+			return err
+		}
+
+		household := entity.Household{Name: req.Name}
+		for _, m := range req.Members {
+			household.Members = append(household.Members, entity.HouseholdMember{Name: m.Name, UserID: m.UserID})
+		}
+
+		createdHousehold, err := hs.CreateHousehold(ctx, household)
+		if err != nil {
+			return err
+		}
+
+		return newHouseholdResp(createdHousehold)
+	}
+}
@@ -0,0 +1,76 @@
+package household_handler_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"testing"
+
+	"git.home/alex/go-subscriptions/internal/api/handler/household_handler"
+	"git.home/alex/go-subscriptions/internal/domain/service"
+	"git.home/alex/go-subscriptions/internal/repository/memory"
+	"git.home/alex/go-subscriptions/tests/tests_assert"
+	"github.com/stretchr/testify/assert"
+)
+
+type memberDTO struct {
+	ID     uint   `json:"id,omitempty"`
+	Name   string `json:"name"`
+	UserID uint   `json:"user_id,omitempty"`
+}
+
+type householdDTO struct {
+	ID      uint        `json:"id,omitempty"`
+	Name    string      `json:"name"`
+	Members []memberDTO `json:"members"`
+}
+
+func TestCreateHousehold(t *testing.T) {
+	testCases := []struct {
+		name        string
+		requestBody householdDTO
+		expected    householdDTO
+		wantErr     error
+	}{
+		{
+			name: "Test Create Household",
+			requestBody: householdDTO{
+				Name:    "Home",
+				Members: []memberDTO{{Name: "Alice", UserID: 1}, {Name: "Bob"}},
+			},
+			expected: householdDTO{
+				ID:      1,
+				Name:    "Home",
+				Members: []memberDTO{{ID: 1, Name: "Alice", UserID: 1}, {ID: 2, Name: "Bob"}},
+			},
+		},
+		{
+			name:        "Test validation error",
+			requestBody: householdDTO{Name: ""},
+			wantErr:     service.ErrInvalidHousehold,
+		},
+	}
+
+	hs := service.NewHouseholdService(memory.NewHouseholdRepository(), memory.NewSubscriptionRepository())
+	ctx := context.Background()
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			requestBodyBytes, _ := json.Marshal(tc.requestBody)
+			r := &http.Request{
+				Body: io.NopCloser(bytes.NewBuffer(requestBodyBytes)),
+			}
+
+			response := household_handler.CreateHousehold(ctx, hs)(r, nil)
+
+			if tc.wantErr != nil {
+				assert.ErrorIs(t, tc.wantErr, response.(error))
+				return
+			}
+
+			tests_assert.EqualAsJSON(t, tc.expected, response)
+		})
+	}
+}
@@ -0,0 +1,30 @@
+package household_handler
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+
+	"git.home/alex/go-subscriptions/internal/api/api_response"
+	"git.home/alex/go-subscriptions/internal/auth"
+	"git.home/alex/go-subscriptions/internal/domain/service"
+	"github.com/julienschmidt/httprouter"
+)
+
+func DeleteHousehold(ctx context.Context, hs *service.HouseholdService) api_response.Handle {
+	return func(r *http.Request, ps httprouter.Params) any {
+		ctx := auth.RequestContext(ctx, r)
+
+		id, err := strconv.Atoi(ps.ByName("id"))
+		if err != nil {
+			return err
+		}
+
+		err = hs.DeleteHousehold(ctx, uint(id))
+		if err != nil {
+			return err
+		}
+
+		return nil
+	}
+}
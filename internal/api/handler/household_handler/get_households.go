@@ -0,0 +1,29 @@
+package household_handler
+
+import (
+	"context"
+	"net/http"
+
+	"git.home/alex/go-subscriptions/internal/api/api_response"
+	"git.home/alex/go-subscriptions/internal/auth"
+	"git.home/alex/go-subscriptions/internal/domain/service"
+	"github.com/julienschmidt/httprouter"
+)
+
+func GetHouseholds(ctx context.Context, hs *service.HouseholdService) api_response.Handle {
+	return func(r *http.Request, _ httprouter.Params) any {
+		ctx := auth.RequestContext(ctx, r)
+
+		households, err := hs.GetAllHouseholds(ctx)
+		if err != nil {
+			return err
+		}
+
+		householdDTOs := make([]householdResp, len(households))
+		for i := range households {
+			householdDTOs[i] = newHouseholdResp(&households[i])
+		}
+
+		return householdDTOs
+	}
+}
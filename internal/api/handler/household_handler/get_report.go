@@ -0,0 +1,78 @@
+package household_handler
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+
+	"git.home/alex/go-subscriptions/internal/api/api_response"
+	"git.home/alex/go-subscriptions/internal/auth"
+	"git.home/alex/go-subscriptions/internal/domain/service"
+	"github.com/julienschmidt/httprouter"
+)
+
+func GetReport(ctx context.Context, hs *service.HouseholdService) api_response.Handle {
+	return func(r *http.Request, ps httprouter.Params) any {
+		ctx := auth.RequestContext(ctx, r)
+
+		id, err := strconv.Atoi(ps.ByName("id"))
+		if err != nil {
+			return err
+		}
+
+		report, err := hs.GetReport(ctx, uint(id))
+		if err != nil {
+			return err
+		}
+
+		type itemResp struct {
+			SubscriptionID uint    `json:"subscription_id"`
+			Name           string  `json:"name"`
+			Currency       string  `json:"currency"`
+			MonthlyCost    float64 `json:"monthly_cost"`
+			MonthlyShare   float64 `json:"monthly_share"`
+		}
+
+		type memberReportResp struct {
+			MemberID uint               `json:"member_id"`
+			Name     string             `json:"name"`
+			Monthly  map[string]float64 `json:"monthly"`
+			Items    []itemResp         `json:"items"`
+		}
+
+		type resp struct {
+			HouseholdID uint               `json:"household_id"`
+			Name        string             `json:"name"`
+			Monthly     map[string]float64 `json:"monthly"`
+			Members     []memberReportResp `json:"members"`
+		}
+
+		members := make([]memberReportResp, len(report.Members))
+		for i, m := range report.Members {
+			items := make([]itemResp, len(m.Items))
+			for j, item := range m.Items {
+				items[j] = itemResp{
+					SubscriptionID: item.Subscription.ID,
+					Name:           item.Subscription.Name,
+					Currency:       item.Subscription.Currency.Code,
+					MonthlyCost:    item.MonthlyCost,
+					MonthlyShare:   item.MonthlyShare,
+				}
+			}
+
+			members[i] = memberReportResp{
+				MemberID: m.Member.ID,
+				Name:     m.Member.Name,
+				Monthly:  m.Totals,
+				Items:    items,
+			}
+		}
+
+		return resp{
+			HouseholdID: report.Household.ID,
+			Name:        report.Household.Name,
+			Monthly:     report.Totals,
+			Members:     members,
+		}
+	}
+}
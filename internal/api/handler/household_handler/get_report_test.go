@@ -0,0 +1,111 @@
+package household_handler_test
+
+import (
+	"context"
+	"testing"
+
+	"git.home/alex/go-subscriptions/internal/api/handler/household_handler"
+	"git.home/alex/go-subscriptions/internal/domain/entity"
+	"git.home/alex/go-subscriptions/internal/domain/repository"
+	"git.home/alex/go-subscriptions/internal/domain/service"
+	"git.home/alex/go-subscriptions/internal/repository/memory"
+	"git.home/alex/go-subscriptions/tests/tests_assert"
+	"github.com/julienschmidt/httprouter"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetReport(t *testing.T) {
+	type itemResp struct {
+		SubscriptionID uint    `json:"subscription_id"`
+		Name           string  `json:"name"`
+		Currency       string  `json:"currency"`
+		MonthlyCost    float64 `json:"monthly_cost"`
+		MonthlyShare   float64 `json:"monthly_share"`
+	}
+
+	type memberReportResp struct {
+		MemberID uint               `json:"member_id"`
+		Name     string             `json:"name"`
+		Monthly  map[string]float64 `json:"monthly"`
+		Items    []itemResp         `json:"items"`
+	}
+
+	type resp struct {
+		HouseholdID uint               `json:"household_id"`
+		Name        string             `json:"name"`
+		Monthly     map[string]float64 `json:"monthly"`
+		Members     []memberReportResp `json:"members"`
+	}
+
+	ctx := context.Background()
+	subscriptions := memory.NewSubscriptionRepository()
+	hs := service.NewHouseholdService(memory.NewHouseholdRepository(), subscriptions)
+
+	household, err := hs.CreateHousehold(ctx, entity.Household{
+		Name:    "Home",
+		Members: []entity.HouseholdMember{{Name: "Alice"}, {Name: "Bob"}},
+	})
+	require.NoError(t, err)
+
+	subscription, err := subscriptions.Create(ctx, entity.Subscription{Name: "Music", Price: 15, Currency: entity.USD, Cycle: entity.Monthly})
+	require.NoError(t, err)
+
+	_, err = hs.ShareSubscription(ctx, subscription.ID, entity.SubscriptionShare{
+		HouseholdID: household.ID,
+		Rule:        entity.SplitFixed,
+		Parts:       []entity.SharePart{{MemberID: 1, Value: 10}, {MemberID: 2, Value: 5}},
+	})
+	require.NoError(t, err)
+
+	testCases := []struct {
+		name     string
+		id       string
+		expected resp
+		wantErr  error
+	}{
+		{
+			name: "Report",
+			id:   "1",
+			expected: resp{
+				HouseholdID: 1,
+				Name:        "Home",
+				Monthly:     map[string]float64{"USD": 15},
+				Members: []memberReportResp{
+					{
+						MemberID: 1,
+						Name:     "Alice",
+						Monthly:  map[string]float64{"USD": 10},
+						Items:    []itemResp{{SubscriptionID: 1, Name: "Music", Currency: "USD", MonthlyCost: 15, MonthlyShare: 10}},
+					},
+					{
+						MemberID: 2,
+						Name:     "Bob",
+						Monthly:  map[string]float64{"USD": 5},
+						Items:    []itemResp{{SubscriptionID: 1, Name: "Music", Currency: "USD", MonthlyCost: 15, MonthlyShare: 5}},
+					},
+				},
+			},
+		},
+		{
+			name:    "Unknown household",
+			id:      "10",
+			wantErr: repository.ErrNotFoundHousehold,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ps := httprouter.Params{{Key: "id", Value: tc.id}}
+
+			response := household_handler.GetReport(ctx, hs)(nil, ps)
+
+			if tc.wantErr != nil {
+				assert.ErrorIs(t, tc.wantErr, response.(error))
+				return
+			}
+
+			tests_assert.EqualAsJSON(t, tc.expected, response)
+		})
+	}
+}
@@ -0,0 +1,28 @@
+package household_handler
+
+import "git.home/alex/go-subscriptions/internal/domain/entity"
+
+type memberResp struct {
+	ID     uint   `json:"id"`
+	Name   string `json:"name"`
+	UserID uint   `json:"user_id,omitempty"`
+}
+
+type householdResp struct {
+	ID      uint         `json:"id"`
+	Name    string       `json:"name"`
+	Members []memberResp `json:"members"`
+}
+
+func newHouseholdResp(household *entity.Household) householdResp {
+	members := make([]memberResp, len(household.Members))
+	for i, m := range household.Members {
+		members[i] = memberResp{ID: m.ID, Name: m.Name, UserID: m.UserID}
+	}
+
+	return householdResp{
+		ID:      household.ID,
+		Name:    household.Name,
+		Members: members,
+	}
+}
@@ -0,0 +1,69 @@
+package household_handler
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strconv"
+
+	"git.home/alex/go-subscriptions/internal/api/api_response"
+	"git.home/alex/go-subscriptions/internal/auth"
+	"git.home/alex/go-subscriptions/internal/domain/entity"
+	"git.home/alex/go-subscriptions/internal/domain/service"
+	"github.com/julienschmidt/httprouter"
+)
+
+type shareResp struct {
+	SubscriptionID uint           `json:"subscription_id"`
+	HouseholdID    uint           `json:"household_id"`
+	Rule           string         `json:"rule"`
+	Parts          []sharePartDTO `json:"parts"`
+}
+
+type sharePartDTO struct {
+	MemberID uint    `json:"member_id"`
+	Value    float64 `json:"value"`
+}
+
+func ShareSubscription(ctx context.Context, hs *service.HouseholdService) api_response.Handle {
+	return func(r *http.Request, ps httprouter.Params) any {
+		ctx := auth.RequestContext(ctx, r)
+
+		id, err := strconv.Atoi(ps.ByName("id"))
+		if err != nil {
+			return err
+		}
+
+		var req struct {
+			HouseholdID uint           `json:"household_id"`
+			Rule        string         `json:"rule"`
+			Parts       []sharePartDTO `json:"parts"`
+		}
+		err = json.NewDecoder(r.Body).Decode(&req)
+		if err != nil {
+			return err
+		}
+
+		share := entity.SubscriptionShare{HouseholdID: req.HouseholdID, Rule: entity.SplitRule(req.Rule)}
+		for _, part := range req.Parts {
+			share.Parts = append(share.Parts, entity.SharePart{MemberID: part.MemberID, Value: part.Value})
+		}
+
+		subscription, err := hs.ShareSubscription(ctx, uint(id), share)
+		if err != nil {
+			return err
+		}
+
+		parts := make([]sharePartDTO, len(subscription.Share.Parts))
+		for i, part := range subscription.Share.Parts {
+			parts[i] = sharePartDTO{MemberID: part.MemberID, Value: part.Value}
+		}
+
+		return shareResp{
+			SubscriptionID: subscription.ID,
+			HouseholdID:    subscription.Share.HouseholdID,
+			Rule:           string(subscription.Share.Rule),
+			Parts:          parts,
+		}
+	}
+}
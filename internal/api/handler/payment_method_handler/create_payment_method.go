@@ -0,0 +1,38 @@
+package payment_method_handler
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+
+	"git.home/alex/go-subscriptions/internal/api/api_response"
+	"git.home/alex/go-subscriptions/internal/auth"
+	"git.home/alex/go-subscriptions/internal/clock"
+	"git.home/alex/go-subscriptions/internal/domain/service"
+	"github.com/julienschmidt/httprouter"
+)
+
+func CreatePaymentMethod(ctx context.Context, ms *service.PaymentMethodService) api_response.Handle {
+	return func(r *http.Request, _ httprouter.Params) any {
+		ctx := auth.RequestContext(ctx, r)
+
+		var req methodReq
+
+		err := json.NewDecoder(r.Body).Decode(&req)
+		if err != nil {
+			return err
+		}
+
+		method, err := req.entity(0)
+		if err != nil {
+			return err
+		}
+
+		createdMethod, err := ms.CreatePaymentMethod(ctx, method)
+		if err != nil {
+			return err
+		}
+
+		return newMethodResp(createdMethod, clock.Today(ctx))
+	}
+}
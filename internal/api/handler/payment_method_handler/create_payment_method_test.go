@@ -0,0 +1,72 @@
+package payment_method_handler_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"git.home/alex/go-subscriptions/internal/api/handler/payment_method_handler"
+	"git.home/alex/go-subscriptions/internal/domain/service"
+	"git.home/alex/go-subscriptions/internal/repository/memory"
+	"git.home/alex/go-subscriptions/tests/tests_assert"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCreatePaymentMethod(t *testing.T) {
+	type resp struct {
+		ID       uint   `json:"id"`
+		Label    string `json:"label"`
+		Type     string `json:"type"`
+		LastFour string `json:"last_four,omitempty"`
+		Expiry   string `json:"expiry,omitempty"`
+		Expired  bool   `json:"expired"`
+	}
+
+	testCases := []struct {
+		name     string
+		body     string
+		expected resp
+		wantErr  error
+	}{
+		{
+			name:     "Card",
+			body:     `{"label": "Visa", "type": "card", "last_four": "4242", "expiry": "2999-03"}`,
+			expected: resp{ID: 1, Label: "Visa", Type: "card", LastFour: "4242", Expiry: "2999-03"},
+		},
+		{
+			name:     "Expired card",
+			body:     `{"label": "Visa", "type": "card", "expiry": "2020-01"}`,
+			expected: resp{ID: 1, Label: "Visa", Type: "card", Expiry: "2020-01", Expired: true},
+		},
+		{
+			name:     "Without expiry",
+			body:     `{"label": "PayPal", "type": "wallet"}`,
+			expected: resp{ID: 1, Label: "PayPal", Type: "wallet"},
+		},
+		{
+			name:    "Invalid expiry",
+			body:    `{"label": "Visa", "type": "card", "expiry": "03/27"}`,
+			wantErr: service.ErrInvalidPaymentMethod,
+		},
+	}
+
+	ctx := context.Background()
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ms := service.NewPaymentMethodService(memory.NewPaymentMethodRepository(), memory.NewSubscriptionRepository())
+			r := httptest.NewRequest(http.MethodPost, "/api/payment_method", strings.NewReader(tc.body))
+
+			response := payment_method_handler.CreatePaymentMethod(ctx, ms)(r, nil)
+
+			if tc.wantErr != nil {
+				assert.ErrorIs(t, response.(error), tc.wantErr)
+				return
+			}
+
+			tests_assert.EqualAsJSON(t, tc.expected, response)
+		})
+	}
+}
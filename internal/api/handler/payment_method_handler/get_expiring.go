@@ -0,0 +1,71 @@
+package payment_method_handler
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strconv"
+
+	"git.home/alex/go-subscriptions/internal/api/api_response"
+	"git.home/alex/go-subscriptions/internal/auth"
+	"git.home/alex/go-subscriptions/internal/clock"
+	"git.home/alex/go-subscriptions/internal/domain/service"
+	"github.com/julienschmidt/httprouter"
+)
+
+const (
+	defaultExpiryAlertDays = 60
+	maxExpiryAlertDays     = 366
+)
+
+var ErrInvalidWindow = errors.New("the number of days is not valid")
+
+// GetExpiring lists the payment methods expiring within ?days days, by default 60, that still have
+// subscriptions charged to them, so the subscriptions can be moved to another method in time.
+func GetExpiring(ctx context.Context, ms *service.PaymentMethodService) api_response.Handle {
+	return func(r *http.Request, _ httprouter.Params) any {
+		ctx := auth.RequestContext(ctx, r)
+
+		days := defaultExpiryAlertDays
+
+		if value := r.URL.Query().Get("days"); value != "" {
+			var err error
+
+			days, err = strconv.Atoi(value)
+			if err != nil || days < 0 || days > maxExpiryAlertDays {
+				return ErrInvalidWindow
+			}
+		}
+
+		now := clock.Today(ctx)
+
+		expiring, err := ms.GetExpiring(ctx, now, now.AddDate(0, 0, days))
+		if err != nil {
+			return err
+		}
+
+		type subscriptionResp struct {
+			ID   uint   `json:"id"`
+			Name string `json:"name"`
+		}
+
+		type resp struct {
+			methodResp
+			Subscriptions []subscriptionResp `json:"subscriptions"`
+		}
+
+		expiringDTOs := make([]resp, len(expiring))
+		for i := range expiring {
+			expiringDTOs[i] = resp{methodResp: newMethodResp(&expiring[i].PaymentMethod, now)}
+
+			for _, subscription := range expiring[i].Subscriptions {
+				expiringDTOs[i].Subscriptions = append(expiringDTOs[i].Subscriptions, subscriptionResp{
+					ID:   subscription.ID,
+					Name: subscription.Name,
+				})
+			}
+		}
+
+		return expiringDTOs
+	}
+}
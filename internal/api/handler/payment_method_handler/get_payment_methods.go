@@ -0,0 +1,30 @@
+package payment_method_handler
+
+import (
+	"context"
+	"net/http"
+
+	"git.home/alex/go-subscriptions/internal/api/api_response"
+	"git.home/alex/go-subscriptions/internal/auth"
+	"git.home/alex/go-subscriptions/internal/clock"
+	"git.home/alex/go-subscriptions/internal/domain/service"
+	"github.com/julienschmidt/httprouter"
+)
+
+func GetPaymentMethods(ctx context.Context, ms *service.PaymentMethodService) api_response.Handle {
+	return func(r *http.Request, _ httprouter.Params) any {
+		ctx := auth.RequestContext(ctx, r)
+
+		methods, err := ms.GetAllPaymentMethods(ctx)
+		if err != nil {
+			return err
+		}
+
+		methodDTOs := make([]methodResp, len(methods))
+		for i := range methods {
+			methodDTOs[i] = newMethodResp(&methods[i], clock.Today(ctx))
+		}
+
+		return methodDTOs
+	}
+}
@@ -0,0 +1,40 @@
+package payment_method_handler
+
+import (
+	"context"
+	"net/http"
+
+	"git.home/alex/go-subscriptions/internal/api/api_response"
+	"git.home/alex/go-subscriptions/internal/auth"
+	"git.home/alex/go-subscriptions/internal/clock"
+	"git.home/alex/go-subscriptions/internal/domain/service"
+	"github.com/julienschmidt/httprouter"
+)
+
+func GetReport(ctx context.Context, ms *service.PaymentMethodService) api_response.Handle {
+	return func(r *http.Request, _ httprouter.Params) any {
+		ctx := auth.RequestContext(ctx, r)
+
+		reports, err := ms.GetReport(ctx)
+		if err != nil {
+			return err
+		}
+
+		type resp struct {
+			methodResp
+			Monthly       map[string]float64 `json:"monthly"`
+			Subscriptions int                `json:"subscriptions"`
+		}
+
+		reportDTOs := make([]resp, len(reports))
+		for i := range reports {
+			reportDTOs[i] = resp{
+				methodResp:    newMethodResp(&reports[i].PaymentMethod, clock.Today(ctx)),
+				Monthly:       reports[i].Totals,
+				Subscriptions: reports[i].Subscriptions,
+			}
+		}
+
+		return reportDTOs
+	}
+}
@@ -0,0 +1,47 @@
+package payment_method_handler
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strconv"
+
+	"git.home/alex/go-subscriptions/internal/api/api_response"
+	"git.home/alex/go-subscriptions/internal/auth"
+	"git.home/alex/go-subscriptions/internal/domain/service"
+	"github.com/julienschmidt/httprouter"
+)
+
+// LinkSubscription sets the payment method the subscription is charged to; a zero
+// payment_method_id unlinks it.
+func LinkSubscription(ctx context.Context, ms *service.PaymentMethodService) api_response.Handle {
+	return func(r *http.Request, ps httprouter.Params) any {
+		ctx := auth.RequestContext(ctx, r)
+
+		id, err := strconv.Atoi(ps.ByName("id"))
+		if err != nil {
+			return err
+		}
+
+		var req struct {
+			PaymentMethodID uint `json:"payment_method_id"`
+		}
+
+		err = json.NewDecoder(r.Body).Decode(&req)
+		if err != nil {
+			return err
+		}
+
+		subscription, err := ms.LinkSubscription(ctx, uint(id), req.PaymentMethodID)
+		if err != nil {
+			return err
+		}
+
+		type resp struct {
+			SubscriptionID  uint `json:"subscription_id"`
+			PaymentMethodID uint `json:"payment_method_id"`
+		}
+
+		return resp{SubscriptionID: subscription.ID, PaymentMethodID: subscription.PaymentMethodID}
+	}
+}
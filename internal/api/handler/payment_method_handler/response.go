@@ -0,0 +1,59 @@
+package payment_method_handler
+
+import (
+	"time"
+
+	"git.home/alex/go-subscriptions/internal/domain/entity"
+	"git.home/alex/go-subscriptions/internal/domain/service"
+)
+
+// expiryLayout formats the month a card expires in.
+const expiryLayout = "2006-01"
+
+type methodReq struct {
+	Label    string                   `json:"label"`
+	Type     entity.PaymentMethodType `json:"type"`
+	LastFour string                   `json:"last_four"`
+	// Expiry is the year and month the method expires in, empty when it doesn't expire.
+	Expiry string `json:"expiry"`
+}
+
+func (req methodReq) entity(id uint) (entity.PaymentMethod, error) {
+	method := entity.PaymentMethod{ID: id, Label: req.Label, Type: req.Type, LastFour: req.LastFour}
+
+	if req.Expiry != "" {
+		expiry, err := time.Parse(expiryLayout, req.Expiry)
+		if err != nil {
+			return entity.PaymentMethod{}, service.ErrInvalidPaymentMethod
+		}
+
+		method.ExpiryYear, method.ExpiryMonth = expiry.Year(), expiry.Month()
+	}
+
+	return method, nil
+}
+
+type methodResp struct {
+	ID       uint   `json:"id"`
+	Label    string `json:"label"`
+	Type     string `json:"type"`
+	LastFour string `json:"last_four,omitempty"`
+	Expiry   string `json:"expiry,omitempty"`
+	Expired  bool   `json:"expired"`
+}
+
+func newMethodResp(method *entity.PaymentMethod, today time.Time) methodResp {
+	resp := methodResp{
+		ID:       method.ID,
+		Label:    method.Label,
+		Type:     string(method.Type),
+		LastFour: method.LastFour,
+		Expired:  method.ExpiresBefore(today),
+	}
+
+	if method.Expires() {
+		resp.Expiry = time.Date(method.ExpiryYear, method.ExpiryMonth, 1, 0, 0, 0, 0, time.UTC).Format(expiryLayout)
+	}
+
+	return resp
+}
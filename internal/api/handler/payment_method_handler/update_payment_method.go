@@ -0,0 +1,44 @@
+package payment_method_handler
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strconv"
+
+	"git.home/alex/go-subscriptions/internal/api/api_response"
+	"git.home/alex/go-subscriptions/internal/auth"
+	"git.home/alex/go-subscriptions/internal/clock"
+	"git.home/alex/go-subscriptions/internal/domain/service"
+	"github.com/julienschmidt/httprouter"
+)
+
+func UpdatePaymentMethod(ctx context.Context, ms *service.PaymentMethodService) api_response.Handle {
+	return func(r *http.Request, ps httprouter.Params) any {
+		ctx := auth.RequestContext(ctx, r)
+
+		id, err := strconv.Atoi(ps.ByName("id"))
+		if err != nil {
+			return err
+		}
+
+		var req methodReq
+
+		err = json.NewDecoder(r.Body).Decode(&req)
+		if err != nil {
+			return err
+		}
+
+		method, err := req.entity(uint(id))
+		if err != nil {
+			return err
+		}
+
+		updatedMethod, err := ms.UpdatePaymentMethod(ctx, method)
+		if err != nil {
+			return err
+		}
+
+		return newMethodResp(updatedMethod, clock.Today(ctx))
+	}
+}
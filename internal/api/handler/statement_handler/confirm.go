@@ -0,0 +1,35 @@
+package statement_handler
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+
+	"git.home/alex/go-subscriptions/internal/api/api_response"
+	"git.home/alex/go-subscriptions/internal/auth"
+	"git.home/alex/go-subscriptions/internal/domain/service"
+	"github.com/julienschmidt/httprouter"
+)
+
+// Confirm creates subscriptions from the proposals of a scan, usually a reviewed subset of them.
+func Confirm(ctx context.Context, ss *service.StatementService) api_response.Handle {
+	return func(r *http.Request, _ httprouter.Params) any {
+		ctx := auth.RequestContext(ctx, r)
+
+		var req struct {
+			Proposals []service.StatementProposal `json:"proposals"`
+		}
+
+		err := json.NewDecoder(r.Body).Decode(&req)
+		if err != nil {
+			return err
+		}
+
+		report, err := ss.Confirm(ctx, req.Proposals)
+		if err != nil {
+			return err
+		}
+
+		return report
+	}
+}
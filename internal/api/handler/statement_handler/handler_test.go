@@ -0,0 +1,139 @@
+package statement_handler_test
+
+import (
+	"bytes"
+	"context"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"git.home/alex/go-subscriptions/internal/api/handler/statement_handler"
+	"git.home/alex/go-subscriptions/internal/domain/entity"
+	"git.home/alex/go-subscriptions/internal/domain/service"
+	"git.home/alex/go-subscriptions/internal/repository/memory"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newStatementService(t *testing.T) *service.StatementService {
+	t.Helper()
+
+	ctx := context.Background()
+	currencies := service.NewCurrencyService(memory.NewCurrencyRepository())
+	cycles := service.NewCycleService(memory.NewCycleRepository())
+
+	_, err := currencies.CreateCurrency(ctx, entity.USD)
+	require.NoError(t, err)
+	_, err = cycles.CreateCycle(ctx, entity.Monthly)
+	require.NoError(t, err)
+
+	return service.NewStatementService(currencies, cycles, service.NewSubscriptionService(memory.NewSubscriptionRepository()))
+}
+
+func newScanRequest(t *testing.T, field, content string, values map[string]string) *http.Request {
+	t.Helper()
+
+	var body bytes.Buffer
+
+	mw := multipart.NewWriter(&body)
+	fw, err := mw.CreateFormFile(field, "statement.csv")
+	require.NoError(t, err)
+	_, _ = fw.Write([]byte(content))
+
+	for name, value := range values {
+		require.NoError(t, mw.WriteField(name, value))
+	}
+
+	require.NoError(t, mw.Close())
+
+	r := httptest.NewRequest(http.MethodPost, "/api/statements/scan", &body)
+	r.Header.Set("Content-Type", mw.FormDataContentType())
+
+	return r
+}
+
+func TestScan(t *testing.T) {
+	const content = "Day\tText\tSum\n2024-01-05\tNetflix\t-10.00\n2024-02-05\tNetflix\t-10.00\n2024-03-05\tNetflix\t-10.00\n"
+
+	testCases := []struct {
+		name     string
+		field    string
+		content  string
+		values   map[string]string
+		expected *service.StatementScanReport
+		wantErr  error
+	}{
+		{
+			name:  "Test scan with mapping",
+			field: statement_handler.FileField,
+			values: map[string]string{
+				"format": "csv", "date_column": "day", "description_column": "text", "amount_column": "sum",
+				"delimiter": `\t`, "currency": "USD",
+			},
+			expected: &service.StatementScanReport{
+				Format:       service.StatementFormatCSV,
+				Transactions: 3,
+				Proposals: []service.StatementProposal{{
+					Merchant: "NETFLIX", Name: "Netflix", Price: 10, CurrencyCode: "USD",
+					CycleID: 1, CycleName: "Monthly", CycleDays: 30, CycleCalendar: true, NextPaymentDate: "2024-04-05",
+					Occurrences: 3, FirstCharge: "2024-01-05", LastCharge: "2024-03-05",
+				}},
+			},
+		},
+		{
+			name:    "Test missing column error",
+			field:   statement_handler.FileField,
+			wantErr: service.ErrInvalidStatement,
+		},
+		{
+			name:    "Test missing file error",
+			field:   "upload",
+			wantErr: statement_handler.ErrMissingFile,
+		},
+		{
+			name:    "Test file too large error",
+			field:   statement_handler.FileField,
+			content: content + strings.Repeat("2024-03-05\tBakery\t-4.20\n", 1<<20),
+			wantErr: statement_handler.ErrFileTooLarge,
+		},
+	}
+
+	ss := newStatementService(t)
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if tc.content == "" {
+				tc.content = content
+			}
+
+			r := newScanRequest(t, tc.field, tc.content, tc.values)
+
+			response := statement_handler.Scan(context.Background(), ss)(r, nil)
+
+			if tc.wantErr != nil {
+				assert.ErrorIs(t, response.(error), tc.wantErr)
+				return
+			}
+
+			assert.Equal(t, tc.expected, response)
+		})
+	}
+}
+
+func TestConfirm(t *testing.T) {
+	ss := newStatementService(t)
+
+	body := `{"proposals":[{"name":"Netflix","price":10,"currency_code":"USD","cycle_id":1},{"name":"Gym","price":5}]}`
+	r := httptest.NewRequest(http.MethodPost, "/api/statements/confirm", strings.NewReader(body))
+
+	response := statement_handler.Confirm(context.Background(), ss)(r, nil)
+
+	assert.Equal(t, &service.StatementConfirmReport{
+		Created:       1,
+		Subscriptions: []uint{1},
+		CreatedCycles: []string{},
+		Errors:        []service.StatementProposalError{{Proposal: 1, Error: `the subscription is invalid: currency ""`}},
+	}, response)
+}
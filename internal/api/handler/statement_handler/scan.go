@@ -0,0 +1,71 @@
+package statement_handler
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strconv"
+
+	"git.home/alex/go-subscriptions/internal/api/api_response"
+	"git.home/alex/go-subscriptions/internal/auth"
+	"git.home/alex/go-subscriptions/internal/domain/service"
+	"github.com/julienschmidt/httprouter"
+)
+
+const (
+	FileField = "file"
+
+	maxUploadSize = 10 << 20
+	maxMemory     = 1 << 20
+)
+
+var (
+	ErrMissingFile  = errors.New("the statement file is missing from the form")
+	ErrFileTooLarge = errors.New("the statement file is larger than 10 MiB")
+)
+
+// Scan reads the "file" field of a multipart form. The other form fields set the format and the
+// column mapping of CSV statements; see StatementMapping for their names.
+func Scan(ctx context.Context, ss *service.StatementService) api_response.Handle {
+	return func(r *http.Request, _ httprouter.Params) any {
+		ctx := auth.RequestContext(ctx, r)
+
+		r.Body = http.MaxBytesReader(nil, r.Body, maxUploadSize)
+
+		err := r.ParseMultipartForm(maxMemory)
+		if err != nil {
+			var tooLarge *http.MaxBytesError
+			if errors.As(err, &tooLarge) {
+				return ErrFileTooLarge
+			}
+
+			return err
+		}
+
+		file, _, err := r.FormFile(FileField)
+		if err != nil {
+			return ErrMissingFile
+		}
+		defer file.Close()
+
+		decimalComma, _ := strconv.ParseBool(r.FormValue("decimal_comma"))
+
+		mapping := service.StatementMapping{
+			DateColumn:        r.FormValue("date_column"),
+			DescriptionColumn: r.FormValue("description_column"),
+			AmountColumn:      r.FormValue("amount_column"),
+			CurrencyColumn:    r.FormValue("currency_column"),
+			DateLayout:        r.FormValue("date_layout"),
+			Delimiter:         r.FormValue("delimiter"),
+			DecimalComma:      decimalComma,
+			Currency:          r.FormValue("currency"),
+		}
+
+		report, err := ss.Scan(ctx, file, service.StatementFormat(r.FormValue("format")), mapping)
+		if err != nil {
+			return err
+		}
+
+		return report
+	}
+}
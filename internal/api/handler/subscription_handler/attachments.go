@@ -0,0 +1,181 @@
+package subscription_handler
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"mime"
+	"net/http"
+	"strconv"
+	"time"
+
+	"git.home/alex/go-subscriptions/internal/api/api_response"
+	"git.home/alex/go-subscriptions/internal/auth"
+	"git.home/alex/go-subscriptions/internal/domain/entity"
+	"git.home/alex/go-subscriptions/internal/domain/service"
+	"github.com/julienschmidt/httprouter"
+)
+
+const (
+	AttachmentFileField        = "file"
+	AttachmentPaymentDateField = "payment_date"
+
+	// maxAttachmentRequestSize caps the whole form; the service enforces the configured limit on the file.
+	maxAttachmentRequestSize = 64 << 20
+	maxAttachmentMemory      = 1 << 20
+)
+
+var (
+	ErrMissingAttachment = errors.New("the file is missing from the form")
+)
+
+type attachmentResp struct {
+	ID             uint      `json:"id"`
+	SubscriptionID uint      `json:"subscription_id"`
+	PaymentDate    string    `json:"payment_date,omitempty"`
+	Name           string    `json:"name"`
+	ContentType    string    `json:"content_type"`
+	Size           int64     `json:"size"`
+	Checksum       string    `json:"checksum"`
+	CreatedAt      time.Time `json:"created_at"`
+	URL            string    `json:"url"`
+}
+
+func newAttachmentResp(attachment *entity.Attachment) attachmentResp {
+	return attachmentResp{
+		ID:             attachment.ID,
+		SubscriptionID: attachment.SubscriptionID,
+		PaymentDate:    formatOptionalDate(attachment.PaymentDate),
+		Name:           attachment.Name,
+		ContentType:    attachment.ContentType,
+		Size:           attachment.Size,
+		Checksum:       attachment.Checksum,
+		CreatedAt:      attachment.CreatedAt,
+		URL:            "/api/attachment/" + strconv.FormatUint(uint64(attachment.ID), 10),
+	}
+}
+
+// AddAttachment reads the "file" field of a multipart form. The optional "payment_date" field ties
+// the file to the charge of that day, e.g. for an invoice.
+func AddAttachment(ctx context.Context, ho *HandlerOpts) api_response.Handle {
+	return func(r *http.Request, ps httprouter.Params) any {
+		ctx := auth.RequestContext(ctx, r)
+
+		id, err := strconv.Atoi(ps.ByName("id"))
+		if err != nil {
+			return err
+		}
+
+		r.Body = http.MaxBytesReader(nil, r.Body, maxAttachmentRequestSize)
+
+		err = r.ParseMultipartForm(maxAttachmentMemory)
+		if err != nil {
+			var tooLarge *http.MaxBytesError
+			if errors.As(err, &tooLarge) {
+				return service.ErrAttachmentTooLarge
+			}
+
+			return err
+		}
+
+		var paymentDate entity.PaymentDate
+
+		if value := r.FormValue(AttachmentPaymentDateField); value != "" {
+			date, err := time.Parse(PaymentDateLayout, value)
+			if err != nil {
+				return ErrInvalidPaymentDate
+			}
+
+			paymentDate = entity.PaymentDate(date)
+		}
+
+		file, header, err := r.FormFile(AttachmentFileField)
+		if err != nil {
+			return ErrMissingAttachment
+		}
+		defer file.Close()
+
+		attachment, err := ho.AttachmentService.AddAttachment(ctx, uint(id), header.Filename, paymentDate, file)
+		if err != nil {
+			return err
+		}
+
+		return newAttachmentResp(attachment)
+	}
+}
+
+func GetAttachments(ctx context.Context, ho *HandlerOpts) api_response.Handle {
+	return func(r *http.Request, ps httprouter.Params) any {
+		ctx := auth.RequestContext(ctx, r)
+
+		id, err := strconv.Atoi(ps.ByName("id"))
+		if err != nil {
+			return err
+		}
+
+		attachments, err := ho.AttachmentService.GetAttachments(ctx, uint(id))
+		if err != nil {
+			return err
+		}
+
+		attachmentDTOs := make([]attachmentResp, len(attachments))
+		for i := range attachments {
+			attachmentDTOs[i] = newAttachmentResp(&attachments[i])
+		}
+
+		return attachmentDTOs
+	}
+}
+
+// DownloadAttachment writes the file with its original name as a download.
+func DownloadAttachment(ctx context.Context, ho *HandlerOpts) httprouter.Handle {
+	return func(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
+		ctx := auth.RequestContext(ctx, r)
+
+		id, err := strconv.Atoi(ps.ByName("id"))
+		if err != nil {
+			writeRawError(w, err)
+
+			return
+		}
+
+		attachment, content, err := ho.AttachmentService.OpenAttachment(ctx, uint(id))
+		if err != nil {
+			writeRawError(w, err)
+
+			return
+		}
+		defer content.Close()
+
+		w.Header().Set("Content-Type", attachment.ContentType)
+		w.Header().Set("Content-Length", strconv.FormatInt(attachment.Size, 10))
+		w.Header().Set("Content-Disposition", mime.FormatMediaType("attachment", map[string]string{"filename": attachment.Name}))
+		w.Header().Set("X-Content-Type-Options", "nosniff")
+		_, _ = io.Copy(w, content)
+	}
+}
+
+func DeleteAttachment(ctx context.Context, ho *HandlerOpts) api_response.Handle {
+	return func(r *http.Request, ps httprouter.Params) any {
+		ctx := auth.RequestContext(ctx, r)
+
+		id, err := strconv.Atoi(ps.ByName("id"))
+		if err != nil {
+			return err
+		}
+
+		err = ho.AttachmentService.DeleteAttachment(ctx, uint(id))
+		if err != nil {
+			return err
+		}
+
+		return nil
+	}
+}
+
+// writeRawError reports an error of a handler that otherwise doesn't respond with JSON.
+func writeRawError(w http.ResponseWriter, err error) {
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(api_response.Error(err))
+}
@@ -0,0 +1,110 @@
+package subscription_handler_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"git.home/alex/go-subscriptions/internal/api/handler"
+	"git.home/alex/go-subscriptions/internal/api/handler/subscription_handler"
+	"git.home/alex/go-subscriptions/internal/blobstore"
+	"git.home/alex/go-subscriptions/internal/domain/entity"
+	"git.home/alex/go-subscriptions/internal/domain/service"
+	"git.home/alex/go-subscriptions/internal/repository/memory"
+	"github.com/julienschmidt/httprouter"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newAttachmentRouter(t *testing.T) *httprouter.Router {
+	t.Helper()
+
+	ctx := context.Background()
+	subscriptions := memory.NewSubscriptionRepository()
+
+	_, err := subscriptions.Create(ctx, entity.Subscription{Name: "Streaming", Price: 10})
+	require.NoError(t, err)
+
+	opts := &subscription_handler.HandlerOpts{
+		SubscriptionService: service.NewSubscriptionService(subscriptions),
+		AttachmentService:   service.NewAttachmentService(memory.NewAttachmentRepository(), subscriptions, blobstore.NewMemory(), 0),
+	}
+
+	router := httprouter.New()
+	router.POST("/api/subscription/:id/attachments", handler.Handle(subscription_handler.AddAttachment(ctx, opts)))
+	router.GET("/api/subscription/:id/attachments", handler.Handle(subscription_handler.GetAttachments(ctx, opts)))
+	router.GET("/api/attachment/:id", subscription_handler.DownloadAttachment(ctx, opts))
+	router.DELETE("/api/attachment/:id", handler.Handle(subscription_handler.DeleteAttachment(ctx, opts)))
+
+	return router
+}
+
+func newAttachmentRequest(t *testing.T, fields map[string]string, name, content string) *http.Request {
+	t.Helper()
+
+	var body bytes.Buffer
+
+	mw := multipart.NewWriter(&body)
+	for k, v := range fields {
+		require.NoError(t, mw.WriteField(k, v))
+	}
+
+	fw, err := mw.CreateFormFile(subscription_handler.AttachmentFileField, name)
+	require.NoError(t, err)
+	_, _ = fw.Write([]byte(content))
+	require.NoError(t, mw.Close())
+
+	r := httptest.NewRequest(http.MethodPost, "/api/subscription/1/attachments", &body)
+	r.Header.Set("Content-Type", mw.FormDataContentType())
+
+	return r
+}
+
+func TestAttachments(t *testing.T) {
+	router := newAttachmentRouter(t)
+	serve := func(r *http.Request) *httptest.ResponseRecorder {
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, r)
+
+		return w
+	}
+
+	w := serve(newAttachmentRequest(t, map[string]string{"payment_date": "2024-04-01"}, "invoice april.pdf", "%PDF-1.4\n"))
+
+	var added struct {
+		Status string `json:"status"`
+		Data   struct {
+			ID          uint   `json:"id"`
+			PaymentDate string `json:"payment_date"`
+			Name        string `json:"name"`
+			ContentType string `json:"content_type"`
+			URL         string `json:"url"`
+		} `json:"data"`
+	}
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &added))
+	require.Equal(t, "success", added.Status, w.Body.String())
+	assert.Equal(t, "2024-04-01", added.Data.PaymentDate)
+	assert.Equal(t, "application/pdf", added.Data.ContentType)
+	assert.Equal(t, "/api/attachment/1", added.Data.URL)
+
+	w = serve(httptest.NewRequest(http.MethodGet, "/api/subscription/1/attachments", nil))
+	assert.Contains(t, w.Body.String(), `"name":"invoice april.pdf"`)
+
+	w = serve(httptest.NewRequest(http.MethodGet, added.Data.URL, nil))
+	assert.Equal(t, "%PDF-1.4\n", w.Body.String())
+	assert.Equal(t, "application/pdf", w.Header().Get("Content-Type"))
+	assert.Equal(t, `attachment; filename="invoice april.pdf"`, w.Header().Get("Content-Disposition"))
+
+	w = serve(newAttachmentRequest(t, map[string]string{"payment_date": "April"}, "invoice.pdf", "%PDF-1.4\n"))
+	assert.Contains(t, w.Body.String(), subscription_handler.ErrInvalidPaymentDate.Error())
+
+	w = serve(httptest.NewRequest(http.MethodDelete, added.Data.URL, nil))
+	assert.JSONEq(t, `{"status":"success","data":null,"error":""}`, w.Body.String())
+
+	w = serve(httptest.NewRequest(http.MethodGet, added.Data.URL, nil))
+	assert.Contains(t, w.Body.String(), "the attachment was not found in the repository")
+}
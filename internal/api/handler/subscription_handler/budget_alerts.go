@@ -0,0 +1,53 @@
+package subscription_handler
+
+import (
+	"context"
+	"log/slog"
+
+	"git.home/alex/go-subscriptions/internal/clock"
+	"git.home/alex/go-subscriptions/internal/domain/entity"
+)
+
+type budgetAlertResp struct {
+	BudgetID     uint    `json:"budget_id"`
+	CategoryID   uint    `json:"category_id,omitempty"`
+	Period       string  `json:"period"`
+	Amount       float64 `json:"amount"`
+	Spent        float64 `json:"spent"`
+	CurrencyCode string  `json:"currency"`
+}
+
+type subscriptionAlertsResp struct {
+	subscriptionResp
+	// BudgetAlerts lists the budgets the change pushed over budget.
+	BudgetAlerts []budgetAlertResp `json:"budget_alerts,omitempty"`
+}
+
+// newSubscriptionAlertsResp reports the subscription saved by a create (before is nil) or an update.
+// The alerts are only reported here, nothing keeps them. The subscription is stored already, so
+// failing to check the budgets only drops the alerts and is logged.
+func newSubscriptionAlertsResp(ctx context.Context, ho *HandlerOpts, before, after *entity.Subscription) subscriptionAlertsResp {
+	resp := subscriptionAlertsResp{subscriptionResp: newSubscriptionResp(after, clock.Today(ctx))}
+	if ho.BudgetService == nil {
+		return resp
+	}
+
+	exceeded, err := ho.BudgetService.Exceeded(ctx, before, *after)
+	if err != nil {
+		slog.Warn("Budget alerts", "subscription", after.ID, "error", err)
+		return resp
+	}
+
+	for _, status := range exceeded {
+		resp.BudgetAlerts = append(resp.BudgetAlerts, budgetAlertResp{
+			BudgetID:     status.Budget.ID,
+			CategoryID:   status.Budget.CategoryID,
+			Period:       string(status.Budget.Period),
+			Amount:       status.Budget.Amount,
+			Spent:        status.Spent,
+			CurrencyCode: status.Budget.CurrencyCode,
+		})
+	}
+
+	return resp
+}
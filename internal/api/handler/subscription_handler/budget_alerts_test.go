@@ -0,0 +1,78 @@
+package subscription_handler_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"git.home/alex/go-subscriptions/internal/api/api_response"
+	"git.home/alex/go-subscriptions/internal/api/handler/subscription_handler"
+	"git.home/alex/go-subscriptions/internal/domain/entity"
+	"git.home/alex/go-subscriptions/internal/domain/service"
+	"git.home/alex/go-subscriptions/internal/repository/memory"
+	"github.com/julienschmidt/httprouter"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBudgetAlerts(t *testing.T) {
+	ctx := context.Background()
+	categories := memory.NewCategoryRepository()
+	subscriptions := memory.NewSubscriptionRepository()
+
+	opts := newHandlerOpts(t)
+	opts.SubscriptionService = service.NewSubscriptionService(subscriptions)
+	opts.CategoryService = service.NewCategoryService(categories, memory.NewSubscriptionRepository())
+	opts.BudgetService = service.NewBudgetService(memory.NewBudgetRepository(), categories, subscriptions)
+
+	category, err := opts.CategoryService.CreateCategory(ctx, entity.Category{Name: "Video"})
+	require.NoError(t, err)
+	_, err = opts.BudgetService.CreateBudget(ctx, entity.Budget{
+		CategoryID:   category.ID,
+		Period:       entity.BudgetMonthly,
+		Amount:       15,
+		CurrencyCode: "USD",
+	})
+	require.NoError(t, err)
+
+	type alertsResp struct {
+		ID           uint `json:"id"`
+		BudgetAlerts []struct {
+			BudgetID uint    `json:"budget_id"`
+			Spent    float64 `json:"spent"`
+		} `json:"budget_alerts"`
+	}
+
+	save := func(t *testing.T, handle api_response.Handle, ps httprouter.Params, body string) alertsResp {
+		t.Helper()
+
+		data, err := json.Marshal(handle(httptest.NewRequest(http.MethodPost, "/", strings.NewReader(body)), ps))
+		require.NoError(t, err)
+
+		var resp alertsResp
+		require.NoError(t, json.Unmarshal(data, &resp))
+
+		return resp
+	}
+
+	body := `{"name":"Streaming","price":10,"category_id":1,"cycle_id":2,"currency":"USD","next_payment_date":"2999-01-01"}`
+
+	created := save(t, subscription_handler.CreateSubscription(ctx, opts), nil, body)
+	assert.Empty(t, created.BudgetAlerts)
+
+	ps := httprouter.Params{{Key: "id", Value: "1"}}
+	body = strings.Replace(body, `"price":10`, `"price":20`, 1)
+
+	updated := save(t, subscription_handler.UpdateSubscription(ctx, opts), ps, body)
+	require.Len(t, updated.BudgetAlerts, 1)
+	assert.Equal(t, uint(1), updated.BudgetAlerts[0].BudgetID)
+	assert.InDelta(t, 20, updated.BudgetAlerts[0].Spent, 0.001)
+
+	body = strings.Replace(body, `"price":20`, `"price":25`, 1)
+
+	updated = save(t, subscription_handler.UpdateSubscription(ctx, opts), ps, body)
+	assert.Empty(t, updated.BudgetAlerts, "the budget was over already")
+}
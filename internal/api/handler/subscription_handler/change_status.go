@@ -0,0 +1,132 @@
+package subscription_handler
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"strconv"
+	"time"
+
+	"git.home/alex/go-subscriptions/internal/api/api_response"
+	"git.home/alex/go-subscriptions/internal/auth"
+	"git.home/alex/go-subscriptions/internal/clock"
+	"git.home/alex/go-subscriptions/internal/domain/entity"
+	"github.com/julienschmidt/httprouter"
+)
+
+func ActivateSubscription(ctx context.Context, ho *HandlerOpts) api_response.Handle {
+	return func(r *http.Request, ps httprouter.Params) any {
+		ctx := auth.RequestContext(ctx, r)
+
+		id, err := strconv.Atoi(ps.ByName("id"))
+		if err != nil {
+			return err
+		}
+
+		subscription, err := ho.SubscriptionService.Activate(ctx, uint(id))
+
+		return statusResp(ctx, subscription, err)
+	}
+}
+
+// PauseSubscription accepts an optional resume_date; without it the subscription stays paused until activated.
+func PauseSubscription(ctx context.Context, ho *HandlerOpts) api_response.Handle {
+	return func(r *http.Request, ps httprouter.Params) any {
+		ctx := auth.RequestContext(ctx, r)
+
+		id, err := strconv.Atoi(ps.ByName("id"))
+		if err != nil {
+			return err
+		}
+
+		var req struct {
+			ResumeDate *PaymentDate `json:"resume_date"`
+		}
+
+		err = decodeOptional(r, &req)
+		if err != nil {
+			return err
+		}
+
+		subscription, err := ho.SubscriptionService.Pause(ctx, uint(id), optionalDate(req.ResumeDate))
+
+		return statusResp(ctx, subscription, err)
+	}
+}
+
+// CancelSubscription accepts an optional effective_date; without it the subscription is cancelled today.
+func CancelSubscription(ctx context.Context, ho *HandlerOpts) api_response.Handle {
+	return func(r *http.Request, ps httprouter.Params) any {
+		ctx := auth.RequestContext(ctx, r)
+
+		id, err := strconv.Atoi(ps.ByName("id"))
+		if err != nil {
+			return err
+		}
+
+		var req struct {
+			EffectiveDate *PaymentDate `json:"effective_date"`
+		}
+
+		err = decodeOptional(r, &req)
+		if err != nil {
+			return err
+		}
+
+		subscription, err := ho.SubscriptionService.Cancel(ctx, uint(id), optionalDate(req.EffectiveDate))
+
+		return statusResp(ctx, subscription, err)
+	}
+}
+
+func ExpireSubscription(ctx context.Context, ho *HandlerOpts) api_response.Handle {
+	return func(r *http.Request, ps httprouter.Params) any {
+		ctx := auth.RequestContext(ctx, r)
+
+		id, err := strconv.Atoi(ps.ByName("id"))
+		if err != nil {
+			return err
+		}
+
+		subscription, err := ho.SubscriptionService.Expire(ctx, uint(id))
+
+		return statusResp(ctx, subscription, err)
+	}
+}
+
+func statusResp(ctx context.Context, subscription *entity.Subscription, err error) any {
+	if err != nil {
+		return err
+	}
+
+	return newSubscriptionResp(subscription, clock.Today(ctx))
+}
+
+// decodeOptional decodes the request body into v, an empty body leaves v untouched.
+func decodeOptional(r *http.Request, v any) error {
+	if r.Body == nil {
+		return nil
+	}
+
+	err := json.NewDecoder(r.Body).Decode(v)
+	if errors.Is(err, io.EOF) {
+		return nil
+	}
+
+	var parseErr *time.ParseError
+	if errors.As(err, &parseErr) {
+		return ErrInvalidPaymentDate
+	}
+
+	return err
+}
+
+func optionalDate(date *PaymentDate) time.Time {
+	if date == nil {
+		return time.Time{}
+	}
+
+	return time.Time(*date)
+}
@@ -0,0 +1,89 @@
+package subscription_handler_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"git.home/alex/go-subscriptions/internal/api/api_response"
+	"git.home/alex/go-subscriptions/internal/api/handler/subscription_handler"
+	"git.home/alex/go-subscriptions/internal/domain/service"
+	"github.com/julienschmidt/httprouter"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestChangeStatus(t *testing.T) {
+	type step struct {
+		handler    func(ctx context.Context, ho *subscription_handler.HandlerOpts) api_response.Handle
+		body       string
+		wantStatus string
+		wantErr    error
+	}
+
+	testCases := []struct {
+		name  string
+		steps []step
+	}{
+		{
+			name: "Pause and resume",
+			steps: []step{
+				{handler: subscription_handler.PauseSubscription, body: `{"resume_date":"2999-01-01"}`, wantStatus: "paused"},
+				{handler: subscription_handler.PauseSubscription, wantErr: service.ErrInvalidTransition},
+				{handler: subscription_handler.ActivateSubscription, wantStatus: "active"},
+			},
+		},
+		{
+			name: "Scheduled cancellation",
+			steps: []step{
+				{handler: subscription_handler.CancelSubscription, body: `{"effective_date":"2999-01-01"}`, wantStatus: "active"},
+				{handler: subscription_handler.CancelSubscription, wantStatus: "cancelled"},
+				{handler: subscription_handler.ExpireSubscription, wantErr: service.ErrInvalidTransition},
+			},
+		},
+		{
+			name: "Expire and reactivate",
+			steps: []step{
+				{handler: subscription_handler.ExpireSubscription, wantStatus: "expired"},
+				{handler: subscription_handler.ActivateSubscription, wantStatus: "active"},
+			},
+		},
+		{
+			name: "Invalid date",
+			steps: []step{
+				{
+					handler: subscription_handler.PauseSubscription,
+					body:    `{"resume_date":"tomorrow"}`,
+					wantErr: subscription_handler.ErrInvalidPaymentDate,
+				},
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			opts := newHandlerOpts(t)
+			ps := httprouter.Params{{Key: "id", Value: "1"}}
+
+			for _, s := range tc.steps {
+				r := httptest.NewRequest(http.MethodPost, "/", strings.NewReader(s.body))
+				response := s.handler(context.Background(), opts)(r, ps)
+
+				if s.wantErr != nil {
+					err, ok := response.(error)
+					require.True(t, ok, "expected an error, got %v", response)
+					assert.ErrorIs(t, err, s.wantErr)
+
+					continue
+				}
+
+				data, err := json.Marshal(response)
+				require.NoError(t, err)
+				assert.Contains(t, string(data), `"status":"`+s.wantStatus+`"`)
+			}
+		})
+	}
+}
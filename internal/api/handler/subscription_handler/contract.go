@@ -0,0 +1,140 @@
+package subscription_handler
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+	"time"
+
+	"git.home/alex/go-subscriptions/internal/api/api_response"
+	"git.home/alex/go-subscriptions/internal/auth"
+	"git.home/alex/go-subscriptions/internal/clock"
+	"git.home/alex/go-subscriptions/internal/domain/entity"
+	"github.com/julienschmidt/httprouter"
+)
+
+const defaultContractAlertDays = 30
+
+type contractResp struct {
+	StartDate  string `json:"start_date"`
+	EndDate    string `json:"end_date"`
+	NoticeDays uint   `json:"notice_days"`
+	AutoRenew  bool   `json:"auto_renew"`
+	TermEnd    string `json:"term_end"`
+	CancelBy   string `json:"cancel_by,omitempty"`
+}
+
+func newContractResp(contract *entity.Contract, today time.Time) *contractResp {
+	if contract == nil {
+		return nil
+	}
+
+	return &contractResp{
+		StartDate:  formatOptionalDate(contract.StartDate),
+		EndDate:    formatOptionalDate(contract.EndDate),
+		NoticeDays: contract.NoticeDays,
+		AutoRenew:  contract.AutoRenew,
+		TermEnd:    formatOptionalDate(entity.PaymentDate(contract.TermEnd(today))),
+		CancelBy:   formatOptionalDate(entity.PaymentDate(contract.CancelBy(today))),
+	}
+}
+
+func SetContract(ctx context.Context, ho *HandlerOpts) api_response.Handle {
+	return func(r *http.Request, ps httprouter.Params) any {
+		ctx := auth.RequestContext(ctx, r)
+
+		id, err := strconv.Atoi(ps.ByName("id"))
+		if err != nil {
+			return err
+		}
+
+		var req struct {
+			StartDate  PaymentDate `json:"start_date"`
+			EndDate    PaymentDate `json:"end_date"`
+			NoticeDays uint        `json:"notice_days"`
+			AutoRenew  bool        `json:"auto_renew"`
+		}
+
+		err = json.NewDecoder(r.Body).Decode(&req)
+		if err != nil {
+			var parseErr *time.ParseError
+			if errors.As(err, &parseErr) {
+				return ErrInvalidPaymentDate
+			}
+
+			return err
+		}
+
+		subscription, err := ho.SubscriptionService.SetContract(ctx, uint(id), &entity.Contract{
+			StartDate:  entity.PaymentDate(req.StartDate),
+			EndDate:    entity.PaymentDate(req.EndDate),
+			NoticeDays: req.NoticeDays,
+			AutoRenew:  req.AutoRenew,
+		})
+
+		return statusResp(ctx, subscription, err)
+	}
+}
+
+func RemoveContract(ctx context.Context, ho *HandlerOpts) api_response.Handle {
+	return func(r *http.Request, ps httprouter.Params) any {
+		ctx := auth.RequestContext(ctx, r)
+
+		id, err := strconv.Atoi(ps.ByName("id"))
+		if err != nil {
+			return err
+		}
+
+		subscription, err := ho.SubscriptionService.SetContract(ctx, uint(id), nil)
+
+		return statusResp(ctx, subscription, err)
+	}
+}
+
+// GetContractDeadlines lists the notice deadlines and contract ends within ?days days, by default the
+// configured alert window, so contracts can be cancelled before they renew.
+func GetContractDeadlines(ctx context.Context, ho *HandlerOpts) api_response.Handle {
+	return func(r *http.Request, _ httprouter.Params) any {
+		ctx := auth.RequestContext(ctx, r)
+
+		fallback := defaultContractAlertDays
+		if ho.ContractAlertDays > 0 {
+			fallback = int(ho.ContractAlertDays)
+		}
+
+		days, err := windowDays(r, fallback)
+		if err != nil {
+			return err
+		}
+
+		from := clock.Today(ctx)
+
+		deadlines, err := ho.SubscriptionService.ContractDeadlines(ctx, from, from.AddDate(0, 0, days))
+		if err != nil {
+			return err
+		}
+
+		type resp struct {
+			SubscriptionID uint   `json:"subscription_id"`
+			Name           string `json:"name"`
+			Kind           string `json:"kind"`
+			Date           string `json:"date"`
+			DaysLeft       int    `json:"days_left"`
+		}
+
+		deadlineDTOs := make([]resp, len(deadlines))
+		for i, d := range deadlines {
+			deadlineDTOs[i] = resp{
+				SubscriptionID: d.Subscription.ID,
+				Name:           d.Subscription.Name,
+				Kind:           string(d.Kind),
+				Date:           d.Date.Format(PaymentDateLayout),
+				DaysLeft:       int(d.Date.Sub(from).Hours() / 24),
+			}
+		}
+
+		return deadlineDTOs
+	}
+}
@@ -0,0 +1,88 @@
+package subscription_handler_test
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"git.home/alex/go-subscriptions/internal/api/handler/subscription_handler"
+	"git.home/alex/go-subscriptions/internal/domain/service"
+	"github.com/julienschmidt/httprouter"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSetContract(t *testing.T) {
+	today := time.Now().UTC().Truncate(24 * time.Hour)
+	date := func(days int) string { return today.AddDate(0, 0, days).Format(subscription_handler.PaymentDateLayout) }
+
+	testCases := []struct {
+		name          string
+		body          string
+		wantDeadlines string
+		wantErr       error
+	}{
+		{
+			name:          "Auto-renewing",
+			body:          fmt.Sprintf(`{"start_date":%q,"end_date":%q,"notice_days":30,"auto_renew":true}`, date(-320), date(40)),
+			wantDeadlines: "cancel_by " + date(10),
+		},
+		{
+			name:          "Fixed term",
+			body:          fmt.Sprintf(`{"start_date":%q,"end_date":%q}`, date(-340), date(20)),
+			wantDeadlines: "contract_end " + date(20),
+		},
+		{
+			name:    "End before start",
+			body:    fmt.Sprintf(`{"start_date":%q,"end_date":%q}`, date(20), date(-340)),
+			wantErr: service.ErrInvalidContract,
+		},
+		{
+			name:    "Invalid date",
+			body:    `{"start_date":"today","end_date":"2999-01-01"}`,
+			wantErr: subscription_handler.ErrInvalidPaymentDate,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			opts := newHandlerOpts(t)
+			ps := httprouter.Params{{Key: "id", Value: "1"}}
+
+			r := httptest.NewRequest(http.MethodPut, "/", strings.NewReader(tc.body))
+			response := subscription_handler.SetContract(context.Background(), opts)(r, ps)
+
+			if tc.wantErr != nil {
+				err, ok := response.(error)
+				require.True(t, ok, "expected an error, got %v", response)
+				assert.ErrorIs(t, err, tc.wantErr)
+
+				return
+			}
+
+			r = httptest.NewRequest(http.MethodGet, "/api/contracts/deadlines", nil)
+			response = subscription_handler.GetContractDeadlines(context.Background(), opts)(r, nil)
+
+			var deadlines []struct {
+				Kind string `json:"kind"`
+				Date string `json:"date"`
+			}
+			data, err := json.Marshal(response)
+			require.NoError(t, err)
+			require.NoError(t, json.Unmarshal(data, &deadlines))
+
+			require.Len(t, deadlines, 1)
+			assert.Equal(t, tc.wantDeadlines, deadlines[0].Kind+" "+deadlines[0].Date)
+
+			response = subscription_handler.RemoveContract(context.Background(), opts)(r, ps)
+			data, err = json.Marshal(response)
+			require.NoError(t, err)
+			assert.NotContains(t, string(data), "contract")
+		})
+	}
+}
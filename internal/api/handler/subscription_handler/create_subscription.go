@@ -7,12 +7,15 @@ import (
 	"time"
 
 	"git.home/alex/go-subscriptions/internal/api/api_response"
+	"git.home/alex/go-subscriptions/internal/auth"
 	"git.home/alex/go-subscriptions/internal/domain/entity"
 	"github.com/julienschmidt/httprouter"
 )
 
 func CreateSubscription(ctx context.Context, ho *HandlerOpts) api_response.Handle {
 	return func(r *http.Request, _ httprouter.Params) any {
+		ctx := auth.RequestContext(ctx, r)
+
 		var req struct {
 			Name            string      `json:"name"`
 			Note            string      `json:"note,omitempty"`
@@ -22,6 +25,10 @@ func CreateSubscription(ctx context.Context, ho *HandlerOpts) api_response.Handl
 			CycleID         uint        `json:"cycle_id"`
 			CurrencyCode    string      `json:"currency"`
 			NextPaymentDate PaymentDate `json:"next_payment_date"`
+			// Status defaults to active; a trial needs its end date, price is charged after it.
+			Status         entity.SubscriptionStatus `json:"status,omitempty"`
+			TrialStartDate *PaymentDate              `json:"trial_start_date,omitempty"`
+			TrialEndDate   *PaymentDate              `json:"trial_end_date,omitempty"`
 		}
 
 		err := json.NewDecoder(r.Body).Decode(&req)
@@ -49,7 +56,7 @@ func CreateSubscription(ctx context.Context, ho *HandlerOpts) api_response.Handl
 			return err
 		}
 
-		createdSubscription, err := ho.SubscriptionService.CreateSubscription(ctx, entity.Subscription{
+		subscription := entity.Subscription{
 			Name:            req.Name,
 			Note:            req.Note,
 			Logo:            req.Logo,
@@ -58,33 +65,22 @@ func CreateSubscription(ctx context.Context, ho *HandlerOpts) api_response.Handl
 			Cycle:           *cycle,
 			Currency:        *currency,
 			NextPaymentDate: entity.PaymentDate(req.NextPaymentDate),
-		})
-		if err != nil {
-			return err
+			Status:          req.Status,
+		}
+
+		if req.TrialStartDate != nil {
+			subscription.TrialStartDate = entity.PaymentDate(*req.TrialStartDate)
 		}
 
-		type resp struct {
-			ID              uint    `json:"id"`
-			Name            string  `json:"name"`
-			Note            string  `json:"note"`
-			Logo            string  `json:"logo"`
-			Price           float64 `json:"price"`
-			CategoryID      uint    `json:"category_id"`
-			CycleID         uint    `json:"cycle_id"`
-			CurrencyCode    string  `json:"currency"`
-			NextPaymentDate string  `json:"next_payment_date"`
+		if req.TrialEndDate != nil {
+			subscription.TrialEndDate = entity.PaymentDate(*req.TrialEndDate)
 		}
 
-		return resp{
-			ID:              createdSubscription.ID,
-			Name:            createdSubscription.Name,
-			Note:            createdSubscription.Note,
-			Logo:            createdSubscription.Logo,
-			Price:           createdSubscription.Price,
-			CategoryID:      createdSubscription.Category.ID,
-			CycleID:         createdSubscription.Cycle.ID,
-			CurrencyCode:    createdSubscription.Currency.Code,
-			NextPaymentDate: time.Time(createdSubscription.NextPaymentDate).Format(PaymentDateLayout),
+		createdSubscription, err := ho.SubscriptionService.CreateSubscription(ctx, subscription)
+		if err != nil {
+			return err
 		}
+
+		return newSubscriptionAlertsResp(ctx, ho, nil, createdSubscription)
 	}
 }
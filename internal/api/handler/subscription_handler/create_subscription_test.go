@@ -39,11 +39,12 @@ func TestCreateSubscription(t *testing.T) {
 		CycleID         uint    `json:"cycle_id"`
 		CurrencyCode    string  `json:"currency"`
 		NextPaymentDate string  `json:"next_payment_date"`
+		Status          string  `json:"status"`
 	}
 
 	opts := &subscription_handler.HandlerOpts{
 		SubscriptionService: service.NewSubscriptionService(memory.NewSubscriptionRepository()),
-		CategoryService:     service.NewCategoryService(memory.NewCategoryRepository()),
+		CategoryService:     service.NewCategoryService(memory.NewCategoryRepository(), memory.NewSubscriptionRepository()),
 		CycleService:        service.NewCycleService(memory.NewCycleRepository()),
 		CurrencyService:     service.NewCurrencyService(memory.NewCurrencyRepository()),
 	}
@@ -86,6 +87,7 @@ func TestCreateSubscription(t *testing.T) {
 				CycleID:         entity.Weekly.ID,
 				CurrencyCode:    entity.RUB.Code,
 				NextPaymentDate: "2022-01-01",
+				Status:          "active",
 			},
 			wantErr: nil,
 		},
@@ -111,6 +113,7 @@ func TestCreateSubscription(t *testing.T) {
 				CycleID:         entity.Monthly.ID,
 				CurrencyCode:    entity.USD.Code,
 				NextPaymentDate: "2024-05-21",
+				Status:          "active",
 			},
 			wantErr: nil,
 		},
@@ -0,0 +1,29 @@
+package subscription_handler
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+
+	"git.home/alex/go-subscriptions/internal/api/api_response"
+	"git.home/alex/go-subscriptions/internal/auth"
+	"github.com/julienschmidt/httprouter"
+)
+
+func DeleteSubscription(ctx context.Context, ho *HandlerOpts) api_response.Handle {
+	return func(r *http.Request, ps httprouter.Params) any {
+		ctx := auth.RequestContext(ctx, r)
+
+		id, err := strconv.Atoi(ps.ByName("id"))
+		if err != nil {
+			return err
+		}
+
+		err = ho.SubscriptionService.DeleteSubscription(ctx, uint(id))
+		if err != nil {
+			return err
+		}
+
+		return nil
+	}
+}
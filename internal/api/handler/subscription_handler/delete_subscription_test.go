@@ -0,0 +1,47 @@
+package subscription_handler_test
+
+import (
+	"context"
+	"testing"
+
+	"git.home/alex/go-subscriptions/internal/api/handler/subscription_handler"
+	"git.home/alex/go-subscriptions/internal/domain/repository"
+	"github.com/julienschmidt/httprouter"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDeleteSubscription(t *testing.T) {
+	testCases := []struct {
+		name    string
+		id      string
+		wantErr error
+	}{
+		{
+			name: "Test Delete Subscription",
+			id:   "1",
+		},
+		{
+			name:    "Test already deleted error",
+			id:      "1",
+			wantErr: repository.ErrDeleteSubscription,
+		},
+	}
+
+	opts := newHandlerOpts(t)
+	ctx := context.Background()
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ps := httprouter.Params{{Key: "id", Value: tc.id}}
+
+			response := subscription_handler.DeleteSubscription(ctx, opts)(nil, ps)
+
+			if tc.wantErr != nil {
+				assert.ErrorIs(t, tc.wantErr, response.(error))
+				return
+			}
+
+			assert.Nil(t, response)
+		})
+	}
+}
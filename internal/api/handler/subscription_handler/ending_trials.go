@@ -0,0 +1,54 @@
+package subscription_handler
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"git.home/alex/go-subscriptions/internal/api/api_response"
+	"git.home/alex/go-subscriptions/internal/auth"
+	"git.home/alex/go-subscriptions/internal/clock"
+	"github.com/julienschmidt/httprouter"
+)
+
+const defaultTrialAlertDays = 7
+
+// GetEndingTrials lists the trials ending within ?days days, by default the configured alert window,
+// so they can be cancelled before they convert to paid subscriptions.
+func GetEndingTrials(ctx context.Context, ho *HandlerOpts) api_response.Handle {
+	return func(r *http.Request, _ httprouter.Params) any {
+		ctx := auth.RequestContext(ctx, r)
+
+		fallback := defaultTrialAlertDays
+		if ho.TrialAlertDays > 0 {
+			fallback = int(ho.TrialAlertDays)
+		}
+
+		days, err := windowDays(r, fallback)
+		if err != nil {
+			return err
+		}
+
+		from := clock.Today(ctx)
+
+		trials, err := ho.SubscriptionService.EndingTrials(ctx, from, from.AddDate(0, 0, days))
+		if err != nil {
+			return err
+		}
+
+		type resp struct {
+			subscriptionResp
+			DaysLeft int `json:"days_left"`
+		}
+
+		trialDTOs := make([]resp, len(trials))
+		for i := range trials {
+			trialDTOs[i] = resp{
+				subscriptionResp: newSubscriptionResp(&trials[i], from),
+				DaysLeft:         int(time.Time(trials[i].TrialEndDate).Sub(from).Hours() / 24),
+			}
+		}
+
+		return trialDTOs
+	}
+}
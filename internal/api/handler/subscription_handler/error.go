@@ -4,4 +4,5 @@ import "errors"
 
 var (
 	ErrInvalidPaymentDate = errors.New("the payment date is not valid")
+	ErrInvalidWindow      = errors.New("the number of days is not valid")
 )
@@ -0,0 +1,30 @@
+package subscription_handler
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+
+	"git.home/alex/go-subscriptions/internal/api/api_response"
+	"git.home/alex/go-subscriptions/internal/auth"
+	"git.home/alex/go-subscriptions/internal/clock"
+	"github.com/julienschmidt/httprouter"
+)
+
+func GetSubscription(ctx context.Context, ho *HandlerOpts) api_response.Handle {
+	return func(r *http.Request, ps httprouter.Params) any {
+		ctx := auth.RequestContext(ctx, r)
+
+		id, err := strconv.Atoi(ps.ByName("id"))
+		if err != nil {
+			return err
+		}
+
+		subscription, err := ho.SubscriptionService.GetSubscription(ctx, uint(id))
+		if err != nil {
+			return err
+		}
+
+		return newSubscriptionResp(subscription, clock.Today(ctx))
+	}
+}
@@ -0,0 +1,112 @@
+package subscription_handler_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"git.home/alex/go-subscriptions/internal/api/handler/subscription_handler"
+	"git.home/alex/go-subscriptions/internal/domain/entity"
+	"git.home/alex/go-subscriptions/internal/domain/repository"
+	"git.home/alex/go-subscriptions/internal/domain/service"
+	"git.home/alex/go-subscriptions/internal/repository/memory"
+	"git.home/alex/go-subscriptions/tests/tests_assert"
+	"github.com/julienschmidt/httprouter"
+	"github.com/stretchr/testify/assert"
+)
+
+type subscriptionResp struct {
+	ID              uint    `json:"id"`
+	Name            string  `json:"name"`
+	Note            string  `json:"note"`
+	Logo            string  `json:"logo"`
+	Price           float64 `json:"price"`
+	CategoryID      uint    `json:"category_id"`
+	CycleID         uint    `json:"cycle_id"`
+	CurrencyCode    string  `json:"currency"`
+	NextPaymentDate string  `json:"next_payment_date"`
+	Status          string  `json:"status"`
+	TrialStartDate  string  `json:"trial_start_date,omitempty"`
+	TrialEndDate    string  `json:"trial_end_date,omitempty"`
+	ResumeDate      string  `json:"resume_date,omitempty"`
+	CancelDate      string  `json:"cancel_date,omitempty"`
+}
+
+func newHandlerOpts(t *testing.T) *subscription_handler.HandlerOpts {
+	t.Helper()
+
+	opts := &subscription_handler.HandlerOpts{
+		SubscriptionService: service.NewSubscriptionService(memory.NewSubscriptionRepository()),
+		CategoryService:     service.NewCategoryService(memory.NewCategoryRepository(), memory.NewSubscriptionRepository()),
+		CycleService:        service.NewCycleService(memory.NewCycleRepository()),
+		CurrencyService:     service.NewCurrencyService(memory.NewCurrencyRepository()),
+	}
+	ctx := context.Background()
+
+	category, _ := opts.CategoryService.CreateCategory(ctx, entity.Category{Name: "Test Category"})
+	_, _ = opts.CategoryService.CreateCategory(ctx, entity.Category{Name: "Test Category 2"})
+	_, _ = opts.CycleService.CreateCycle(ctx, entity.Weekly)
+	cycle, _ := opts.CycleService.CreateCycle(ctx, entity.Monthly)
+	currency, _ := opts.CurrencyService.CreateCurrency(ctx, entity.USD)
+	_, _ = opts.CurrencyService.CreateCurrency(ctx, entity.RUB)
+
+	_, _ = opts.SubscriptionService.CreateSubscription(ctx, entity.Subscription{
+		Name:            "Test Subscription",
+		Note:            "Test Note",
+		Price:           10,
+		Category:        *category,
+		Cycle:           *cycle,
+		Currency:        *currency,
+		NextPaymentDate: entity.PaymentDate(time.Date(2024, 5, 21, 0, 0, 0, 0, time.UTC)),
+	})
+
+	return opts
+}
+
+func TestGetSubscription(t *testing.T) {
+	testCases := []struct {
+		name     string
+		id       string
+		expected subscriptionResp
+		wantErr  error
+	}{
+		{
+			name: "Test Get Subscription",
+			id:   "1",
+			expected: subscriptionResp{
+				ID:              1,
+				Name:            "Test Subscription",
+				Note:            "Test Note",
+				Price:           10,
+				CategoryID:      1,
+				CycleID:         entity.Monthly.ID,
+				CurrencyCode:    entity.USD.Code,
+				NextPaymentDate: "2024-05-21",
+				Status:          "active",
+			},
+		},
+		{
+			name:    "Test not found error",
+			id:      "10",
+			wantErr: repository.ErrNotFoundSubscription,
+		},
+	}
+
+	opts := newHandlerOpts(t)
+	ctx := context.Background()
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ps := httprouter.Params{{Key: "id", Value: tc.id}}
+
+			response := subscription_handler.GetSubscription(ctx, opts)(nil, ps)
+
+			if tc.wantErr != nil {
+				assert.ErrorIs(t, tc.wantErr, response.(error))
+				return
+			}
+
+			tests_assert.EqualAsJSON(t, tc.expected, response)
+		})
+	}
+}
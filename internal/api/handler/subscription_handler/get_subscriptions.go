@@ -0,0 +1,60 @@
+package subscription_handler
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+
+	"git.home/alex/go-subscriptions/internal/api/api_response"
+	"git.home/alex/go-subscriptions/internal/auth"
+	"git.home/alex/go-subscriptions/internal/clock"
+	"git.home/alex/go-subscriptions/internal/domain/service"
+	"github.com/julienschmidt/httprouter"
+)
+
+// GetSubscriptions lists the subscriptions, only those carrying every ?tag=ID when tags are given.
+func GetSubscriptions(ctx context.Context, ho *HandlerOpts) api_response.Handle {
+	return func(r *http.Request, _ httprouter.Params) any {
+		ctx := auth.RequestContext(ctx, r)
+
+		tagIDs, err := tagFilter(r)
+		if err != nil {
+			return err
+		}
+
+		subscriptions, err := ho.SubscriptionService.GetAllSubscriptions(ctx)
+		if err != nil {
+			return err
+		}
+
+		today := clock.Today(ctx)
+		subscriptionDTOs := make([]subscriptionResp, 0, len(subscriptions))
+		for i := range subscriptions {
+			if subscriptions[i].HasTags(tagIDs...) {
+				subscriptionDTOs = append(subscriptionDTOs, newSubscriptionResp(&subscriptions[i], today))
+			}
+		}
+
+		return subscriptionDTOs
+	}
+}
+
+func tagFilter(r *http.Request) ([]uint, error) {
+	if r == nil {
+		return nil, nil
+	}
+
+	values := r.URL.Query()["tag"]
+	tagIDs := make([]uint, len(values))
+
+	for i, value := range values {
+		id, err := strconv.ParseUint(value, 10, 0)
+		if err != nil {
+			return nil, service.ErrInvalidTag
+		}
+
+		tagIDs[i] = uint(id)
+	}
+
+	return tagIDs, nil
+}
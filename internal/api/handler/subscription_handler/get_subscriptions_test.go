@@ -0,0 +1,68 @@
+package subscription_handler_test
+
+import (
+	"context"
+	"testing"
+
+	"git.home/alex/go-subscriptions/internal/api/handler/subscription_handler"
+	"git.home/alex/go-subscriptions/internal/domain/entity"
+	"git.home/alex/go-subscriptions/internal/domain/repository"
+	"git.home/alex/go-subscriptions/internal/domain/service"
+	"git.home/alex/go-subscriptions/tests"
+	"git.home/alex/go-subscriptions/tests/mock_repository"
+	"git.home/alex/go-subscriptions/tests/tests_assert"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetSubscriptions(t *testing.T) {
+	testCases := []struct {
+		name          string
+		subscriptions repository.Subscriptions
+		mockError     error
+		expected      []subscriptionResp
+		wantErr       error
+	}{
+		{
+			name: "Success",
+			subscriptions: repository.Subscriptions{
+				{ID: 1, Name: "Subscription 1", Price: 10, Cycle: entity.Monthly, Currency: entity.USD},
+				{ID: 2, Name: "Subscription 2", Price: 20, Cycle: entity.Yearly, Currency: entity.RUB},
+			},
+			expected: []subscriptionResp{
+				{
+					ID: 1, Name: "Subscription 1", Price: 10, CycleID: entity.Monthly.ID, CurrencyCode: "USD",
+					NextPaymentDate: "0001-01-01", Status: "active",
+				},
+				{
+					ID: 2, Name: "Subscription 2", Price: 20, CycleID: entity.Yearly.ID, CurrencyCode: "RUB",
+					NextPaymentDate: "0001-01-01", Status: "active",
+				},
+			},
+		},
+		{
+			name:      "Error",
+			mockError: tests.ErrTest,
+			wantErr:   tests.ErrTest,
+		},
+	}
+
+	ctx := context.Background()
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			mockRepo := new(mock_repository.MockSubscriptionRepository)
+			mockRepo.On("GetAll", ctx).Return(tc.subscriptions, tc.mockError)
+
+			opts := &subscription_handler.HandlerOpts{SubscriptionService: service.NewSubscriptionService(mockRepo)}
+
+			response := subscription_handler.GetSubscriptions(ctx, opts)(nil, nil)
+
+			if tc.wantErr != nil {
+				assert.ErrorIs(t, tc.wantErr, response.(error))
+				return
+			}
+
+			tests_assert.EqualAsJSON(t, tc.expected, response)
+		})
+	}
+}
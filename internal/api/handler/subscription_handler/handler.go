@@ -7,4 +7,11 @@ type HandlerOpts struct {
 	CategoryService     *service.CategoryService
 	CycleService        *service.CycleService
 	CurrencyService     *service.CurrencyService
+	// BudgetService is optional; without it creates and updates report no budget alerts.
+	BudgetService     *service.BudgetService
+	AttachmentService *service.AttachmentService
+	// TrialAlertDays is how many days ahead ending trials are reported by default.
+	TrialAlertDays uint
+	// ContractAlertDays is how many days ahead contract deadlines are reported by default.
+	ContractAlertDays uint
 }
@@ -0,0 +1,118 @@
+package subscription_handler
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+	"time"
+
+	"git.home/alex/go-subscriptions/internal/api/api_response"
+	"git.home/alex/go-subscriptions/internal/auth"
+	"git.home/alex/go-subscriptions/internal/clock"
+	"github.com/julienschmidt/httprouter"
+)
+
+const defaultPriceIncreaseDays = 30
+
+type priceResp struct {
+	Price         float64 `json:"price"`
+	EffectiveDate string  `json:"effective_date,omitempty"`
+}
+
+func GetPrices(ctx context.Context, ho *HandlerOpts) api_response.Handle {
+	return func(r *http.Request, ps httprouter.Params) any {
+		ctx := auth.RequestContext(ctx, r)
+
+		id, err := strconv.Atoi(ps.ByName("id"))
+		if err != nil {
+			return err
+		}
+
+		prices, err := ho.SubscriptionService.PriceHistory(ctx, uint(id))
+		if err != nil {
+			return err
+		}
+
+		priceDTOs := make([]priceResp, len(prices))
+		for i, p := range prices {
+			priceDTOs[i] = priceResp{Price: p.Price, EffectiveDate: formatOptionalDate(p.EffectiveDate)}
+		}
+
+		return priceDTOs
+	}
+}
+
+// ChangePrice records a new price; effective_date defaults to today and may lie in the future.
+func ChangePrice(ctx context.Context, ho *HandlerOpts) api_response.Handle {
+	return func(r *http.Request, ps httprouter.Params) any {
+		ctx := auth.RequestContext(ctx, r)
+
+		id, err := strconv.Atoi(ps.ByName("id"))
+		if err != nil {
+			return err
+		}
+
+		var req struct {
+			Price         float64      `json:"price"`
+			EffectiveDate *PaymentDate `json:"effective_date"`
+		}
+
+		err = json.NewDecoder(r.Body).Decode(&req)
+		if err != nil {
+			var parseErr *time.ParseError
+			if errors.As(err, &parseErr) {
+				return ErrInvalidPaymentDate
+			}
+
+			return err
+		}
+
+		subscription, err := ho.SubscriptionService.ChangePrice(ctx, uint(id), req.Price, optionalDate(req.EffectiveDate))
+
+		return statusResp(ctx, subscription, err)
+	}
+}
+
+// GetPriceIncreases lists the price rises of the last ?days days, the latest first.
+func GetPriceIncreases(ctx context.Context, ho *HandlerOpts) api_response.Handle {
+	return func(r *http.Request, _ httprouter.Params) any {
+		ctx := auth.RequestContext(ctx, r)
+
+		days, err := windowDays(r, defaultPriceIncreaseDays)
+		if err != nil {
+			return err
+		}
+
+		to := clock.Today(ctx)
+
+		increases, err := ho.SubscriptionService.PriceIncreases(ctx, to.AddDate(0, 0, -days), to)
+		if err != nil {
+			return err
+		}
+
+		type resp struct {
+			SubscriptionID uint    `json:"subscription_id"`
+			Name           string  `json:"name"`
+			CurrencyCode   string  `json:"currency"`
+			OldPrice       float64 `json:"old_price"`
+			NewPrice       float64 `json:"new_price"`
+			EffectiveDate  string  `json:"effective_date"`
+		}
+
+		increaseDTOs := make([]resp, len(increases))
+		for i, increase := range increases {
+			increaseDTOs[i] = resp{
+				SubscriptionID: increase.Subscription.ID,
+				Name:           increase.Subscription.Name,
+				CurrencyCode:   increase.Subscription.Currency.Code,
+				OldPrice:       increase.OldPrice,
+				NewPrice:       increase.NewPrice,
+				EffectiveDate:  increase.EffectiveDate.Format(PaymentDateLayout),
+			}
+		}
+
+		return increaseDTOs
+	}
+}
@@ -0,0 +1,69 @@
+package subscription_handler_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"git.home/alex/go-subscriptions/internal/api/handler/subscription_handler"
+	"git.home/alex/go-subscriptions/internal/domain/service"
+	"github.com/julienschmidt/httprouter"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPrices(t *testing.T) {
+	opts := newHandlerOpts(t)
+	ctx := context.Background()
+	ps := httprouter.Params{{Key: "id", Value: "1"}}
+	raised := time.Now().UTC().AddDate(0, 0, -3).Format(subscription_handler.PaymentDateLayout)
+
+	r := httptest.NewRequest(http.MethodPost, "/", strings.NewReader(`{"price":0}`))
+	response := subscription_handler.ChangePrice(ctx, opts)(r, ps)
+	assert.ErrorIs(t, response.(error), service.ErrInvalidPrice)
+
+	r = httptest.NewRequest(http.MethodPost, "/", strings.NewReader(`{"price":12,"effective_date":"`+raised+`"}`))
+	response = subscription_handler.ChangePrice(ctx, opts)(r, ps)
+	data, err := json.Marshal(response)
+	require.NoError(t, err)
+	assert.Contains(t, string(data), `"price":12`)
+
+	response = subscription_handler.GetPrices(ctx, opts)(httptest.NewRequest(http.MethodGet, "/", nil), ps)
+	data, err = json.Marshal(response)
+	require.NoError(t, err)
+	assert.JSONEq(t, `[{"price":10},{"price":12,"effective_date":"`+raised+`"}]`, string(data))
+
+	testCases := []struct {
+		name      string
+		query     string
+		wantCount int
+	}{
+		{name: "Default window", wantCount: 1},
+		{name: "Two days", query: "?days=2", wantCount: 0},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			r := httptest.NewRequest(http.MethodGet, "/api/prices/increased"+tc.query, nil)
+			response := subscription_handler.GetPriceIncreases(ctx, opts)(r, nil)
+
+			var increases []struct {
+				OldPrice float64 `json:"old_price"`
+				NewPrice float64 `json:"new_price"`
+			}
+			data, err := json.Marshal(response)
+			require.NoError(t, err)
+			require.NoError(t, json.Unmarshal(data, &increases))
+
+			require.Len(t, increases, tc.wantCount)
+			for _, increase := range increases {
+				assert.InDelta(t, 10, increase.OldPrice, 0.001)
+				assert.InDelta(t, 12, increase.NewPrice, 0.001)
+			}
+		})
+	}
+}
@@ -0,0 +1,61 @@
+package subscription_handler
+
+import (
+	"time"
+
+	"git.home/alex/go-subscriptions/internal/domain/entity"
+)
+
+type subscriptionResp struct {
+	ID              uint          `json:"id"`
+	Name            string        `json:"name"`
+	Note            string        `json:"note"`
+	Logo            string        `json:"logo"`
+	Price           float64       `json:"price"`
+	CategoryID      uint          `json:"category_id"`
+	CycleID         uint          `json:"cycle_id"`
+	CurrencyCode    string        `json:"currency"`
+	NextPaymentDate string        `json:"next_payment_date"`
+	Status          string        `json:"status"`
+	TrialStartDate  string        `json:"trial_start_date,omitempty"`
+	TrialEndDate    string        `json:"trial_end_date,omitempty"`
+	ResumeDate      string        `json:"resume_date,omitempty"`
+	CancelDate      string        `json:"cancel_date,omitempty"`
+	TagIDs          []uint        `json:"tag_ids,omitempty"`
+	PaymentMethodID uint          `json:"payment_method_id,omitempty"`
+	LogoAssetID     uint          `json:"logo_asset_id,omitempty"`
+	Contract        *contractResp `json:"contract,omitempty"`
+}
+
+// newSubscriptionResp reports the state in effect today, e.g. a subscription with a scheduled
+// cancellation is active and carries the cancel date.
+func newSubscriptionResp(subscription *entity.Subscription, today time.Time) subscriptionResp {
+	return subscriptionResp{
+		ID:              subscription.ID,
+		Name:            subscription.Name,
+		Note:            subscription.Note,
+		Logo:            subscription.Logo,
+		Price:           subscription.PriceAt(today),
+		CategoryID:      subscription.Category.ID,
+		CycleID:         subscription.Cycle.ID,
+		CurrencyCode:    subscription.Currency.Code,
+		NextPaymentDate: time.Time(subscription.NextPaymentDate).Format(PaymentDateLayout),
+		Status:          string(subscription.StatusAt(today)),
+		TrialStartDate:  formatOptionalDate(subscription.TrialStartDate),
+		TrialEndDate:    formatOptionalDate(subscription.TrialEndDate),
+		ResumeDate:      formatOptionalDate(subscription.ResumeDate),
+		CancelDate:      formatOptionalDate(subscription.CancelDate),
+		TagIDs:          subscription.TagIDs,
+		PaymentMethodID: subscription.PaymentMethodID,
+		LogoAssetID:     subscription.LogoAssetID,
+		Contract:        newContractResp(subscription.Contract, today),
+	}
+}
+
+func formatOptionalDate(date entity.PaymentDate) string {
+	if time.Time(date).IsZero() {
+		return ""
+	}
+
+	return time.Time(date).Format(PaymentDateLayout)
+}
@@ -0,0 +1,107 @@
+package subscription_handler
+
+import (
+	"context"
+	"net/http"
+	"sort"
+	"strconv"
+	"time"
+
+	"git.home/alex/go-subscriptions/internal/api/api_response"
+	"git.home/alex/go-subscriptions/internal/auth"
+	"git.home/alex/go-subscriptions/internal/clock"
+	"github.com/julienschmidt/httprouter"
+)
+
+const (
+	defaultUpcomingDays = 30
+	maxWindowDays       = 366
+
+	eventPayment          = "payment"
+	eventContractDeadline = "contract_deadline"
+)
+
+// GetUpcomingPayments projects the charges of the next ?days days, today included, along with the
+// contract deadlines of the same days as events of their own type.
+func GetUpcomingPayments(ctx context.Context, ho *HandlerOpts) api_response.Handle {
+	return func(r *http.Request, _ httprouter.Params) any {
+		ctx := auth.RequestContext(ctx, r)
+
+		days, err := windowDays(r, defaultUpcomingDays)
+		if err != nil {
+			return err
+		}
+
+		from := clock.Today(ctx)
+
+		to := from.AddDate(0, 0, days)
+
+		payments, err := ho.SubscriptionService.UpcomingPayments(ctx, from, to)
+		if err != nil {
+			return err
+		}
+
+		deadlines, err := ho.SubscriptionService.ContractDeadlines(ctx, from, to)
+		if err != nil {
+			return err
+		}
+
+		type resp struct {
+			Type            string  `json:"type"`
+			SubscriptionID  uint    `json:"subscription_id"`
+			Name            string  `json:"name"`
+			Date            string  `json:"date"`
+			Amount          float64 `json:"amount"`
+			CurrencyCode    string  `json:"currency"`
+			FirstAfterTrial bool    `json:"first_after_trial"`
+			Kind            string  `json:"kind,omitempty"`
+
+			date time.Time
+		}
+
+		eventDTOs := make([]resp, 0, len(payments)+len(deadlines))
+		for _, p := range payments {
+			eventDTOs = append(eventDTOs, resp{
+				Type:            eventPayment,
+				SubscriptionID:  p.Subscription.ID,
+				Name:            p.Subscription.Name,
+				Date:            p.Date.Format(PaymentDateLayout),
+				Amount:          p.Amount,
+				CurrencyCode:    p.Subscription.Currency.Code,
+				FirstAfterTrial: p.FirstAfterTrial,
+				date:            p.Date,
+			})
+		}
+
+		for _, d := range deadlines {
+			eventDTOs = append(eventDTOs, resp{
+				Type:           eventContractDeadline,
+				SubscriptionID: d.Subscription.ID,
+				Name:           d.Subscription.Name,
+				Date:           d.Date.Format(PaymentDateLayout),
+				CurrencyCode:   d.Subscription.Currency.Code,
+				Kind:           string(d.Kind),
+				date:           d.Date,
+			})
+		}
+
+		sort.SliceStable(eventDTOs, func(i, j int) bool { return eventDTOs[i].date.Before(eventDTOs[j].date) })
+
+		return eventDTOs
+	}
+}
+
+// windowDays reads the ?days query parameter.
+func windowDays(r *http.Request, fallback int) (int, error) {
+	value := r.URL.Query().Get("days")
+	if value == "" {
+		return fallback, nil
+	}
+
+	days, err := strconv.Atoi(value)
+	if err != nil || days < 0 || days > maxWindowDays {
+		return 0, ErrInvalidWindow
+	}
+
+	return days, nil
+}
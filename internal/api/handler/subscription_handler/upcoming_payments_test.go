@@ -0,0 +1,165 @@
+package subscription_handler_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"git.home/alex/go-subscriptions/internal/api/handler/subscription_handler"
+	"git.home/alex/go-subscriptions/internal/domain/entity"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newScheduleOpts(t *testing.T) *subscription_handler.HandlerOpts {
+	t.Helper()
+
+	opts := newHandlerOpts(t)
+	ctx := context.Background()
+	today := time.Now().UTC().Truncate(24 * time.Hour)
+
+	subscription, err := opts.SubscriptionService.GetSubscription(ctx, 1)
+	require.NoError(t, err)
+
+	subscription.NextPaymentDate = entity.PaymentDate(today.AddDate(0, 0, 5))
+	_, err = opts.SubscriptionService.UpdateSubscription(ctx, *subscription)
+	require.NoError(t, err)
+
+	trial := *subscription
+	trial.Name = "Trial"
+	trial.Status = entity.StatusTrial
+	trial.TrialEndDate = entity.PaymentDate(today.AddDate(0, 0, 3))
+	_, err = opts.SubscriptionService.CreateSubscription(ctx, trial)
+	require.NoError(t, err)
+
+	return opts
+}
+
+func TestGetUpcomingPayments(t *testing.T) {
+	testCases := []struct {
+		name      string
+		query     string
+		wantCount int
+		wantErr   error
+	}{
+		{name: "Default window", query: "", wantCount: 2},
+		{name: "Four days", query: "?days=4", wantCount: 1},
+		{name: "Invalid window", query: "?days=many", wantErr: subscription_handler.ErrInvalidWindow},
+		{name: "Window too long", query: "?days=1000", wantErr: subscription_handler.ErrInvalidWindow},
+	}
+
+	opts := newScheduleOpts(t)
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			r := httptest.NewRequest(http.MethodGet, "/api/payments/upcoming"+tc.query, nil)
+			response := subscription_handler.GetUpcomingPayments(context.Background(), opts)(r, nil)
+
+			if tc.wantErr != nil {
+				assert.ErrorIs(t, response.(error), tc.wantErr)
+				return
+			}
+
+			var payments []struct {
+				Name            string `json:"name"`
+				FirstAfterTrial bool   `json:"first_after_trial"`
+			}
+			data, err := json.Marshal(response)
+			require.NoError(t, err)
+			require.NoError(t, json.Unmarshal(data, &payments))
+
+			require.Len(t, payments, tc.wantCount)
+			assert.Equal(t, "Trial", payments[0].Name)
+			assert.True(t, payments[0].FirstAfterTrial)
+		})
+	}
+}
+
+func TestGetUpcomingPayments_ContractDeadlines(t *testing.T) {
+	ctx := context.Background()
+	today := time.Now().UTC().Truncate(24 * time.Hour)
+	opts := newScheduleOpts(t)
+
+	_, err := opts.SubscriptionService.SetContract(ctx, 1, &entity.Contract{
+		StartDate:  entity.PaymentDate(today.AddDate(0, 0, -320)),
+		EndDate:    entity.PaymentDate(today.AddDate(0, 0, 40)),
+		NoticeDays: 30,
+		AutoRenew:  true,
+	})
+	require.NoError(t, err)
+
+	r := httptest.NewRequest(http.MethodGet, "/api/payments/upcoming", nil)
+	response := subscription_handler.GetUpcomingPayments(ctx, opts)(r, nil)
+
+	var events []struct {
+		Type           string `json:"type"`
+		SubscriptionID uint   `json:"subscription_id"`
+		Date           string `json:"date"`
+		Kind           string `json:"kind"`
+	}
+	data, err := json.Marshal(response)
+	require.NoError(t, err)
+	require.NoError(t, json.Unmarshal(data, &events))
+
+	var deadlines, payments int
+
+	for i, event := range events {
+		if i > 0 {
+			assert.LessOrEqual(t, events[i-1].Date, event.Date, "events are ordered by date")
+		}
+
+		switch event.Type {
+		case "payment":
+			payments++
+		case "contract_deadline":
+			deadlines++
+			assert.Equal(t, uint(1), event.SubscriptionID)
+			assert.Equal(t, "cancel_by", event.Kind)
+			assert.Equal(t, today.AddDate(0, 0, 10).Format(subscription_handler.PaymentDateLayout), event.Date)
+		}
+	}
+
+	assert.Equal(t, 1, deadlines)
+	assert.Equal(t, 2, payments)
+}
+
+func TestGetEndingTrials(t *testing.T) {
+	testCases := []struct {
+		name      string
+		alertDays uint
+		query     string
+		wantCount int
+	}{
+		{name: "Default alert window", wantCount: 1},
+		{name: "Configured alert window", alertDays: 2, wantCount: 0},
+		{name: "Query overrides the configuration", alertDays: 2, query: "?days=3", wantCount: 1},
+	}
+
+	opts := newScheduleOpts(t)
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			opts.TrialAlertDays = tc.alertDays
+
+			r := httptest.NewRequest(http.MethodGet, "/api/trials/ending"+tc.query, nil)
+			response := subscription_handler.GetEndingTrials(context.Background(), opts)(r, nil)
+
+			var trials []struct {
+				Status   string `json:"status"`
+				DaysLeft int    `json:"days_left"`
+			}
+			data, err := json.Marshal(response)
+			require.NoError(t, err)
+			require.NoError(t, json.Unmarshal(data, &trials))
+
+			require.Len(t, trials, tc.wantCount)
+			for _, trial := range trials {
+				assert.Equal(t, "trial", trial.Status)
+				assert.Equal(t, 3, trial.DaysLeft)
+			}
+		})
+	}
+}
@@ -0,0 +1,83 @@
+package subscription_handler
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"time"
+
+	"git.home/alex/go-subscriptions/internal/api/api_response"
+	"git.home/alex/go-subscriptions/internal/auth"
+	"git.home/alex/go-subscriptions/internal/domain/entity"
+	"github.com/julienschmidt/httprouter"
+)
+
+func UpdateSubscription(ctx context.Context, ho *HandlerOpts) api_response.Handle {
+	return func(r *http.Request, ps httprouter.Params) any {
+		ctx := auth.RequestContext(ctx, r)
+
+		id, err := strconv.Atoi(ps.ByName("id"))
+		if err != nil {
+			return err
+		}
+
+		var req struct {
+			Name            string      `json:"name"`
+			Note            string      `json:"note,omitempty"`
+			Logo            string      `json:"logo,omitempty"`
+			Price           float64     `json:"price"`
+			CategoryID      uint        `json:"category_id"`
+			CycleID         uint        `json:"cycle_id"`
+			CurrencyCode    string      `json:"currency"`
+			NextPaymentDate PaymentDate `json:"next_payment_date"`
+		}
+
+		err = json.NewDecoder(r.Body).Decode(&req)
+		if err != nil {
+			_, ok := err.(*time.ParseError)
+			if ok {
+				return ErrInvalidPaymentDate
+			}
+
+			return err
+		}
+
+		subscription, err := ho.SubscriptionService.GetSubscription(ctx, uint(id))
+		if err != nil {
+			return err
+		}
+
+		category, err := ho.CategoryService.GetCategory(ctx, req.CategoryID)
+		if err != nil {
+			return err
+		}
+
+		cycle, err := ho.CycleService.GetCycle(ctx, req.CycleID)
+		if err != nil {
+			return err
+		}
+
+		currency, err := ho.CurrencyService.GetCurrency(ctx, req.CurrencyCode)
+		if err != nil {
+			return err
+		}
+
+		before := *subscription
+		subscription.Name = req.Name
+		subscription.Note = req.Note
+		subscription.Logo = req.Logo
+		subscription.Price = req.Price
+		subscription.Category = *category
+		subscription.Cycle = *cycle
+		subscription.Currency = *currency
+		subscription.NextPaymentDate = entity.PaymentDate(req.NextPaymentDate)
+
+		updatedSubscription, err := ho.SubscriptionService.UpdateSubscription(ctx, *subscription)
+		if err != nil {
+			return err
+		}
+
+		return newSubscriptionAlertsResp(ctx, ho, &before, updatedSubscription)
+	}
+}
@@ -0,0 +1,117 @@
+package subscription_handler_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"testing"
+
+	"git.home/alex/go-subscriptions/internal/api/handler/subscription_handler"
+	"git.home/alex/go-subscriptions/internal/domain/entity"
+	"git.home/alex/go-subscriptions/internal/domain/repository"
+	"git.home/alex/go-subscriptions/internal/domain/service"
+	"git.home/alex/go-subscriptions/tests/tests_assert"
+	"github.com/julienschmidt/httprouter"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestUpdateSubscription(t *testing.T) {
+	type req struct {
+		Name            string  `json:"name"`
+		Note            string  `json:"note,omitempty"`
+		Price           float64 `json:"price"`
+		CategoryID      uint    `json:"category_id"`
+		CycleID         uint    `json:"cycle_id"`
+		CurrencyCode    string  `json:"currency"`
+		NextPaymentDate string  `json:"next_payment_date"`
+	}
+
+	valid := req{
+		Name:            "Updated Subscription",
+		Note:            "Updated Note",
+		Price:           12.5,
+		CategoryID:      2,
+		CycleID:         entity.Weekly.ID,
+		CurrencyCode:    entity.RUB.Code,
+		NextPaymentDate: "2024-06-01",
+	}
+
+	with := func(modify func(r *req)) req {
+		r := valid
+		modify(&r)
+		return r
+	}
+
+	testCases := []struct {
+		name        string
+		id          string
+		requestBody req
+		expected    subscriptionResp
+		wantErr     error
+	}{
+		{
+			name:        "Test Update Subscription",
+			id:          "1",
+			requestBody: valid,
+			expected: subscriptionResp{
+				ID:              1,
+				Name:            "Updated Subscription",
+				Note:            "Updated Note",
+				Price:           12.5,
+				CategoryID:      2,
+				CycleID:         entity.Weekly.ID,
+				CurrencyCode:    entity.RUB.Code,
+				NextPaymentDate: "2024-06-01",
+				Status:          "active",
+			},
+		},
+		{
+			name:        "Test subscription not found error",
+			id:          "10",
+			requestBody: valid,
+			wantErr:     repository.ErrNotFoundSubscription,
+		},
+		{
+			name:        "Test price is zero error",
+			id:          "1",
+			requestBody: with(func(r *req) { r.Price = 0 }),
+			wantErr:     service.ErrInvalidSubscription,
+		},
+		{
+			name:        "Test cycle not found error",
+			id:          "1",
+			requestBody: with(func(r *req) { r.CycleID = 10 }),
+			wantErr:     repository.ErrNotFoundCycle,
+		},
+		{
+			name:        "Test invalid payment date error",
+			id:          "1",
+			requestBody: with(func(r *req) { r.NextPaymentDate = "tomorrow" }),
+			wantErr:     subscription_handler.ErrInvalidPaymentDate,
+		},
+	}
+
+	opts := newHandlerOpts(t)
+	ctx := context.Background()
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			requestBodyBytes, _ := json.Marshal(tc.requestBody)
+			r := &http.Request{
+				Body: io.NopCloser(bytes.NewBuffer(requestBodyBytes)),
+			}
+			ps := httprouter.Params{{Key: "id", Value: tc.id}}
+
+			response := subscription_handler.UpdateSubscription(ctx, opts)(r, ps)
+
+			if tc.wantErr != nil {
+				assert.ErrorIs(t, tc.wantErr, response.(error))
+				return
+			}
+
+			tests_assert.EqualAsJSON(t, tc.expected, response)
+		})
+	}
+}
@@ -0,0 +1,45 @@
+package tag_handler
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+
+	"git.home/alex/go-subscriptions/internal/api/api_response"
+	"git.home/alex/go-subscriptions/internal/auth"
+	"git.home/alex/go-subscriptions/internal/domain/entity"
+	"git.home/alex/go-subscriptions/internal/domain/service"
+	"github.com/julienschmidt/httprouter"
+)
+
+func CreateTag(ctx context.Context, cs *service.TagService) api_response.Handle {
+	return func(r *http.Request, _ httprouter.Params) any {
+		ctx := auth.RequestContext(ctx, r)
+
+		var req struct {
+			Name string `json:"name"`
+		}
+
+		err := json.NewDecoder(r.Body).Decode(&req)
+		if err != nil {
+			return err
+		}
+
+		createdTag, err := cs.CreateTag(ctx, entity.Tag{
+			Name: req.Name,
+		})
+		if err != nil {
+			return err
+		}
+
+		type resp struct {
+			ID   uint   `json:"id"`
+			Name string `json:"name"`
+		}
+
+		return resp{
+			ID:   createdTag.ID,
+			Name: createdTag.Name,
+		}
+	}
+}
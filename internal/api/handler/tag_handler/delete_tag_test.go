@@ -0,0 +1,55 @@
+package tag_handler_test
+
+import (
+	"context"
+	"testing"
+
+	"git.home/alex/go-subscriptions/internal/api/handler/tag_handler"
+	"git.home/alex/go-subscriptions/internal/domain/entity"
+	"git.home/alex/go-subscriptions/internal/domain/repository"
+	"git.home/alex/go-subscriptions/internal/domain/service"
+	"git.home/alex/go-subscriptions/internal/repository/memory"
+	"github.com/julienschmidt/httprouter"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDeleteTag(t *testing.T) {
+	testCases := []struct {
+		name    string
+		id      string
+		tag     entity.Tag
+		wantErr error
+	}{
+		{
+			name:    "success",
+			id:      "1",
+			tag:     entity.Tag{ID: 1, Name: "Test Tag"},
+			wantErr: nil,
+		},
+		{
+			name:    "error",
+			id:      "10",
+			tag:     entity.Tag{ID: 2, Name: "Test Tag"},
+			wantErr: repository.ErrNotFoundTag,
+		},
+	}
+
+	cs := service.NewTagService(memory.NewTagRepository(), memory.NewSubscriptionRepository())
+	ctx := context.Background()
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			_, _ = cs.CreateTag(ctx, tc.tag)
+			ps := httprouter.Params{{Key: "id", Value: tc.id}}
+
+			response := tag_handler.DeleteTag(ctx, cs)(nil, ps)
+
+			if tc.wantErr != nil {
+				assert.ErrorIs(t, tc.wantErr, response.(error))
+				return
+			}
+
+			assert.Nil(t, response)
+		})
+	}
+}
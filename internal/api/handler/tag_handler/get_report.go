@@ -0,0 +1,41 @@
+package tag_handler
+
+import (
+	"context"
+	"net/http"
+
+	"git.home/alex/go-subscriptions/internal/api/api_response"
+	"git.home/alex/go-subscriptions/internal/auth"
+	"git.home/alex/go-subscriptions/internal/domain/service"
+	"github.com/julienschmidt/httprouter"
+)
+
+func GetReport(ctx context.Context, ts *service.TagService) api_response.Handle {
+	return func(r *http.Request, _ httprouter.Params) any {
+		ctx := auth.RequestContext(ctx, r)
+
+		reports, err := ts.GetReport(ctx)
+		if err != nil {
+			return err
+		}
+
+		type resp struct {
+			TagID         uint               `json:"tag_id"`
+			Name          string             `json:"name"`
+			Monthly       map[string]float64 `json:"monthly"`
+			Subscriptions int                `json:"subscriptions"`
+		}
+
+		reportDTOs := make([]resp, len(reports))
+		for i, report := range reports {
+			reportDTOs[i] = resp{
+				TagID:         report.Tag.ID,
+				Name:          report.Tag.Name,
+				Monthly:       report.Totals,
+				Subscriptions: report.Subscriptions,
+			}
+		}
+
+		return reportDTOs
+	}
+}
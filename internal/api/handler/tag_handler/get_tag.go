@@ -0,0 +1,38 @@
+package tag_handler
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+
+	"git.home/alex/go-subscriptions/internal/api/api_response"
+	"git.home/alex/go-subscriptions/internal/auth"
+	"git.home/alex/go-subscriptions/internal/domain/service"
+	"github.com/julienschmidt/httprouter"
+)
+
+func GetTag(ctx context.Context, cs *service.TagService) api_response.Handle {
+	return func(r *http.Request, ps httprouter.Params) any {
+		ctx := auth.RequestContext(ctx, r)
+
+		id, err := strconv.Atoi(ps.ByName("id"))
+		if err != nil {
+			return err
+		}
+
+		tag, err := cs.GetTag(ctx, uint(id))
+		if err != nil {
+			return err
+		}
+
+		type resp struct {
+			ID   uint   `json:"id"`
+			Name string `json:"name"`
+		}
+
+		return resp{
+			ID:   tag.ID,
+			Name: tag.Name,
+		}
+	}
+}
@@ -0,0 +1,37 @@
+package tag_handler
+
+import (
+	"context"
+	"net/http"
+
+	"git.home/alex/go-subscriptions/internal/api/api_response"
+	"git.home/alex/go-subscriptions/internal/auth"
+	"git.home/alex/go-subscriptions/internal/domain/service"
+	"github.com/julienschmidt/httprouter"
+)
+
+func GetTags(ctx context.Context, cs *service.TagService) api_response.Handle {
+	return func(r *http.Request, _ httprouter.Params) any {
+		ctx := auth.RequestContext(ctx, r)
+
+		tags, err := cs.GetAllTags(ctx)
+		if err != nil {
+			return err
+		}
+
+		type resp struct {
+			ID   uint   `json:"id"`
+			Name string `json:"name"`
+		}
+
+		tagDTOs := make([]resp, len(tags))
+		for i, tag := range tags {
+			tagDTOs[i] = resp{
+				ID:   tag.ID,
+				Name: tag.Name,
+			}
+		}
+
+		return tagDTOs
+	}
+}
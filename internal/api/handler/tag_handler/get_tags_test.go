@@ -0,0 +1,70 @@
+package tag_handler_test
+
+import (
+	"context"
+	"testing"
+
+	"git.home/alex/go-subscriptions/internal/api/handler/tag_handler"
+	"git.home/alex/go-subscriptions/internal/domain/repository"
+	"git.home/alex/go-subscriptions/internal/domain/service"
+	"git.home/alex/go-subscriptions/internal/repository/memory"
+	"git.home/alex/go-subscriptions/tests"
+	"git.home/alex/go-subscriptions/tests/mock_repository"
+	"git.home/alex/go-subscriptions/tests/tests_assert"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetTags(t *testing.T) {
+	type resp struct {
+		ID   uint   `json:"id"`
+		Name string `json:"name"`
+	}
+
+	testCases := []struct {
+		name      string
+		tags      repository.Tags
+		mockError error
+		expected  []resp
+		wantErr   error
+	}{
+		{
+			name: "Success",
+			tags: repository.Tags{
+				{ID: 1, Name: "Tag 1"},
+				{ID: 2, Name: "Tag 2"},
+			},
+			mockError: nil,
+			expected: []resp{
+				{ID: 1, Name: "Tag 1"},
+				{ID: 2, Name: "Tag 2"},
+			},
+		},
+		{
+			name:      "Error",
+			tags:      nil,
+			mockError: tests.ErrTest,
+			expected:  nil,
+			wantErr:   tests.ErrTest,
+		},
+	}
+
+	ctx := context.Background()
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			mockRepo := new(mock_repository.MockTagRepository)
+			mockRepo.On("GetAll", ctx).Return(tc.tags, tc.mockError)
+
+			cs := service.NewTagService(mockRepo, memory.NewSubscriptionRepository())
+
+			response := tag_handler.GetTags(ctx, cs)(nil, nil)
+
+			if tc.wantErr != nil {
+				assert.ErrorIs(t, tc.wantErr, response.(error))
+				return
+			}
+
+			tests_assert.EqualAsJSON(t, tc.expected, response)
+		})
+	}
+}
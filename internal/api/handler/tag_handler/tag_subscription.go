@@ -0,0 +1,56 @@
+package tag_handler
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+
+	"git.home/alex/go-subscriptions/internal/api/api_response"
+	"git.home/alex/go-subscriptions/internal/auth"
+	"git.home/alex/go-subscriptions/internal/domain/entity"
+	"git.home/alex/go-subscriptions/internal/domain/service"
+	"github.com/julienschmidt/httprouter"
+)
+
+type subscriptionTagsResp struct {
+	SubscriptionID uint   `json:"subscription_id"`
+	TagIDs         []uint `json:"tag_ids"`
+}
+
+type tagChange func(ctx context.Context, subscriptionID, tagID uint) (*entity.Subscription, error)
+
+func TagSubscription(ctx context.Context, ts *service.TagService) api_response.Handle {
+	return changeTags(ctx, ts.TagSubscription)
+}
+
+func UntagSubscription(ctx context.Context, ts *service.TagService) api_response.Handle {
+	return changeTags(ctx, ts.UntagSubscription)
+}
+
+func changeTags(ctx context.Context, change tagChange) api_response.Handle {
+	return func(r *http.Request, ps httprouter.Params) any {
+		ctx := auth.RequestContext(ctx, r)
+
+		id, err := strconv.Atoi(ps.ByName("id"))
+		if err != nil {
+			return err
+		}
+
+		tagID, err := strconv.Atoi(ps.ByName("tag_id"))
+		if err != nil {
+			return err
+		}
+
+		subscription, err := change(ctx, uint(id), uint(tagID))
+		if err != nil {
+			return err
+		}
+
+		tagIDs := subscription.TagIDs
+		if tagIDs == nil {
+			tagIDs = []uint{}
+		}
+
+		return subscriptionTagsResp{SubscriptionID: subscription.ID, TagIDs: tagIDs}
+	}
+}
@@ -0,0 +1,53 @@
+package tag_handler
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strconv"
+
+	"git.home/alex/go-subscriptions/internal/api/api_response"
+	"git.home/alex/go-subscriptions/internal/auth"
+	"git.home/alex/go-subscriptions/internal/domain/service"
+	"github.com/julienschmidt/httprouter"
+)
+
+func UpdateTag(ctx context.Context, cs *service.TagService) api_response.Handle {
+	return func(r *http.Request, ps httprouter.Params) any {
+		ctx := auth.RequestContext(ctx, r)
+
+		id, err := strconv.Atoi(ps.ByName("id"))
+		if err != nil {
+			return err
+		}
+
+		var req struct {
+			Name string `json:"name"`
+		}
+		err = json.NewDecoder(r.Body).Decode(&req)
+		if err != nil {
+			return err
+		}
+
+		tag, err := cs.GetTag(ctx, uint(id))
+		if err != nil {
+			return err
+		}
+
+		tag.Name = req.Name
+		updatedTag, err := cs.UpdateTag(ctx, *tag)
+		if err != nil {
+			return err
+		}
+
+		type resp struct {
+			ID   uint   `json:"id"`
+			Name string `json:"name"`
+		}
+
+		return resp{
+			ID:   updatedTag.ID,
+			Name: updatedTag.Name,
+		}
+	}
+}
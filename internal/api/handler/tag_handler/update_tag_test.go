@@ -0,0 +1,92 @@
+package tag_handler_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"testing"
+
+	"git.home/alex/go-subscriptions/internal/api/handler/tag_handler"
+	"git.home/alex/go-subscriptions/internal/domain/entity"
+	"git.home/alex/go-subscriptions/internal/domain/repository"
+	"git.home/alex/go-subscriptions/internal/domain/service"
+	"git.home/alex/go-subscriptions/internal/repository/memory"
+	"git.home/alex/go-subscriptions/tests/tests_assert"
+	"github.com/julienschmidt/httprouter"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestUpdateTag(t *testing.T) {
+	type req struct {
+		Name string `json:"name"`
+	}
+
+	type resp struct {
+		ID   uint   `json:"id"`
+		Name string `json:"name"`
+	}
+
+	testCases := []struct {
+		name        string
+		initialTag  entity.Tag
+		requestBody req
+		id          string
+		expected    resp
+		wantErr     error
+	}{
+		{
+			name:        "success",
+			initialTag:  entity.Tag{Name: "Test Tag"},
+			requestBody: req{Name: "Updated Tag"},
+			id:          "1",
+			expected:    resp{ID: 1, Name: "Updated Tag"},
+		},
+		{
+			name:        "success",
+			initialTag:  entity.Tag{Name: "Test Tag 2"},
+			requestBody: req{Name: "Updated Tag 2"},
+			id:          "2",
+			expected:    resp{ID: 2, Name: "Updated Tag 2"},
+		},
+		{
+			name:        "validation error",
+			initialTag:  entity.Tag{Name: "Test Tag 3"},
+			requestBody: req{Name: ""},
+			id:          "3",
+			wantErr:     service.ErrInvalidTag,
+		},
+		{
+			name:        "not found error",
+			initialTag:  entity.Tag{Name: "Test Tag 4"},
+			requestBody: req{Name: "Updated Tag 2"},
+			id:          "10",
+			wantErr:     repository.ErrNotFoundTag,
+		},
+	}
+
+	cs := service.NewTagService(memory.NewTagRepository(), memory.NewSubscriptionRepository())
+	ctx := context.Background()
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			_, _ = cs.CreateTag(ctx, tc.initialTag)
+
+			requestBodyBytes, _ := json.Marshal(tc.requestBody)
+			r := &http.Request{
+				Body: io.NopCloser(bytes.NewBuffer(requestBodyBytes)),
+			}
+			ps := httprouter.Params{{Key: "id", Value: tc.id}}
+
+			response := tag_handler.UpdateTag(ctx, cs)(r, ps)
+
+			if tc.wantErr != nil {
+				assert.ErrorIs(t, tc.wantErr, response.(error))
+				return
+			}
+
+			tests_assert.EqualAsJSON(t, tc.expected, response)
+		})
+	}
+}
@@ -0,0 +1,7 @@
+package user_handler
+
+import "errors"
+
+var (
+	ErrRegistrationDisabled = errors.New("registration is disabled")
+)
@@ -0,0 +1,23 @@
+package user_handler
+
+import (
+	"context"
+	"net/http"
+
+	"git.home/alex/go-subscriptions/internal/api/api_response"
+	"git.home/alex/go-subscriptions/internal/auth"
+	"github.com/julienschmidt/httprouter"
+)
+
+func GetCurrentUser(ctx context.Context, ho *HandlerOpts) api_response.Handle {
+	return func(r *http.Request, _ httprouter.Params) any {
+		ctx := auth.RequestContext(ctx, r)
+
+		user, err := ho.UserService.GetCurrentUser(ctx)
+		if err != nil {
+			return err
+		}
+
+		return newUserResp(user)
+	}
+}
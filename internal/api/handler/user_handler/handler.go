@@ -0,0 +1,14 @@
+package user_handler
+
+import (
+	"git.home/alex/go-subscriptions/internal/auth"
+	"git.home/alex/go-subscriptions/internal/domain/service"
+)
+
+type HandlerOpts struct {
+	UserService *service.UserService
+	// TokenIssuer signs the tokens returned by Login. Login fails when it is nil.
+	TokenIssuer *auth.TokenIssuer
+	// AllowRegistration opens Register to anonymous clients.
+	AllowRegistration bool
+}
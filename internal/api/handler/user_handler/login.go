@@ -0,0 +1,48 @@
+package user_handler
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"time"
+
+	"git.home/alex/go-subscriptions/internal/api/api_response"
+	"git.home/alex/go-subscriptions/internal/auth"
+	"github.com/julienschmidt/httprouter"
+)
+
+func Login(ctx context.Context, ho *HandlerOpts) api_response.Handle {
+	return func(r *http.Request, _ httprouter.Params) any {
+		var req struct {
+			Username string `json:"username"`
+			Password string `json:"password"`
+		}
+
+		err := json.NewDecoder(r.Body).Decode(&req)
+		if err != nil {
+			return err
+		}
+
+		user, err := ho.UserService.Authenticate(ctx, req.Username, req.Password)
+		if err != nil {
+			return err
+		}
+
+		token, err := ho.TokenIssuer.Issue(user.Username, user.ID, auth.ScopeReadWrite)
+		if err != nil {
+			return err
+		}
+
+		type resp struct {
+			AccessToken string `json:"access_token"`
+			TokenType   string `json:"token_type"`
+			ExpiresAt   string `json:"expires_at"`
+		}
+
+		return resp{
+			AccessToken: token.AccessToken,
+			TokenType:   "Bearer",
+			ExpiresAt:   token.ExpiresAt.UTC().Format(time.RFC3339),
+		}
+	}
+}
@@ -0,0 +1,96 @@
+package user_handler_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"testing"
+	"time"
+
+	"git.home/alex/go-subscriptions/internal/api/handler/user_handler"
+	"git.home/alex/go-subscriptions/internal/auth"
+	"git.home/alex/go-subscriptions/internal/domain/service"
+	"git.home/alex/go-subscriptions/internal/repository/memory"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLogin(t *testing.T) {
+	type req struct {
+		Username string `json:"username"`
+		Password string `json:"password"`
+	}
+
+	secret := []byte("test-secret")
+	ctx := context.Background()
+
+	opts := &user_handler.HandlerOpts{
+		UserService: service.NewUserService(memory.NewUserRepository()),
+		TokenIssuer: auth.NewTokenIssuer(secret, "", "", time.Hour),
+	}
+
+	user, err := opts.UserService.RegisterUser(ctx, "alice", "correct horse")
+	require.NoError(t, err)
+
+	authenticator, err := auth.NewJWTAuthenticator(auth.WithHS256Secret(secret))
+	require.NoError(t, err)
+
+	testCases := []struct {
+		name        string
+		requestBody req
+		issuer      *auth.TokenIssuer
+		wantErr     error
+	}{
+		{
+			name:        "Valid credentials",
+			requestBody: req{Username: "alice", Password: "correct horse"},
+			issuer:      opts.TokenIssuer,
+		},
+		{
+			name:        "Wrong password",
+			requestBody: req{Username: "alice", Password: "wrong password"},
+			issuer:      opts.TokenIssuer,
+			wantErr:     service.ErrInvalidCredentials,
+		},
+		{
+			name:        "Issuer is not configured",
+			requestBody: req{Username: "alice", Password: "correct horse"},
+			issuer:      nil,
+			wantErr:     auth.ErrIssuerNotConfigured,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			requestBodyBytes, _ := json.Marshal(tc.requestBody)
+			r := &http.Request{
+				Body: io.NopCloser(bytes.NewBuffer(requestBodyBytes)),
+			}
+
+			handlerOpts := *opts
+			handlerOpts.TokenIssuer = tc.issuer
+
+			response := user_handler.Login(ctx, &handlerOpts)(r, nil)
+
+			if tc.wantErr != nil {
+				assert.ErrorIs(t, response.(error), tc.wantErr)
+				return
+			}
+
+			var body struct {
+				AccessToken string `json:"access_token"`
+			}
+			data, _ := json.Marshal(response)
+			require.NoError(t, json.Unmarshal(data, &body))
+
+			tokenRequest := &http.Request{Header: http.Header{}}
+			tokenRequest.Header.Set("Authorization", "Bearer "+body.AccessToken)
+
+			principal, err := authenticator.Authenticate(tokenRequest)
+			assert.NoError(t, err)
+			assert.Equal(t, user.ID, principal.UserID)
+		})
+	}
+}
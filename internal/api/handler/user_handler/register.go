@@ -0,0 +1,43 @@
+package user_handler
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+
+	"git.home/alex/go-subscriptions/internal/api/api_response"
+	"github.com/julienschmidt/httprouter"
+)
+
+func Register(ctx context.Context, ho *HandlerOpts) api_response.Handle {
+	return func(r *http.Request, _ httprouter.Params) any {
+		if !ho.AllowRegistration {
+			return ErrRegistrationDisabled
+		}
+
+		var req struct {
+			Username string `json:"username"`
+			Password string `json:"password"`
+		}
+
+		err := json.NewDecoder(r.Body).Decode(&req)
+		if err != nil {
+			return err
+		}
+
+		user, err := ho.UserService.RegisterUser(ctx, req.Username, req.Password)
+		if err != nil {
+			return err
+		}
+
+		type resp struct {
+			ID       uint   `json:"id"`
+			Username string `json:"username"`
+		}
+
+		return resp{
+			ID:       user.ID,
+			Username: user.Username,
+		}
+	}
+}
@@ -0,0 +1,84 @@
+package user_handler_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"testing"
+
+	"git.home/alex/go-subscriptions/internal/api/handler/user_handler"
+	"git.home/alex/go-subscriptions/internal/domain/repository"
+	"git.home/alex/go-subscriptions/internal/domain/service"
+	"git.home/alex/go-subscriptions/internal/repository/memory"
+	"git.home/alex/go-subscriptions/tests/tests_assert"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRegister(t *testing.T) {
+	type req struct {
+		Username string `json:"username"`
+		Password string `json:"password"`
+	}
+
+	type resp struct {
+		ID       uint   `json:"id"`
+		Username string `json:"username"`
+	}
+
+	testCases := []struct {
+		name              string
+		requestBody       req
+		allowRegistration bool
+		expected          resp
+		wantErr           error
+	}{
+		{
+			name:              "Registration disabled",
+			requestBody:       req{Username: "alice", Password: "correct horse"},
+			allowRegistration: false,
+			wantErr:           user_handler.ErrRegistrationDisabled,
+		},
+		{
+			name:              "Register user",
+			requestBody:       req{Username: "alice", Password: "correct horse"},
+			allowRegistration: true,
+			expected:          resp{ID: 1, Username: "alice"},
+		},
+		{
+			name:              "Duplicate user",
+			requestBody:       req{Username: "alice", Password: "correct horse"},
+			allowRegistration: true,
+			wantErr:           repository.ErrAlreadyExistsUser,
+		},
+		{
+			name:              "Weak password",
+			requestBody:       req{Username: "bob", Password: "123"},
+			allowRegistration: true,
+			wantErr:           service.ErrWeakPassword,
+		},
+	}
+
+	userService := service.NewUserService(memory.NewUserRepository())
+	ctx := context.Background()
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			requestBodyBytes, _ := json.Marshal(tc.requestBody)
+			r := &http.Request{
+				Body: io.NopCloser(bytes.NewBuffer(requestBodyBytes)),
+			}
+
+			opts := &user_handler.HandlerOpts{UserService: userService, AllowRegistration: tc.allowRegistration}
+			response := user_handler.Register(ctx, opts)(r, nil)
+
+			if tc.wantErr != nil {
+				assert.ErrorIs(t, tc.wantErr, response.(error))
+				return
+			}
+
+			tests_assert.EqualAsJSON(t, tc.expected, response)
+		})
+	}
+}
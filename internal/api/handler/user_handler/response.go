@@ -0,0 +1,17 @@
+package user_handler
+
+import "git.home/alex/go-subscriptions/internal/domain/entity"
+
+type userResp struct {
+	ID       uint   `json:"id"`
+	Username string `json:"username"`
+	Timezone string `json:"timezone,omitempty"`
+}
+
+func newUserResp(user *entity.User) userResp {
+	return userResp{
+		ID:       user.ID,
+		Username: user.Username,
+		Timezone: user.Timezone,
+	}
+}
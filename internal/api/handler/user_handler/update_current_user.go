@@ -0,0 +1,34 @@
+package user_handler
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+
+	"git.home/alex/go-subscriptions/internal/api/api_response"
+	"git.home/alex/go-subscriptions/internal/auth"
+	"github.com/julienschmidt/httprouter"
+)
+
+// UpdateCurrentUser changes the settings of the current user; an empty timezone resets it to the default.
+func UpdateCurrentUser(ctx context.Context, ho *HandlerOpts) api_response.Handle {
+	return func(r *http.Request, _ httprouter.Params) any {
+		ctx := auth.RequestContext(ctx, r)
+
+		var req struct {
+			Timezone string `json:"timezone"`
+		}
+
+		err := json.NewDecoder(r.Body).Decode(&req)
+		if err != nil {
+			return err
+		}
+
+		user, err := ho.UserService.SetTimezone(ctx, req.Timezone)
+		if err != nil {
+			return err
+		}
+
+		return newUserResp(user)
+	}
+}
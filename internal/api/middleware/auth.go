@@ -0,0 +1,63 @@
+package middleware
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"slices"
+	"strings"
+
+	"git.home/alex/go-subscriptions/internal/api/api_response"
+	"git.home/alex/go-subscriptions/internal/auth"
+)
+
+var (
+	errUnauthorized = errors.New("unauthorized")
+)
+
+const protectedPrefix = "/api/"
+
+type Middleware func(http.Handler) http.Handler
+
+// Auth authenticates every request under /api/ and stores the principal in the request context.
+// Other routes, such as /health, and the given public paths are passed through untouched.
+func Auth(a auth.Authenticator, publicPaths ...string) Middleware {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if !strings.HasPrefix(r.URL.Path, protectedPrefix) || slices.Contains(publicPaths, r.URL.Path) {
+				next.ServeHTTP(w, r)
+
+				return
+			}
+
+			principal, err := a.Authenticate(r)
+			if err != nil {
+				w.Header().Set("WWW-Authenticate", `Bearer realm="api"`)
+				writeError(w, http.StatusUnauthorized, err)
+
+				return
+			}
+
+			if !principal.Allows(r.Method) {
+				writeError(w, http.StatusForbidden, auth.ErrInsufficientScope)
+
+				return
+			}
+
+			next.ServeHTTP(w, r.WithContext(auth.WithPrincipal(r.Context(), principal)))
+		})
+	}
+}
+
+func writeError(w http.ResponseWriter, status int, err error) {
+	if !errors.Is(err, auth.ErrNoCredentials) && !errors.Is(err, auth.ErrInsufficientScope) {
+		// Do not tell the client why exactly the credentials were rejected.
+		err = errUnauthorized
+	}
+
+	response, _ := json.Marshal(api_response.Error(err))
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_, _ = w.Write(response)
+}
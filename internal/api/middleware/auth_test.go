@@ -0,0 +1,126 @@
+package middleware_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"git.home/alex/go-subscriptions/internal/api/middleware"
+	"git.home/alex/go-subscriptions/internal/auth"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAuth(t *testing.T) {
+	testCases := []struct {
+		name           string
+		method         string
+		path           string
+		key            string
+		expectedStatus int
+		expectedBody   string
+	}{
+		{
+			name:           "Health is open",
+			method:         http.MethodGet,
+			path:           "/health",
+			expectedStatus: http.StatusOK,
+			expectedBody:   "anonymous",
+		},
+		{
+			name:           "Public path is open",
+			method:         http.MethodGet,
+			path:           "/api/docs",
+			expectedStatus: http.StatusOK,
+			expectedBody:   "anonymous",
+		},
+		{
+			name:           "Missing credentials",
+			method:         http.MethodGet,
+			path:           "/api/categories",
+			expectedStatus: http.StatusUnauthorized,
+			expectedBody:   `{"status":"error","error":"no credentials provided","data":null}`,
+		},
+		{
+			name:           "Invalid credentials",
+			method:         http.MethodGet,
+			path:           "/api/categories",
+			key:            "wrong",
+			expectedStatus: http.StatusUnauthorized,
+			expectedBody:   `{"status":"error","error":"unauthorized","data":null}`,
+		},
+		{
+			name:           "Read-only key can read",
+			method:         http.MethodGet,
+			path:           "/api/categories",
+			key:            "ro",
+			expectedStatus: http.StatusOK,
+			expectedBody:   "reader",
+		},
+		{
+			name:           "Read-only key cannot write",
+			method:         http.MethodDelete,
+			path:           "/api/category/1",
+			key:            "ro",
+			expectedStatus: http.StatusForbidden,
+			expectedBody:   `{"status":"error","error":"insufficient scope","data":null}`,
+		},
+		{
+			name:           "Read-write key can write",
+			method:         http.MethodDelete,
+			path:           "/api/category/1",
+			key:            "rw",
+			expectedStatus: http.StatusOK,
+			expectedBody:   "writer",
+		},
+		{
+			name:           "Key without a user acts on the data no user owns",
+			method:         http.MethodGet,
+			path:           "/api/categories",
+			key:            "unbound",
+			expectedStatus: http.StatusOK,
+			expectedBody:   "unbound",
+		},
+		{
+			name:           "Admin key",
+			method:         http.MethodGet,
+			path:           "/api/admin/backup",
+			key:            "admin",
+			expectedStatus: http.StatusOK,
+			expectedBody:   "admin",
+		},
+	}
+
+	a := auth.NewAPIKeyAuthenticator(
+		auth.APIKey{Name: "reader", Key: "ro", UserID: 1, Scope: auth.ScopeRead},
+		auth.APIKey{Name: "writer", Key: "rw", UserID: 1, Scope: auth.ScopeReadWrite},
+		auth.APIKey{Name: "unbound", Key: "unbound", Scope: auth.ScopeRead},
+		auth.APIKey{Name: "admin", Key: "admin", Scope: auth.ScopeAdmin},
+	)
+
+	next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		principal, ok := auth.PrincipalFromContext(r.Context())
+		if !ok {
+			_, _ = w.Write([]byte("anonymous"))
+			return
+		}
+
+		_, _ = w.Write([]byte(principal.Subject))
+	})
+
+	h := middleware.Auth(a, "/api/docs")(next)
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			r := httptest.NewRequest(tc.method, tc.path, nil)
+			if tc.key != "" {
+				r.Header.Set(auth.APIKeyHeader, tc.key)
+			}
+
+			h.ServeHTTP(w, r)
+
+			assert.Equal(t, tc.expectedStatus, w.Code)
+			assert.Equal(t, tc.expectedBody, w.Body.String())
+		})
+	}
+}
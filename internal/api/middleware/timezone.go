@@ -0,0 +1,23 @@
+package middleware
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"git.home/alex/go-subscriptions/internal/clock"
+)
+
+// Timezone stores the time zone of the authenticated user in the request context, so it must run
+// after Auth. Requests of users without a zone keep the default set on the server context.
+func Timezone(locate func(ctx context.Context) (*time.Location, bool)) Middleware {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if loc, ok := locate(r.Context()); ok {
+				r = r.WithContext(clock.WithLocation(r.Context(), loc))
+			}
+
+			next.ServeHTTP(w, r)
+		})
+	}
+}
@@ -0,0 +1,61 @@
+package middleware_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"git.home/alex/go-subscriptions/internal/api/middleware"
+	"git.home/alex/go-subscriptions/internal/auth"
+	"git.home/alex/go-subscriptions/internal/clock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTimezone(t *testing.T) {
+	tokyo, err := clock.LoadLocation("Asia/Tokyo")
+	require.NoError(t, err)
+	berlin, err := clock.LoadLocation("Europe/Berlin")
+	require.NoError(t, err)
+
+	locate := func(ctx context.Context) (*time.Location, bool) {
+		principal, ok := auth.PrincipalFromContext(ctx)
+		if !ok || principal.UserID != 1 {
+			return nil, false
+		}
+
+		return tokyo, true
+	}
+
+	testCases := []struct {
+		name      string
+		principal *auth.Principal
+		want      string
+	}{
+		{name: "User zone", principal: &auth.Principal{UserID: 1}, want: "Asia/Tokyo"},
+		{name: "User without a zone", principal: &auth.Principal{UserID: 2}, want: "Europe/Berlin"},
+		{name: "Anonymous", want: "Europe/Berlin"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			serverCtx := clock.WithLocation(context.Background(), berlin)
+
+			var got string
+			h := middleware.Timezone(locate)(http.HandlerFunc(func(_ http.ResponseWriter, r *http.Request) {
+				got = clock.Location(auth.RequestContext(serverCtx, r)).String()
+			}))
+
+			r := httptest.NewRequest(http.MethodGet, "/api/subscriptions", nil)
+			if tc.principal != nil {
+				r = r.WithContext(auth.WithPrincipal(r.Context(), tc.principal))
+			}
+
+			h.ServeHTTP(httptest.NewRecorder(), r)
+
+			assert.Equal(t, tc.want, got)
+		})
+	}
+}
@@ -1,30 +1,73 @@
 package api
 
 import (
+	"net/http"
+
 	"git.home/alex/go-subscriptions/internal/api/handler"
+	"git.home/alex/go-subscriptions/internal/api/handler/asset_handler"
+	"git.home/alex/go-subscriptions/internal/api/handler/backup_handler"
+	"git.home/alex/go-subscriptions/internal/api/handler/budget_handler"
 	"git.home/alex/go-subscriptions/internal/api/handler/category_handler"
+	"git.home/alex/go-subscriptions/internal/api/handler/csv_handler"
 	"git.home/alex/go-subscriptions/internal/api/handler/currency_handler"
 	"git.home/alex/go-subscriptions/internal/api/handler/cycle_handler"
-	"git.home/alex/go-subscriptions/internal/api/handler/empty_handler"
+	"git.home/alex/go-subscriptions/internal/api/handler/docs_handler"
 	"git.home/alex/go-subscriptions/internal/api/handler/health_handler"
+	"git.home/alex/go-subscriptions/internal/api/handler/household_handler"
+	"git.home/alex/go-subscriptions/internal/api/handler/payment_method_handler"
+	"git.home/alex/go-subscriptions/internal/api/handler/statement_handler"
 	"git.home/alex/go-subscriptions/internal/api/handler/subscription_handler"
+	"git.home/alex/go-subscriptions/internal/api/handler/tag_handler"
+	"git.home/alex/go-subscriptions/internal/api/handler/user_handler"
 	"git.home/alex/go-subscriptions/internal/domain/service"
+	"git.home/alex/go-subscriptions/internal/factory"
+	"git.home/alex/go-subscriptions/internal/health"
+	"git.home/alex/go-subscriptions/internal/metrics"
+	"github.com/julienschmidt/httprouter"
 )
 
 func WithHealthHandler() Configuration {
 	return func(s *HTTPServer) error {
-		s.router.GET("/health", health_handler.Handle())
+		s.handle(http.MethodGet, "/health", health_handler.Handle())
+		return nil
+	}
+}
+
+// WithReadyHandler serves the readiness checks; /health stays a plain liveness check.
+func WithReadyHandler(r *health.Registry) Configuration {
+	return func(s *HTTPServer) error {
+		s.handle(http.MethodGet, "/ready", health_handler.Ready(r))
+		return nil
+	}
+}
+
+// WithMetricsHandler serves /metrics outside /api, so the scraper needs no credentials.
+func WithMetricsHandler(m *metrics.Metrics) Configuration {
+	return func(s *HTTPServer) error {
+		s.handle(http.MethodGet, "/metrics", func(w http.ResponseWriter, r *http.Request, _ httprouter.Params) {
+			m.Handler().ServeHTTP(w, r)
+		})
+		return nil
+	}
+}
+
+func WithDocsHandlers() Configuration {
+	return func(s *HTTPServer) error {
+		s.handle(http.MethodGet, docs_handler.SpecPath, docs_handler.Spec())
+		s.handle(http.MethodGet, docs_handler.DocsPath, docs_handler.Docs())
 		return nil
 	}
 }
 
 func WithCategoryHandlers(cs *service.CategoryService) Configuration {
 	return func(s *HTTPServer) error {
-		s.router.POST("/api/category", handler.Handle(category_handler.CreateCategory(s.ctx, cs)))
-		s.router.GET("/api/category/:id", handler.Handle(category_handler.GetCategory(s.ctx, cs)))
-		s.router.GET("/api/categories", handler.Handle(category_handler.GetCategories(s.ctx, cs)))
-		s.router.PUT("/api/category/:id", handler.Handle(category_handler.UpdateCategory(s.ctx, cs)))
-		s.router.DELETE("/api/category/:id", handler.Handle(category_handler.DeleteCategory(s.ctx, cs)))
+		s.handle(http.MethodPost, "/api/category", handler.Handle(category_handler.CreateCategory(s.ctx, cs)))
+		s.handle(http.MethodGet, "/api/category/:id", handler.Handle(category_handler.GetCategory(s.ctx, cs)))
+		s.handle(http.MethodGet, "/api/categories", handler.Handle(category_handler.GetCategories(s.ctx, cs)))
+		s.handle(http.MethodPut, "/api/category/:id", handler.Handle(category_handler.UpdateCategory(s.ctx, cs)))
+		s.handle(http.MethodDelete, "/api/category/:id", handler.Handle(category_handler.DeleteCategory(s.ctx, cs)))
+		s.handle(http.MethodGet, "/api/categories/tree", handler.Handle(category_handler.GetTree(s.ctx, cs)))
+		s.handle(http.MethodGet, "/api/categories/report", handler.Handle(category_handler.GetReport(s.ctx, cs)))
 
 		return nil
 	}
@@ -32,11 +75,11 @@ func WithCategoryHandlers(cs *service.CategoryService) Configuration {
 
 func WithCurrencyHandlers(cs *service.CurrencyService) Configuration {
 	return func(s *HTTPServer) error {
-		s.router.POST("/api/currency", handler.Handle(currency_handler.CreateCurrency(s.ctx, cs)))
-		s.router.GET("/api/currency/:code", handler.Handle(currency_handler.GetCurrency(s.ctx, cs)))
-		s.router.GET("/api/currencies", handler.Handle(currency_handler.GetCurrencies(s.ctx, cs)))
-		s.router.PUT("/api/currency/:code", handler.Handle(currency_handler.UpdateCurrency(s.ctx, cs)))
-		s.router.DELETE("/api/currency/:code", handler.Handle(currency_handler.DeleteCurrency(s.ctx, cs)))
+		s.handle(http.MethodPost, "/api/currency", handler.Handle(currency_handler.CreateCurrency(s.ctx, cs)))
+		s.handle(http.MethodGet, "/api/currency/:code", handler.Handle(currency_handler.GetCurrency(s.ctx, cs)))
+		s.handle(http.MethodGet, "/api/currencies", handler.Handle(currency_handler.GetCurrencies(s.ctx, cs)))
+		s.handle(http.MethodPut, "/api/currency/:code", handler.Handle(currency_handler.UpdateCurrency(s.ctx, cs)))
+		s.handle(http.MethodDelete, "/api/currency/:code", handler.Handle(currency_handler.DeleteCurrency(s.ctx, cs)))
 
 		return nil
 	}
@@ -44,11 +87,11 @@ func WithCurrencyHandlers(cs *service.CurrencyService) Configuration {
 
 func WithCycleHandlers(cs *service.CycleService) Configuration {
 	return func(s *HTTPServer) error {
-		s.router.POST("/api/cycle", handler.Handle(cycle_handler.CreateCycle(s.ctx, cs)))
-		s.router.GET("/api/cycle/:id", handler.Handle(cycle_handler.GetCycle(s.ctx, cs)))
-		s.router.GET("/api/cycles", handler.Handle(cycle_handler.GetCycles(s.ctx, cs)))
-		s.router.PUT("/api/cycle/:id", handler.Handle(cycle_handler.UpdateCycle(s.ctx, cs)))
-		s.router.DELETE("/api/cycle/:id", handler.Handle(cycle_handler.DeleteCycle(s.ctx, cs)))
+		s.handle(http.MethodPost, "/api/cycle", handler.Handle(cycle_handler.CreateCycle(s.ctx, cs)))
+		s.handle(http.MethodGet, "/api/cycle/:id", handler.Handle(cycle_handler.GetCycle(s.ctx, cs)))
+		s.handle(http.MethodGet, "/api/cycles", handler.Handle(cycle_handler.GetCycles(s.ctx, cs)))
+		s.handle(http.MethodPut, "/api/cycle/:id", handler.Handle(cycle_handler.UpdateCycle(s.ctx, cs)))
+		s.handle(http.MethodDelete, "/api/cycle/:id", handler.Handle(cycle_handler.DeleteCycle(s.ctx, cs)))
 
 		return nil
 	}
@@ -56,11 +99,140 @@ func WithCycleHandlers(cs *service.CycleService) Configuration {
 
 func WithSubscribeHandlers(opts *subscription_handler.HandlerOpts) Configuration {
 	return func(s *HTTPServer) error {
-		s.router.POST("/api/subscription", handler.Handle(subscription_handler.CreateSubscription(s.ctx, opts)))
-		s.router.GET("/api/subscription/:id", empty_handler.Handle())
-		s.router.GET("/api/subscriptions", empty_handler.Handle())
-		s.router.PUT("/api/subscription/:id", empty_handler.Handle())
-		s.router.DELETE("/api/subscription/:id", empty_handler.Handle())
+		s.handle(http.MethodPost, "/api/subscription", handler.Handle(subscription_handler.CreateSubscription(s.ctx, opts)))
+		s.handle(http.MethodGet, "/api/subscription/:id", handler.Handle(subscription_handler.GetSubscription(s.ctx, opts)))
+		s.handle(http.MethodGet, "/api/subscriptions", handler.Handle(subscription_handler.GetSubscriptions(s.ctx, opts)))
+		s.handle(http.MethodPut, "/api/subscription/:id", handler.Handle(subscription_handler.UpdateSubscription(s.ctx, opts)))
+		s.handle(http.MethodDelete, "/api/subscription/:id", handler.Handle(subscription_handler.DeleteSubscription(s.ctx, opts)))
+		s.handle(http.MethodPost, "/api/subscription/:id/activate", handler.Handle(subscription_handler.ActivateSubscription(s.ctx, opts)))
+		s.handle(http.MethodPost, "/api/subscription/:id/pause", handler.Handle(subscription_handler.PauseSubscription(s.ctx, opts)))
+		s.handle(http.MethodPost, "/api/subscription/:id/cancel", handler.Handle(subscription_handler.CancelSubscription(s.ctx, opts)))
+		s.handle(http.MethodPost, "/api/subscription/:id/expire", handler.Handle(subscription_handler.ExpireSubscription(s.ctx, opts)))
+		s.handle(http.MethodGet, "/api/subscription/:id/prices", handler.Handle(subscription_handler.GetPrices(s.ctx, opts)))
+		s.handle(http.MethodPost, "/api/subscription/:id/prices", handler.Handle(subscription_handler.ChangePrice(s.ctx, opts)))
+		s.handle(http.MethodGet, "/api/prices/increased", handler.Handle(subscription_handler.GetPriceIncreases(s.ctx, opts)))
+		s.handle(http.MethodGet, "/api/payments/upcoming", handler.Handle(subscription_handler.GetUpcomingPayments(s.ctx, opts)))
+		s.handle(http.MethodGet, "/api/trials/ending", handler.Handle(subscription_handler.GetEndingTrials(s.ctx, opts)))
+		s.handle(http.MethodPut, "/api/subscription/:id/contract", handler.Handle(subscription_handler.SetContract(s.ctx, opts)))
+		s.handle(http.MethodDelete, "/api/subscription/:id/contract", handler.Handle(subscription_handler.RemoveContract(s.ctx, opts)))
+		s.handle(http.MethodGet, "/api/contracts/deadlines", handler.Handle(subscription_handler.GetContractDeadlines(s.ctx, opts)))
+		s.handle(http.MethodPost, "/api/subscription/:id/attachments", handler.Handle(subscription_handler.AddAttachment(s.ctx, opts)))
+		s.handle(http.MethodGet, "/api/subscription/:id/attachments", handler.Handle(subscription_handler.GetAttachments(s.ctx, opts)))
+		s.handle(http.MethodGet, "/api/attachment/:id", subscription_handler.DownloadAttachment(s.ctx, opts))
+		s.handle(http.MethodDelete, "/api/attachment/:id", handler.Handle(subscription_handler.DeleteAttachment(s.ctx, opts)))
+
+		return nil
+	}
+}
+
+// WithUserHandlers registers the account endpoints. Registration and login live outside /api
+// so that they stay reachable without credentials.
+func WithUserHandlers(opts *user_handler.HandlerOpts) Configuration {
+	return func(s *HTTPServer) error {
+		s.handle(http.MethodPost, "/auth/register", handler.Handle(user_handler.Register(s.ctx, opts)))
+		s.handle(http.MethodPost, "/auth/login", handler.Handle(user_handler.Login(s.ctx, opts)))
+		s.handle(http.MethodGet, "/api/user", handler.Handle(user_handler.GetCurrentUser(s.ctx, opts)))
+		s.handle(http.MethodPut, "/api/user", handler.Handle(user_handler.UpdateCurrentUser(s.ctx, opts)))
+
+		return nil
+	}
+}
+
+func WithHouseholdHandlers(hs *service.HouseholdService) Configuration {
+	return func(s *HTTPServer) error {
+		s.handle(http.MethodPost, "/api/household", handler.Handle(household_handler.CreateHousehold(s.ctx, hs)))
+		s.handle(http.MethodGet, "/api/household/:id", handler.Handle(household_handler.GetHousehold(s.ctx, hs)))
+		s.handle(http.MethodGet, "/api/households", handler.Handle(household_handler.GetHouseholds(s.ctx, hs)))
+		s.handle(http.MethodPut, "/api/household/:id", handler.Handle(household_handler.UpdateHousehold(s.ctx, hs)))
+		s.handle(http.MethodDelete, "/api/household/:id", handler.Handle(household_handler.DeleteHousehold(s.ctx, hs)))
+		s.handle(http.MethodPost, "/api/household/:id/member", handler.Handle(household_handler.AddMember(s.ctx, hs)))
+		s.handle(http.MethodDelete, "/api/household/:id/member/:member_id", handler.Handle(household_handler.RemoveMember(s.ctx, hs)))
+		s.handle(http.MethodGet, "/api/household/:id/report", handler.Handle(household_handler.GetReport(s.ctx, hs)))
+		s.handle(http.MethodPut, "/api/subscription/:id/share", handler.Handle(household_handler.ShareSubscription(s.ctx, hs)))
+		s.handle(http.MethodDelete, "/api/subscription/:id/share", handler.Handle(household_handler.UnshareSubscription(s.ctx, hs)))
+
+		return nil
+	}
+}
+
+func WithBudgetHandlers(bs *service.BudgetService) Configuration {
+	return func(s *HTTPServer) error {
+		s.handle(http.MethodPost, "/api/budget", handler.Handle(budget_handler.CreateBudget(s.ctx, bs)))
+		s.handle(http.MethodGet, "/api/budget/:id", handler.Handle(budget_handler.GetBudget(s.ctx, bs)))
+		s.handle(http.MethodGet, "/api/budgets", handler.Handle(budget_handler.GetBudgets(s.ctx, bs)))
+		s.handle(http.MethodPut, "/api/budget/:id", handler.Handle(budget_handler.UpdateBudget(s.ctx, bs)))
+		s.handle(http.MethodDelete, "/api/budget/:id", handler.Handle(budget_handler.DeleteBudget(s.ctx, bs)))
+		s.handle(http.MethodGet, "/api/budget/:id/status", handler.Handle(budget_handler.GetStatus(s.ctx, bs)))
+		s.handle(http.MethodGet, "/api/budgets/status", handler.Handle(budget_handler.GetStatuses(s.ctx, bs)))
+
+		return nil
+	}
+}
+
+func WithTagHandlers(ts *service.TagService) Configuration {
+	return func(s *HTTPServer) error {
+		s.handle(http.MethodPost, "/api/tag", handler.Handle(tag_handler.CreateTag(s.ctx, ts)))
+		s.handle(http.MethodGet, "/api/tag/:id", handler.Handle(tag_handler.GetTag(s.ctx, ts)))
+		s.handle(http.MethodGet, "/api/tags", handler.Handle(tag_handler.GetTags(s.ctx, ts)))
+		s.handle(http.MethodPut, "/api/tag/:id", handler.Handle(tag_handler.UpdateTag(s.ctx, ts)))
+		s.handle(http.MethodDelete, "/api/tag/:id", handler.Handle(tag_handler.DeleteTag(s.ctx, ts)))
+		s.handle(http.MethodGet, "/api/tags/report", handler.Handle(tag_handler.GetReport(s.ctx, ts)))
+		s.handle(http.MethodPut, "/api/subscription/:id/tag/:tag_id", handler.Handle(tag_handler.TagSubscription(s.ctx, ts)))
+		s.handle(http.MethodDelete, "/api/subscription/:id/tag/:tag_id", handler.Handle(tag_handler.UntagSubscription(s.ctx, ts)))
+
+		return nil
+	}
+}
+
+func WithPaymentMethodHandlers(ms *service.PaymentMethodService) Configuration {
+	return func(s *HTTPServer) error {
+		s.handle(http.MethodPost, "/api/payment_method", handler.Handle(payment_method_handler.CreatePaymentMethod(s.ctx, ms)))
+		s.handle(http.MethodGet, "/api/payment_method/:id", handler.Handle(payment_method_handler.GetPaymentMethod(s.ctx, ms)))
+		s.handle(http.MethodGet, "/api/payment_methods", handler.Handle(payment_method_handler.GetPaymentMethods(s.ctx, ms)))
+		s.handle(http.MethodPut, "/api/payment_method/:id", handler.Handle(payment_method_handler.UpdatePaymentMethod(s.ctx, ms)))
+		s.handle(http.MethodDelete, "/api/payment_method/:id", handler.Handle(payment_method_handler.DeletePaymentMethod(s.ctx, ms)))
+		s.handle(http.MethodGet, "/api/payment_methods/report", handler.Handle(payment_method_handler.GetReport(s.ctx, ms)))
+		s.handle(http.MethodGet, "/api/payment_methods/expiring", handler.Handle(payment_method_handler.GetExpiring(s.ctx, ms)))
+		s.handle(http.MethodPut, "/api/subscription/:id/payment_method", handler.Handle(payment_method_handler.LinkSubscription(s.ctx, ms)))
+
+		return nil
+	}
+}
+
+// WithAssetHandlers serves the uploaded images as raw bytes rather than JSON.
+func WithAssetHandlers(as *service.AssetService) Configuration {
+	return func(s *HTTPServer) error {
+		s.handle(http.MethodPost, "/api/subscription/:id/logo", handler.Handle(asset_handler.UploadLogo(s.ctx, as)))
+		s.handle(http.MethodDelete, "/api/subscription/:id/logo", handler.Handle(asset_handler.RemoveLogo(s.ctx, as)))
+		s.handle(http.MethodGet, "/api/asset/:id", asset_handler.Serve(s.ctx, as, false))
+		s.handle(http.MethodGet, "/api/asset/:id/thumbnail", asset_handler.Serve(s.ctx, as, true))
+
+		return nil
+	}
+}
+
+func WithCSVHandlers(cs *service.CSVService) Configuration {
+	return func(s *HTTPServer) error {
+		s.handle(http.MethodPost, "/api/subscriptions/import", handler.Handle(csv_handler.Import(s.ctx, cs)))
+		s.handle(http.MethodGet, "/api/subscriptions/export", csv_handler.Export(s.ctx, cs))
+
+		return nil
+	}
+}
+
+func WithStatementHandlers(ss *service.StatementService) Configuration {
+	return func(s *HTTPServer) error {
+		s.handle(http.MethodPost, "/api/statements/scan", handler.Handle(statement_handler.Scan(s.ctx, ss)))
+		s.handle(http.MethodPost, "/api/statements/confirm", handler.Handle(statement_handler.Confirm(s.ctx, ss)))
+
+		return nil
+	}
+}
+
+func WithBackupHandlers(rf *factory.RepositoryFactory) Configuration {
+	return func(s *HTTPServer) error {
+		s.handle(http.MethodGet, "/api/admin/backup", backup_handler.Backup(s.ctx, rf))
+		s.handle(http.MethodPost, "/api/admin/restore", handler.Handle(backup_handler.Restore(s.ctx, rf)))
 
 		return nil
 	}
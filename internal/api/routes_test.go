@@ -0,0 +1,96 @@
+package api_test
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"testing"
+
+	"git.home/alex/go-subscriptions/internal/api"
+	"git.home/alex/go-subscriptions/internal/api/handler/docs_handler"
+	"git.home/alex/go-subscriptions/internal/app"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var pathParam = regexp.MustCompile(`:(\w+)`)
+
+// newTestServer wires the server like the run command does, on the memory storage.
+func newTestServer(t *testing.T) *api.HTTPServer {
+	t.Helper()
+
+	dir := t.TempDir()
+	configFile := filepath.Join(dir, "config.yaml")
+	config := "storage: memory\ntimeout: 15s\nassets:\n  dir: " + filepath.Join(dir, "assets") + "\n"
+	require.NoError(t, os.WriteFile(configFile, []byte(config), 0o600))
+
+	application, err := app.NewApp(configFile)
+	require.NoError(t, err)
+
+	s, err := api.NewHTTPServer(application.ServerConfigurations()...)
+	require.NoError(t, err)
+
+	return s
+}
+
+func TestRoutesAreDocumented(t *testing.T) {
+	data, err := docs_handler.SpecJSON()
+	require.NoError(t, err)
+
+	var spec struct {
+		OpenAPI string                            `json:"openapi"`
+		Paths   map[string]map[string]interface{} `json:"paths"`
+	}
+	require.NoError(t, json.Unmarshal(data, &spec))
+	assert.True(t, strings.HasPrefix(spec.OpenAPI, "3."))
+
+	registered := make(map[string]bool)
+
+	for _, route := range newTestServer(t).Routes() {
+		path := pathParam.ReplaceAllString(route.Path, "{$1}")
+		method := strings.ToLower(route.Method)
+		registered[method+" "+path] = true
+
+		_, ok := spec.Paths[path][method]
+		assert.True(t, ok, "%s %s is not described in the OpenAPI document", route.Method, route.Path)
+	}
+
+	for path, operations := range spec.Paths {
+		for method := range operations {
+			if method == "parameters" {
+				continue
+			}
+
+			assert.True(t, registered[method+" "+path], "%s %s is described but not registered", method, path)
+		}
+	}
+}
+
+func TestMetrics(t *testing.T) {
+	h := newTestServer(t).Handler()
+
+	for _, path := range []string{"/api/category/1", "/api/category/2", "/health"} {
+		h.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, path, nil))
+	}
+
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+	require.Equal(t, http.StatusOK, w.Code)
+
+	body, err := io.ReadAll(w.Body)
+	require.NoError(t, err)
+
+	for _, line := range []string{
+		`subscriptions_http_requests_total{code="200",method="GET",route="/api/category/:id"} 2`,
+		`subscriptions_http_requests_total{code="200",method="GET",route="/health"} 1`,
+		`subscriptions_repository_operation_errors_total{backend="memory",operation="get",repository="category"} 2`,
+		`subscriptions_active_subscriptions 0`,
+	} {
+		assert.Contains(t, string(body), line)
+	}
+}
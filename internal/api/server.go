@@ -10,14 +10,27 @@ import (
 	"syscall"
 	"time"
 
+	"git.home/alex/go-subscriptions/internal/api/handler/docs_handler"
+	"git.home/alex/go-subscriptions/internal/api/middleware"
+	"git.home/alex/go-subscriptions/internal/auth"
+	"git.home/alex/go-subscriptions/internal/domain/service"
+	"git.home/alex/go-subscriptions/internal/metrics"
 	"github.com/julienschmidt/httprouter"
 )
 
 type HTTPServer struct {
-	listenAddr string
-	timeout    time.Duration
-	router     *httprouter.Router
-	ctx        context.Context
+	listenAddr  string
+	timeout     time.Duration
+	router      *httprouter.Router
+	middlewares []middleware.Middleware
+	routes      []Route
+	ctx         context.Context
+	metrics     *metrics.Metrics
+}
+
+type Route struct {
+	Method string
+	Path   string
 }
 
 type Configuration func(s *HTTPServer) error
@@ -70,10 +83,83 @@ func WithContext(ctx context.Context) Configuration {
 	}
 }
 
+func WithMiddleware(m middleware.Middleware) Configuration {
+	return func(s *HTTPServer) error {
+		s.middlewares = append(s.middlewares, m)
+		return nil
+	}
+}
+
+// WithAuth protects the /api routes, except for the API documentation.
+func WithAuth(a auth.Authenticator) Configuration {
+	return WithMiddleware(middleware.Auth(a, docs_handler.SpecPath, docs_handler.DocsPath))
+}
+
+// WithUserTimezones evaluates "today" in the zone of the authenticated user. It has to come after WithAuth.
+func WithUserTimezones(us *service.UserService) Configuration {
+	return WithMiddleware(middleware.Timezone(us.Location))
+}
+
+// WithMetrics records the requests of every route registered after it.
+func WithMetrics(m *metrics.Metrics) Configuration {
+	return func(s *HTTPServer) error {
+		s.metrics = m
+		return nil
+	}
+}
+
+// Routes returns the registered routes in registration order.
+func (s *HTTPServer) Routes() []Route {
+	return s.routes
+}
+
+func (s *HTTPServer) handle(method, path string, h httprouter.Handle) {
+	s.routes = append(s.routes, Route{Method: method, Path: path})
+
+	if s.metrics != nil {
+		h = observe(s.metrics, method, path, h)
+	}
+
+	s.router.Handle(method, path, h)
+}
+
+// observe labels the request with the route pattern rather than the path, so /api/category/1
+// and /api/category/2 end up in the same series.
+func observe(m *metrics.Metrics, method, path string, h httprouter.Handle) httprouter.Handle {
+	return func(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
+		start := time.Now()
+		sw := &statusWriter{ResponseWriter: w, status: http.StatusOK}
+
+		h(sw, r, ps)
+
+		m.ObserveHTTP(method, path, sw.status, time.Since(start))
+	}
+}
+
+type statusWriter struct {
+	http.ResponseWriter
+	status int
+}
+
+func (w *statusWriter) WriteHeader(status int) {
+	w.status = status
+	w.ResponseWriter.WriteHeader(status)
+}
+
+// Handler returns the router wrapped in the configured middlewares, the first one being the outermost.
+func (s *HTTPServer) Handler() http.Handler {
+	var h http.Handler = s.router
+	for i := len(s.middlewares) - 1; i >= 0; i-- {
+		h = s.middlewares[i](h)
+	}
+
+	return h
+}
+
 func (s *HTTPServer) ListenAndServe() {
 	server := &http.Server{
 		Addr:        s.listenAddr,
-		Handler:     s.router,
+		Handler:     s.Handler(),
 		ReadTimeout: s.timeout * time.Second,
 	}
 
@@ -85,7 +171,7 @@ func (s *HTTPServer) ListenAndServe() {
 	go func() {
 		slog.Info("API server started", "address", s.listenAddr)
 		if err := server.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
-			slog.Error("listen: %s\n", err)
+			slog.Error("listen", "error", err)
 		}
 	}()
 
@@ -2,10 +2,19 @@ package app
 
 import (
 	"context"
+	"database/sql"
 	"errors"
+	"fmt"
+	"os"
 
+	"git.home/alex/go-subscriptions/internal/auth"
+	"git.home/alex/go-subscriptions/internal/blobstore"
+	"git.home/alex/go-subscriptions/internal/clock"
 	"git.home/alex/go-subscriptions/internal/config"
 	"git.home/alex/go-subscriptions/internal/factory"
+	"git.home/alex/go-subscriptions/internal/health"
+	"git.home/alex/go-subscriptions/internal/metrics"
+	"git.home/alex/go-subscriptions/internal/repository/sqlite"
 )
 
 var (
@@ -13,9 +22,14 @@ var (
 )
 
 type App struct {
-	Context        context.Context
-	Config         *config.Config
-	ServiceFactory *factory.ServiceFactory
+	Context           context.Context
+	Config            *config.Config
+	RepositoryFactory *factory.RepositoryFactory
+	ServiceFactory    *factory.ServiceFactory
+	Authenticator     auth.Authenticator
+	TokenIssuer       *auth.TokenIssuer
+	Metrics           *metrics.Metrics
+	Health            *health.Registry
 }
 
 type Configuration func(a *App) error
@@ -26,7 +40,20 @@ func NewApp(configFile string) (*App, error) {
 		return nil, err
 	}
 
-	rf, err := factoryRepository(cfg.Storage)
+	loc, err := clock.LoadLocation(cfg.Timezone)
+	if err != nil {
+		return nil, fmt.Errorf("timezone: %w", err)
+	}
+
+	ctx := clock.WithLocation(context.Background(), loc)
+	m := metrics.New()
+
+	rf, err := factoryRepository(ctx, cfg, m)
+	if err != nil {
+		return nil, err
+	}
+
+	err = m.Register(metrics.NewSubscriptionCollector(ctx, rf.SubscriptionRepository))
 	if err != nil {
 		return nil, err
 	}
@@ -37,15 +64,41 @@ func NewApp(configFile string) (*App, error) {
 		factory.WithCurrencyService(),
 		factory.WithCycleService(),
 		factory.WithSubscriptionService(),
+		factory.WithUserService(),
+		factory.WithHouseholdService(),
+		factory.WithBudgetService(),
+		factory.WithTagService(),
+		factory.WithPaymentMethodService(),
+		factory.WithAssetService(cfg.Assets.MaxSize),
+		factory.WithAttachmentService(cfg.Assets.MaxAttachmentSize),
+		factory.WithCSVService(),
+		factory.WithStatementService(),
 	)
 	if err != nil {
 		return nil, err
 	}
 
+	registry, err := health.NewRegistry()
+	if err != nil {
+		return nil, err
+	}
+
+	registry.Register("storage", "datastore", storageCheck(cfg.Storage, rf))
+
+	authenticator, err := factoryAuthenticator(cfg.Auth)
+	if err != nil {
+		return nil, err
+	}
+
 	a, err := newApp(
 		withConfig(cfg),
-		withContext(context.Background()),
+		withContext(ctx),
+		withRepositoryFactory(rf),
 		withServiceFactory(sf),
+		withAuthenticator(authenticator),
+		withTokenIssuer(factoryTokenIssuer(cfg.Auth.JWT)),
+		withMetrics(m),
+		withHealth(registry),
 	)
 	if err != nil {
 		return nil, err
@@ -54,6 +107,11 @@ func NewApp(configFile string) (*App, error) {
 	return a, nil
 }
 
+// Persistent reports whether the storage keeps its data after the process exits.
+func (a *App) Persistent() bool {
+	return a.Config.Storage != "memory"
+}
+
 func newApp(cfgs ...Configuration) (*App, error) {
 	a := &App{}
 
@@ -82,6 +140,13 @@ func withContext(ctx context.Context) Configuration {
 	}
 }
 
+func withRepositoryFactory(factory *factory.RepositoryFactory) Configuration {
+	return func(a *App) error {
+		a.RepositoryFactory = factory
+		return nil
+	}
+}
+
 func withServiceFactory(factory *factory.ServiceFactory) Configuration {
 	return func(a *App) error {
 		a.ServiceFactory = factory
@@ -89,10 +154,156 @@ func withServiceFactory(factory *factory.ServiceFactory) Configuration {
 	}
 }
 
-func factoryRepository(storage string) (*factory.RepositoryFactory, error) {
-	if storage == "memory" {
-		return factory.NewRepositoryFactory(factory.WithMemoryRepository())
+func withAuthenticator(authenticator auth.Authenticator) Configuration {
+	return func(a *App) error {
+		a.Authenticator = authenticator
+		return nil
+	}
+}
+
+func withTokenIssuer(issuer *auth.TokenIssuer) Configuration {
+	return func(a *App) error {
+		a.TokenIssuer = issuer
+		return nil
+	}
+}
+
+func withMetrics(m *metrics.Metrics) Configuration {
+	return func(a *App) error {
+		a.Metrics = m
+		return nil
+	}
+}
+
+func withHealth(registry *health.Registry) Configuration {
+	return func(a *App) error {
+		a.Health = registry
+		return nil
+	}
+}
+
+func factoryRepository(ctx context.Context, cfg *config.Config, m *metrics.Metrics) (*factory.RepositoryFactory, error) {
+	instrumentation := factory.WithInstrumentation(m, cfg.Storage)
+
+	store, err := blobstore.NewFileSystem(cfg.Assets.Dir)
+	if err != nil {
+		return nil, fmt.Errorf("assets: %w", err)
+	}
+
+	blobs := factory.WithBlobStore(store)
+
+	switch cfg.Storage {
+	case "memory":
+		return factory.NewRepositoryFactory(factory.WithMemoryRepository(), blobs, instrumentation)
+	case "sqlite":
+		db, err := openSQLite(ctx, cfg.SQLite)
+		if err != nil {
+			return nil, fmt.Errorf("sqlite: %w", err)
+		}
+
+		return factory.NewRepositoryFactory(factory.WithSqliteRepository(db), blobs, instrumentation)
 	}
 
 	return nil, errUndefinedStorage
 }
+
+// openSQLite opens the database and brings its schema up to date, or checks that it is when
+// auto_migrate is off. The database stays open for the lifetime of the process.
+func openSQLite(ctx context.Context, cfg config.SQLiteConfig) (*sql.DB, error) {
+	db, err := sqlite.Open(cfg.Path)
+	if err != nil {
+		return nil, err
+	}
+
+	if cfg.AutoMigrate {
+		err = sqlite.Migrate(ctx, db)
+	} else {
+		err = sqlite.CheckSchema(ctx, db)
+	}
+
+	if err != nil {
+		_ = db.Close()
+		return nil, err
+	}
+
+	return db, nil
+}
+
+// storageCheck reads from the storage, so it fails when the backend can't serve requests.
+func storageCheck(storage string, rf *factory.RepositoryFactory) health.CheckFunc {
+	return func(ctx context.Context) error {
+		_, err := rf.CurrencyRepository.GetAll(ctx)
+		if err != nil {
+			return fmt.Errorf("%s: %w", storage, err)
+		}
+
+		return nil
+	}
+}
+
+// factoryAuthenticator returns nil when no credentials are configured.
+func factoryAuthenticator(cfg config.AuthConfig) (auth.Authenticator, error) {
+	if !cfg.Enabled() {
+		return nil, nil
+	}
+
+	var chain auth.Chain
+
+	if len(cfg.APIKeys) > 0 {
+		keys := make([]auth.APIKey, len(cfg.APIKeys))
+		for i, k := range cfg.APIKeys {
+			scope, err := auth.ParseScope(k.Scope)
+			if err != nil {
+				return nil, err
+			}
+
+			keys[i] = auth.APIKey{Name: k.Name, Key: k.Key, UserID: k.UserID, Scope: scope}
+		}
+
+		chain = append(chain, auth.NewAPIKeyAuthenticator(keys...))
+	}
+
+	if cfg.JWT.Enabled() {
+		jwtCfgs := []auth.JWTConfiguration{
+			auth.WithIssuer(cfg.JWT.Issuer),
+			auth.WithAudience(cfg.JWT.Audience),
+			auth.WithLeeway(cfg.JWT.Leeway),
+		}
+
+		if cfg.JWT.HS256Secret != "" {
+			jwtCfgs = append(jwtCfgs, auth.WithHS256Secret([]byte(cfg.JWT.HS256Secret)))
+		}
+
+		if cfg.JWT.RS256PublicKeyFile != "" {
+			data, err := os.ReadFile(cfg.JWT.RS256PublicKeyFile)
+			if err != nil {
+				return nil, err
+			}
+
+			key, err := auth.ParseRSAPublicKeyPEM(data)
+			if err != nil {
+				return nil, err
+			}
+
+			jwtCfgs = append(jwtCfgs, auth.WithRS256PublicKey(key))
+		}
+
+		jwtAuthenticator, err := auth.NewJWTAuthenticator(jwtCfgs...)
+		if err != nil {
+			return nil, err
+		}
+
+		chain = append(chain, jwtAuthenticator)
+	}
+
+	return chain, nil
+}
+
+// factoryTokenIssuer returns nil when no HS256 secret is configured, which disables password login.
+func factoryTokenIssuer(cfg config.JWTConfig) *auth.TokenIssuer {
+	if cfg.HS256Secret == "" {
+		return nil
+	}
+
+	return auth.NewTokenIssuer([]byte(cfg.HS256Secret), cfg.Issuer, cfg.Audience, cfg.TokenTTL)
+}
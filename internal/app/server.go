@@ -0,0 +1,60 @@
+package app
+
+import (
+	"git.home/alex/go-subscriptions/internal/api"
+	"git.home/alex/go-subscriptions/internal/api/handler/subscription_handler"
+	"git.home/alex/go-subscriptions/internal/api/handler/user_handler"
+)
+
+// ServerConfigurations wires every handler of the HTTP API to the services of the app, with
+// authentication when credentials are configured.
+func (a *App) ServerConfigurations() []api.Configuration {
+	sf := a.ServiceFactory
+
+	cfgs := []api.Configuration{
+		api.WithTimeout(a.Config.Timeout),
+		api.WithListenAddr(a.Config.ListenAddr),
+		api.WithContext(a.Context),
+		api.WithDefaultRouter(),
+		api.WithMetrics(a.Metrics),
+		api.WithHealthHandler(),
+		api.WithReadyHandler(a.Health),
+		api.WithMetricsHandler(a.Metrics),
+		api.WithDocsHandlers(),
+		api.WithCategoryHandlers(sf.CategoryService),
+		api.WithCurrencyHandlers(sf.CurrencyService),
+		api.WithCycleHandlers(sf.CycleService),
+		api.WithSubscribeHandlers(&subscription_handler.HandlerOpts{
+			SubscriptionService: sf.SubscriptionService,
+			CategoryService:     sf.CategoryService,
+			CycleService:        sf.CycleService,
+			CurrencyService:     sf.CurrencyService,
+			BudgetService:       sf.BudgetService,
+			AttachmentService:   sf.AttachmentService,
+			TrialAlertDays:      a.Config.Trials.AlertDays,
+			ContractAlertDays:   a.Config.Contracts.AlertDays,
+		}),
+		api.WithCSVHandlers(sf.CSVService),
+		api.WithStatementHandlers(sf.StatementService),
+		api.WithBackupHandlers(a.RepositoryFactory),
+		api.WithHouseholdHandlers(sf.HouseholdService),
+		api.WithBudgetHandlers(sf.BudgetService),
+		api.WithTagHandlers(sf.TagService),
+		api.WithPaymentMethodHandlers(sf.PaymentMethodService),
+		api.WithAssetHandlers(sf.AssetService),
+		api.WithUserHandlers(&user_handler.HandlerOpts{
+			UserService:       sf.UserService,
+			TokenIssuer:       a.TokenIssuer,
+			AllowRegistration: a.Config.Auth.AllowRegistration,
+		}),
+	}
+
+	if a.Authenticator != nil {
+		cfgs = append(cfgs,
+			api.WithAuth(a.Authenticator),
+			api.WithUserTimezones(sf.UserService),
+		)
+	}
+
+	return cfgs
+}
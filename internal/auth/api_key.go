@@ -0,0 +1,38 @@
+package auth
+
+import (
+	"crypto/subtle"
+	"net/http"
+)
+
+const APIKeyHeader = "X-API-Key"
+
+type APIKey struct {
+	Name   string
+	Key    string
+	UserID uint
+	Scope  Scope
+}
+
+type APIKeyAuthenticator struct {
+	keys []APIKey
+}
+
+func NewAPIKeyAuthenticator(keys ...APIKey) *APIKeyAuthenticator {
+	return &APIKeyAuthenticator{keys: keys}
+}
+
+func (a *APIKeyAuthenticator) Authenticate(r *http.Request) (*Principal, error) {
+	key := r.Header.Get(APIKeyHeader)
+	if key == "" {
+		return nil, ErrNoCredentials
+	}
+
+	for _, k := range a.keys {
+		if subtle.ConstantTimeCompare([]byte(k.Key), []byte(key)) == 1 {
+			return &Principal{Subject: k.Name, UserID: k.UserID, Scope: k.Scope}, nil
+		}
+	}
+
+	return nil, ErrInvalidAPIKey
+}
@@ -0,0 +1,86 @@
+package auth_test
+
+import (
+	"net/http"
+	"testing"
+
+	"git.home/alex/go-subscriptions/internal/auth"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAPIKeyAuthenticator_Authenticate(t *testing.T) {
+	testCases := []struct {
+		name       string
+		key        string
+		wantResult *auth.Principal
+		wantErr    error
+	}{
+		{
+			name:       "Read-write key",
+			key:        "secret-rw",
+			wantResult: &auth.Principal{Subject: "admin", Scope: auth.ScopeReadWrite},
+		},
+		{
+			name:       "Read-only key",
+			key:        "secret-ro",
+			wantResult: &auth.Principal{Subject: "dashboard", Scope: auth.ScopeRead},
+		},
+		{
+			name:    "Unknown key",
+			key:     "unknown",
+			wantErr: auth.ErrInvalidAPIKey,
+		},
+		{
+			name:    "No key",
+			key:     "",
+			wantErr: auth.ErrNoCredentials,
+		},
+	}
+
+	a := auth.NewAPIKeyAuthenticator(
+		auth.APIKey{Name: "admin", Key: "secret-rw", Scope: auth.ScopeReadWrite},
+		auth.APIKey{Name: "dashboard", Key: "secret-ro", Scope: auth.ScopeRead},
+	)
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			r := &http.Request{Header: http.Header{}}
+			if tc.key != "" {
+				r.Header.Set(auth.APIKeyHeader, tc.key)
+			}
+
+			result, err := a.Authenticate(r)
+
+			if tc.wantErr != nil {
+				assert.ErrorIs(t, err, tc.wantErr)
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, tc.wantResult, result)
+		})
+	}
+}
+
+func TestPrincipal_Allows(t *testing.T) {
+	testCases := []struct {
+		name   string
+		scope  auth.Scope
+		method string
+		want   bool
+	}{
+		{name: "Read scope GET", scope: auth.ScopeRead, method: http.MethodGet, want: true},
+		{name: "Read scope POST", scope: auth.ScopeRead, method: http.MethodPost, want: false},
+		{name: "Read scope DELETE", scope: auth.ScopeRead, method: http.MethodDelete, want: false},
+		{name: "Read-write scope GET", scope: auth.ScopeReadWrite, method: http.MethodGet, want: true},
+		{name: "Read-write scope PUT", scope: auth.ScopeReadWrite, method: http.MethodPut, want: true},
+		{name: "Admin scope DELETE", scope: auth.ScopeAdmin, method: http.MethodDelete, want: true},
+		{name: "Empty scope GET", scope: "", method: http.MethodGet, want: false},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.want, auth.Principal{Scope: tc.scope}.Allows(tc.method))
+		})
+	}
+}
@@ -0,0 +1,37 @@
+package auth
+
+import (
+	"errors"
+	"net/http"
+)
+
+var (
+	ErrNoCredentials     = errors.New("no credentials provided")
+	ErrInvalidAPIKey     = errors.New("the api key is not valid")
+	ErrInsufficientScope = errors.New("insufficient scope")
+)
+
+type Authenticator interface {
+	Authenticate(r *http.Request) (*Principal, error)
+}
+
+// Chain tries each authenticator in order. An authenticator that finds no credentials
+// of its kind returns ErrNoCredentials and the next one is tried.
+type Chain []Authenticator
+
+func NewChain(authenticators ...Authenticator) Chain {
+	return authenticators
+}
+
+func (c Chain) Authenticate(r *http.Request) (*Principal, error) {
+	for _, a := range c {
+		principal, err := a.Authenticate(r)
+		if errors.Is(err, ErrNoCredentials) {
+			continue
+		}
+
+		return principal, err
+	}
+
+	return nil, ErrNoCredentials
+}
@@ -0,0 +1,89 @@
+package auth
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"time"
+)
+
+var (
+	ErrIssuerNotConfigured = errors.New("token issuing is not configured")
+)
+
+const defaultTokenTTL = 24 * time.Hour
+
+// TokenIssuer signs HS256 tokens for users that logged in with a password.
+// The tokens are accepted by a JWTAuthenticator configured with the same secret.
+type TokenIssuer struct {
+	secret   []byte
+	issuer   string
+	audience string
+	ttl      time.Duration
+	now      func() time.Time
+}
+
+type Token struct {
+	AccessToken string
+	ExpiresAt   time.Time
+}
+
+func NewTokenIssuer(secret []byte, issuer, audience string, ttl time.Duration) *TokenIssuer {
+	if ttl <= 0 {
+		ttl = defaultTokenTTL
+	}
+
+	return &TokenIssuer{secret: secret, issuer: issuer, audience: audience, ttl: ttl, now: time.Now}
+}
+
+func (i *TokenIssuer) Issue(subject string, userID uint, scope Scope) (*Token, error) {
+	if i == nil || len(i.secret) == 0 {
+		return nil, ErrIssuerNotConfigured
+	}
+
+	now := i.now()
+	issuedAt := now.Unix()
+	expiresAt := now.Add(i.ttl).Unix()
+
+	claims := jwtClaims{
+		Subject:   subject,
+		UserID:    userID,
+		Issuer:    i.issuer,
+		IssuedAt:  &issuedAt,
+		ExpiresAt: &expiresAt,
+		Scope:     "read",
+	}
+
+	if i.audience != "" {
+		claims.Audience = audience{i.audience}
+	}
+
+	switch scope {
+	case ScopeReadWrite:
+		claims.Scope = "read write"
+	case ScopeAdmin:
+		claims.Scope = "read write admin"
+	}
+
+	header, err := json.Marshal(jwtHeader{Alg: AlgHS256, Typ: "JWT"})
+	if err != nil {
+		return nil, err
+	}
+
+	payload, err := json.Marshal(claims)
+	if err != nil {
+		return nil, err
+	}
+
+	signingInput := base64.RawURLEncoding.EncodeToString(header) + "." + base64.RawURLEncoding.EncodeToString(payload)
+
+	mac := hmac.New(sha256.New, i.secret)
+	mac.Write([]byte(signingInput))
+
+	return &Token{
+		AccessToken: signingInput + "." + base64.RawURLEncoding.EncodeToString(mac.Sum(nil)),
+		ExpiresAt:   time.Unix(expiresAt, 0),
+	}, nil
+}
@@ -0,0 +1,294 @@
+package auth
+
+import (
+	"crypto"
+	"crypto/hmac"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"net/http"
+	"strings"
+	"time"
+)
+
+var (
+	ErrInvalidToken     = errors.New("the token is not valid")
+	ErrTokenExpired     = errors.New("the token has expired")
+	ErrUnsupportedAlg   = errors.New("the token signing algorithm is not supported")
+	ErrInvalidPublicKey = errors.New("the public key is not a valid RSA key")
+)
+
+const (
+	AlgHS256 = "HS256"
+	AlgRS256 = "RS256"
+
+	bearerPrefix = "Bearer "
+)
+
+type JWTAuthenticator struct {
+	hmacSecret []byte
+	publicKey  *rsa.PublicKey
+	issuer     string
+	audience   string
+	leeway     time.Duration
+	now        func() time.Time
+}
+
+type JWTConfiguration func(a *JWTAuthenticator) error
+
+func NewJWTAuthenticator(cfgs ...JWTConfiguration) (*JWTAuthenticator, error) {
+	a := &JWTAuthenticator{now: time.Now}
+
+	// Apply all Configurations passed in
+	for _, cfg := range cfgs {
+		err := cfg(a)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return a, nil
+}
+
+func WithHS256Secret(secret []byte) JWTConfiguration {
+	return func(a *JWTAuthenticator) error {
+		a.hmacSecret = secret
+		return nil
+	}
+}
+
+func WithRS256PublicKey(key *rsa.PublicKey) JWTConfiguration {
+	return func(a *JWTAuthenticator) error {
+		a.publicKey = key
+		return nil
+	}
+}
+
+func WithIssuer(issuer string) JWTConfiguration {
+	return func(a *JWTAuthenticator) error {
+		a.issuer = issuer
+		return nil
+	}
+}
+
+func WithAudience(audience string) JWTConfiguration {
+	return func(a *JWTAuthenticator) error {
+		a.audience = audience
+		return nil
+	}
+}
+
+func WithLeeway(leeway time.Duration) JWTConfiguration {
+	return func(a *JWTAuthenticator) error {
+		a.leeway = leeway
+		return nil
+	}
+}
+
+func WithClock(now func() time.Time) JWTConfiguration {
+	return func(a *JWTAuthenticator) error {
+		a.now = now
+		return nil
+	}
+}
+
+// ParseRSAPublicKeyPEM accepts both PKIX ("PUBLIC KEY") and PKCS#1 ("RSA PUBLIC KEY") encodings.
+func ParseRSAPublicKeyPEM(data []byte) (*rsa.PublicKey, error) {
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return nil, ErrInvalidPublicKey
+	}
+
+	if key, err := x509.ParsePKCS1PublicKey(block.Bytes); err == nil {
+		return key, nil
+	}
+
+	key, err := x509.ParsePKIXPublicKey(block.Bytes)
+	if err != nil {
+		return nil, ErrInvalidPublicKey
+	}
+
+	rsaKey, ok := key.(*rsa.PublicKey)
+	if !ok {
+		return nil, ErrInvalidPublicKey
+	}
+
+	return rsaKey, nil
+}
+
+type jwtHeader struct {
+	Alg string `json:"alg"`
+	Typ string `json:"typ"`
+}
+
+type jwtClaims struct {
+	Subject   string   `json:"sub"`
+	UserID    uint     `json:"uid,omitempty"`
+	Issuer    string   `json:"iss,omitempty"`
+	Audience  audience `json:"aud,omitempty"`
+	IssuedAt  *int64   `json:"iat,omitempty"`
+	ExpiresAt *int64   `json:"exp,omitempty"`
+	NotBefore *int64   `json:"nbf,omitempty"`
+	Scope     string   `json:"scope,omitempty"`
+}
+
+// audience accepts both a single string and an array of strings, as allowed by RFC 7519.
+type audience []string
+
+func (a *audience) UnmarshalJSON(input []byte) error {
+	var single string
+	if err := json.Unmarshal(input, &single); err == nil {
+		*a = audience{single}
+		return nil
+	}
+
+	var multiple []string
+	if err := json.Unmarshal(input, &multiple); err != nil {
+		return err
+	}
+
+	*a = multiple
+	return nil
+}
+
+func (a *JWTAuthenticator) Authenticate(r *http.Request) (*Principal, error) {
+	header := r.Header.Get("Authorization")
+	if !strings.HasPrefix(header, bearerPrefix) {
+		return nil, ErrNoCredentials
+	}
+
+	claims, err := a.verify(strings.TrimSpace(strings.TrimPrefix(header, bearerPrefix)))
+	if err != nil {
+		return nil, err
+	}
+
+	return &Principal{Subject: claims.Subject, UserID: claims.UserID, Scope: scopeFromClaim(claims.Scope)}, nil
+}
+
+func (a *JWTAuthenticator) verify(token string) (*jwtClaims, error) {
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 {
+		return nil, ErrInvalidToken
+	}
+
+	var header jwtHeader
+	if err := decodeSegment(parts[0], &header); err != nil {
+		return nil, ErrInvalidToken
+	}
+
+	signature, err := base64.RawURLEncoding.DecodeString(parts[2])
+	if err != nil {
+		return nil, ErrInvalidToken
+	}
+
+	if err = a.verifySignature(header.Alg, parts[0]+"."+parts[1], signature); err != nil {
+		return nil, err
+	}
+
+	var claims jwtClaims
+	if err = decodeSegment(parts[1], &claims); err != nil {
+		return nil, ErrInvalidToken
+	}
+
+	if err = a.validateClaims(&claims); err != nil {
+		return nil, err
+	}
+
+	return &claims, nil
+}
+
+func (a *JWTAuthenticator) verifySignature(alg, signingInput string, signature []byte) error {
+	switch alg {
+	case AlgHS256:
+		if len(a.hmacSecret) == 0 {
+			return ErrUnsupportedAlg
+		}
+
+		mac := hmac.New(sha256.New, a.hmacSecret)
+		mac.Write([]byte(signingInput))
+		if !hmac.Equal(signature, mac.Sum(nil)) {
+			return ErrInvalidToken
+		}
+
+		return nil
+	case AlgRS256:
+		if a.publicKey == nil {
+			return ErrUnsupportedAlg
+		}
+
+		digest := sha256.Sum256([]byte(signingInput))
+		if err := rsa.VerifyPKCS1v15(a.publicKey, crypto.SHA256, digest[:], signature); err != nil {
+			return ErrInvalidToken
+		}
+
+		return nil
+	}
+
+	return ErrUnsupportedAlg
+}
+
+func (a *JWTAuthenticator) validateClaims(claims *jwtClaims) error {
+	now := a.now()
+
+	if claims.ExpiresAt == nil {
+		return ErrInvalidToken
+	}
+
+	if now.After(time.Unix(*claims.ExpiresAt, 0).Add(a.leeway)) {
+		return ErrTokenExpired
+	}
+
+	if claims.NotBefore != nil && now.Add(a.leeway).Before(time.Unix(*claims.NotBefore, 0)) {
+		return ErrInvalidToken
+	}
+
+	if a.issuer != "" && claims.Issuer != a.issuer {
+		return ErrInvalidToken
+	}
+
+	if a.audience != "" && !claims.Audience.contains(a.audience) {
+		return ErrInvalidToken
+	}
+
+	return nil
+}
+
+func (a audience) contains(value string) bool {
+	for _, v := range a {
+		if v == value {
+			return true
+		}
+	}
+
+	return false
+}
+
+func decodeSegment(segment string, v any) error {
+	data, err := base64.RawURLEncoding.DecodeString(segment)
+	if err != nil {
+		return err
+	}
+
+	return json.Unmarshal(data, v)
+}
+
+// scopeFromClaim maps the space-delimited OAuth "scope" claim to a Scope.
+// A token without the "write" or "admin" scope is read-only.
+func scopeFromClaim(claim string) Scope {
+	scope := ScopeRead
+
+	for _, s := range strings.Fields(claim) {
+		switch s {
+		case string(ScopeAdmin):
+			return ScopeAdmin
+		case "write", string(ScopeReadWrite):
+			scope = ScopeReadWrite
+		}
+	}
+
+	return scope
+}
@@ -0,0 +1,252 @@
+package auth_test
+
+import (
+	"crypto"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"net/http"
+	"testing"
+	"time"
+
+	"git.home/alex/go-subscriptions/internal/auth"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var now = time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+
+func encodeSegment(t *testing.T, v any) string {
+	t.Helper()
+
+	data, err := json.Marshal(v)
+	require.NoError(t, err)
+
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+func signHS256(t *testing.T, secret []byte, claims map[string]any) string {
+	t.Helper()
+
+	input := encodeSegment(t, map[string]string{"alg": "HS256", "typ": "JWT"}) + "." + encodeSegment(t, claims)
+	mac := hmac.New(sha256.New, secret)
+	mac.Write([]byte(input))
+
+	return input + "." + base64.RawURLEncoding.EncodeToString(mac.Sum(nil))
+}
+
+func signRS256(t *testing.T, key *rsa.PrivateKey, claims map[string]any) string {
+	t.Helper()
+
+	input := encodeSegment(t, map[string]string{"alg": "RS256", "typ": "JWT"}) + "." + encodeSegment(t, claims)
+	digest := sha256.Sum256([]byte(input))
+	signature, err := rsa.SignPKCS1v15(rand.Reader, key, crypto.SHA256, digest[:])
+	require.NoError(t, err)
+
+	return input + "." + base64.RawURLEncoding.EncodeToString(signature)
+}
+
+func TestJWTAuthenticator_Authenticate(t *testing.T) {
+	secret := []byte("test-secret")
+	rsaKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+
+	a, err := auth.NewJWTAuthenticator(
+		auth.WithHS256Secret(secret),
+		auth.WithRS256PublicKey(&rsaKey.PublicKey),
+		auth.WithIssuer("issuer"),
+		auth.WithAudience("subscriptions"),
+		auth.WithClock(func() time.Time { return now }),
+	)
+	require.NoError(t, err)
+
+	valid := map[string]any{
+		"sub":   "alice",
+		"iss":   "issuer",
+		"aud":   []string{"other", "subscriptions"},
+		"exp":   now.Add(time.Hour).Unix(),
+		"scope": "read write",
+	}
+
+	with := func(key string, value any) map[string]any {
+		claims := make(map[string]any, len(valid))
+		for k, v := range valid {
+			claims[k] = v
+		}
+		claims[key] = value
+
+		return claims
+	}
+
+	testCases := []struct {
+		name       string
+		header     string
+		wantResult *auth.Principal
+		wantErr    error
+	}{
+		{
+			name:       "Valid HS256 token",
+			header:     "Bearer " + signHS256(t, secret, valid),
+			wantResult: &auth.Principal{Subject: "alice", Scope: auth.ScopeReadWrite},
+		},
+		{
+			name:       "Valid RS256 token",
+			header:     "Bearer " + signRS256(t, rsaKey, valid),
+			wantResult: &auth.Principal{Subject: "alice", Scope: auth.ScopeReadWrite},
+		},
+		{
+			name:       "Read-only token",
+			header:     "Bearer " + signHS256(t, secret, with("scope", "read")),
+			wantResult: &auth.Principal{Subject: "alice", Scope: auth.ScopeRead},
+		},
+		{
+			name:       "Single audience",
+			header:     "Bearer " + signHS256(t, secret, with("aud", "subscriptions")),
+			wantResult: &auth.Principal{Subject: "alice", Scope: auth.ScopeReadWrite},
+		},
+		{
+			name:       "Admin token",
+			header:     "Bearer " + signHS256(t, secret, with("scope", "read admin")),
+			wantResult: &auth.Principal{Subject: "alice", Scope: auth.ScopeAdmin},
+		},
+		{
+			name:       "User token",
+			header:     "Bearer " + signHS256(t, secret, with("uid", 7)),
+			wantResult: &auth.Principal{Subject: "alice", UserID: 7, Scope: auth.ScopeReadWrite},
+		},
+		{
+			name:    "Wrong secret",
+			header:  "Bearer " + signHS256(t, []byte("other"), valid),
+			wantErr: auth.ErrInvalidToken,
+		},
+		{
+			name:    "Expired token",
+			header:  "Bearer " + signHS256(t, secret, with("exp", now.Add(-time.Minute).Unix())),
+			wantErr: auth.ErrTokenExpired,
+		},
+		{
+			name:    "Token without expiry",
+			header:  "Bearer " + signHS256(t, secret, with("exp", nil)),
+			wantErr: auth.ErrInvalidToken,
+		},
+		{
+			name:    "Not yet valid token",
+			header:  "Bearer " + signHS256(t, secret, with("nbf", now.Add(time.Minute).Unix())),
+			wantErr: auth.ErrInvalidToken,
+		},
+		{
+			name:    "Wrong issuer",
+			header:  "Bearer " + signHS256(t, secret, with("iss", "other")),
+			wantErr: auth.ErrInvalidToken,
+		},
+		{
+			name:    "Wrong audience",
+			header:  "Bearer " + signHS256(t, secret, with("aud", "other")),
+			wantErr: auth.ErrInvalidToken,
+		},
+		{
+			name:    "Unsigned token",
+			header:  "Bearer " + encodeSegment(t, map[string]string{"alg": "none"}) + "." + encodeSegment(t, valid) + ".",
+			wantErr: auth.ErrUnsupportedAlg,
+		},
+		{
+			name:    "Malformed token",
+			header:  "Bearer abc",
+			wantErr: auth.ErrInvalidToken,
+		},
+		{
+			name:    "No bearer token",
+			header:  "Basic abc",
+			wantErr: auth.ErrNoCredentials,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			r := &http.Request{Header: http.Header{}}
+			r.Header.Set("Authorization", tc.header)
+
+			result, err := a.Authenticate(r)
+
+			if tc.wantErr != nil {
+				assert.ErrorIs(t, err, tc.wantErr)
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, tc.wantResult, result)
+		})
+	}
+}
+
+func TestParseRSAPublicKeyPEM(t *testing.T) {
+	rsaKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+
+	pkix, err := x509.MarshalPKIXPublicKey(&rsaKey.PublicKey)
+	require.NoError(t, err)
+
+	testCases := []struct {
+		name    string
+		data    []byte
+		wantErr error
+	}{
+		{
+			name: "PKIX",
+			data: pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: pkix}),
+		},
+		{
+			name: "PKCS1",
+			data: pem.EncodeToMemory(&pem.Block{Type: "RSA PUBLIC KEY", Bytes: x509.MarshalPKCS1PublicKey(&rsaKey.PublicKey)}),
+		},
+		{
+			name:    "Not a PEM",
+			data:    []byte("garbage"),
+			wantErr: auth.ErrInvalidPublicKey,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			key, err := auth.ParseRSAPublicKeyPEM(tc.data)
+
+			if tc.wantErr != nil {
+				assert.ErrorIs(t, err, tc.wantErr)
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.True(t, rsaKey.PublicKey.Equal(key))
+		})
+	}
+}
+
+func TestTokenIssuer_Issue(t *testing.T) {
+	secret := []byte("test-secret")
+
+	issuer := auth.NewTokenIssuer(secret, "issuer", "subscriptions", time.Hour)
+	token, err := issuer.Issue("alice", 7, auth.ScopeReadWrite)
+	require.NoError(t, err)
+
+	a, err := auth.NewJWTAuthenticator(
+		auth.WithHS256Secret(secret),
+		auth.WithIssuer("issuer"),
+		auth.WithAudience("subscriptions"),
+	)
+	require.NoError(t, err)
+
+	r := &http.Request{Header: http.Header{}}
+	r.Header.Set("Authorization", "Bearer "+token.AccessToken)
+
+	principal, err := a.Authenticate(r)
+	assert.NoError(t, err)
+	assert.Equal(t, &auth.Principal{Subject: "alice", UserID: 7, Scope: auth.ScopeReadWrite}, principal)
+
+	_, err = auth.NewTokenIssuer(nil, "", "", 0).Issue("alice", 7, auth.ScopeRead)
+	assert.ErrorIs(t, err, auth.ErrIssuerNotConfigured)
+}
@@ -0,0 +1,92 @@
+package auth
+
+import (
+	"context"
+	"errors"
+	"net/http"
+
+	"git.home/alex/go-subscriptions/internal/clock"
+)
+
+var (
+	ErrInvalidScope = errors.New("the scope is not valid")
+)
+
+type Scope string
+
+const (
+	ScopeRead      Scope = "read"
+	ScopeReadWrite Scope = "read-write"
+	// ScopeAdmin allows everything read-write does and acting on the data of all users.
+	ScopeAdmin Scope = "admin"
+)
+
+func ParseScope(s string) (Scope, error) {
+	switch Scope(s) {
+	case ScopeRead, ScopeReadWrite, ScopeAdmin:
+		return Scope(s), nil
+	case "":
+		return ScopeRead, nil
+	}
+
+	return "", ErrInvalidScope
+}
+
+// Principal acts on the data of the user UserID, or on the data of all users with the admin scope.
+// A principal without a user and without the admin scope acts on the data no user owns, which is
+// all the data created before user accounts existed.
+type Principal struct {
+	Subject string
+	UserID  uint
+	Scope   Scope
+}
+
+// Allows reports whether the principal may perform a request with the given HTTP method.
+// Safe methods only need read access, everything else requires read-write.
+func (p Principal) Allows(method string) bool {
+	switch method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions:
+		return p.Scope == ScopeRead || p.Scope == ScopeReadWrite || p.Scope == ScopeAdmin
+	}
+
+	return p.Scope == ScopeReadWrite || p.Scope == ScopeAdmin
+}
+
+type principalKey struct{}
+
+func WithPrincipal(ctx context.Context, principal *Principal) context.Context {
+	return context.WithValue(ctx, principalKey{}, principal)
+}
+
+func PrincipalFromContext(ctx context.Context) (*Principal, bool) {
+	principal, ok := ctx.Value(principalKey{}).(*Principal)
+
+	return principal, ok && principal != nil
+}
+
+// IsAdmin reports whether the call made with ctx may act on the data of all users:
+// there is no principal (authentication disabled, CLI) or it has the admin scope.
+func IsAdmin(ctx context.Context) bool {
+	principal, ok := PrincipalFromContext(ctx)
+
+	return !ok || principal.Scope == ScopeAdmin
+}
+
+// RequestContext copies the principal authenticated for r and the user's time zone into ctx, so that
+// services called with a long-lived context still act on behalf of the request's user.
+func RequestContext(ctx context.Context, r *http.Request) context.Context {
+	if r == nil {
+		return ctx
+	}
+
+	if loc, ok := clock.FromContext(r.Context()); ok {
+		ctx = clock.WithLocation(ctx, loc)
+	}
+
+	principal, ok := PrincipalFromContext(r.Context())
+	if !ok {
+		return ctx
+	}
+
+	return WithPrincipal(ctx, principal)
+}
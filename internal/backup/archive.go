@@ -0,0 +1,381 @@
+package backup
+
+import (
+	"time"
+
+	"git.home/alex/go-subscriptions/internal/domain/entity"
+)
+
+// Version of the archive format. Bump it when a change can't be read by older releases;
+// new entities are added as new optional sections and don't need a bump. Version 2 added the
+// calendar flag of cycles, which version 1 implied for every cycle of 30 or 365 days.
+const Version = 2
+
+// Archive is a storage independent snapshot of every entity. References are kept as IDs
+// (currencies by code) and resolved again on restore.
+type Archive struct {
+	Version        int             `json:"version"`
+	CreatedAt      time.Time       `json:"created_at"`
+	Users          []User          `json:"users"`
+	Currencies     []Currency      `json:"currencies"`
+	Cycles         []Cycle         `json:"cycles"`
+	Categories     []Category      `json:"categories"`
+	Tags           []Tag           `json:"tags"`
+	PaymentMethods []PaymentMethod `json:"payment_methods"`
+	Assets         []Asset         `json:"assets"`
+	Households     []Household     `json:"households"`
+	Subscriptions  []Subscription  `json:"subscriptions"`
+	Attachments    []Attachment    `json:"attachments"`
+	Budgets        []Budget        `json:"budgets"`
+}
+
+// Counts returns the number of entities per section.
+func (a *Archive) Counts() map[string]int {
+	return map[string]int{
+		"users":           len(a.Users),
+		"currencies":      len(a.Currencies),
+		"cycles":          len(a.Cycles),
+		"categories":      len(a.Categories),
+		"tags":            len(a.Tags),
+		"payment_methods": len(a.PaymentMethods),
+		"assets":          len(a.Assets),
+		"households":      len(a.Households),
+		"subscriptions":   len(a.Subscriptions),
+		"attachments":     len(a.Attachments),
+		"budgets":         len(a.Budgets),
+	}
+}
+
+type User struct {
+	ID           uint   `json:"id"`
+	Username     string `json:"username"`
+	PasswordHash string `json:"password_hash"`
+	Timezone     string `json:"timezone,omitempty"`
+}
+
+type Currency struct {
+	Code   string `json:"code"`
+	Symbol string `json:"symbol"`
+	Name   string `json:"name"`
+	UserID uint   `json:"user_id,omitempty"`
+}
+
+type Cycle struct {
+	ID       uint   `json:"id"`
+	Name     string `json:"name"`
+	Days     uint   `json:"days"`
+	Rule     string `json:"rule,omitempty"`
+	Calendar bool   `json:"calendar,omitempty"`
+	UserID   uint   `json:"user_id,omitempty"`
+}
+
+type Category struct {
+	ID       uint   `json:"id"`
+	Name     string `json:"name"`
+	UserID   uint   `json:"user_id,omitempty"`
+	ParentID uint   `json:"parent_id,omitempty"`
+}
+
+type Tag struct {
+	ID     uint   `json:"id"`
+	Name   string `json:"name"`
+	UserID uint   `json:"user_id,omitempty"`
+}
+
+type PaymentMethod struct {
+	ID          uint   `json:"id"`
+	UserID      uint   `json:"user_id,omitempty"`
+	Label       string `json:"label"`
+	Type        string `json:"type"`
+	LastFour    string `json:"last_four,omitempty"`
+	ExpiryYear  int    `json:"expiry_year,omitempty"`
+	ExpiryMonth int    `json:"expiry_month,omitempty"`
+}
+
+// Asset carries the image and its thumbnail, base64 encoded, so the archive alone restores the logos.
+// The thumbnail has no checksum of its own on the entity, so the archive records one.
+type Asset struct {
+	ID                uint      `json:"id"`
+	UserID            uint      `json:"user_id,omitempty"`
+	ContentType       string    `json:"content_type"`
+	Size              int64     `json:"size"`
+	Checksum          string    `json:"checksum"`
+	CreatedAt         time.Time `json:"created_at"`
+	Content           []byte    `json:"content"`
+	Thumbnail         []byte    `json:"thumbnail"`
+	ThumbnailChecksum string    `json:"thumbnail_checksum"`
+}
+
+type Household struct {
+	ID      uint              `json:"id"`
+	UserID  uint              `json:"user_id,omitempty"`
+	Name    string            `json:"name"`
+	Members []HouseholdMember `json:"members"`
+}
+
+type HouseholdMember struct {
+	ID     uint   `json:"id"`
+	Name   string `json:"name"`
+	UserID uint   `json:"user_id,omitempty"`
+}
+
+type Subscription struct {
+	ID              uint               `json:"id"`
+	UserID          uint               `json:"user_id,omitempty"`
+	Name            string             `json:"name"`
+	Note            string             `json:"note,omitempty"`
+	Logo            string             `json:"logo,omitempty"`
+	Price           float64            `json:"price"`
+	CategoryID      uint               `json:"category_id,omitempty"`
+	CycleID         uint               `json:"cycle_id"`
+	CurrencyCode    string             `json:"currency"`
+	NextPaymentDate time.Time          `json:"next_payment_date"`
+	Share           *SubscriptionShare `json:"share,omitempty"`
+	Status          string             `json:"status,omitempty"`
+	TrialStartDate  *time.Time         `json:"trial_start_date,omitempty"`
+	TrialEndDate    *time.Time         `json:"trial_end_date,omitempty"`
+	ResumeDate      *time.Time         `json:"resume_date,omitempty"`
+	CancelDate      *time.Time         `json:"cancel_date,omitempty"`
+	Prices          []PriceChange      `json:"prices,omitempty"`
+	TagIDs          []uint             `json:"tag_ids,omitempty"`
+	PaymentMethodID uint               `json:"payment_method_id,omitempty"`
+	Contract        *Contract          `json:"contract,omitempty"`
+	LogoAssetID     uint               `json:"logo_asset_id,omitempty"`
+}
+
+// Attachment carries its content, base64 encoded, so the archive alone restores the files.
+type Attachment struct {
+	ID             uint       `json:"id"`
+	UserID         uint       `json:"user_id,omitempty"`
+	SubscriptionID uint       `json:"subscription_id"`
+	PaymentDate    *time.Time `json:"payment_date,omitempty"`
+	Name           string     `json:"name"`
+	ContentType    string     `json:"content_type"`
+	Checksum       string     `json:"checksum"`
+	CreatedAt      time.Time  `json:"created_at"`
+	Content        []byte     `json:"content"`
+}
+
+type Contract struct {
+	StartDate  time.Time `json:"start_date"`
+	EndDate    time.Time `json:"end_date"`
+	NoticeDays uint      `json:"notice_days,omitempty"`
+	AutoRenew  bool      `json:"auto_renew,omitempty"`
+}
+
+// PriceChange has no effective date for the price the subscription started with.
+type PriceChange struct {
+	Price         float64    `json:"price"`
+	EffectiveDate *time.Time `json:"effective_date,omitempty"`
+}
+
+type SubscriptionShare struct {
+	HouseholdID uint        `json:"household_id"`
+	Rule        string      `json:"rule"`
+	Parts       []SharePart `json:"parts,omitempty"`
+}
+
+type SharePart struct {
+	MemberID uint    `json:"member_id"`
+	Value    float64 `json:"value"`
+}
+
+type Budget struct {
+	ID           uint    `json:"id"`
+	UserID       uint    `json:"user_id,omitempty"`
+	CategoryID   uint    `json:"category_id,omitempty"`
+	Period       string  `json:"period"`
+	Amount       float64 `json:"amount"`
+	CurrencyCode string  `json:"currency"`
+}
+
+func newHousehold(h entity.Household) Household {
+	household := Household{ID: h.ID, UserID: h.UserID, Name: h.Name, Members: []HouseholdMember{}}
+	for _, m := range h.Members {
+		household.Members = append(household.Members, HouseholdMember{ID: m.ID, Name: m.Name, UserID: m.UserID})
+	}
+
+	return household
+}
+
+func (h Household) entity() entity.Household {
+	household := entity.Household{ID: h.ID, UserID: h.UserID, Name: h.Name}
+	for _, m := range h.Members {
+		household.Members = append(household.Members, entity.HouseholdMember{ID: m.ID, Name: m.Name, UserID: m.UserID})
+	}
+
+	return household
+}
+
+func (c Contract) entity() entity.Contract {
+	return entity.Contract{
+		StartDate:  entity.PaymentDate(c.StartDate),
+		EndDate:    entity.PaymentDate(c.EndDate),
+		NoticeDays: c.NoticeDays,
+		AutoRenew:  c.AutoRenew,
+	}
+}
+
+func newSubscription(s entity.Subscription) Subscription {
+	subscription := Subscription{
+		ID:              s.ID,
+		UserID:          s.UserID,
+		Name:            s.Name,
+		Note:            s.Note,
+		Logo:            s.Logo,
+		Price:           s.Price,
+		CategoryID:      s.Category.ID,
+		CycleID:         s.Cycle.ID,
+		CurrencyCode:    s.Currency.Code,
+		NextPaymentDate: time.Time(s.NextPaymentDate),
+		Status:          string(s.Status),
+		TrialStartDate:  optionalDate(s.TrialStartDate),
+		TrialEndDate:    optionalDate(s.TrialEndDate),
+		ResumeDate:      optionalDate(s.ResumeDate),
+		CancelDate:      optionalDate(s.CancelDate),
+		TagIDs:          s.TagIDs,
+		PaymentMethodID: s.PaymentMethodID,
+		LogoAssetID:     s.LogoAssetID,
+	}
+
+	if s.Share != nil {
+		share := &SubscriptionShare{HouseholdID: s.Share.HouseholdID, Rule: string(s.Share.Rule)}
+		for _, p := range s.Share.Parts {
+			share.Parts = append(share.Parts, SharePart{MemberID: p.MemberID, Value: p.Value})
+		}
+
+		subscription.Share = share
+	}
+
+	if c := s.Contract; c != nil {
+		subscription.Contract = &Contract{
+			StartDate:  time.Time(c.StartDate),
+			EndDate:    time.Time(c.EndDate),
+			NoticeDays: c.NoticeDays,
+			AutoRenew:  c.AutoRenew,
+		}
+	}
+
+	for _, p := range s.Prices {
+		subscription.Prices = append(subscription.Prices, PriceChange{Price: p.Price, EffectiveDate: optionalDate(p.EffectiveDate)})
+	}
+
+	return subscription
+}
+
+func newPaymentMethod(m entity.PaymentMethod) PaymentMethod {
+	return PaymentMethod{
+		ID:          m.ID,
+		UserID:      m.UserID,
+		Label:       m.Label,
+		Type:        string(m.Type),
+		LastFour:    m.LastFour,
+		ExpiryYear:  m.ExpiryYear,
+		ExpiryMonth: int(m.ExpiryMonth),
+	}
+}
+
+func (m PaymentMethod) entity() entity.PaymentMethod {
+	return entity.PaymentMethod{
+		ID:          m.ID,
+		UserID:      m.UserID,
+		Label:       m.Label,
+		Type:        entity.PaymentMethodType(m.Type),
+		LastFour:    m.LastFour,
+		ExpiryYear:  m.ExpiryYear,
+		ExpiryMonth: time.Month(m.ExpiryMonth),
+	}
+}
+
+func newAsset(a entity.Asset, content, thumbnail []byte) Asset {
+	return Asset{
+		ID:                a.ID,
+		UserID:            a.UserID,
+		ContentType:       a.ContentType,
+		Size:              a.Size,
+		Checksum:          a.Checksum,
+		CreatedAt:         a.CreatedAt,
+		Content:           content,
+		Thumbnail:         thumbnail,
+		ThumbnailChecksum: checksum(thumbnail),
+	}
+}
+
+func (a Asset) entity() entity.Asset {
+	return entity.Asset{
+		ID:          a.ID,
+		UserID:      a.UserID,
+		ContentType: a.ContentType,
+		Size:        a.Size,
+		Checksum:    a.Checksum,
+		CreatedAt:   a.CreatedAt,
+	}
+}
+
+func newAttachment(a entity.Attachment, content []byte) Attachment {
+	return Attachment{
+		ID:             a.ID,
+		UserID:         a.UserID,
+		SubscriptionID: a.SubscriptionID,
+		PaymentDate:    optionalDate(a.PaymentDate),
+		Name:           a.Name,
+		ContentType:    a.ContentType,
+		Checksum:       a.Checksum,
+		CreatedAt:      a.CreatedAt,
+		Content:        content,
+	}
+}
+
+func (a Attachment) entity() entity.Attachment {
+	return entity.Attachment{
+		ID:             a.ID,
+		UserID:         a.UserID,
+		SubscriptionID: a.SubscriptionID,
+		PaymentDate:    paymentDate(a.PaymentDate),
+		Name:           a.Name,
+		ContentType:    a.ContentType,
+		Size:           int64(len(a.Content)),
+		Checksum:       a.Checksum,
+		CreatedAt:      a.CreatedAt,
+	}
+}
+
+func newBudget(b entity.Budget) Budget {
+	return Budget{
+		ID:           b.ID,
+		UserID:       b.UserID,
+		CategoryID:   b.CategoryID,
+		Period:       string(b.Period),
+		Amount:       b.Amount,
+		CurrencyCode: b.CurrencyCode,
+	}
+}
+
+func (b Budget) entity() entity.Budget {
+	return entity.Budget{
+		ID:           b.ID,
+		UserID:       b.UserID,
+		CategoryID:   b.CategoryID,
+		Period:       entity.BudgetPeriod(b.Period),
+		Amount:       b.Amount,
+		CurrencyCode: b.CurrencyCode,
+	}
+}
+
+func optionalDate(date entity.PaymentDate) *time.Time {
+	if time.Time(date).IsZero() {
+		return nil
+	}
+
+	t := time.Time(date)
+
+	return &t
+}
+
+func paymentDate(t *time.Time) entity.PaymentDate {
+	if t == nil {
+		return entity.PaymentDate{}
+	}
+
+	return entity.PaymentDate(*t)
+}
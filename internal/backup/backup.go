@@ -0,0 +1,621 @@
+package backup
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"time"
+
+	"git.home/alex/go-subscriptions/internal/blobstore"
+	"git.home/alex/go-subscriptions/internal/domain/entity"
+	"git.home/alex/go-subscriptions/internal/domain/service"
+	"git.home/alex/go-subscriptions/internal/factory"
+)
+
+var (
+	ErrInvalidArchive     = errors.New("the backup archive is not valid")
+	ErrUnsupportedVersion = errors.New("the backup archive version is not supported")
+	ErrStorageNotEmpty    = errors.New("the storage is not empty")
+)
+
+// Dump reads every entity from the repositories into an archive.
+func Dump(ctx context.Context, rf *factory.RepositoryFactory) (*Archive, error) {
+	return dump(ctx, rf, true)
+}
+
+// dump reads the archive from the repositories. Without blobs the assets and attachments leave
+// their content out and only carry the checksums of it, which is enough to count and compare them.
+func dump(ctx context.Context, rf *factory.RepositoryFactory, blobs bool) (*Archive, error) {
+	a := &Archive{
+		Version:        Version,
+		CreatedAt:      time.Now().UTC(),
+		Users:          []User{},
+		Currencies:     []Currency{},
+		Cycles:         []Cycle{},
+		Categories:     []Category{},
+		Tags:           []Tag{},
+		PaymentMethods: []PaymentMethod{},
+		Assets:         []Asset{},
+		Households:     []Household{},
+		Subscriptions:  []Subscription{},
+		Attachments:    []Attachment{},
+		Budgets:        []Budget{},
+	}
+
+	users, err := rf.UserRepository.GetAll(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, u := range users {
+		a.Users = append(a.Users, User{ID: u.ID, Username: u.Username, PasswordHash: u.PasswordHash, Timezone: u.Timezone})
+	}
+
+	currencies, err := rf.CurrencyRepository.GetAll(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, c := range currencies {
+		a.Currencies = append(a.Currencies, Currency{Code: c.Code, Symbol: c.Symbol, Name: c.Name, UserID: c.UserID})
+	}
+
+	cycles, err := rf.CycleRepository.GetAll(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, c := range cycles {
+		a.Cycles = append(a.Cycles, Cycle{ID: c.ID, Name: c.Name, Days: c.Days, Rule: c.Rule, Calendar: c.Calendar, UserID: c.UserID})
+	}
+
+	categories, err := rf.CategoryRepository.GetAll(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, c := range categories {
+		a.Categories = append(a.Categories, Category{ID: c.ID, Name: c.Name, UserID: c.UserID, ParentID: c.ParentID})
+	}
+
+	tags, err := rf.TagRepository.GetAll(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, t := range tags {
+		a.Tags = append(a.Tags, Tag{ID: t.ID, Name: t.Name, UserID: t.UserID})
+	}
+
+	methods, err := rf.PaymentMethodRepository.GetAll(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, m := range methods {
+		a.PaymentMethods = append(a.PaymentMethods, newPaymentMethod(m))
+	}
+
+	assets, err := rf.AssetRepository.GetAll(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, asset := range assets {
+		if !blobs {
+			a.Assets = append(a.Assets, newAsset(asset, nil, nil))
+			continue
+		}
+
+		content, err := readBlob(ctx, rf.Blobs, service.AssetKey(asset.ID, false))
+		if err != nil {
+			return nil, fmt.Errorf("asset %d: %w", asset.ID, err)
+		}
+
+		thumbnail, err := readBlob(ctx, rf.Blobs, service.AssetKey(asset.ID, true))
+		if err != nil {
+			return nil, fmt.Errorf("asset %d thumbnail: %w", asset.ID, err)
+		}
+
+		a.Assets = append(a.Assets, newAsset(asset, content, thumbnail))
+	}
+
+	households, err := rf.HouseholdRepository.GetAll(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, h := range households {
+		a.Households = append(a.Households, newHousehold(h))
+	}
+
+	subscriptions, err := rf.SubscriptionRepository.GetAll(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, s := range subscriptions {
+		a.Subscriptions = append(a.Subscriptions, newSubscription(s))
+	}
+
+	attachments, err := archivedAttachments(ctx, rf)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, attachment := range attachments {
+		if !blobs {
+			a.Attachments = append(a.Attachments, newAttachment(attachment, nil))
+			continue
+		}
+
+		content, err := readBlob(ctx, rf.Blobs, service.AttachmentKey(attachment.ID))
+		if err != nil {
+			return nil, fmt.Errorf("attachment %d: %w", attachment.ID, err)
+		}
+
+		a.Attachments = append(a.Attachments, newAttachment(attachment, content))
+	}
+
+	budgets, err := rf.BudgetRepository.GetAll(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, b := range budgets {
+		a.Budgets = append(a.Budgets, newBudget(b))
+	}
+
+	return a, nil
+}
+
+// Restore writes the archive into empty repositories, keeping the original IDs. The archive is
+// validated before anything is written, and a restore that fails halfway is rolled back.
+func Restore(ctx context.Context, rf *factory.RepositoryFactory, a *Archive) error {
+	err := Validate(a)
+	if err != nil {
+		return err
+	}
+
+	empty, err := isEmpty(ctx, rf)
+	if err != nil {
+		return err
+	}
+
+	if !empty {
+		return ErrStorageNotEmpty
+	}
+
+	var undo undoLog
+
+	err = restore(ctx, rf, a, &undo)
+	if err != nil {
+		// The storage was empty, so deleting what the restore wrote leaves it as it was found.
+		return errors.Join(err, undo.rollback())
+	}
+
+	return nil
+}
+
+// Validate checks the archive version and that every reference points to an archived entity.
+func Validate(a *Archive) error {
+	if a.Version < 1 || a.Version > Version {
+		return fmt.Errorf("%w: %d", ErrUnsupportedVersion, a.Version)
+	}
+
+	cycles := make(map[uint]bool, len(a.Cycles))
+	for _, c := range a.Cycles {
+		if c.Rule != "" {
+			if _, err := entity.ParseRecurrence(c.Rule); err != nil {
+				return fmt.Errorf("%w: cycle %d has an invalid rule: %w", ErrInvalidArchive, c.ID, err)
+			}
+		}
+
+		cycles[c.ID] = true
+	}
+
+	categories := make(map[uint]bool, len(a.Categories))
+	for _, c := range a.Categories {
+		categories[c.ID] = true
+	}
+
+	for _, c := range a.Categories {
+		if c.ParentID != 0 && !categories[c.ParentID] {
+			return fmt.Errorf("%w: category %d references the unknown parent %d", ErrInvalidArchive, c.ID, c.ParentID)
+		}
+	}
+
+	if _, ok := parentsFirst(a.Categories); !ok {
+		return fmt.Errorf("%w: the category parents form a loop", ErrInvalidArchive)
+	}
+
+	currencies := make(map[string]bool, len(a.Currencies))
+	for _, c := range a.Currencies {
+		currencies[c.Code] = true
+	}
+
+	tags := make(map[uint]bool, len(a.Tags))
+	for _, t := range a.Tags {
+		tags[t.ID] = true
+	}
+
+	methods := make(map[uint]bool, len(a.PaymentMethods))
+	for _, m := range a.PaymentMethods {
+		methods[m.ID] = true
+	}
+
+	assets := make(map[uint]bool, len(a.Assets))
+	for _, asset := range a.Assets {
+		switch {
+		case checksum(asset.Content) != asset.Checksum:
+			return fmt.Errorf("%w: asset %d doesn't match its checksum", ErrInvalidArchive, asset.ID)
+		case checksum(asset.Thumbnail) != asset.ThumbnailChecksum:
+			return fmt.Errorf("%w: the thumbnail of asset %d doesn't match its checksum", ErrInvalidArchive, asset.ID)
+		}
+
+		assets[asset.ID] = true
+	}
+
+	households := make(map[uint]bool, len(a.Households))
+	for _, h := range a.Households {
+		households[h.ID] = true
+	}
+
+	for _, s := range a.Subscriptions {
+		switch {
+		case !cycles[s.CycleID]:
+			return fmt.Errorf("%w: subscription %d references the unknown cycle %d", ErrInvalidArchive, s.ID, s.CycleID)
+		case !currencies[s.CurrencyCode]:
+			return fmt.Errorf("%w: subscription %d references the unknown currency %q", ErrInvalidArchive, s.ID, s.CurrencyCode)
+		case s.CategoryID != 0 && !categories[s.CategoryID]:
+			return fmt.Errorf("%w: subscription %d references the unknown category %d", ErrInvalidArchive, s.ID, s.CategoryID)
+		case s.PaymentMethodID != 0 && !methods[s.PaymentMethodID]:
+			return fmt.Errorf("%w: subscription %d references the unknown payment method %d", ErrInvalidArchive, s.ID, s.PaymentMethodID)
+		case s.LogoAssetID != 0 && !assets[s.LogoAssetID]:
+			return fmt.Errorf("%w: subscription %d references the unknown logo asset %d", ErrInvalidArchive, s.ID, s.LogoAssetID)
+		case s.Share != nil && !households[s.Share.HouseholdID]:
+			return fmt.Errorf("%w: subscription %d references the unknown household %d", ErrInvalidArchive, s.ID, s.Share.HouseholdID)
+		case !entity.SubscriptionStatus(s.Status).Valid():
+			return fmt.Errorf("%w: subscription %d has the unknown status %q", ErrInvalidArchive, s.ID, s.Status)
+		case s.Contract != nil && !s.Contract.entity().Valid():
+			return fmt.Errorf("%w: subscription %d has an invalid contract", ErrInvalidArchive, s.ID)
+		}
+
+		for _, tagID := range s.TagIDs {
+			if !tags[tagID] {
+				return fmt.Errorf("%w: subscription %d references the unknown tag %d", ErrInvalidArchive, s.ID, tagID)
+			}
+		}
+	}
+
+	subscriptions := make(map[uint]bool, len(a.Subscriptions))
+	for _, s := range a.Subscriptions {
+		subscriptions[s.ID] = true
+	}
+
+	for _, at := range a.Attachments {
+		switch {
+		case !subscriptions[at.SubscriptionID]:
+			return fmt.Errorf("%w: attachment %d references the unknown subscription %d", ErrInvalidArchive, at.ID, at.SubscriptionID)
+		case checksum(at.Content) != at.Checksum:
+			return fmt.Errorf("%w: attachment %d doesn't match its checksum", ErrInvalidArchive, at.ID)
+		}
+	}
+
+	for _, b := range a.Budgets {
+		switch {
+		case b.CategoryID != 0 && !categories[b.CategoryID]:
+			return fmt.Errorf("%w: budget %d references the unknown category %d", ErrInvalidArchive, b.ID, b.CategoryID)
+		case !entity.BudgetPeriod(b.Period).Valid():
+			return fmt.Errorf("%w: budget %d has the unknown period %q", ErrInvalidArchive, b.ID, b.Period)
+		}
+	}
+
+	return nil
+}
+
+// restore writes the archive and records in undo how to delete every entity and file it wrote.
+func restore(ctx context.Context, rf *factory.RepositoryFactory, a *Archive, undo *undoLog) error {
+	for _, u := range a.Users {
+		user := entity.User{ID: u.ID, Username: u.Username, PasswordHash: u.PasswordHash, Timezone: u.Timezone}
+		_, err := rf.UserRepository.Restore(ctx, user)
+		if err != nil {
+			return err
+		}
+
+		deleteLater(ctx, undo, rf.UserRepository.Delete, u.ID)
+	}
+
+	currencies := make(map[string]entity.Currency, len(a.Currencies))
+	for _, c := range a.Currencies {
+		currencies[c.Code] = entity.Currency{Code: c.Code, Symbol: c.Symbol, Name: c.Name, UserID: c.UserID}
+
+		_, err := rf.CurrencyRepository.Restore(ctx, currencies[c.Code])
+		if err != nil {
+			return err
+		}
+
+		deleteLater(ctx, undo, rf.CurrencyRepository.Delete, c.Code)
+	}
+
+	cycles := make(map[uint]entity.Cycle, len(a.Cycles))
+	for _, c := range a.Cycles {
+		calendar := c.Calendar
+		if a.Version < 2 {
+			calendar = c.Rule == "" && (c.Days == entity.CalendarMonthDays || c.Days == entity.CalendarYearDays)
+		}
+
+		cycles[c.ID] = entity.Cycle{ID: c.ID, Name: c.Name, Days: c.Days, Rule: c.Rule, Calendar: calendar, UserID: c.UserID}
+
+		_, err := rf.CycleRepository.Restore(ctx, cycles[c.ID])
+		if err != nil {
+			return err
+		}
+
+		deleteLater(ctx, undo, rf.CycleRepository.Delete, c.ID)
+	}
+
+	// Validate made sure the parents can come first, which the foreign keys of the SQL storage need.
+	ordered, _ := parentsFirst(a.Categories)
+
+	categories := make(map[uint]entity.Category, len(a.Categories))
+	for _, c := range ordered {
+		categories[c.ID] = entity.Category{ID: c.ID, Name: c.Name, UserID: c.UserID, ParentID: c.ParentID}
+
+		_, err := rf.CategoryRepository.Restore(ctx, categories[c.ID])
+		if err != nil {
+			return err
+		}
+
+		deleteLater(ctx, undo, rf.CategoryRepository.Delete, c.ID)
+	}
+
+	for _, t := range a.Tags {
+		_, err := rf.TagRepository.Restore(ctx, entity.Tag{ID: t.ID, Name: t.Name, UserID: t.UserID})
+		if err != nil {
+			return err
+		}
+
+		deleteLater(ctx, undo, rf.TagRepository.Delete, t.ID)
+	}
+
+	for _, m := range a.PaymentMethods {
+		_, err := rf.PaymentMethodRepository.Restore(ctx, m.entity())
+		if err != nil {
+			return err
+		}
+
+		deleteLater(ctx, undo, rf.PaymentMethodRepository.Delete, m.ID)
+	}
+
+	for _, asset := range a.Assets {
+		err := rf.Blobs.Put(ctx, service.AssetKey(asset.ID, false), bytes.NewReader(asset.Content))
+		if err != nil {
+			return err
+		}
+
+		deleteLater(ctx, undo, rf.Blobs.Delete, service.AssetKey(asset.ID, false))
+
+		err = rf.Blobs.Put(ctx, service.AssetKey(asset.ID, true), bytes.NewReader(asset.Thumbnail))
+		if err != nil {
+			return err
+		}
+
+		deleteLater(ctx, undo, rf.Blobs.Delete, service.AssetKey(asset.ID, true))
+
+		_, err = rf.AssetRepository.Restore(ctx, asset.entity())
+		if err != nil {
+			return err
+		}
+
+		deleteLater(ctx, undo, rf.AssetRepository.Delete, asset.ID)
+	}
+
+	for _, h := range a.Households {
+		_, err := rf.HouseholdRepository.Restore(ctx, h.entity())
+		if err != nil {
+			return err
+		}
+
+		deleteLater(ctx, undo, rf.HouseholdRepository.Delete, h.ID)
+	}
+
+	for _, s := range a.Subscriptions {
+		subscription := entity.Subscription{
+			ID:              s.ID,
+			UserID:          s.UserID,
+			Price:           s.Price,
+			Category:        categories[s.CategoryID],
+			Currency:        currencies[s.CurrencyCode],
+			Cycle:           cycles[s.CycleID],
+			NextPaymentDate: entity.PaymentDate(s.NextPaymentDate),
+			Name:            s.Name,
+			Note:            s.Note,
+			Logo:            s.Logo,
+			Status:          entity.SubscriptionStatus(s.Status),
+			TrialStartDate:  paymentDate(s.TrialStartDate),
+			TrialEndDate:    paymentDate(s.TrialEndDate),
+			ResumeDate:      paymentDate(s.ResumeDate),
+			CancelDate:      paymentDate(s.CancelDate),
+			TagIDs:          s.TagIDs,
+			PaymentMethodID: s.PaymentMethodID,
+			LogoAssetID:     s.LogoAssetID,
+		}
+
+		if s.Share != nil {
+			subscription.Share = &entity.SubscriptionShare{HouseholdID: s.Share.HouseholdID, Rule: entity.SplitRule(s.Share.Rule)}
+			for _, p := range s.Share.Parts {
+				subscription.Share.Parts = append(subscription.Share.Parts, entity.SharePart{MemberID: p.MemberID, Value: p.Value})
+			}
+		}
+
+		if s.Contract != nil {
+			contract := s.Contract.entity()
+			subscription.Contract = &contract
+		}
+
+		for _, p := range s.Prices {
+			subscription.Prices = append(subscription.Prices, entity.PriceChange{Price: p.Price, EffectiveDate: paymentDate(p.EffectiveDate)})
+		}
+
+		_, err := rf.SubscriptionRepository.Restore(ctx, subscription)
+		if err != nil {
+			return err
+		}
+
+		deleteLater(ctx, undo, rf.SubscriptionRepository.Delete, s.ID)
+	}
+
+	for _, at := range a.Attachments {
+		err := rf.Blobs.Put(ctx, service.AttachmentKey(at.ID), bytes.NewReader(at.Content))
+		if err != nil {
+			return err
+		}
+
+		deleteLater(ctx, undo, rf.Blobs.Delete, service.AttachmentKey(at.ID))
+
+		_, err = rf.AttachmentRepository.Restore(ctx, at.entity())
+		if err != nil {
+			return err
+		}
+
+		deleteLater(ctx, undo, rf.AttachmentRepository.Delete, at.ID)
+	}
+
+	for _, b := range a.Budgets {
+		_, err := rf.BudgetRepository.Restore(ctx, b.entity())
+		if err != nil {
+			return err
+		}
+
+		deleteLater(ctx, undo, rf.BudgetRepository.Delete, b.ID)
+	}
+
+	return nil
+}
+
+// archivedAttachments returns the attachments that go into an archive. Attachments left behind by a
+// deleted subscription would fail Validate on restore, so they are left out.
+func archivedAttachments(ctx context.Context, rf *factory.RepositoryFactory) ([]entity.Attachment, error) {
+	subscriptions, err := rf.SubscriptionRepository.GetAll(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	subscriptionIDs := make(map[uint]bool, len(subscriptions))
+	for _, s := range subscriptions {
+		subscriptionIDs[s.ID] = true
+	}
+
+	attachments, err := rf.AttachmentRepository.GetAll(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	archived := make([]entity.Attachment, 0, len(attachments))
+
+	for _, attachment := range attachments {
+		if subscriptionIDs[attachment.SubscriptionID] {
+			archived = append(archived, attachment)
+		}
+	}
+
+	return archived, nil
+}
+
+// parentsFirst orders the categories so that every parent comes before its children. It reports
+// false when the parents form a loop and no such order exists.
+func parentsFirst(categories []Category) ([]Category, bool) {
+	ordered := make([]Category, 0, len(categories))
+	placed := make(map[uint]bool, len(categories))
+
+	for len(ordered) < len(categories) {
+		progress := false
+
+		for _, c := range categories {
+			if !placed[c.ID] && (c.ParentID == 0 || placed[c.ParentID]) {
+				ordered = append(ordered, c)
+				placed[c.ID] = true
+				progress = true
+			}
+		}
+
+		if !progress {
+			return nil, false
+		}
+	}
+
+	return ordered, true
+}
+
+// undoLog holds the deletes that take back a partial restore, in the order of the writes.
+type undoLog []func() error
+
+func deleteLater[K any](ctx context.Context, undo *undoLog, del func(context.Context, K) error, key K) {
+	*undo = append(*undo, func() error { return del(ctx, key) })
+}
+
+// rollback runs the deletes in reverse, so nothing is deleted before what references it.
+func (u undoLog) rollback() error {
+	errs := make([]error, 0, len(u))
+	for i := len(u) - 1; i >= 0; i-- {
+		errs = append(errs, u[i]())
+	}
+
+	return errors.Join(errs...)
+}
+
+func isEmpty(ctx context.Context, rf *factory.RepositoryFactory) (bool, error) {
+	a, err := dump(ctx, rf, false)
+	if err != nil {
+		return false, err
+	}
+
+	for _, n := range a.Counts() {
+		if n > 0 {
+			return false, nil
+		}
+	}
+
+	return true, nil
+}
+
+func readBlob(ctx context.Context, store blobstore.Store, key string) ([]byte, error) {
+	r, err := store.Open(ctx, key)
+	if err != nil {
+		return nil, err
+	}
+	defer r.Close()
+
+	return io.ReadAll(r)
+}
+
+// checksum is the hex encoded SHA-256 the services record for stored files.
+func checksum(data []byte) string {
+	sum := sha256.Sum256(data)
+
+	return hex.EncodeToString(sum[:])
+}
+
+func Write(w io.Writer, a *Archive) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+
+	return enc.Encode(a)
+}
+
+func Read(r io.Reader) (*Archive, error) {
+	var a Archive
+
+	err := json.NewDecoder(r).Decode(&a)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidArchive, err)
+	}
+
+	return &a, nil
+}
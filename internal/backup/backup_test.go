@@ -0,0 +1,432 @@
+package backup_test
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"git.home/alex/go-subscriptions/internal/backup"
+	"git.home/alex/go-subscriptions/internal/blobstore"
+	"git.home/alex/go-subscriptions/internal/domain/entity"
+	"git.home/alex/go-subscriptions/internal/domain/service"
+	"git.home/alex/go-subscriptions/internal/factory"
+	"git.home/alex/go-subscriptions/internal/repository/sqlite"
+	"git.home/alex/go-subscriptions/tests"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newRepositoryFactory(t *testing.T) *factory.RepositoryFactory {
+	t.Helper()
+
+	rf, err := factory.NewRepositoryFactory(factory.WithMemoryRepository())
+	require.NoError(t, err)
+
+	return rf
+}
+
+func seed(t *testing.T, rf *factory.RepositoryFactory) {
+	t.Helper()
+
+	ctx := context.Background()
+
+	_, err := rf.UserRepository.Create(ctx, entity.User{Username: "alice", PasswordHash: "hash", Timezone: "Europe/Berlin"})
+	require.NoError(t, err)
+	_, err = rf.CurrencyRepository.Create(ctx, entity.USD)
+	require.NoError(t, err)
+	cycle, err := rf.CycleRepository.Create(ctx, entity.Monthly)
+	require.NoError(t, err)
+	_, err = rf.CycleRepository.Create(ctx, entity.Cycle{Name: "Quarterly", Rule: "FREQ=MONTHLY;INTERVAL=3;BYMONTHDAY=1", UserID: 1})
+	require.NoError(t, err)
+
+	// Leave a gap in the category IDs to check they are kept.
+	for _, name := range []string{"Music", "Removed", "Video"} {
+		_, err = rf.CategoryRepository.Create(ctx, entity.Category{Name: name, UserID: 1})
+		require.NoError(t, err)
+	}
+	require.NoError(t, rf.CategoryRepository.Delete(ctx, 2))
+
+	video, err := rf.CategoryRepository.Get(ctx, 3)
+	require.NoError(t, err)
+
+	_, err = rf.CategoryRepository.Create(ctx, entity.Category{Name: "Movies", UserID: 1, ParentID: video.ID})
+	require.NoError(t, err)
+
+	tag, err := rf.TagRepository.Create(ctx, entity.Tag{Name: "Work", UserID: 1})
+	require.NoError(t, err)
+
+	method, err := rf.PaymentMethodRepository.Create(ctx, entity.PaymentMethod{
+		UserID:      1,
+		Label:       "Visa",
+		Type:        entity.PaymentCard,
+		LastFour:    "4242",
+		ExpiryYear:  2027,
+		ExpiryMonth: time.March,
+	})
+	require.NoError(t, err)
+
+	image := []byte("\x89PNG logo")
+	logo, err := rf.AssetRepository.Create(ctx, entity.Asset{
+		UserID:      1,
+		ContentType: "image/png",
+		Size:        int64(len(image)),
+		Checksum:    "2323e0e7da7ccf37a49d86b32ddebe5002af71ed9c27aba1a43da7eb918bd438",
+		CreatedAt:   time.Date(2024, 2, 1, 12, 0, 0, 0, time.UTC),
+	})
+	require.NoError(t, err)
+	require.NoError(t, rf.Blobs.Put(ctx, service.AssetKey(logo.ID, false), bytes.NewReader(image)))
+	require.NoError(t, rf.Blobs.Put(ctx, service.AssetKey(logo.ID, true), strings.NewReader("\x89PNG thumb")))
+
+	household, err := rf.HouseholdRepository.Create(ctx, entity.Household{
+		UserID:  1,
+		Name:    "Home",
+		Members: []entity.HouseholdMember{{ID: 1, Name: "Alice", UserID: 1}, {ID: 2, Name: "Bob"}},
+	})
+	require.NoError(t, err)
+
+	subscription, err := rf.SubscriptionRepository.Create(ctx, entity.Subscription{
+		UserID:          1,
+		Name:            "Streaming",
+		Price:           12,
+		Category:        *video,
+		Currency:        entity.USD,
+		Cycle:           *cycle,
+		NextPaymentDate: entity.PaymentDate(time.Date(2024, 5, 1, 0, 0, 0, 0, time.UTC)),
+		Status:          entity.StatusPaused,
+		ResumeDate:      entity.PaymentDate(time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC)),
+		TagIDs:          []uint{tag.ID},
+		PaymentMethodID: method.ID,
+		LogoAssetID:     logo.ID,
+		Contract: &entity.Contract{
+			StartDate:  entity.PaymentDate(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)),
+			EndDate:    entity.PaymentDate(time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)),
+			NoticeDays: 30,
+			AutoRenew:  true,
+		},
+		Prices: []entity.PriceChange{
+			{Price: 10},
+			{Price: 12, EffectiveDate: entity.PaymentDate(time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC))},
+		},
+		Share: &entity.SubscriptionShare{
+			HouseholdID: household.ID,
+			Rule:        entity.SplitFixed,
+			Parts:       []entity.SharePart{{MemberID: 1, Value: 8}, {MemberID: 2, Value: 4}},
+		},
+	})
+	require.NoError(t, err)
+
+	invoice := []byte("%PDF-1.4 invoice")
+	attachment, err := rf.AttachmentRepository.Create(ctx, entity.Attachment{
+		UserID:         1,
+		SubscriptionID: subscription.ID,
+		PaymentDate:    entity.PaymentDate(time.Date(2024, 4, 1, 0, 0, 0, 0, time.UTC)),
+		Name:           "invoice-april.pdf",
+		ContentType:    "application/pdf",
+		Size:           int64(len(invoice)),
+		Checksum:       "fe9fdd43245f8d76714bd6bac0628eb81668e188f894ec6a0d5bc45006bb5885",
+		CreatedAt:      time.Date(2024, 4, 2, 9, 0, 0, 0, time.UTC),
+	})
+	require.NoError(t, err)
+	require.NoError(t, rf.Blobs.Put(ctx, service.AttachmentKey(attachment.ID), bytes.NewReader(invoice)))
+
+	_, err = rf.BudgetRepository.Create(ctx, entity.Budget{
+		UserID:       1,
+		CategoryID:   video.ID,
+		Period:       entity.BudgetMonthly,
+		Amount:       20,
+		CurrencyCode: "USD",
+	})
+	require.NoError(t, err)
+}
+
+func TestDumpRestore(t *testing.T) {
+	ctx := context.Background()
+	source := newRepositoryFactory(t)
+	seed(t, source)
+
+	archive, err := backup.Dump(ctx, source)
+	require.NoError(t, err)
+
+	var buf bytes.Buffer
+	require.NoError(t, backup.Write(&buf, archive))
+
+	read, err := backup.Read(&buf)
+	require.NoError(t, err)
+
+	target := newRepositoryFactory(t)
+	require.NoError(t, backup.Restore(ctx, target, read))
+
+	restored, err := backup.Dump(ctx, target)
+	require.NoError(t, err)
+
+	restored.CreatedAt = archive.CreatedAt
+	assert.Equal(t, archive, restored)
+
+	subscription, err := target.SubscriptionRepository.Get(ctx, 1)
+	require.NoError(t, err)
+	assert.Equal(t, uint(3), subscription.Category.ID)
+	assert.Equal(t, "Video", subscription.Category.Name)
+	assert.Equal(t, "US Dollar", subscription.Currency.Name)
+
+	category, err := target.CategoryRepository.Create(ctx, entity.Category{Name: "New"})
+	require.NoError(t, err)
+	assert.Equal(t, uint(5), category.ID)
+}
+
+func TestDumpRestore_DeletedSubscription(t *testing.T) {
+	ctx := context.Background()
+	source := newRepositoryFactory(t)
+	seed(t, source)
+
+	sf, err := factory.NewServiceFactory(
+		factory.WithRepositoryFactory(source),
+		factory.WithSubscriptionService(),
+		factory.WithAttachmentService(0),
+	)
+	require.NoError(t, err)
+
+	require.NoError(t, sf.SubscriptionService.DeleteSubscription(ctx, 1))
+
+	attachments, err := source.AttachmentRepository.GetAll(ctx)
+	require.NoError(t, err)
+	assert.Empty(t, attachments)
+
+	_, err = source.Blobs.Open(ctx, service.AttachmentKey(1))
+	assert.ErrorIs(t, err, blobstore.ErrNotFound)
+
+	// An attachment orphaned before deletes cascaded is left out of the archive.
+	_, err = source.AttachmentRepository.Create(ctx, entity.Attachment{SubscriptionID: 1, Name: "orphan.pdf"})
+	require.NoError(t, err)
+
+	archive, err := backup.Dump(ctx, source)
+	require.NoError(t, err)
+	assert.Empty(t, archive.Subscriptions)
+	assert.Empty(t, archive.Attachments)
+
+	require.NoError(t, backup.Restore(ctx, newRepositoryFactory(t), archive))
+}
+
+// failingStore fails to store one key.
+type failingStore struct {
+	blobstore.Store
+	key string
+}
+
+func (s failingStore) Put(ctx context.Context, key string, r io.Reader) error {
+	if key == s.key {
+		return tests.ErrTest
+	}
+
+	return s.Store.Put(ctx, key, r)
+}
+
+func TestRestore_RollsBack(t *testing.T) {
+	ctx := context.Background()
+	source := newRepositoryFactory(t)
+	seed(t, source)
+
+	archive, err := backup.Dump(ctx, source)
+	require.NoError(t, err)
+
+	target, err := factory.NewRepositoryFactory(
+		factory.WithMemoryRepository(),
+		factory.WithBlobStore(failingStore{Store: blobstore.NewMemory(), key: service.AttachmentKey(1)}),
+	)
+	require.NoError(t, err)
+
+	err = backup.Restore(ctx, target, archive)
+	assert.ErrorIs(t, err, tests.ErrTest)
+
+	restored, err := backup.Dump(ctx, target)
+	require.NoError(t, err)
+
+	for section, count := range restored.Counts() {
+		assert.Zero(t, count, section)
+	}
+
+	_, err = target.Blobs.Open(ctx, service.AssetKey(1, false))
+	assert.ErrorIs(t, err, blobstore.ErrNotFound)
+}
+
+func TestRestore_ChildCategoriesFirst(t *testing.T) {
+	ctx := context.Background()
+
+	db, err := sqlite.Open(filepath.Join(t.TempDir(), "subscriptions.db"))
+	require.NoError(t, err)
+	defer db.Close()
+
+	require.NoError(t, sqlite.Migrate(ctx, db))
+
+	target, err := factory.NewRepositoryFactory(factory.WithSqliteRepository(db), factory.WithBlobStore(blobstore.NewMemory()))
+	require.NoError(t, err)
+
+	archive, err := backup.Read(strings.NewReader(`{"version":2,"categories":[` +
+		`{"id":2,"name":"Movies","parent_id":1},{"id":1,"name":"Video"}]}`))
+	require.NoError(t, err)
+	require.NoError(t, backup.Restore(ctx, target, archive))
+
+	category, err := target.CategoryRepository.Get(ctx, 2)
+	require.NoError(t, err)
+	assert.Equal(t, uint(1), category.ParentID)
+}
+
+func TestRestore_VersionOneCalendarCycles(t *testing.T) {
+	ctx := context.Background()
+	target := newRepositoryFactory(t)
+
+	archive, err := backup.Read(strings.NewReader(`{"version":1,"cycles":[` +
+		`{"id":1,"name":"Monthly","days":30},{"id":2,"name":"Weekly","days":7},{"id":3,"name":"Yearly","days":365}]}`))
+	require.NoError(t, err)
+	require.NoError(t, backup.Restore(ctx, target, archive))
+
+	cycles, err := target.CycleRepository.GetAll(ctx)
+	require.NoError(t, err)
+	require.Len(t, cycles, 3)
+	assert.True(t, cycles[0].Calendar)
+	assert.False(t, cycles[1].Calendar)
+	assert.True(t, cycles[2].Calendar)
+}
+
+func TestRestore_Errors(t *testing.T) {
+	ctx := context.Background()
+	source := newRepositoryFactory(t)
+	seed(t, source)
+
+	archive, err := backup.Dump(ctx, source)
+	require.NoError(t, err)
+
+	testCases := []struct {
+		name    string
+		modify  func(a *backup.Archive)
+		target  *factory.RepositoryFactory
+		wantErr error
+	}{
+		{
+			name:    "Storage is not empty",
+			modify:  func(*backup.Archive) {},
+			target:  source,
+			wantErr: backup.ErrStorageNotEmpty,
+		},
+		{
+			name:    "Newer version",
+			modify:  func(a *backup.Archive) { a.Version = backup.Version + 1 },
+			wantErr: backup.ErrUnsupportedVersion,
+		},
+		{
+			name:    "Unknown cycle",
+			modify:  func(a *backup.Archive) { a.Cycles = nil },
+			wantErr: backup.ErrInvalidArchive,
+		},
+		{
+			name: "Invalid cycle rule",
+			modify: func(a *backup.Archive) {
+				a.Cycles = append([]backup.Cycle{}, a.Cycles...)
+				a.Cycles[1].Rule = "FREQ=SOMETIMES"
+			},
+			wantErr: backup.ErrInvalidArchive,
+		},
+		{
+			name:    "Unknown parent category",
+			modify:  func(a *backup.Archive) { a.Categories = []backup.Category{{ID: 3, Name: "Video", ParentID: 2}} },
+			wantErr: backup.ErrInvalidArchive,
+		},
+		{
+			name: "Category parent loop",
+			modify: func(a *backup.Archive) {
+				a.Categories = []backup.Category{{ID: 2, Name: "Music", ParentID: 3}, {ID: 3, Name: "Video", ParentID: 2}}
+			},
+			wantErr: backup.ErrInvalidArchive,
+		},
+		{
+			name:    "Unknown budget category",
+			modify:  func(a *backup.Archive) { a.Budgets = []backup.Budget{{ID: 1, CategoryID: 2, Period: "monthly"}} },
+			wantErr: backup.ErrInvalidArchive,
+		},
+		{
+			name:    "Unknown tag",
+			modify:  func(a *backup.Archive) { a.Tags = nil },
+			wantErr: backup.ErrInvalidArchive,
+		},
+		{
+			name:    "Unknown payment method",
+			modify:  func(a *backup.Archive) { a.PaymentMethods = nil },
+			wantErr: backup.ErrInvalidArchive,
+		},
+		{
+			name:    "Unknown logo asset",
+			modify:  func(a *backup.Archive) { a.Assets = nil },
+			wantErr: backup.ErrInvalidArchive,
+		},
+		{
+			name: "Asset checksum mismatch",
+			modify: func(a *backup.Archive) {
+				asset := a.Assets[0]
+				asset.Content = []byte("tampered")
+				a.Assets = []backup.Asset{asset}
+			},
+			wantErr: backup.ErrInvalidArchive,
+		},
+		{
+			name: "Thumbnail checksum mismatch",
+			modify: func(a *backup.Archive) {
+				asset := a.Assets[0]
+				asset.Thumbnail = nil
+				a.Assets = []backup.Asset{asset}
+			},
+			wantErr: backup.ErrInvalidArchive,
+		},
+		{
+			name: "Unknown attachment subscription",
+			modify: func(a *backup.Archive) {
+				a.Attachments = []backup.Attachment{{ID: 1, SubscriptionID: 2}}
+			},
+			wantErr: backup.ErrInvalidArchive,
+		},
+		{
+			name: "Attachment checksum mismatch",
+			modify: func(a *backup.Archive) {
+				at := a.Attachments[0]
+				at.Content = []byte("tampered")
+				a.Attachments = []backup.Attachment{at}
+			},
+			wantErr: backup.ErrInvalidArchive,
+		},
+		{
+			name: "Invalid contract",
+			modify: func(a *backup.Archive) {
+				s := a.Subscriptions[0]
+				s.Contract = &backup.Contract{}
+				a.Subscriptions = []backup.Subscription{s}
+			},
+			wantErr: backup.ErrInvalidArchive,
+		},
+		{
+			name:    "Unknown household",
+			modify:  func(a *backup.Archive) { a.Households = nil },
+			wantErr: backup.ErrInvalidArchive,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			a := *archive
+			tc.modify(&a)
+
+			target := tc.target
+			if target == nil {
+				target = newRepositoryFactory(t)
+			}
+
+			assert.ErrorIs(t, backup.Restore(ctx, target, &a), tc.wantErr)
+		})
+	}
+}
+
+func TestRead_Invalid(t *testing.T) {
+	_, err := backup.Read(strings.NewReader("not json"))
+	assert.ErrorIs(t, err, backup.ErrInvalidArchive)
+}
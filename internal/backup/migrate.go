@@ -0,0 +1,340 @@
+package backup
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+
+	"git.home/alex/go-subscriptions/internal/domain/service"
+	"git.home/alex/go-subscriptions/internal/factory"
+)
+
+var (
+	ErrVerificationFailed = errors.New("the migrated data does not match the source")
+)
+
+// Sections in migration order; every section only references the ones before it.
+var Sections = []string{
+	"users", "currencies", "cycles", "categories", "tags", "payment_methods", "assets", "households", "subscriptions",
+	"attachments", "budgets",
+}
+
+type SectionReport struct {
+	Name     string `json:"name"`
+	Count    int    `json:"count"`
+	Checksum string `json:"checksum"`
+	Resumed  bool   `json:"resumed"`
+}
+
+type MigrationReport struct {
+	Sections []SectionReport `json:"sections"`
+}
+
+// migrationState is persisted after every section so an interrupted migration can resume.
+// It maps the completed sections to the checksum of the source data they were copied from.
+type migrationState struct {
+	Completed map[string]string `json:"completed"`
+}
+
+// Checksums returns the SHA-256 of every archive section. They don't depend on the storage
+// the archive was dumped from, so two storages holding the same data have equal checksums.
+// File contents are covered by the checksums the assets and attachments record of them, so an
+// archive dumped without blobs has the same checksums as a full one.
+func Checksums(a *Archive) (map[string]string, error) {
+	assets := make([]Asset, len(a.Assets))
+	for i, asset := range a.Assets {
+		asset.Content, asset.Thumbnail, asset.ThumbnailChecksum = nil, nil, ""
+		assets[i] = asset
+	}
+
+	attachments := make([]Attachment, len(a.Attachments))
+	for i, attachment := range a.Attachments {
+		attachment.Content = nil
+		attachments[i] = attachment
+	}
+
+	sections := map[string]any{
+		"users":           a.Users,
+		"currencies":      a.Currencies,
+		"cycles":          a.Cycles,
+		"categories":      a.Categories,
+		"tags":            a.Tags,
+		"payment_methods": a.PaymentMethods,
+		"assets":          assets,
+		"households":      a.Households,
+		"subscriptions":   a.Subscriptions,
+		"attachments":     attachments,
+		"budgets":         a.Budgets,
+	}
+
+	checksums := make(map[string]string, len(sections))
+
+	for name, section := range sections {
+		data, err := json.Marshal(section)
+		if err != nil {
+			return nil, err
+		}
+
+		sum := sha256.Sum256(data)
+		checksums[name] = hex.EncodeToString(sum[:])
+	}
+
+	return checksums, nil
+}
+
+// Migrate copies every entity from one storage to another section by section, keeping the IDs,
+// and verifies the counts and checksums afterwards. Progress is recorded in statePath; running
+// Migrate again with the same file skips the sections already copied. The file is removed once
+// the migration has been verified. File contents are only read to copy them.
+func Migrate(ctx context.Context, from, to *factory.RepositoryFactory, statePath string) (*MigrationReport, error) {
+	source, err := dump(ctx, from, false)
+	if err != nil {
+		return nil, err
+	}
+
+	checksums, err := Checksums(source)
+	if err != nil {
+		return nil, err
+	}
+
+	state, resuming, err := loadState(statePath)
+	if err != nil {
+		return nil, err
+	}
+
+	if !resuming {
+		empty, err := isEmpty(ctx, to)
+		if err != nil {
+			return nil, err
+		}
+
+		if !empty {
+			return nil, ErrStorageNotEmpty
+		}
+	}
+
+	counts := source.Counts()
+	report := &MigrationReport{}
+
+	for _, name := range Sections {
+		section := SectionReport{Name: name, Count: counts[name], Checksum: checksums[name]}
+
+		if state.Completed[name] == checksums[name] {
+			section.Resumed = true
+		} else {
+			err = copySection(ctx, from, to, name)
+			if err != nil {
+				return nil, fmt.Errorf("migrate %s: %w", name, err)
+			}
+
+			state.Completed[name] = checksums[name]
+
+			err = saveState(statePath, state)
+			if err != nil {
+				return nil, err
+			}
+		}
+
+		report.Sections = append(report.Sections, section)
+	}
+
+	err = verify(ctx, to, counts, checksums)
+	if err != nil {
+		return nil, err
+	}
+
+	return report, removeState(statePath)
+}
+
+func verify(ctx context.Context, to *factory.RepositoryFactory, counts map[string]int, checksums map[string]string) error {
+	target, err := dump(ctx, to, false)
+	if err != nil {
+		return err
+	}
+
+	targetChecksums, err := Checksums(target)
+	if err != nil {
+		return err
+	}
+
+	targetCounts := target.Counts()
+
+	for _, name := range Sections {
+		if targetCounts[name] != counts[name] {
+			return fmt.Errorf("%w: %s count is %d, expected %d", ErrVerificationFailed, name, targetCounts[name], counts[name])
+		}
+
+		if targetChecksums[name] != checksums[name] {
+			return fmt.Errorf("%w: %s checksum differs", ErrVerificationFailed, name)
+		}
+	}
+
+	return nil
+}
+
+func copySection(ctx context.Context, from, to *factory.RepositoryFactory, name string) error {
+	switch name {
+	case "users":
+		return copyAll(ctx, from.UserRepository.GetAll, to.UserRepository.Restore)
+	case "currencies":
+		return copyAll(ctx, from.CurrencyRepository.GetAll, to.CurrencyRepository.Restore)
+	case "cycles":
+		return copyAll(ctx, from.CycleRepository.GetAll, to.CycleRepository.Restore)
+	case "categories":
+		return copyAll(ctx, from.CategoryRepository.GetAll, to.CategoryRepository.Restore)
+	case "tags":
+		return copyAll(ctx, from.TagRepository.GetAll, to.TagRepository.Restore)
+	case "payment_methods":
+		return copyAll(ctx, from.PaymentMethodRepository.GetAll, to.PaymentMethodRepository.Restore)
+	case "assets":
+		return copyAssets(ctx, from, to)
+	case "households":
+		return copyAll(ctx, from.HouseholdRepository.GetAll, to.HouseholdRepository.Restore)
+	case "subscriptions":
+		return copyAll(ctx, from.SubscriptionRepository.GetAll, to.SubscriptionRepository.Restore)
+	case "attachments":
+		return copyAttachments(ctx, from, to)
+	case "budgets":
+		return copyAll(ctx, from.BudgetRepository.GetAll, to.BudgetRepository.Restore)
+	}
+
+	return fmt.Errorf("unknown section %q", name)
+}
+
+// copyAll restores every entity into the target. Restoring replaces entities with the same ID,
+// so copying a section again after an interruption is safe.
+func copyAll[T any, S ~[]T](
+	ctx context.Context,
+	getAll func(context.Context) (S, error),
+	restore func(context.Context, T) (*T, error),
+) error {
+	items, err := getAll(ctx)
+	if err != nil {
+		return err
+	}
+
+	for _, item := range items {
+		_, err = restore(ctx, item)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// copyAttachments copies the content between the blob stores along with the metadata. It copies
+// the same attachments as Dump archives, so the checksums of the section match.
+func copyAttachments(ctx context.Context, from, to *factory.RepositoryFactory) error {
+	attachments, err := archivedAttachments(ctx, from)
+	if err != nil {
+		return err
+	}
+
+	for _, attachment := range attachments {
+		key := service.AttachmentKey(attachment.ID)
+
+		content, err := readBlob(ctx, from.Blobs, key)
+		if err != nil {
+			return err
+		}
+
+		err = to.Blobs.Put(ctx, key, bytes.NewReader(content))
+		if err != nil {
+			return err
+		}
+
+		_, err = to.AttachmentRepository.Restore(ctx, attachment)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// copyAssets copies the images and their thumbnails between the blob stores along with the metadata.
+func copyAssets(ctx context.Context, from, to *factory.RepositoryFactory) error {
+	assets, err := from.AssetRepository.GetAll(ctx)
+	if err != nil {
+		return err
+	}
+
+	for _, asset := range assets {
+		for _, thumbnail := range []bool{false, true} {
+			key := service.AssetKey(asset.ID, thumbnail)
+
+			content, err := readBlob(ctx, from.Blobs, key)
+			if err != nil {
+				return err
+			}
+
+			err = to.Blobs.Put(ctx, key, bytes.NewReader(content))
+			if err != nil {
+				return err
+			}
+		}
+
+		_, err = to.AssetRepository.Restore(ctx, asset)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func loadState(path string) (*migrationState, bool, error) {
+	state := &migrationState{Completed: make(map[string]string)}
+
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return state, false, nil
+	}
+
+	if err != nil {
+		return nil, false, err
+	}
+
+	err = json.Unmarshal(data, state)
+	if err != nil {
+		return nil, false, fmt.Errorf("read migration state %s: %w", path, err)
+	}
+
+	if state.Completed == nil {
+		state.Completed = make(map[string]string)
+	}
+
+	return state, true, nil
+}
+
+// saveState replaces the state file atomically so an interruption never leaves it half written.
+func saveState(path string, state *migrationState) error {
+	data, err := json.Marshal(state)
+	if err != nil {
+		return err
+	}
+
+	tmp := path + ".tmp"
+
+	err = os.WriteFile(tmp, data, 0o600)
+	if err != nil {
+		return err
+	}
+
+	return os.Rename(tmp, path)
+}
+
+func removeState(path string) error {
+	err := os.Remove(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil
+	}
+
+	return err
+}
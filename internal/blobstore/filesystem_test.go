@@ -0,0 +1,40 @@
+package blobstore_test
+
+import (
+	"context"
+	"io"
+	"strings"
+	"testing"
+
+	"git.home/alex/go-subscriptions/internal/blobstore"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFileSystem(t *testing.T) {
+	ctx := context.Background()
+
+	store, err := blobstore.NewFileSystem(t.TempDir())
+	require.NoError(t, err)
+
+	require.NoError(t, store.Put(ctx, "1", strings.NewReader("first")))
+	require.NoError(t, store.Put(ctx, "1", strings.NewReader("second")))
+
+	r, err := store.Open(ctx, "1")
+	require.NoError(t, err)
+
+	data, err := io.ReadAll(r)
+	require.NoError(t, err)
+	require.NoError(t, r.Close())
+	assert.Equal(t, "second", string(data))
+
+	require.NoError(t, store.Delete(ctx, "1"))
+
+	_, err = store.Open(ctx, "1")
+	assert.ErrorIs(t, err, blobstore.ErrNotFound)
+	assert.ErrorIs(t, store.Delete(ctx, "1"), blobstore.ErrNotFound)
+
+	for _, key := range []string{"", "../1", "a/b", ".upload-1"} {
+		assert.ErrorIs(t, store.Put(ctx, key, strings.NewReader("x")), blobstore.ErrInvalidKey, key)
+	}
+}
@@ -0,0 +1,63 @@
+package blobstore
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"sync"
+)
+
+// Memory keeps the blobs in a map; it backs the memory storage and tests.
+type Memory struct {
+	blobs map[string][]byte
+	sync.Mutex
+}
+
+func NewMemory() *Memory {
+	return &Memory{
+		blobs: make(map[string][]byte),
+	}
+}
+
+func (s *Memory) Put(_ context.Context, key string, r io.Reader) error {
+	if key == "" {
+		return ErrInvalidKey
+	}
+
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return err
+	}
+
+	s.Lock()
+	defer s.Unlock()
+
+	s.blobs[key] = data
+
+	return nil
+}
+
+func (s *Memory) Open(_ context.Context, key string) (io.ReadCloser, error) {
+	s.Lock()
+	defer s.Unlock()
+
+	data, ok := s.blobs[key]
+	if !ok {
+		return nil, ErrNotFound
+	}
+
+	return io.NopCloser(bytes.NewReader(data)), nil
+}
+
+func (s *Memory) Delete(_ context.Context, key string) error {
+	s.Lock()
+	defer s.Unlock()
+
+	if _, ok := s.blobs[key]; !ok {
+		return ErrNotFound
+	}
+
+	delete(s.blobs, key)
+
+	return nil
+}
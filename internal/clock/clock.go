@@ -0,0 +1,61 @@
+// Package clock tells what day it is for the caller. Payment dates are civil dates held as midnight
+// UTC, so "today" is the caller's calendar day in their time zone, held the same way.
+package clock
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	// Embed the time zone database so zones load on hosts without one.
+	_ "time/tzdata"
+)
+
+type locationKey struct{}
+
+var locations sync.Map
+
+// LoadLocation is time.LoadLocation with the loaded zones cached, as it is called for every request.
+func LoadLocation(name string) (*time.Location, error) {
+	if loc, ok := locations.Load(name); ok {
+		return loc.(*time.Location), nil
+	}
+
+	loc, err := time.LoadLocation(name)
+	if err != nil {
+		return nil, err
+	}
+
+	locations.Store(name, loc)
+
+	return loc, nil
+}
+
+func WithLocation(ctx context.Context, loc *time.Location) context.Context {
+	return context.WithValue(ctx, locationKey{}, loc)
+}
+
+func FromContext(ctx context.Context) (*time.Location, bool) {
+	loc, ok := ctx.Value(locationKey{}).(*time.Location)
+
+	return loc, ok && loc != nil
+}
+
+// Location returns the time zone of the caller, UTC when none is set.
+func Location(ctx context.Context) *time.Location {
+	if loc, ok := FromContext(ctx); ok {
+		return loc
+	}
+
+	return time.UTC
+}
+
+// Today returns the current day of the caller.
+func Today(ctx context.Context) time.Time {
+	return Date(time.Now().In(Location(ctx)))
+}
+
+// Date returns the calendar day of t in its own location as midnight UTC.
+func Date(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+}
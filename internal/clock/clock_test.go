@@ -0,0 +1,33 @@
+package clock_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"git.home/alex/go-subscriptions/internal/clock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDate(t *testing.T) {
+	losAngeles, err := clock.LoadLocation("America/Los_Angeles")
+	require.NoError(t, err)
+
+	evening := time.Date(2024, 5, 2, 3, 30, 0, 0, time.UTC).In(losAngeles)
+
+	assert.Equal(t, time.Date(2024, 5, 1, 0, 0, 0, 0, time.UTC), clock.Date(evening))
+	assert.Equal(t, time.Date(2024, 5, 2, 0, 0, 0, 0, time.UTC), clock.Date(evening.UTC()))
+}
+
+func TestLocation(t *testing.T) {
+	ctx := context.Background()
+	assert.Equal(t, time.UTC, clock.Location(ctx))
+
+	tokyo, err := clock.LoadLocation("Asia/Tokyo")
+	require.NoError(t, err)
+	assert.Equal(t, tokyo, clock.Location(clock.WithLocation(ctx, tokyo)))
+
+	_, err = clock.LoadLocation("Mars/Olympus_Mons")
+	assert.Error(t, err)
+}
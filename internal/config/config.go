@@ -10,6 +10,69 @@ type Config struct {
 	Storage    string        `yaml:"storage" env-default:"memory"`
 	ListenAddr string        `yaml:"listen_addr" required:"true"`
 	Timeout    time.Duration `yaml:"timeout" env-default:"15"`
+	// Timezone is the IANA zone "today" is evaluated in for users that haven't set their own.
+	Timezone  string          `yaml:"timezone" env-default:"UTC"`
+	Auth      AuthConfig      `yaml:"auth"`
+	SQLite    SQLiteConfig    `yaml:"sqlite"`
+	Trials    TrialsConfig    `yaml:"trials"`
+	Contracts ContractsConfig `yaml:"contracts"`
+	Assets    AssetsConfig    `yaml:"assets"`
+}
+
+type TrialsConfig struct {
+	// AlertDays is how many days ahead /api/trials/ending reports trials by default.
+	AlertDays uint `yaml:"alert_days" env-default:"7"`
+}
+
+type ContractsConfig struct {
+	// AlertDays is how many days ahead /api/contracts/deadlines reports deadlines by default.
+	AlertDays uint `yaml:"alert_days" env-default:"30"`
+}
+
+type AssetsConfig struct {
+	// Dir is where uploaded images, their thumbnails and attachments are stored.
+	Dir string `yaml:"dir" env-default:"assets"`
+	// MaxSize is the largest image upload in bytes.
+	MaxSize int64 `yaml:"max_size" env-default:"1048576"`
+	// MaxAttachmentSize is the largest attachment upload in bytes.
+	MaxAttachmentSize int64 `yaml:"max_attachment_size" env-default:"10485760"`
+}
+
+type SQLiteConfig struct {
+	Path string `yaml:"path" env-default:"subscriptions.db"`
+	// AutoMigrate applies the pending migrations on startup; without it a pending migration stops startup.
+	AutoMigrate bool `yaml:"auto_migrate" env-default:"true"`
+}
+
+type AuthConfig struct {
+	APIKeys           []APIKeyConfig `yaml:"api_keys"`
+	JWT               JWTConfig      `yaml:"jwt"`
+	AllowRegistration bool           `yaml:"allow_registration"`
+}
+
+type APIKeyConfig struct {
+	Name   string `yaml:"name"`
+	Key    string `yaml:"key"`
+	UserID uint   `yaml:"user_id"`
+	Scope  string `yaml:"scope"`
+}
+
+type JWTConfig struct {
+	HS256Secret        string        `yaml:"hs256_secret" env:"JWT_HS256_SECRET"`
+	RS256PublicKeyFile string        `yaml:"rs256_public_key_file"`
+	Issuer             string        `yaml:"issuer"`
+	Audience           string        `yaml:"audience"`
+	Leeway             time.Duration `yaml:"leeway"`
+	TokenTTL           time.Duration `yaml:"token_ttl"`
+}
+
+// Enabled reports whether any credentials are configured. Without them the API is left open.
+func (c AuthConfig) Enabled() bool {
+	return len(c.APIKeys) > 0 || c.JWT.Enabled()
+}
+
+func (c JWTConfig) Enabled() bool {
+	return c.HS256Secret != "" || c.RS256PublicKeyFile != ""
 }
 
 func LoadConfig(configFile string) (*Config, error) {
@@ -0,0 +1,15 @@
+package entity
+
+import "time"
+
+// Asset is an uploaded image, e.g. a subscription logo. The content lives in the blob store under
+// the keys of the original and its thumbnail; assets are never modified, a new upload gets a new ID.
+type Asset struct {
+	ID          uint
+	UserID      uint
+	ContentType string
+	Size        int64
+	// Checksum is the hex SHA-256 of the content.
+	Checksum  string
+	CreatedAt time.Time
+}
@@ -0,0 +1,18 @@
+package entity
+
+import "time"
+
+// Attachment is a file kept with a subscription, e.g. the invoice of a charge. PaymentDate is the
+// day of the charge it documents, zero for a file about the subscription as a whole.
+type Attachment struct {
+	ID             uint
+	UserID         uint
+	SubscriptionID uint
+	PaymentDate    PaymentDate
+	Name           string
+	ContentType    string
+	Size           int64
+	// Checksum is the hex SHA-256 of the content.
+	Checksum  string
+	CreatedAt time.Time
+}
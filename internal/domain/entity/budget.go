@@ -0,0 +1,43 @@
+package entity
+
+import "slices"
+
+type BudgetPeriod string
+
+const (
+	BudgetMonthly BudgetPeriod = "monthly"
+	BudgetYearly  BudgetPeriod = "yearly"
+)
+
+func (p BudgetPeriod) Valid() bool {
+	return p == BudgetMonthly || p == BudgetYearly
+}
+
+// Months is the number of months the period spans.
+func (p BudgetPeriod) Months() float64 {
+	if p == BudgetYearly {
+		return 12
+	}
+
+	return 1
+}
+
+// Budget caps the spending of one category and its subcategories, or of all subscriptions when
+// CategoryID is zero.
+// Amount is in CurrencyCode; subscriptions in other currencies don't count against it.
+type Budget struct {
+	ID           uint
+	UserID       uint
+	CategoryID   uint
+	Period       BudgetPeriod
+	Amount       float64
+	CurrencyCode string
+}
+
+// Covers reports whether the subscription counts against the budget. ancestors are the IDs of the
+// categories above the one of the subscription, so a budget covers the subcategories of its category.
+func (b Budget) Covers(s Subscription, ancestors []uint) bool {
+	return (b.UserID == 0 || s.UserID == b.UserID) &&
+		(b.CategoryID == 0 || s.Category.ID == b.CategoryID || slices.Contains(ancestors, b.CategoryID)) &&
+		s.Currency.Code == b.CurrencyCode
+}
@@ -1,6 +1,10 @@
 package entity
 
+// Category groups subscriptions. A category nests under its parent; ParentID is zero for a
+// top level category.
 type Category struct {
-	ID   uint
-	Name string
+	ID       uint
+	UserID   uint
+	ParentID uint
+	Name     string
 }
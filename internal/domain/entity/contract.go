@@ -0,0 +1,98 @@
+package entity
+
+import "time"
+
+// maxRenewals bounds the terms scanned for a date, so a malformed contract can't loop for long.
+const maxRenewals = 10000
+
+// Contract is a fixed-term commitment. A term runs from StartDate up to, but excluding, EndDate;
+// an auto-renewing contract starts a term of the same length on EndDate unless it is cancelled
+// NoticeDays before, while any other contract expires the subscription on EndDate.
+type Contract struct {
+	StartDate  PaymentDate
+	EndDate    PaymentDate
+	NoticeDays uint
+	AutoRenew  bool
+}
+
+func (c Contract) Valid() bool {
+	start, end := time.Time(c.StartDate), time.Time(c.EndDate)
+
+	return !start.IsZero() && !end.IsZero() && start.Before(end)
+}
+
+// Ended reports whether a contract that doesn't renew is over on the day of t.
+func (c Contract) Ended(t time.Time) bool {
+	return !c.AutoRenew && reached(c.EndDate, t)
+}
+
+// TermEnd returns the end of the term the day of t falls in, or the last term once it has ended.
+func (c Contract) TermEnd(t time.Time) time.Time {
+	if !c.AutoRenew {
+		return time.Time(c.EndDate)
+	}
+
+	for k := 0; k < maxRenewals; k++ {
+		end := c.termEnd(k)
+		if !reached(PaymentDate(end), t) {
+			return end
+		}
+	}
+
+	return time.Time{}
+}
+
+// CancelBy returns the last day notice can be given to stop the next renewal that can still be
+// stopped on the day of t. It is zero for a contract that doesn't renew.
+func (c Contract) CancelBy(t time.Time) time.Time {
+	deadlines := c.CancelDeadlines(t, time.Time{})
+	if len(deadlines) == 0 {
+		return time.Time{}
+	}
+
+	return deadlines[0]
+}
+
+// CancelDeadlines returns the notice deadlines of an auto-renewing contract from the day of from to
+// to, both included. A zero to returns the first deadline only.
+func (c Contract) CancelDeadlines(from, to time.Time) []time.Time {
+	if !c.AutoRenew || !c.Valid() {
+		return nil
+	}
+
+	var deadlines []time.Time
+
+	for k := 0; k < maxRenewals; k++ {
+		deadline := c.termEnd(k).AddDate(0, 0, -int(c.NoticeDays))
+		if !reached(PaymentDate(from), deadline) {
+			continue
+		}
+
+		if !to.IsZero() && deadline.After(to) {
+			break
+		}
+
+		deadlines = append(deadlines, deadline)
+
+		if to.IsZero() {
+			break
+		}
+	}
+
+	return deadlines
+}
+
+// termEnd returns the end of the k-th renewal, k = 0 being the initial term. Terms spanning whole
+// months renew by months so they keep their day of month; other terms renew by days.
+func (c Contract) termEnd(k int) time.Time {
+	start, end := time.Time(c.StartDate), time.Time(c.EndDate)
+
+	months := (end.Year()-start.Year())*12 + int(end.Month()-start.Month())
+	if months > 0 && start.AddDate(0, months, 0).Equal(end) {
+		return end.AddDate(0, k*months, 0)
+	}
+
+	days := int(end.Sub(start).Hours() / 24)
+
+	return end.AddDate(0, 0, k*days)
+}
@@ -0,0 +1,94 @@
+package entity_test
+
+import (
+	"testing"
+	"time"
+
+	"git.home/alex/go-subscriptions/internal/domain/entity"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestContract_CancelBy(t *testing.T) {
+	date := func(y int, m time.Month, d int) time.Time { return time.Date(y, m, d, 0, 0, 0, 0, time.UTC) }
+
+	yearly := entity.Contract{
+		StartDate:  entity.PaymentDate(date(2024, time.January, 31)),
+		EndDate:    entity.PaymentDate(date(2025, time.January, 31)),
+		NoticeDays: 30,
+		AutoRenew:  true,
+	}
+
+	testCases := []struct {
+		name        string
+		contract    entity.Contract
+		now         time.Time
+		wantTermEnd time.Time
+		wantCancel  time.Time
+	}{
+		{
+			name:        "First term",
+			contract:    yearly,
+			now:         date(2024, time.June, 1),
+			wantTermEnd: date(2025, time.January, 31),
+			wantCancel:  date(2025, time.January, 1),
+		},
+		{
+			name:        "Notice deadline passed",
+			contract:    yearly,
+			now:         date(2025, time.January, 2),
+			wantTermEnd: date(2025, time.January, 31),
+			wantCancel:  date(2026, time.January, 1),
+		},
+		{
+			name:        "Renewed term",
+			contract:    yearly,
+			now:         date(2025, time.March, 1),
+			wantTermEnd: date(2026, time.January, 31),
+			wantCancel:  date(2026, time.January, 1),
+		},
+		{
+			name: "Term in days",
+			contract: entity.Contract{
+				StartDate: entity.PaymentDate(date(2024, time.May, 1)),
+				EndDate:   entity.PaymentDate(date(2024, time.May, 15)),
+				AutoRenew: true,
+			},
+			now:         date(2024, time.May, 20),
+			wantTermEnd: date(2024, time.May, 29),
+			wantCancel:  date(2024, time.May, 29),
+		},
+		{
+			name:        "Fixed term",
+			contract:    entity.Contract{StartDate: yearly.StartDate, EndDate: yearly.EndDate, NoticeDays: 30},
+			now:         date(2024, time.June, 1),
+			wantTermEnd: date(2025, time.January, 31),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.wantTermEnd, tc.contract.TermEnd(tc.now))
+			assert.Equal(t, tc.wantCancel, tc.contract.CancelBy(tc.now))
+		})
+	}
+}
+
+func TestContract_CancelDeadlines(t *testing.T) {
+	monthly := entity.Contract{
+		StartDate:  entity.PaymentDate(time.Date(2024, time.January, 1, 0, 0, 0, 0, time.UTC)),
+		EndDate:    entity.PaymentDate(time.Date(2024, time.February, 1, 0, 0, 0, 0, time.UTC)),
+		NoticeDays: 7,
+		AutoRenew:  true,
+	}
+
+	deadlines := monthly.CancelDeadlines(
+		time.Date(2024, time.March, 1, 0, 0, 0, 0, time.UTC),
+		time.Date(2024, time.May, 31, 0, 0, 0, 0, time.UTC),
+	)
+
+	assert.Equal(t, []time.Time{
+		time.Date(2024, time.March, 25, 0, 0, 0, 0, time.UTC),
+		time.Date(2024, time.April, 24, 0, 0, 0, 0, time.UTC),
+		time.Date(2024, time.May, 25, 0, 0, 0, 0, time.UTC),
+	}, deadlines)
+}
@@ -1,9 +1,12 @@
 package entity
 
+// Currency is shared reference data visible to every user. UserID is only
+// kept for the rows older versions created per user and is zero otherwise.
 type Currency struct {
 	Code   string
 	Symbol string
 	Name   string
+	UserID uint
 }
 
 var (
@@ -1,13 +1,202 @@
 package entity
 
+import (
+	"sync"
+	"time"
+)
+
+// Cycle with a zero UserID is global and visible to every user. A cycle repeats either every Days
+// days or, when Rule is set, on the dates of an RFC 5545 recurrence rule. A Calendar cycle follows
+// the calendar instead of counting days: with 30 days it repeats every month and with 365 days
+// every year, on the same day of month.
 type Cycle struct {
-	ID   uint
-	Name string
-	Days uint
+	ID       uint
+	Name     string
+	Days     uint
+	Rule     string
+	Calendar bool
+	UserID   uint
 }
 
 var (
-	Weekly  = Cycle{1, "Weekly", 7}
-	Monthly = Cycle{2, "Monthly", 30}
-	Yearly  = Cycle{3, "Yearly", 365}
+	Weekly  = Cycle{ID: 1, Name: "Weekly", Days: 7}
+	Monthly = Cycle{ID: 2, Name: "Monthly", Days: CalendarMonthDays, Calendar: true}
+	Yearly  = Cycle{ID: 3, Name: "Yearly", Days: CalendarYearDays, Calendar: true}
+)
+
+// The lengths in days of the calendar cycles.
+const (
+	CalendarMonthDays = 30
+	CalendarYearDays  = 365
 )
+
+const (
+	daysPerYear   = 365.0
+	monthsPerYear = 12
+)
+
+// PerMonth returns how many times the cycle repeats in an average month. Calendar cycles repeat
+// exactly once or one twelfth of a time, other cycles are proportional to their days
+// and rule cycles average their dates over four years.
+func (c Cycle) PerMonth() float64 {
+	if c.Rule != "" {
+		rule, ok := compiledRules.get(c.Rule)
+		if !ok {
+			return 0
+		}
+
+		return rule.perMonth
+	}
+
+	if c.Days == 0 {
+		return 0
+	}
+
+	if months := c.calendarMonths(); months != 0 {
+		return 1.0 / float64(months)
+	}
+
+	return daysPerYear / monthsPerYear / float64(c.Days)
+}
+
+// Next returns the billing date one cycle after t.
+func (c Cycle) Next(t time.Time) time.Time {
+	return c.Nth(t, 1)
+}
+
+// Nth returns the n-th billing date after start. Calendar cycles follow the calendar and
+// keep the day of month of start, moved to the last day of shorter months, so a subscription started
+// on the 31st is billed on the 29th of February and on the 31st again in March. Other cycles add
+// their days. A rule cycle steps through the dates of its rule, with start as the start.
+// A cycle without days or a valid rule never repeats and returns the zero time.
+func (c Cycle) Nth(start time.Time, n int) time.Time {
+	dates := c.Dates(start)
+
+	date := start
+	for i := 0; i < n; i++ {
+		date = dates.Next()
+	}
+
+	return date
+}
+
+// Dates returns the billing dates after start in order, as Nth numbers them. Use it rather than
+// Nth to walk many dates: a rule cycle only reaches the n-th date through the ones before.
+func (c Cycle) Dates(start time.Time) *CycleDates {
+	dates := &CycleDates{cycle: c, start: start, date: start}
+
+	if c.Rule != "" {
+		rule, ok := compiledRules.get(c.Rule)
+		if ok {
+			dates.rule = rule.Recurrence
+		}
+	}
+
+	return dates
+}
+
+// CycleDates steps through the billing dates of a cycle.
+type CycleDates struct {
+	cycle Cycle
+	rule  *Recurrence
+	start time.Time
+	date  time.Time
+	n     int
+}
+
+// Next returns the following billing date, or the zero time once the cycle doesn't repeat any more.
+func (d *CycleDates) Next() time.Time {
+	if d.date.IsZero() {
+		return d.date
+	}
+
+	d.n++
+
+	switch {
+	case d.cycle.Rule != "" && d.rule == nil, d.cycle.Rule == "" && d.cycle.Days == 0:
+		d.date = time.Time{}
+	case d.rule != nil:
+		d.date = d.rule.Next(d.date)
+	case d.cycle.calendarMonths() != 0:
+		// Counted from start rather than from the previous date, so the day of month lost in a
+		// short month comes back in the next.
+		d.date = addMonths(d.start, d.n*d.cycle.calendarMonths())
+	default:
+		d.date = d.start.AddDate(0, 0, d.n*int(d.cycle.Days))
+	}
+
+	return d.date
+}
+
+// calendarMonths returns how many calendar months a calendar cycle spans, or zero for a cycle that
+// counts days.
+func (c Cycle) calendarMonths() int {
+	if !c.Calendar {
+		return 0
+	}
+
+	switch c.Days {
+	case CalendarMonthDays:
+		return 1
+	case CalendarYearDays:
+		return monthsPerYear
+	}
+
+	return 0
+}
+
+// addMonths adds n months to t without overflowing into the following month: Jan 31 plus one month
+// is the last day of February.
+func addMonths(t time.Time, n int) time.Time {
+	first := time.Date(t.Year(), t.Month()+time.Month(n), 1, t.Hour(), t.Minute(), t.Second(), t.Nanosecond(), t.Location())
+	last := first.AddDate(0, 1, -1).Day()
+
+	return first.AddDate(0, 0, min(t.Day(), last)-1)
+}
+
+// compiledRule is a parsed cycle rule with its average, which takes four years of dates to work out.
+type compiledRule struct {
+	*Recurrence
+	perMonth float64
+}
+
+// maxCachedRules bounds the rule cache. Rules are user input, so without a bound every distinct
+// text ever seen would stay in memory.
+const maxCachedRules = 256
+
+// ruleCache keeps the compiled rules of cycles by their text, so that payment dates and monthly
+// costs don't parse a rule again for every date. Invalid rules are not cached, and a full cache
+// drops an arbitrary rule to make room.
+type ruleCache struct {
+	sync.Mutex
+	rules map[string]compiledRule
+}
+
+var compiledRules = &ruleCache{rules: make(map[string]compiledRule)}
+
+func (c *ruleCache) get(text string) (compiledRule, bool) {
+	c.Lock()
+	defer c.Unlock()
+
+	if rule, ok := c.rules[text]; ok {
+		return rule, true
+	}
+
+	recurrence, err := ParseRecurrence(text)
+	if err != nil {
+		return compiledRule{}, false
+	}
+
+	rule := compiledRule{Recurrence: recurrence, perMonth: recurrence.PerMonth()}
+
+	if len(c.rules) >= maxCachedRules {
+		for cached := range c.rules {
+			delete(c.rules, cached)
+			break
+		}
+	}
+
+	c.rules[text] = rule
+
+	return rule, true
+}
@@ -0,0 +1,117 @@
+package entity_test
+
+import (
+	"testing"
+	"time"
+
+	"git.home/alex/go-subscriptions/internal/domain/entity"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCycle_PerMonth(t *testing.T) {
+	testCases := []struct {
+		name  string
+		cycle entity.Cycle
+		want  float64
+	}{
+		{name: "Monthly", cycle: entity.Monthly, want: 1},
+		{name: "Yearly", cycle: entity.Yearly, want: 1.0 / 12},
+		{name: "Weekly", cycle: entity.Weekly, want: 365.0 / 12 / 7},
+		{name: "Thirty days", cycle: entity.Cycle{Days: 30}, want: 365.0 / 12 / 30},
+		{name: "Zero days", cycle: entity.Cycle{}, want: 0},
+		{name: "Quarterly rule", cycle: entity.Cycle{Rule: "FREQ=MONTHLY;INTERVAL=3;BYMONTHDAY=1"}, want: 1.0 / 3},
+		{
+			name:  "First weekday of the quarter",
+			cycle: entity.Cycle{Rule: "FREQ=MONTHLY;INTERVAL=3;BYDAY=MO,TU,WE,TH,FR;BYSETPOS=1"},
+			want:  1.0 / 3,
+		},
+		{name: "Daily rule", cycle: entity.Cycle{Rule: "FREQ=DAILY"}, want: (3*365 + 366) / 48.0},
+		{name: "Invalid rule", cycle: entity.Cycle{Days: 30, Rule: "FREQ=SOMETIMES"}, want: 0},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.InDelta(t, tc.want, tc.cycle.PerMonth(), 1e-9)
+		})
+	}
+}
+
+func TestCycle_Next(t *testing.T) {
+	jan31 := time.Date(2024, 1, 31, 0, 0, 0, 0, time.UTC)
+
+	testCases := []struct {
+		name  string
+		cycle entity.Cycle
+		want  time.Time
+	}{
+		{name: "Monthly ends with the month", cycle: entity.Monthly, want: time.Date(2024, 2, 29, 0, 0, 0, 0, time.UTC)},
+		{name: "Yearly", cycle: entity.Yearly, want: time.Date(2025, 1, 31, 0, 0, 0, 0, time.UTC)},
+		{name: "Weekly", cycle: entity.Weekly, want: time.Date(2024, 2, 7, 0, 0, 0, 0, time.UTC)},
+		{name: "Thirty days", cycle: entity.Cycle{Days: 30}, want: time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC)},
+		{name: "Zero days", cycle: entity.Cycle{}, want: time.Time{}},
+		{name: "Rule", cycle: entity.Cycle{Rule: "FREQ=MONTHLY;BYMONTHDAY=-1"}, want: time.Date(2024, 2, 29, 0, 0, 0, 0, time.UTC)},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.want, tc.cycle.Next(jan31))
+		})
+	}
+}
+
+func TestCycle_Nth(t *testing.T) {
+	date := func(year int, month time.Month, day int) time.Time {
+		return time.Date(year, month, day, 0, 0, 0, 0, time.UTC)
+	}
+
+	testCases := []struct {
+		name  string
+		cycle entity.Cycle
+		start time.Time
+		want  []time.Time
+	}{
+		{
+			name:  "Monthly from the 31st",
+			cycle: entity.Monthly,
+			start: date(2024, 1, 31),
+			want:  []time.Time{date(2024, 2, 29), date(2024, 3, 31), date(2024, 4, 30), date(2024, 5, 31), date(2024, 6, 30)},
+		},
+		{
+			name:  "Yearly from a leap day",
+			cycle: entity.Yearly,
+			start: date(2024, 2, 29),
+			want:  []time.Time{date(2025, 2, 28), date(2026, 2, 28), date(2027, 2, 28), date(2028, 2, 29), date(2029, 2, 28)},
+		},
+		{
+			name:  "Weekly",
+			cycle: entity.Weekly,
+			start: date(2024, 1, 31),
+			want:  []time.Time{date(2024, 2, 7), date(2024, 2, 14), date(2024, 2, 21), date(2024, 2, 28), date(2024, 3, 6)},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			for n, want := range tc.want {
+				assert.Equal(t, want, tc.cycle.Nth(tc.start, n+1))
+			}
+		})
+	}
+}
+
+func TestCycle_Dates(t *testing.T) {
+	start := time.Date(2024, 1, 31, 0, 0, 0, 0, time.UTC)
+
+	for _, cycle := range []entity.Cycle{
+		entity.Monthly,
+		entity.Weekly,
+		{Rule: "FREQ=MONTHLY;BYDAY=MO,TU,WE,TH,FR;BYSETPOS=-1"},
+		{Rule: "FREQ=DAILY;UNTIL=20240205"},
+		{},
+	} {
+		dates := cycle.Dates(start)
+		for n := 1; n <= 24; n++ {
+			assert.Equal(t, cycle.Nth(start, n), dates.Next(), "%+v date %d", cycle, n)
+		}
+	}
+}
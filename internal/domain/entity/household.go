@@ -0,0 +1,63 @@
+package entity
+
+// Household groups the people who share subscriptions. Members don't need a user account;
+// a member with a UserID can see the household and its report.
+type Household struct {
+	ID      uint
+	UserID  uint
+	Name    string
+	Members []HouseholdMember
+}
+
+type HouseholdMember struct {
+	ID     uint
+	Name   string
+	UserID uint
+}
+
+func (h Household) Member(id uint) (HouseholdMember, bool) {
+	for _, m := range h.Members {
+		if m.ID == id {
+			return m, true
+		}
+	}
+
+	return HouseholdMember{}, false
+}
+
+func (h Household) HasUser(userID uint) bool {
+	if h.UserID == userID {
+		return true
+	}
+
+	for _, m := range h.Members {
+		if m.UserID != 0 && m.UserID == userID {
+			return true
+		}
+	}
+
+	return false
+}
+
+type SplitRule string
+
+const (
+	SplitEqual      SplitRule = "equal"
+	SplitPercentage SplitRule = "percentage"
+	SplitFixed      SplitRule = "fixed"
+)
+
+// SubscriptionShare describes how the cost of a subscription is split among household members.
+// For SplitEqual the values are ignored and an empty Parts list means all members.
+// For SplitPercentage the values are percents summing to 100.
+// For SplitFixed the values are amounts per billing cycle summing to the subscription price.
+type SubscriptionShare struct {
+	HouseholdID uint
+	Rule        SplitRule
+	Parts       []SharePart
+}
+
+type SharePart struct {
+	MemberID uint
+	Value    float64
+}
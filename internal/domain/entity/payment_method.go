@@ -0,0 +1,51 @@
+package entity
+
+import "time"
+
+type PaymentMethodType string
+
+const (
+	PaymentCard        PaymentMethodType = "card"
+	PaymentBankAccount PaymentMethodType = "bank_account"
+	PaymentWallet      PaymentMethodType = "wallet"
+	PaymentOther       PaymentMethodType = "other"
+)
+
+func (t PaymentMethodType) Valid() bool {
+	switch t {
+	case PaymentCard, PaymentBankAccount, PaymentWallet, PaymentOther:
+		return true
+	default:
+		return false
+	}
+}
+
+// PaymentMethod is what subscriptions are charged to. A method without an expiry has a zero
+// ExpiryYear and ExpiryMonth; a card stays valid until the end of its expiry month.
+type PaymentMethod struct {
+	ID          uint
+	UserID      uint
+	Label       string
+	Type        PaymentMethodType
+	LastFour    string
+	ExpiryYear  int
+	ExpiryMonth time.Month
+}
+
+func (m PaymentMethod) Expires() bool {
+	return m.ExpiryYear != 0
+}
+
+// ExpiresAt is the first day the method can no longer be charged, zero when it doesn't expire.
+func (m PaymentMethod) ExpiresAt() time.Time {
+	if !m.Expires() {
+		return time.Time{}
+	}
+
+	return time.Date(m.ExpiryYear, m.ExpiryMonth+1, 1, 0, 0, 0, 0, time.UTC)
+}
+
+// ExpiresBefore reports whether the method can't be charged on date, including when it has expired already.
+func (m PaymentMethod) ExpiresBefore(date time.Time) bool {
+	return m.Expires() && !date.Before(m.ExpiresAt())
+}
@@ -0,0 +1,55 @@
+package entity
+
+import (
+	"sort"
+	"time"
+)
+
+// PriceChange is a price in effect from EffectiveDate on. The first change of a history may have
+// a zero EffectiveDate, meaning the price the subscription started with.
+type PriceChange struct {
+	Price         float64
+	EffectiveDate PaymentDate
+}
+
+// PriceAt returns the price in effect on the day of t. Without a history it is the current Price.
+func (s Subscription) PriceAt(t time.Time) float64 {
+	if len(s.Prices) == 0 {
+		return s.Price
+	}
+
+	price := s.Prices[0].Price
+	for _, change := range s.Prices {
+		if !time.Time(change.EffectiveDate).IsZero() && !reached(change.EffectiveDate, t) {
+			break
+		}
+
+		price = change.Price
+	}
+
+	return price
+}
+
+// WithPrice records price as effective from the day of date and returns the new history. A change
+// on the same day replaces the earlier one; the price before the first recorded change is kept
+// with a zero date.
+func (s Subscription) WithPrice(price float64, date time.Time) []PriceChange {
+	history := make([]PriceChange, 0, len(s.Prices)+2)
+	if len(s.Prices) == 0 {
+		history = append(history, PriceChange{Price: s.Price})
+	}
+
+	day := PaymentDate(time.Date(date.Year(), date.Month(), date.Day(), 0, 0, 0, 0, time.UTC))
+	for _, change := range s.Prices {
+		if change.EffectiveDate != day {
+			history = append(history, change)
+		}
+	}
+
+	history = append(history, PriceChange{Price: price, EffectiveDate: day})
+	sort.SliceStable(history, func(i, j int) bool {
+		return time.Time(history[i].EffectiveDate).Before(time.Time(history[j].EffectiveDate))
+	})
+
+	return history
+}
@@ -0,0 +1,62 @@
+package entity_test
+
+import (
+	"testing"
+	"time"
+
+	"git.home/alex/go-subscriptions/internal/domain/entity"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSubscription_PriceAt(t *testing.T) {
+	day := func(d int) entity.PaymentDate { return entity.PaymentDate(time.Date(2024, 5, d, 0, 0, 0, 0, time.UTC)) }
+	subscription := entity.Subscription{
+		Price:  12,
+		Prices: []entity.PriceChange{{Price: 10}, {Price: 12, EffectiveDate: day(10)}, {Price: 15, EffectiveDate: day(20)}},
+	}
+
+	assert.InDelta(t, 10, subscription.PriceAt(time.Date(2024, 5, 9, 23, 0, 0, 0, time.UTC)), 0.001)
+	assert.InDelta(t, 12, subscription.PriceAt(time.Date(2024, 5, 10, 8, 0, 0, 0, time.UTC)), 0.001)
+	assert.InDelta(t, 15, subscription.PriceAt(time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC)), 0.001)
+	assert.InDelta(t, 7, entity.Subscription{Price: 7}.PriceAt(time.Now()), 0.001)
+}
+
+func TestSubscription_WithPrice(t *testing.T) {
+	day := func(d int) entity.PaymentDate { return entity.PaymentDate(time.Date(2024, 5, d, 0, 0, 0, 0, time.UTC)) }
+
+	testCases := []struct {
+		name   string
+		prices []entity.PriceChange
+		price  float64
+		date   time.Time
+		want   []entity.PriceChange
+	}{
+		{
+			name:  "First change keeps the initial price",
+			price: 12,
+			date:  time.Date(2024, 5, 10, 15, 0, 0, 0, time.UTC),
+			want:  []entity.PriceChange{{Price: 10}, {Price: 12, EffectiveDate: day(10)}},
+		},
+		{
+			name:   "Same day replaces the change",
+			prices: []entity.PriceChange{{Price: 10}, {Price: 12, EffectiveDate: day(10)}},
+			price:  11,
+			date:   time.Date(2024, 5, 10, 0, 0, 0, 0, time.UTC),
+			want:   []entity.PriceChange{{Price: 10}, {Price: 11, EffectiveDate: day(10)}},
+		},
+		{
+			name:   "Backdated change is sorted",
+			prices: []entity.PriceChange{{Price: 10}, {Price: 12, EffectiveDate: day(10)}},
+			price:  11,
+			date:   time.Date(2024, 5, 5, 0, 0, 0, 0, time.UTC),
+			want:   []entity.PriceChange{{Price: 10}, {Price: 11, EffectiveDate: day(5)}, {Price: 12, EffectiveDate: day(10)}},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			subscription := entity.Subscription{Price: 10, Prices: tc.prices}
+			assert.Equal(t, tc.want, subscription.WithPrice(tc.price, tc.date))
+		})
+	}
+}
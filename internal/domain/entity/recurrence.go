@@ -0,0 +1,464 @@
+package entity
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// maxRecurrencePeriods bounds the periods scanned for the next occurrence, so a rule that hardly
+// ever matches, like a daily rule for the 29th of February, is still found while one that never
+// matches gives up.
+const maxRecurrencePeriods = 2000
+
+type Frequency string
+
+const (
+	FreqDaily   Frequency = "DAILY"
+	FreqWeekly  Frequency = "WEEKLY"
+	FreqMonthly Frequency = "MONTHLY"
+	FreqYearly  Frequency = "YEARLY"
+)
+
+var weekdayCodes = []string{"SU", "MO", "TU", "WE", "TH", "FR", "SA"}
+
+// WeekdayNum is a BYDAY entry. A non-zero N picks the N-th such weekday of the month, or of the year
+// for a yearly rule without BYMONTH, counting from the end when negative.
+type WeekdayNum struct {
+	N       int
+	Weekday time.Weekday
+}
+
+// Recurrence is the part of an RFC 5545 RRULE that describes billing dates: FREQ, INTERVAL, BYMONTH,
+// BYMONTHDAY, BYDAY, BYSETPOS and WKST. The date a rule is expanded from plays the part of DTSTART,
+// e.g. a monthly rule without BYMONTHDAY or BYDAY repeats on the day of the month of that date.
+type Recurrence struct {
+	Freq       Frequency
+	Interval   int
+	ByMonth    []time.Month
+	ByMonthDay []int
+	ByDay      []WeekdayNum
+	BySetPos   []int
+	WeekStart  time.Weekday
+}
+
+// ParseRecurrence parses an RRULE value, with or without the "RRULE:" prefix.
+func ParseRecurrence(rule string) (*Recurrence, error) {
+	rule = strings.TrimPrefix(strings.ToUpper(strings.TrimSpace(rule)), "RRULE:")
+	r := &Recurrence{Interval: 1, WeekStart: time.Monday}
+	seen := make(map[string]bool)
+
+	for _, part := range strings.Split(rule, ";") {
+		name, value, ok := strings.Cut(part, "=")
+		if !ok || value == "" {
+			return nil, fmt.Errorf("malformed rule part %q", part)
+		}
+
+		if seen[name] {
+			return nil, fmt.Errorf("repeated rule part %s", name)
+		}
+
+		seen[name] = true
+
+		err := r.parsePart(name, value)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", name, err)
+		}
+	}
+
+	return r, r.validate()
+}
+
+func (r *Recurrence) parsePart(name, value string) error {
+	var err error
+
+	switch name {
+	case "FREQ":
+		r.Freq = Frequency(value)
+		if r.Freq != FreqDaily && r.Freq != FreqWeekly && r.Freq != FreqMonthly && r.Freq != FreqYearly {
+			return fmt.Errorf("unsupported frequency %q", value)
+		}
+	case "INTERVAL":
+		r.Interval, err = parseRuleInt(value, 1, 1000)
+	case "BYMONTH":
+		r.ByMonth, err = parseMonths(value)
+	case "BYMONTHDAY":
+		r.ByMonthDay, err = parseRuleInts(value, 31)
+	case "BYDAY":
+		r.ByDay, err = parseWeekdays(value)
+	case "BYSETPOS":
+		r.BySetPos, err = parseRuleInts(value, 366)
+	case "WKST":
+		r.WeekStart, err = parseWeekday(value)
+	default:
+		return errors.New("unsupported rule part")
+	}
+
+	return err
+}
+
+func (r *Recurrence) validate() error {
+	if r.Freq == "" {
+		return errors.New("FREQ is required")
+	}
+
+	if r.Freq == FreqWeekly && len(r.ByMonthDay) > 0 {
+		return errors.New("BYMONTHDAY can't be used with a weekly rule")
+	}
+
+	if len(r.BySetPos) > 0 && len(r.ByMonth)+len(r.ByMonthDay)+len(r.ByDay) == 0 {
+		return errors.New("BYSETPOS needs another BY rule part")
+	}
+
+	for _, day := range r.ByDay {
+		if day.N == 0 {
+			continue
+		}
+
+		maxN := 53
+		if r.Freq == FreqMonthly || len(r.ByMonth) > 0 {
+			maxN = 5
+		}
+
+		if r.Freq == FreqDaily || r.Freq == FreqWeekly || day.N > maxN || day.N < -maxN {
+			return fmt.Errorf("BYDAY: invalid position %d", day.N)
+		}
+	}
+
+	return nil
+}
+
+// String formats the rule the way it is stored, without the "RRULE:" prefix and with the parts in a
+// fixed order.
+func (r Recurrence) String() string {
+	parts := []string{"FREQ=" + string(r.Freq)}
+
+	if r.Interval > 1 {
+		parts = append(parts, "INTERVAL="+strconv.Itoa(r.Interval))
+	}
+
+	if len(r.ByMonth) > 0 {
+		months := make([]int, len(r.ByMonth))
+		for i, m := range r.ByMonth {
+			months[i] = int(m)
+		}
+
+		parts = append(parts, "BYMONTH="+joinInts(months))
+	}
+
+	if len(r.ByMonthDay) > 0 {
+		parts = append(parts, "BYMONTHDAY="+joinInts(r.ByMonthDay))
+	}
+
+	if len(r.ByDay) > 0 {
+		days := make([]string, len(r.ByDay))
+		for i, d := range r.ByDay {
+			days[i] = weekdayCodes[d.Weekday]
+			if d.N != 0 {
+				days[i] = strconv.Itoa(d.N) + days[i]
+			}
+		}
+
+		parts = append(parts, "BYDAY="+strings.Join(days, ","))
+	}
+
+	if len(r.BySetPos) > 0 {
+		parts = append(parts, "BYSETPOS="+joinInts(r.BySetPos))
+	}
+
+	if r.WeekStart != time.Monday {
+		parts = append(parts, "WKST="+weekdayCodes[r.WeekStart])
+	}
+
+	return strings.Join(parts, ";")
+}
+
+// Next returns the first occurrence after t, with t as the start of the rule. It returns the zero
+// time when the rule doesn't match any date.
+func (r Recurrence) Next(t time.Time) time.Time {
+	start := r.periodStart(t)
+
+	for k := 0; k < maxRecurrencePeriods; k++ {
+		for _, date := range r.occurrences(t, r.advance(start, k*r.Interval)) {
+			if date.After(t) {
+				return date
+			}
+		}
+	}
+
+	return time.Time{}
+}
+
+// PerMonth returns the average number of occurrences per month over four years, leap day included.
+// The years are counted from and including the 1st of January 2024 to the 1st of January 2028.
+func (r Recurrence) PerMonth() float64 {
+	from := time.Date(2024, time.January, 1, 0, 0, 0, 0, time.UTC)
+	to := from.AddDate(4, 0, 0)
+	count := 0
+
+	// Next only returns dates after its argument, so the first day is checked on its own.
+	for _, date := range r.occurrences(from, r.periodStart(from)) {
+		if date.Equal(from) {
+			count++
+		}
+	}
+
+	for date := r.Next(from); !date.IsZero() && date.Before(to); date = r.Next(date) {
+		count++
+	}
+
+	return float64(count) / (4 * monthsPerYear)
+}
+
+type dateSpan struct {
+	from, to time.Time
+}
+
+func (r Recurrence) periodStart(t time.Time) time.Time {
+	day := time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, t.Location())
+
+	switch r.Freq {
+	case FreqWeekly:
+		return day.AddDate(0, 0, -int((day.Weekday()-r.WeekStart+7)%7))
+	case FreqMonthly:
+		return day.AddDate(0, 0, 1-day.Day())
+	case FreqYearly:
+		return time.Date(t.Year(), time.January, 1, 0, 0, 0, 0, t.Location())
+	default:
+		return day
+	}
+}
+
+func (r Recurrence) advance(start time.Time, n int) time.Time {
+	switch r.Freq {
+	case FreqWeekly:
+		return start.AddDate(0, 0, 7*n)
+	case FreqMonthly:
+		return start.AddDate(0, n, 0)
+	case FreqYearly:
+		return start.AddDate(n, 0, 0)
+	default:
+		return start.AddDate(0, 0, n)
+	}
+}
+
+// spans returns the ranges the days of the period starting on start are picked from; BYDAY positions
+// count within a span.
+func (r Recurrence) spans(anchor, start time.Time) []dateSpan {
+	switch r.Freq {
+	case FreqDaily:
+		return []dateSpan{{start, start.AddDate(0, 0, 1)}}
+	case FreqWeekly:
+		return []dateSpan{{start, start.AddDate(0, 0, 7)}}
+	case FreqMonthly:
+		return []dateSpan{{start, start.AddDate(0, 1, 0)}}
+	}
+
+	months := r.ByMonth
+
+	switch {
+	case len(months) > 0:
+	case len(r.ByMonthDay) > 0:
+		months = []time.Month{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12}
+	case len(r.ByDay) > 0:
+		return []dateSpan{{start, start.AddDate(1, 0, 0)}}
+	default:
+		months = []time.Month{anchor.Month()}
+	}
+
+	spans := make([]dateSpan, len(months))
+	for i, m := range months {
+		from := time.Date(start.Year(), m, 1, 0, 0, 0, 0, start.Location())
+		spans[i] = dateSpan{from, from.AddDate(0, 1, 0)}
+	}
+
+	return spans
+}
+
+func (r Recurrence) occurrences(anchor, start time.Time) []time.Time {
+	var dates []time.Time
+
+	for _, span := range r.spans(anchor, start) {
+		for date := span.from; date.Before(span.to); date = date.AddDate(0, 0, 1) {
+			if r.matches(date, anchor, span) {
+				dates = append(dates, date)
+			}
+		}
+	}
+
+	if len(r.BySetPos) == 0 {
+		return dates
+	}
+
+	picked := make([]time.Time, 0, len(r.BySetPos))
+
+	for _, pos := range r.BySetPos {
+		i := pos - 1
+		if pos < 0 {
+			i = len(dates) + pos
+		}
+
+		if i >= 0 && i < len(dates) {
+			picked = append(picked, dates[i])
+		}
+	}
+
+	sort.Slice(picked, func(i, j int) bool { return picked[i].Before(picked[j]) })
+
+	return picked
+}
+
+func (r Recurrence) matches(date, anchor time.Time, span dateSpan) bool {
+	if len(r.ByMonth) > 0 && !containsMonth(r.ByMonth, date.Month()) {
+		return false
+	}
+
+	if len(r.ByMonthDay) > 0 {
+		if !matchesMonthDay(r.ByMonthDay, date) {
+			return false
+		}
+	} else if len(r.ByDay) == 0 && (r.Freq == FreqMonthly || r.Freq == FreqYearly) && date.Day() != anchor.Day() {
+		return false
+	}
+
+	if len(r.ByDay) == 0 {
+		return r.Freq != FreqWeekly || date.Weekday() == anchor.Weekday()
+	}
+
+	for _, day := range r.ByDay {
+		if day.Weekday != date.Weekday() {
+			continue
+		}
+
+		switch {
+		case day.N == 0:
+			return true
+		case day.N > 0 && daysBetween(span.from, date)/7+1 == day.N:
+			return true
+		case day.N < 0 && (daysBetween(date, span.to)-1)/7+1 == -day.N:
+			return true
+		}
+	}
+
+	return false
+}
+
+func matchesMonthDay(days []int, date time.Time) bool {
+	last := time.Date(date.Year(), date.Month()+1, 0, 0, 0, 0, 0, date.Location()).Day()
+
+	for _, day := range days {
+		if day == date.Day() || day < 0 && last+day+1 == date.Day() {
+			return true
+		}
+	}
+
+	return false
+}
+
+func containsMonth(months []time.Month, month time.Month) bool {
+	for _, m := range months {
+		if m == month {
+			return true
+		}
+	}
+
+	return false
+}
+
+// daysBetween counts calendar days, so a daylight saving change in between doesn't shift it.
+func daysBetween(from, to time.Time) int {
+	return int(math.Round(to.Sub(from).Hours() / 24))
+}
+
+func parseRuleInt(value string, low, high int) (int, error) {
+	n, err := strconv.Atoi(value)
+	if err != nil || n < low || n > high {
+		return 0, fmt.Errorf("invalid number %q", value)
+	}
+
+	return n, nil
+}
+
+// parseRuleInts parses a list of non-zero numbers between -limit and limit.
+func parseRuleInts(value string, limit int) ([]int, error) {
+	var numbers []int
+
+	for _, item := range strings.Split(value, ",") {
+		n, err := parseRuleInt(item, -limit, limit)
+		if err != nil || n == 0 {
+			return nil, fmt.Errorf("invalid number %q", item)
+		}
+
+		numbers = append(numbers, n)
+	}
+
+	return numbers, nil
+}
+
+func parseMonths(value string) ([]time.Month, error) {
+	var months []time.Month
+
+	for _, item := range strings.Split(value, ",") {
+		n, err := parseRuleInt(item, 1, monthsPerYear)
+		if err != nil {
+			return nil, err
+		}
+
+		months = append(months, time.Month(n))
+	}
+
+	sort.Slice(months, func(i, j int) bool { return months[i] < months[j] })
+
+	return months, nil
+}
+
+func parseWeekdays(value string) ([]WeekdayNum, error) {
+	var days []WeekdayNum
+
+	for _, item := range strings.Split(value, ",") {
+		if len(item) < 2 {
+			return nil, fmt.Errorf("invalid weekday %q", item)
+		}
+
+		weekday, err := parseWeekday(item[len(item)-2:])
+		if err != nil {
+			return nil, err
+		}
+
+		day := WeekdayNum{Weekday: weekday}
+
+		if position := item[:len(item)-2]; position != "" {
+			day.N, err = strconv.Atoi(position)
+			if err != nil || day.N == 0 {
+				return nil, fmt.Errorf("invalid weekday %q", item)
+			}
+		}
+
+		days = append(days, day)
+	}
+
+	return days, nil
+}
+
+func parseWeekday(value string) (time.Weekday, error) {
+	for i, code := range weekdayCodes {
+		if code == value {
+			return time.Weekday(i), nil
+		}
+	}
+
+	return 0, fmt.Errorf("invalid weekday %q", value)
+}
+
+func joinInts(numbers []int) string {
+	items := make([]string, len(numbers))
+	for i, n := range numbers {
+		items[i] = strconv.Itoa(n)
+	}
+
+	return strings.Join(items, ",")
+}
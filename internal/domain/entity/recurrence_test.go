@@ -0,0 +1,114 @@
+package entity_test
+
+import (
+	"testing"
+	"time"
+
+	"git.home/alex/go-subscriptions/internal/domain/entity"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseRecurrence(t *testing.T) {
+	testCases := []struct {
+		name    string
+		rule    string
+		want    string
+		wantErr bool
+	}{
+		{name: "Prefix and case", rule: "rrule:freq=monthly;interval=1", want: "FREQ=MONTHLY"},
+		{name: "Part order", rule: "BYSETPOS=1;BYDAY=MO,TU;INTERVAL=3;FREQ=MONTHLY", want: "FREQ=MONTHLY;INTERVAL=3;BYDAY=MO,TU;BYSETPOS=1"},
+		{name: "Months sorted", rule: "FREQ=YEARLY;BYMONTH=9,3;BYDAY=-1FR", want: "FREQ=YEARLY;BYMONTH=3,9;BYDAY=-1FR"},
+		{name: "Missing FREQ", rule: "INTERVAL=2", wantErr: true},
+		{name: "Unsupported part", rule: "FREQ=MONTHLY;COUNT=3", wantErr: true},
+		{name: "Unsupported frequency", rule: "FREQ=HOURLY", wantErr: true},
+		{name: "Repeated part", rule: "FREQ=MONTHLY;FREQ=YEARLY", wantErr: true},
+		{name: "Position in a weekly rule", rule: "FREQ=WEEKLY;BYDAY=1MO", wantErr: true},
+		{name: "Position out of the month", rule: "FREQ=MONTHLY;BYDAY=6MO", wantErr: true},
+		{name: "Zero month day", rule: "FREQ=MONTHLY;BYMONTHDAY=0", wantErr: true},
+		{name: "Set position alone", rule: "FREQ=MONTHLY;BYSETPOS=1", wantErr: true},
+		{name: "Malformed", rule: "FREQ", wantErr: true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			rule, err := entity.ParseRecurrence(tc.rule)
+			if tc.wantErr {
+				assert.Error(t, err)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tc.want, rule.String())
+		})
+	}
+}
+
+func TestRecurrence_Next(t *testing.T) {
+	date := func(y int, m time.Month, d int) time.Time { return time.Date(y, m, d, 0, 0, 0, 0, time.UTC) }
+
+	testCases := []struct {
+		name  string
+		rule  string
+		start time.Time
+		want  []time.Time
+	}{
+		{
+			name:  "First business day of the quarter",
+			rule:  "FREQ=MONTHLY;INTERVAL=3;BYDAY=MO,TU,WE,TH,FR;BYSETPOS=1",
+			start: date(2024, 1, 1),
+			want:  []time.Time{date(2024, 4, 1), date(2024, 7, 1), date(2024, 10, 1), date(2025, 1, 1)},
+		},
+		{
+			name:  "Specific months only",
+			rule:  "FREQ=YEARLY;BYMONTH=3,9;BYMONTHDAY=15",
+			start: date(2024, 3, 15),
+			want:  []time.Time{date(2024, 9, 15), date(2025, 3, 15), date(2025, 9, 15)},
+		},
+		{
+			name:  "Last Friday of the month",
+			rule:  "FREQ=MONTHLY;BYDAY=-1FR",
+			start: date(2024, 1, 26),
+			want:  []time.Time{date(2024, 2, 23), date(2024, 3, 29)},
+		},
+		{
+			name:  "Month day skips short months",
+			rule:  "FREQ=MONTHLY",
+			start: date(2024, 1, 31),
+			want:  []time.Time{date(2024, 3, 31), date(2024, 5, 31)},
+		},
+		{
+			name:  "Last day of the month",
+			rule:  "FREQ=MONTHLY;BYMONTHDAY=-1",
+			start: date(2024, 1, 31),
+			want:  []time.Time{date(2024, 2, 29), date(2024, 3, 31)},
+		},
+		{
+			name:  "Every other week",
+			rule:  "FREQ=WEEKLY;INTERVAL=2",
+			start: date(2024, 1, 3),
+			want:  []time.Time{date(2024, 1, 17), date(2024, 1, 31)},
+		},
+		{
+			name:  "Never matches",
+			rule:  "FREQ=YEARLY;BYMONTH=2;BYMONTHDAY=30",
+			start: date(2024, 1, 1),
+			want:  []time.Time{{}},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			rule, err := entity.ParseRecurrence(tc.rule)
+			require.NoError(t, err)
+
+			got := make([]time.Time, 0, len(tc.want))
+			for next := tc.start; len(got) < len(tc.want); {
+				next = rule.Next(next)
+				got = append(got, next)
+			}
+
+			assert.Equal(t, tc.want, got)
+		})
+	}
+}
@@ -0,0 +1,93 @@
+package entity
+
+import "time"
+
+// SubscriptionStatus is the lifecycle state of a subscription. The empty status is treated as active,
+// so subscriptions stored before the states existed keep being billed.
+type SubscriptionStatus string
+
+const (
+	StatusTrial     SubscriptionStatus = "trial"
+	StatusActive    SubscriptionStatus = "active"
+	StatusPaused    SubscriptionStatus = "paused"
+	StatusCancelled SubscriptionStatus = "cancelled"
+	StatusExpired   SubscriptionStatus = "expired"
+)
+
+var transitions = map[SubscriptionStatus][]SubscriptionStatus{
+	StatusTrial:     {StatusActive, StatusPaused, StatusCancelled, StatusExpired},
+	StatusActive:    {StatusPaused, StatusCancelled, StatusExpired},
+	StatusPaused:    {StatusActive, StatusCancelled, StatusExpired},
+	StatusCancelled: {StatusActive},
+	StatusExpired:   {StatusActive},
+}
+
+func (s SubscriptionStatus) Valid() bool {
+	_, ok := transitions[s.orActive()]
+	return ok
+}
+
+// CanBecome reports whether a subscription in this state may change to the other one.
+func (s SubscriptionStatus) CanBecome(to SubscriptionStatus) bool {
+	for _, allowed := range transitions[s.orActive()] {
+		if allowed == to {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (s SubscriptionStatus) orActive() SubscriptionStatus {
+	if s == "" {
+		return StatusActive
+	}
+
+	return s
+}
+
+// StatusAt returns the state in effect on the day of t: a trial past its end date and a pause past
+// its resume date are active again, a cancellation only counts from its effective date, and a
+// contract that doesn't renew expires the subscription on its end date.
+func (s Subscription) StatusAt(t time.Time) SubscriptionStatus {
+	status := s.lifecycleStatusAt(t)
+	if status != StatusCancelled && s.Contract != nil && s.Contract.Ended(t) {
+		return StatusExpired
+	}
+
+	return status
+}
+
+func (s Subscription) lifecycleStatusAt(t time.Time) SubscriptionStatus {
+	switch s.Status {
+	case StatusTrial:
+		if reached(s.TrialEndDate, t) {
+			return StatusActive
+		}
+	case StatusPaused:
+		if reached(s.ResumeDate, t) {
+			return StatusActive
+		}
+	case StatusCancelled:
+		if !time.Time(s.CancelDate).IsZero() && !reached(s.CancelDate, t) {
+			return StatusActive
+		}
+	}
+
+	return s.Status.orActive()
+}
+
+// IsActive reports whether the subscription is billed on the day of t.
+func (s Subscription) IsActive(t time.Time) bool {
+	return s.StatusAt(t) == StatusActive
+}
+
+// reached reports whether the day of t is on or after date. A zero date is never reached.
+func reached(date PaymentDate, t time.Time) bool {
+	d := time.Time(date)
+	if d.IsZero() {
+		return false
+	}
+
+	return !t.Before(time.Date(d.Year(), d.Month(), d.Day(), 0, 0, 0, 0, t.Location()))
+}
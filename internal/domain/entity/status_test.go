@@ -0,0 +1,131 @@
+package entity_test
+
+import (
+	"testing"
+	"time"
+
+	"git.home/alex/go-subscriptions/internal/domain/entity"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSubscription_StatusAt(t *testing.T) {
+	day := func(d int) entity.PaymentDate { return entity.PaymentDate(time.Date(2024, 5, d, 0, 0, 0, 0, time.UTC)) }
+	now := time.Date(2024, 5, 10, 15, 0, 0, 0, time.UTC)
+
+	testCases := []struct {
+		name         string
+		subscription entity.Subscription
+		want         entity.SubscriptionStatus
+	}{
+		{name: "No status", subscription: entity.Subscription{}, want: entity.StatusActive},
+		{name: "Trial", subscription: entity.Subscription{Status: entity.StatusTrial, TrialEndDate: day(11)}, want: entity.StatusTrial},
+		{
+			name:         "Trial ended today",
+			subscription: entity.Subscription{Status: entity.StatusTrial, TrialEndDate: day(10)},
+			want:         entity.StatusActive,
+		},
+		{name: "Paused", subscription: entity.Subscription{Status: entity.StatusPaused, ResumeDate: day(20)}, want: entity.StatusPaused},
+		{name: "Paused indefinitely", subscription: entity.Subscription{Status: entity.StatusPaused}, want: entity.StatusPaused},
+		{name: "Resumed", subscription: entity.Subscription{Status: entity.StatusPaused, ResumeDate: day(1)}, want: entity.StatusActive},
+		{
+			name:         "Cancellation scheduled",
+			subscription: entity.Subscription{Status: entity.StatusCancelled, CancelDate: day(31)},
+			want:         entity.StatusActive,
+		},
+		{name: "Cancelled", subscription: entity.Subscription{Status: entity.StatusCancelled, CancelDate: day(10)}, want: entity.StatusCancelled},
+		{name: "Expired", subscription: entity.Subscription{Status: entity.StatusExpired}, want: entity.StatusExpired},
+		{
+			name:         "Contract ended",
+			subscription: entity.Subscription{Contract: &entity.Contract{StartDate: day(1), EndDate: day(10)}},
+			want:         entity.StatusExpired,
+		},
+		{
+			name:         "Contract renewed",
+			subscription: entity.Subscription{Contract: &entity.Contract{StartDate: day(1), EndDate: day(10), AutoRenew: true}},
+			want:         entity.StatusActive,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.want, tc.subscription.StatusAt(now))
+			assert.Equal(t, tc.want == entity.StatusActive, tc.subscription.IsActive(now))
+		})
+	}
+}
+
+func TestSubscriptionStatus_CanBecome(t *testing.T) {
+	assert.True(t, entity.SubscriptionStatus("").CanBecome(entity.StatusPaused))
+	assert.True(t, entity.StatusTrial.CanBecome(entity.StatusActive))
+	assert.True(t, entity.StatusCancelled.CanBecome(entity.StatusActive))
+	assert.False(t, entity.StatusActive.CanBecome(entity.StatusActive))
+	assert.False(t, entity.StatusActive.CanBecome(entity.StatusTrial))
+	assert.False(t, entity.StatusExpired.CanBecome(entity.StatusPaused))
+	assert.False(t, entity.SubscriptionStatus("unknown").Valid())
+}
+
+func TestSubscription_PaymentsBetween(t *testing.T) {
+	date := func(m time.Month, d int) time.Time { return time.Date(2024, m, d, 0, 0, 0, 0, time.UTC) }
+	base := entity.Subscription{Cycle: entity.Monthly, NextPaymentDate: entity.PaymentDate(date(1, 15))}
+
+	with := func(modify func(s *entity.Subscription)) entity.Subscription {
+		s := base
+		modify(&s)
+		return s
+	}
+
+	testCases := []struct {
+		name         string
+		subscription entity.Subscription
+		want         []time.Time
+	}{
+		{
+			name:         "Active",
+			subscription: base,
+			want:         []time.Time{date(3, 15), date(4, 15), date(5, 15)},
+		},
+		{
+			name: "Trial charged from its end date",
+			subscription: with(func(s *entity.Subscription) {
+				s.Status = entity.StatusTrial
+				s.TrialEndDate = entity.PaymentDate(date(4, 2))
+			}),
+			want: []time.Time{date(4, 2), date(5, 2)},
+		},
+		{
+			name: "Paused until May",
+			subscription: with(func(s *entity.Subscription) {
+				s.Status = entity.StatusPaused
+				s.ResumeDate = entity.PaymentDate(date(5, 1))
+			}),
+			want: []time.Time{date(5, 15)},
+		},
+		{
+			name: "Cancelled from April",
+			subscription: with(func(s *entity.Subscription) {
+				s.Status = entity.StatusCancelled
+				s.CancelDate = entity.PaymentDate(date(4, 1))
+			}),
+			want: []time.Time{date(3, 15)},
+		},
+		{
+			name:         "Started on the 31st",
+			subscription: with(func(s *entity.Subscription) { s.NextPaymentDate = entity.PaymentDate(date(1, 31)) }),
+			want:         []time.Time{date(3, 31), date(4, 30), date(5, 31)},
+		},
+		{
+			name:         "Expired",
+			subscription: with(func(s *entity.Subscription) { s.Status = entity.StatusExpired }),
+		},
+		{
+			name:         "No payment date",
+			subscription: with(func(s *entity.Subscription) { s.NextPaymentDate = entity.PaymentDate{} }),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.want, tc.subscription.PaymentsBetween(date(3, 1), date(5, 31)))
+		})
+	}
+}
@@ -7,8 +7,9 @@ import (
 type PaymentDate time.Time
 
 type Subscription struct {
-	ID    uint
-	Price float64
+	ID     uint
+	UserID uint
+	Price  float64
 	Category
 	Currency
 	Cycle
@@ -16,4 +17,66 @@ type Subscription struct {
 	Name            string
 	Note            string
 	Logo            string
+	Share           *SubscriptionShare
+	Status          SubscriptionStatus
+	// TrialStartDate and TrialEndDate bound the free trial; Price is what is charged after it.
+	TrialStartDate PaymentDate
+	// TrialEndDate, ResumeDate and CancelDate belong to the trial, paused and cancelled states.
+	TrialEndDate PaymentDate
+	ResumeDate   PaymentDate
+	CancelDate   PaymentDate
+	// Prices is the price history ordered by effective date, empty until the price first changes.
+	Prices []PriceChange
+	TagIDs []uint
+	// PaymentMethodID is zero when the subscription isn't linked to a payment method.
+	PaymentMethodID uint
+	// Contract is nil for a subscription without a commitment.
+	Contract *Contract
+	// LogoAssetID is zero when no logo image was uploaded; Logo stays a free-form value.
+	LogoAssetID uint
+}
+
+// MonthlyCost is the price in effect on the day of t normalized to an average month.
+func (s Subscription) MonthlyCost(t time.Time) float64 {
+	return s.PriceAt(t) * s.Cycle.PerMonth()
+}
+
+// PaymentsBetween returns the billing dates from from to to, both included, on which the subscription
+// is active. A trial is first charged on its end date, whatever NextPaymentDate says.
+func (s Subscription) PaymentsBetween(from, to time.Time) []time.Time {
+	start := time.Time(s.NextPaymentDate)
+	if s.Status == StatusTrial && !time.Time(s.TrialEndDate).IsZero() {
+		start = time.Time(s.TrialEndDate)
+	}
+
+	if start.IsZero() {
+		return nil
+	}
+
+	dates := s.Cycle.Dates(start)
+
+	date := start
+	for date.Before(from) {
+		date = dates.Next()
+		if date.IsZero() {
+			return nil
+		}
+	}
+
+	var payments []time.Time
+
+	for !date.IsZero() && !date.After(to) {
+		if s.IsActive(date) {
+			payments = append(payments, date)
+		}
+
+		date = dates.Next()
+	}
+
+	return payments
+}
+
+// IsFirstPaidCharge reports whether the payment on date converts the trial into a paid subscription.
+func (s Subscription) IsFirstPaidCharge(date time.Time) bool {
+	return s.Status == StatusTrial && time.Time(s.TrialEndDate).Equal(date)
 }
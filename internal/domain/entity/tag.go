@@ -0,0 +1,21 @@
+package entity
+
+import "slices"
+
+// Tag labels subscriptions across categories; a subscription can carry any number of tags.
+type Tag struct {
+	ID     uint
+	UserID uint
+	Name   string
+}
+
+// HasTags reports whether the subscription carries every one of the tags.
+func (s Subscription) HasTags(ids ...uint) bool {
+	for _, id := range ids {
+		if !slices.Contains(s.TagIDs, id) {
+			return false
+		}
+	}
+
+	return true
+}
@@ -0,0 +1,9 @@
+package entity
+
+type User struct {
+	ID           uint
+	Username     string
+	PasswordHash string
+	// Timezone is an IANA zone name, empty for the configured default.
+	Timezone string
+}
@@ -0,0 +1,25 @@
+package repository
+
+import (
+	"context"
+	"errors"
+
+	"git.home/alex/go-subscriptions/internal/domain/entity"
+)
+
+var (
+	ErrNotFoundAsset = errors.New("the asset was not found in the repository")
+	ErrCreateAsset   = errors.New("failed to add the asset to the repository")
+	ErrDeleteAsset   = errors.New("failed to delete the asset from the repository")
+)
+
+type Assets []entity.Asset
+
+type AssetRepository interface {
+	Create(ctx context.Context, asset entity.Asset) (*entity.Asset, error)
+	Get(ctx context.Context, ID uint) (*entity.Asset, error)
+	GetAll(ctx context.Context) (Assets, error)
+	Delete(ctx context.Context, ID uint) error
+	// Restore stores the asset with its ID, replacing an existing one.
+	Restore(ctx context.Context, asset entity.Asset) (*entity.Asset, error)
+}
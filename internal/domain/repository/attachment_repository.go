@@ -0,0 +1,25 @@
+package repository
+
+import (
+	"context"
+	"errors"
+
+	"git.home/alex/go-subscriptions/internal/domain/entity"
+)
+
+var (
+	ErrNotFoundAttachment = errors.New("the attachment was not found in the repository")
+	ErrCreateAttachment   = errors.New("failed to add the attachment to the repository")
+	ErrDeleteAttachment   = errors.New("failed to delete the attachment from the repository")
+)
+
+type Attachments []entity.Attachment
+
+type AttachmentRepository interface {
+	Create(ctx context.Context, attachment entity.Attachment) (*entity.Attachment, error)
+	Get(ctx context.Context, ID uint) (*entity.Attachment, error)
+	GetAll(ctx context.Context) (Attachments, error)
+	Delete(ctx context.Context, ID uint) error
+	// Restore stores the attachment with its ID, replacing an existing one.
+	Restore(ctx context.Context, attachment entity.Attachment) (*entity.Attachment, error)
+}
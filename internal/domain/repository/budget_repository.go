@@ -0,0 +1,27 @@
+package repository
+
+import (
+	"context"
+	"errors"
+
+	"git.home/alex/go-subscriptions/internal/domain/entity"
+)
+
+var (
+	ErrNotFoundBudget = errors.New("the budget was not found in the repository")
+	ErrCreateBudget   = errors.New("failed to add the budget to the repository")
+	ErrUpdateBudget   = errors.New("failed to update the budget in the repository")
+	ErrDeleteBudget   = errors.New("failed to delete the budget from the repository")
+)
+
+type Budgets []entity.Budget
+
+type BudgetRepository interface {
+	Create(ctx context.Context, budget entity.Budget) (*entity.Budget, error)
+	Get(ctx context.Context, ID uint) (*entity.Budget, error)
+	GetAll(ctx context.Context) (Budgets, error)
+	Update(ctx context.Context, budget entity.Budget) (*entity.Budget, error)
+	Delete(ctx context.Context, ID uint) error
+	// Restore stores the budget with its ID, replacing an existing one.
+	Restore(ctx context.Context, budget entity.Budget) (*entity.Budget, error)
+}
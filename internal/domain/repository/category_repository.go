@@ -22,4 +22,6 @@ type CategoryRepository interface {
 	GetAll(ctx context.Context) (Categories, error)
 	Update(ctx context.Context, category entity.Category) (*entity.Category, error)
 	Delete(ctx context.Context, ID uint) error
+	// Restore stores the category with its ID, replacing an existing one.
+	Restore(ctx context.Context, category entity.Category) (*entity.Category, error)
 }
@@ -23,4 +23,6 @@ type CurrencyRepository interface {
 	GetAll(ctx context.Context) (Currencies, error)
 	Update(ctx context.Context, currency entity.Currency) (*entity.Currency, error)
 	Delete(ctx context.Context, code string) error
+	// Restore stores the currency, replacing an existing one with the same code.
+	Restore(ctx context.Context, currency entity.Currency) (*entity.Currency, error)
 }
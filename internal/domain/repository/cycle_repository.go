@@ -22,4 +22,6 @@ type CycleRepository interface {
 	GetAll(ctx context.Context) (Cycles, error)
 	Update(ctx context.Context, cycle entity.Cycle) (*entity.Cycle, error)
 	Delete(ctx context.Context, ID uint) error
+	// Restore stores the cycle with its ID, replacing an existing one.
+	Restore(ctx context.Context, cycle entity.Cycle) (*entity.Cycle, error)
 }
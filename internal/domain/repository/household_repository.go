@@ -0,0 +1,27 @@
+package repository
+
+import (
+	"context"
+	"errors"
+
+	"git.home/alex/go-subscriptions/internal/domain/entity"
+)
+
+var (
+	ErrNotFoundHousehold = errors.New("the household was not found in the repository")
+	ErrCreateHousehold   = errors.New("failed to add the household to the repository")
+	ErrUpdateHousehold   = errors.New("failed to update the household in the repository")
+	ErrDeleteHousehold   = errors.New("failed to delete the household from the repository")
+)
+
+type Households []entity.Household
+
+type HouseholdRepository interface {
+	Create(ctx context.Context, household entity.Household) (*entity.Household, error)
+	Get(ctx context.Context, ID uint) (*entity.Household, error)
+	GetAll(ctx context.Context) (Households, error)
+	Update(ctx context.Context, household entity.Household) (*entity.Household, error)
+	Delete(ctx context.Context, ID uint) error
+	// Restore stores the household with its ID, replacing an existing one.
+	Restore(ctx context.Context, household entity.Household) (*entity.Household, error)
+}
@@ -0,0 +1,27 @@
+package repository
+
+import (
+	"context"
+	"errors"
+
+	"git.home/alex/go-subscriptions/internal/domain/entity"
+)
+
+var (
+	ErrNotFoundPaymentMethod = errors.New("the payment method was not found in the repository")
+	ErrCreatePaymentMethod   = errors.New("failed to add the payment method to the repository")
+	ErrUpdatePaymentMethod   = errors.New("failed to update the payment method in the repository")
+	ErrDeletePaymentMethod   = errors.New("failed to delete the payment method from the repository")
+)
+
+type PaymentMethods []entity.PaymentMethod
+
+type PaymentMethodRepository interface {
+	Create(ctx context.Context, paymentMethod entity.PaymentMethod) (*entity.PaymentMethod, error)
+	Get(ctx context.Context, ID uint) (*entity.PaymentMethod, error)
+	GetAll(ctx context.Context) (PaymentMethods, error)
+	Update(ctx context.Context, paymentMethod entity.PaymentMethod) (*entity.PaymentMethod, error)
+	Delete(ctx context.Context, ID uint) error
+	// Restore stores the payment method with its ID, replacing an existing one.
+	Restore(ctx context.Context, paymentMethod entity.PaymentMethod) (*entity.PaymentMethod, error)
+}
@@ -22,4 +22,6 @@ type SubscriptionRepository interface {
 	GetAll(ctx context.Context) (Subscriptions, error)
 	Update(ctx context.Context, subscription entity.Subscription) (*entity.Subscription, error)
 	Delete(ctx context.Context, ID uint) error
+	// Restore stores the subscription with its ID, replacing an existing one.
+	Restore(ctx context.Context, subscription entity.Subscription) (*entity.Subscription, error)
 }
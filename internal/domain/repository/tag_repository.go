@@ -0,0 +1,27 @@
+package repository
+
+import (
+	"context"
+	"errors"
+
+	"git.home/alex/go-subscriptions/internal/domain/entity"
+)
+
+var (
+	ErrNotFoundTag = errors.New("the tag was not found in the repository")
+	ErrCreateTag   = errors.New("failed to add the tag to the repository")
+	ErrUpdateTag   = errors.New("failed to update the tag in the repository")
+	ErrDeleteTag   = errors.New("failed to delete the tag from the repository")
+)
+
+type Tags []entity.Tag
+
+type TagRepository interface {
+	Create(ctx context.Context, tag entity.Tag) (*entity.Tag, error)
+	Get(ctx context.Context, ID uint) (*entity.Tag, error)
+	GetAll(ctx context.Context) (Tags, error)
+	Update(ctx context.Context, tag entity.Tag) (*entity.Tag, error)
+	Delete(ctx context.Context, ID uint) error
+	// Restore stores the tag with its ID, replacing an existing one.
+	Restore(ctx context.Context, tag entity.Tag) (*entity.Tag, error)
+}
@@ -0,0 +1,29 @@
+package repository
+
+import (
+	"context"
+	"errors"
+
+	"git.home/alex/go-subscriptions/internal/domain/entity"
+)
+
+var (
+	ErrNotFoundUser      = errors.New("the user was not found in the repository")
+	ErrCreateUser        = errors.New("failed to add the user to the repository")
+	ErrUpdateUser        = errors.New("failed to update the user in the repository")
+	ErrDeleteUser        = errors.New("failed to delete the user from the repository")
+	ErrAlreadyExistsUser = errors.New("user already exists")
+)
+
+type Users []entity.User
+
+type UserRepository interface {
+	Create(ctx context.Context, user entity.User) (*entity.User, error)
+	Get(ctx context.Context, ID uint) (*entity.User, error)
+	GetByUsername(ctx context.Context, username string) (*entity.User, error)
+	GetAll(ctx context.Context) (Users, error)
+	Update(ctx context.Context, user entity.User) (*entity.User, error)
+	Delete(ctx context.Context, ID uint) error
+	// Restore stores the user with its ID, replacing an existing one.
+	Restore(ctx context.Context, user entity.User) (*entity.User, error)
+}
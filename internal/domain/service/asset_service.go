@@ -0,0 +1,317 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"image"
+	"image/color"
+	_ "image/gif" // registers the decoders of the supported image types
+	_ "image/jpeg"
+	"image/png"
+	"io"
+	"net/http"
+	"strconv"
+	"time"
+
+	"git.home/alex/go-subscriptions/internal/blobstore"
+	"git.home/alex/go-subscriptions/internal/domain/entity"
+	"git.home/alex/go-subscriptions/internal/domain/repository"
+)
+
+var (
+	ErrAssetTooLarge       = errors.New("the image is too large")
+	ErrUnsupportedAsset    = errors.New("the file is not a PNG, JPEG or GIF image")
+	ErrNotFoundAssetObject = errors.New("the content of the asset is missing from the store")
+)
+
+const (
+	DefaultMaxAssetSize = 1 << 20
+	// ThumbnailContentType is the type thumbnails are encoded in, whatever the type of the original.
+	ThumbnailContentType = "image/png"
+
+	thumbnailSize = 128
+	// maxAssetDimension bounds the decoded image, so a small file can't expand to a huge bitmap.
+	maxAssetDimension = 4096
+)
+
+var supportedAssetTypes = map[string]bool{
+	"image/png":  true,
+	"image/jpeg": true,
+	"image/gif":  true,
+}
+
+type AssetService struct {
+	repo          repository.AssetRepository
+	subscriptions repository.SubscriptionRepository
+	store         blobstore.Store
+	maxSize       int64
+}
+
+// NewAssetService limits uploads to maxSize bytes; zero falls back to DefaultMaxAssetSize.
+func NewAssetService(
+	repo repository.AssetRepository,
+	subscriptions repository.SubscriptionRepository,
+	store blobstore.Store,
+	maxSize int64,
+) *AssetService {
+	if maxSize <= 0 {
+		maxSize = DefaultMaxAssetSize
+	}
+
+	return &AssetService{
+		repo:          repo,
+		subscriptions: subscriptions,
+		store:         store,
+		maxSize:       maxSize,
+	}
+}
+
+func (s *AssetService) GetAsset(ctx context.Context, id uint) (*entity.Asset, error) {
+	if id == 0 {
+		return nil, repository.ErrNotFoundAsset
+	}
+
+	asset, err := s.repo.Get(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if !canSee(ctx, asset.UserID, false) {
+		return nil, repository.ErrNotFoundAsset
+	}
+
+	return asset, nil
+}
+
+// OpenAsset returns the content of the asset or of its thumbnail. The caller closes the reader.
+func (s *AssetService) OpenAsset(ctx context.Context, id uint, thumbnail bool) (*entity.Asset, io.ReadCloser, error) {
+	asset, err := s.GetAsset(ctx, id)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	r, err := s.store.Open(ctx, AssetKey(asset.ID, thumbnail))
+	if errors.Is(err, blobstore.ErrNotFound) {
+		return nil, nil, ErrNotFoundAssetObject
+	}
+
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return asset, r, nil
+}
+
+// SetSubscriptionLogo stores the image read from r as the logo of the subscription and removes
+// the asset of the previous logo.
+func (s *AssetService) SetSubscriptionLogo(ctx context.Context, subscriptionID uint, r io.Reader) (*entity.Asset, error) {
+	subscription, err := s.subscriptions.Get(ctx, subscriptionID)
+	if err != nil {
+		return nil, err
+	}
+
+	if !canSee(ctx, subscription.UserID, false) {
+		return nil, repository.ErrNotFoundSubscription
+	}
+
+	asset, err := s.upload(ctx, r, subscription.UserID)
+	if err != nil {
+		return nil, err
+	}
+
+	previous := subscription.LogoAssetID
+	subscription.LogoAssetID = asset.ID
+
+	_, err = s.subscriptions.Update(ctx, *subscription)
+	if err != nil {
+		_ = s.deleteAsset(ctx, asset.ID)
+
+		return nil, err
+	}
+
+	if previous != 0 {
+		// The old logo isn't referenced anymore, failing to remove it only leaves garbage behind.
+		_ = s.deleteAsset(ctx, previous)
+	}
+
+	return asset, nil
+}
+
+func (s *AssetService) RemoveSubscriptionLogo(ctx context.Context, subscriptionID uint) (*entity.Subscription, error) {
+	subscription, err := s.subscriptions.Get(ctx, subscriptionID)
+	if err != nil {
+		return nil, err
+	}
+
+	if !canSee(ctx, subscription.UserID, false) {
+		return nil, repository.ErrNotFoundSubscription
+	}
+
+	previous := subscription.LogoAssetID
+	subscription.LogoAssetID = 0
+
+	updated, err := s.subscriptions.Update(ctx, *subscription)
+	if err != nil {
+		return nil, err
+	}
+
+	if previous != 0 {
+		_ = s.deleteAsset(ctx, previous)
+	}
+
+	return updated, nil
+}
+
+// upload checks the size and the sniffed type of the image, then stores it with its thumbnail.
+func (s *AssetService) upload(ctx context.Context, r io.Reader, userID uint) (*entity.Asset, error) {
+	data, err := io.ReadAll(io.LimitReader(r, s.maxSize+1))
+	if err != nil {
+		return nil, err
+	}
+
+	if int64(len(data)) > s.maxSize {
+		return nil, ErrAssetTooLarge
+	}
+
+	contentType := http.DetectContentType(data)
+	if !supportedAssetTypes[contentType] {
+		return nil, ErrUnsupportedAsset
+	}
+
+	thumb, err := encodeThumbnail(data)
+	if err != nil {
+		return nil, err
+	}
+
+	sum := sha256.Sum256(data)
+
+	asset, err := s.repo.Create(ctx, entity.Asset{
+		UserID:      userID,
+		ContentType: contentType,
+		Size:        int64(len(data)),
+		Checksum:    hex.EncodeToString(sum[:]),
+		CreatedAt:   time.Now().UTC(),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	err = s.store.Put(ctx, AssetKey(asset.ID, false), bytes.NewReader(data))
+	if err == nil {
+		err = s.store.Put(ctx, AssetKey(asset.ID, true), bytes.NewReader(thumb))
+	}
+
+	if err != nil {
+		_ = s.deleteAsset(ctx, asset.ID)
+
+		return nil, err
+	}
+
+	return asset, nil
+}
+
+func (s *AssetService) deleteAsset(ctx context.Context, id uint) error {
+	err := s.repo.Delete(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	for _, thumbnail := range []bool{false, true} {
+		err = s.store.Delete(ctx, AssetKey(id, thumbnail))
+		if err != nil && !errors.Is(err, blobstore.ErrNotFound) {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// AssetKey is where the image, or its thumbnail, is kept in the blob store.
+func AssetKey(id uint, thumbnail bool) string {
+	key := strconv.FormatUint(uint64(id), 10)
+	if thumbnail {
+		key += "_thumb"
+	}
+
+	return key
+}
+
+func encodeThumbnail(data []byte) ([]byte, error) {
+	cfg, _, err := image.DecodeConfig(bytes.NewReader(data))
+	if err != nil {
+		return nil, ErrUnsupportedAsset
+	}
+
+	if cfg.Width > maxAssetDimension || cfg.Height > maxAssetDimension {
+		return nil, ErrAssetTooLarge
+	}
+
+	img, _, err := image.Decode(bytes.NewReader(data))
+	if err != nil {
+		return nil, ErrUnsupportedAsset
+	}
+
+	var buf bytes.Buffer
+
+	err = png.Encode(&buf, thumbnail(img, thumbnailSize))
+	if err != nil {
+		return nil, err
+	}
+
+	return buf.Bytes(), nil
+}
+
+// thumbnail scales img down to fit a size×size square, averaging the source pixels each target
+// pixel covers. Images that already fit keep their size.
+func thumbnail(img image.Image, size int) image.Image {
+	b := img.Bounds()
+	w, h := b.Dx(), b.Dy()
+
+	if w > size || h > size {
+		if w >= h {
+			w, h = size, max(1, h*size/b.Dx())
+		} else {
+			w, h = max(1, w*size/b.Dy()), size
+		}
+	}
+
+	dst := image.NewNRGBA(image.Rect(0, 0, w, h))
+
+	for y := 0; y < h; y++ {
+		y0, y1 := b.Min.Y+y*b.Dy()/h, b.Min.Y+(y+1)*b.Dy()/h
+
+		for x := 0; x < w; x++ {
+			x0, x1 := b.Min.X+x*b.Dx()/w, b.Min.X+(x+1)*b.Dx()/w
+			dst.SetNRGBA(x, y, averageColor(img, image.Rect(x0, y0, x1, y1)))
+		}
+	}
+
+	return dst
+}
+
+func averageColor(img image.Image, r image.Rectangle) color.NRGBA {
+	var sr, sg, sb, sa, n uint64
+
+	for y := r.Min.Y; y < r.Max.Y; y++ {
+		for x := r.Min.X; x < r.Max.X; x++ {
+			cr, cg, cb, ca := img.At(x, y).RGBA()
+			sr, sg, sb, sa = sr+uint64(cr), sg+uint64(cg), sb+uint64(cb), sa+uint64(ca)
+			n++
+		}
+	}
+
+	if n == 0 || sa == 0 {
+		return color.NRGBA{}
+	}
+
+	// The sums are alpha-premultiplied, dividing by the summed alpha undoes that.
+	return color.NRGBA{
+		R: uint8(sr * 0xff / sa),
+		G: uint8(sg * 0xff / sa),
+		B: uint8(sb * 0xff / sa),
+		A: uint8(sa / n >> 8),
+	}
+}
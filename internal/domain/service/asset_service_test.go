@@ -0,0 +1,135 @@
+package service_test
+
+import (
+	"bytes"
+	"context"
+	"image"
+	"image/color"
+	"image/png"
+	"io"
+	"strings"
+	"testing"
+
+	"git.home/alex/go-subscriptions/internal/auth"
+	"git.home/alex/go-subscriptions/internal/blobstore"
+	"git.home/alex/go-subscriptions/internal/domain/entity"
+	"git.home/alex/go-subscriptions/internal/domain/repository"
+	"git.home/alex/go-subscriptions/internal/domain/service"
+	"git.home/alex/go-subscriptions/internal/repository/memory"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func encodePNG(t *testing.T, width, height int) []byte {
+	t.Helper()
+
+	img := image.NewNRGBA(image.Rect(0, 0, width, height))
+	for y := 0; y < height; y++ {
+		for x := 0; x < width; x++ {
+			img.SetNRGBA(x, y, color.NRGBA{R: 200, G: 40, B: 40, A: 255})
+		}
+	}
+
+	var buf bytes.Buffer
+	require.NoError(t, png.Encode(&buf, img))
+
+	return buf.Bytes()
+}
+
+func TestAssetService_SetSubscriptionLogo(t *testing.T) {
+	ctx := auth.WithPrincipal(context.Background(), &auth.Principal{UserID: 1})
+	subscriptions := memory.NewSubscriptionRepository()
+
+	store, err := blobstore.NewFileSystem(t.TempDir())
+	require.NoError(t, err)
+
+	assetService := service.NewAssetService(memory.NewAssetRepository(), subscriptions, store, 4096)
+
+	subscription, err := subscriptions.Create(ctx, entity.Subscription{UserID: 1, Name: "Streaming", Price: 10})
+	require.NoError(t, err)
+
+	asset, err := assetService.SetSubscriptionLogo(ctx, subscription.ID, bytes.NewReader(encodePNG(t, 300, 150)))
+	require.NoError(t, err)
+	assert.Equal(t, "image/png", asset.ContentType)
+	assert.Equal(t, uint(1), asset.UserID)
+	assert.Len(t, asset.Checksum, 64)
+
+	linked, err := subscriptions.Get(ctx, subscription.ID)
+	require.NoError(t, err)
+	assert.Equal(t, asset.ID, linked.LogoAssetID)
+
+	_, content, err := assetService.OpenAsset(ctx, asset.ID, true)
+	require.NoError(t, err)
+
+	thumb, err := png.Decode(content)
+	require.NoError(t, err)
+	require.NoError(t, content.Close())
+	assert.Equal(t, image.Rect(0, 0, 128, 64), thumb.Bounds())
+	assert.Equal(t, color.NRGBA{R: 200, G: 40, B: 40, A: 255}, color.NRGBAModel.Convert(thumb.At(10, 10)))
+
+	_, _, err = assetService.OpenAsset(auth.WithPrincipal(ctx, &auth.Principal{UserID: 2}), asset.ID, false)
+	assert.ErrorIs(t, err, repository.ErrNotFoundAsset)
+
+	replaced, err := assetService.SetSubscriptionLogo(ctx, subscription.ID, bytes.NewReader(encodePNG(t, 16, 16)))
+	require.NoError(t, err)
+
+	_, err = assetService.GetAsset(ctx, asset.ID)
+	assert.ErrorIs(t, err, repository.ErrNotFoundAsset)
+
+	_, err = store.Open(ctx, "1")
+	assert.ErrorIs(t, err, blobstore.ErrNotFound)
+
+	_, content, err = assetService.OpenAsset(ctx, replaced.ID, false)
+	require.NoError(t, err)
+
+	data, err := io.ReadAll(content)
+	require.NoError(t, err)
+	require.NoError(t, content.Close())
+	assert.Equal(t, encodePNG(t, 16, 16), data)
+
+	unlinked, err := assetService.RemoveSubscriptionLogo(ctx, subscription.ID)
+	require.NoError(t, err)
+	assert.Zero(t, unlinked.LogoAssetID)
+
+	_, err = assetService.GetAsset(ctx, replaced.ID)
+	assert.ErrorIs(t, err, repository.ErrNotFoundAsset)
+}
+
+func TestAssetService_SetSubscriptionLogoErrors(t *testing.T) {
+	testCases := []struct {
+		name    string
+		content []byte
+		wantErr error
+	}{
+		{name: "Larger than the limit", content: bytes.Repeat([]byte{0}, 4097), wantErr: service.ErrAssetTooLarge},
+		{name: "Not an image", content: []byte("name,price\nNetflix,10\n"), wantErr: service.ErrUnsupportedAsset},
+		{name: "Truncated image", content: encodePNG(t, 16, 16)[:40], wantErr: service.ErrUnsupportedAsset},
+		{name: "Too many pixels", content: encodePNG(t, 5000, 1), wantErr: service.ErrAssetTooLarge},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctx := context.Background()
+			subscriptions := memory.NewSubscriptionRepository()
+			assets := memory.NewAssetRepository()
+
+			store, err := blobstore.NewFileSystem(t.TempDir())
+			require.NoError(t, err)
+
+			subscription, err := subscriptions.Create(ctx, entity.Subscription{Name: "Streaming", Price: 10})
+			require.NoError(t, err)
+
+			_, err = service.NewAssetService(assets, subscriptions, store, 4096).
+				SetSubscriptionLogo(ctx, subscription.ID, bytes.NewReader(tc.content))
+			assert.ErrorIs(t, err, tc.wantErr)
+
+			stored, err := assets.GetAll(ctx)
+			require.NoError(t, err)
+			assert.Empty(t, stored)
+		})
+	}
+
+	_, err := service.NewAssetService(memory.NewAssetRepository(), memory.NewSubscriptionRepository(), nil, 0).
+		SetSubscriptionLogo(context.Background(), 1, strings.NewReader(""))
+	assert.ErrorIs(t, err, repository.ErrNotFoundSubscription)
+}
@@ -0,0 +1,247 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"io"
+	"net/http"
+	"path"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+
+	"git.home/alex/go-subscriptions/internal/blobstore"
+	"git.home/alex/go-subscriptions/internal/domain/entity"
+	"git.home/alex/go-subscriptions/internal/domain/repository"
+)
+
+var (
+	ErrInvalidAttachment        = errors.New("the attachment is not valid")
+	ErrAttachmentTooLarge       = errors.New("the attachment is too large")
+	ErrNotFoundAttachmentObject = errors.New("the content of the attachment is missing from the store")
+)
+
+const (
+	DefaultMaxAttachmentSize = 10 << 20
+
+	maxAttachmentNameLength = 255
+)
+
+type AttachmentService struct {
+	repo          repository.AttachmentRepository
+	subscriptions repository.SubscriptionRepository
+	store         blobstore.Store
+	maxSize       int64
+}
+
+// NewAttachmentService limits uploads to maxSize bytes; zero falls back to DefaultMaxAttachmentSize.
+func NewAttachmentService(
+	repo repository.AttachmentRepository,
+	subscriptions repository.SubscriptionRepository,
+	store blobstore.Store,
+	maxSize int64,
+) *AttachmentService {
+	if maxSize <= 0 {
+		maxSize = DefaultMaxAttachmentSize
+	}
+
+	return &AttachmentService{
+		repo:          repo,
+		subscriptions: subscriptions,
+		store:         store,
+		maxSize:       maxSize,
+	}
+}
+
+// AddAttachment stores the file read from r with the subscription. Only the base of name is kept,
+// the content type is sniffed from the content.
+func (s *AttachmentService) AddAttachment(
+	ctx context.Context,
+	subscriptionID uint,
+	name string,
+	paymentDate entity.PaymentDate,
+	r io.Reader,
+) (*entity.Attachment, error) {
+	name, ok := attachmentName(name)
+	if !ok {
+		return nil, ErrInvalidAttachment
+	}
+
+	subscription, err := s.getSubscription(ctx, subscriptionID)
+	if err != nil {
+		return nil, err
+	}
+
+	data, err := io.ReadAll(io.LimitReader(r, s.maxSize+1))
+	if err != nil {
+		return nil, err
+	}
+
+	if int64(len(data)) > s.maxSize {
+		return nil, ErrAttachmentTooLarge
+	}
+
+	sum := sha256.Sum256(data)
+
+	attachment, err := s.repo.Create(ctx, entity.Attachment{
+		UserID:         subscription.UserID,
+		SubscriptionID: subscription.ID,
+		PaymentDate:    paymentDate,
+		Name:           name,
+		ContentType:    http.DetectContentType(data),
+		Size:           int64(len(data)),
+		Checksum:       hex.EncodeToString(sum[:]),
+		CreatedAt:      time.Now().UTC(),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	err = s.store.Put(ctx, AttachmentKey(attachment.ID), bytes.NewReader(data))
+	if err != nil {
+		_ = s.repo.Delete(ctx, attachment.ID)
+
+		return nil, err
+	}
+
+	return attachment, nil
+}
+
+// GetAttachments lists the files of the subscription in upload order.
+func (s *AttachmentService) GetAttachments(ctx context.Context, subscriptionID uint) (repository.Attachments, error) {
+	_, err := s.getSubscription(ctx, subscriptionID)
+	if err != nil {
+		return nil, err
+	}
+
+	all, err := s.repo.GetAll(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	attachments := repository.Attachments{}
+
+	for _, attachment := range all {
+		if attachment.SubscriptionID == subscriptionID {
+			attachments = append(attachments, attachment)
+		}
+	}
+
+	return attachments, nil
+}
+
+func (s *AttachmentService) GetAttachment(ctx context.Context, id uint) (*entity.Attachment, error) {
+	if id == 0 {
+		return nil, repository.ErrNotFoundAttachment
+	}
+
+	attachment, err := s.repo.Get(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if !canSee(ctx, attachment.UserID, false) {
+		return nil, repository.ErrNotFoundAttachment
+	}
+
+	return attachment, nil
+}
+
+// OpenAttachment returns the content of the attachment. The caller closes the reader.
+func (s *AttachmentService) OpenAttachment(ctx context.Context, id uint) (*entity.Attachment, io.ReadCloser, error) {
+	attachment, err := s.GetAttachment(ctx, id)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	r, err := s.store.Open(ctx, AttachmentKey(attachment.ID))
+	if errors.Is(err, blobstore.ErrNotFound) {
+		return nil, nil, ErrNotFoundAttachmentObject
+	}
+
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return attachment, r, nil
+}
+
+func (s *AttachmentService) DeleteAttachment(ctx context.Context, id uint) error {
+	attachment, err := s.GetAttachment(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	return s.delete(ctx, attachment.ID)
+}
+
+// deleteAll removes the files of a deleted subscription, so no attachment outlives it.
+func (s *AttachmentService) deleteAll(ctx context.Context, subscriptionID uint) error {
+	all, err := s.repo.GetAll(ctx)
+	if err != nil {
+		return err
+	}
+
+	for _, attachment := range all {
+		if attachment.SubscriptionID != subscriptionID {
+			continue
+		}
+
+		err = s.delete(ctx, attachment.ID)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (s *AttachmentService) delete(ctx context.Context, id uint) error {
+	err := s.repo.Delete(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	err = s.store.Delete(ctx, AttachmentKey(id))
+	if err != nil && !errors.Is(err, blobstore.ErrNotFound) {
+		return err
+	}
+
+	return nil
+}
+
+func (s *AttachmentService) getSubscription(ctx context.Context, id uint) (*entity.Subscription, error) {
+	subscription, err := s.subscriptions.Get(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if !canSee(ctx, subscription.UserID, false) {
+		return nil, repository.ErrNotFoundSubscription
+	}
+
+	return subscription, nil
+}
+
+// AttachmentKey is where the content of the attachment is kept in the blob store.
+func AttachmentKey(id uint) string {
+	return "attachment_" + strconv.FormatUint(uint64(id), 10)
+}
+
+// attachmentName strips the directories browsers on Windows send along with the file name.
+func attachmentName(name string) (string, bool) {
+	name = strings.TrimSpace(path.Base(strings.ReplaceAll(name, `\`, "/")))
+
+	switch {
+	case name == "" || name == "." || name == "/":
+		return "", false
+	case len(name) > maxAttachmentNameLength || strings.ContainsFunc(name, unicode.IsControl):
+		return "", false
+	}
+
+	return name, true
+}
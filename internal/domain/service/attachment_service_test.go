@@ -0,0 +1,116 @@
+package service_test
+
+import (
+	"context"
+	"io"
+	"strings"
+	"testing"
+	"time"
+
+	"git.home/alex/go-subscriptions/internal/auth"
+	"git.home/alex/go-subscriptions/internal/blobstore"
+	"git.home/alex/go-subscriptions/internal/domain/entity"
+	"git.home/alex/go-subscriptions/internal/domain/repository"
+	"git.home/alex/go-subscriptions/internal/domain/service"
+	"git.home/alex/go-subscriptions/internal/repository/memory"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAttachmentService_AddAttachment(t *testing.T) {
+	testCases := []struct {
+		name     string
+		fileName string
+		content  string
+		wantName string
+		wantType string
+		wantErr  error
+	}{
+		{name: "PDF invoice", fileName: "invoice.pdf", content: "%PDF-1.4\n", wantName: "invoice.pdf", wantType: "application/pdf"},
+		{
+			name:     "Windows path",
+			fileName: `C:\Users\alice\receipt.txt`,
+			content:  "Paid 9.99",
+			wantName: "receipt.txt",
+			wantType: "text/plain; charset=utf-8",
+		},
+		{name: "No name", fileName: " ", content: "%PDF-1.4\n", wantErr: service.ErrInvalidAttachment},
+		{name: "Control character", fileName: "in\nvoice.pdf", content: "%PDF-1.4\n", wantErr: service.ErrInvalidAttachment},
+		{name: "Too large", fileName: "scan.pdf", content: strings.Repeat("x", 65), wantErr: service.ErrAttachmentTooLarge},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctx := context.Background()
+			subscriptions := memory.NewSubscriptionRepository()
+			store := blobstore.NewMemory()
+			attachmentService := service.NewAttachmentService(memory.NewAttachmentRepository(), subscriptions, store, 64)
+
+			subscription, err := subscriptions.Create(ctx, entity.Subscription{Name: "Streaming", Price: 10})
+			require.NoError(t, err)
+
+			attachment, err := attachmentService.AddAttachment(
+				ctx, subscription.ID, tc.fileName, entity.PaymentDate{}, strings.NewReader(tc.content),
+			)
+			if tc.wantErr != nil {
+				assert.ErrorIs(t, err, tc.wantErr)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tc.wantName, attachment.Name)
+			assert.Equal(t, tc.wantType, attachment.ContentType)
+			assert.Equal(t, int64(len(tc.content)), attachment.Size)
+
+			_, content, err := attachmentService.OpenAttachment(ctx, attachment.ID)
+			require.NoError(t, err)
+
+			data, err := io.ReadAll(content)
+			require.NoError(t, err)
+			assert.Equal(t, tc.content, string(data))
+		})
+	}
+}
+
+func TestAttachmentService_GetAttachments(t *testing.T) {
+	ctx := auth.WithPrincipal(context.Background(), &auth.Principal{UserID: 1})
+	otherCtx := auth.WithPrincipal(context.Background(), &auth.Principal{UserID: 2})
+	subscriptions := memory.NewSubscriptionRepository()
+	store := blobstore.NewMemory()
+	attachmentService := service.NewAttachmentService(memory.NewAttachmentRepository(), subscriptions, store, 0)
+
+	first, err := subscriptions.Create(ctx, entity.Subscription{UserID: 1, Name: "Streaming", Price: 10})
+	require.NoError(t, err)
+	second, err := subscriptions.Create(ctx, entity.Subscription{UserID: 1, Name: "Gym", Price: 30})
+	require.NoError(t, err)
+
+	april := entity.PaymentDate(time.Date(2024, 4, 1, 0, 0, 0, 0, time.UTC))
+
+	invoice, err := attachmentService.AddAttachment(ctx, first.ID, "april.pdf", april, strings.NewReader("%PDF-1.4\n"))
+	require.NoError(t, err)
+	assert.Equal(t, uint(1), invoice.UserID)
+	assert.Equal(t, april, invoice.PaymentDate)
+
+	_, err = attachmentService.AddAttachment(ctx, second.ID, "terms.txt", entity.PaymentDate{}, strings.NewReader("terms"))
+	require.NoError(t, err)
+
+	attachments, err := attachmentService.GetAttachments(ctx, first.ID)
+	require.NoError(t, err)
+	assert.Equal(t, repository.Attachments{*invoice}, attachments)
+
+	_, err = attachmentService.GetAttachments(otherCtx, first.ID)
+	assert.ErrorIs(t, err, repository.ErrNotFoundSubscription)
+
+	_, _, err = attachmentService.OpenAttachment(otherCtx, invoice.ID)
+	assert.ErrorIs(t, err, repository.ErrNotFoundAttachment)
+
+	assert.ErrorIs(t, attachmentService.DeleteAttachment(otherCtx, invoice.ID), repository.ErrNotFoundAttachment)
+	require.NoError(t, attachmentService.DeleteAttachment(ctx, invoice.ID))
+
+	_, err = store.Open(ctx, service.AttachmentKey(invoice.ID))
+	assert.ErrorIs(t, err, blobstore.ErrNotFound)
+
+	attachments, err = attachmentService.GetAttachments(ctx, first.ID)
+	require.NoError(t, err)
+	assert.Empty(t, attachments)
+}
@@ -0,0 +1,271 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"git.home/alex/go-subscriptions/internal/clock"
+	"git.home/alex/go-subscriptions/internal/domain/entity"
+	"git.home/alex/go-subscriptions/internal/domain/repository"
+)
+
+var (
+	ErrInvalidBudget = errors.New("the budget is not valid")
+	ErrBudgetExists  = errors.New("a budget already exists for the category")
+)
+
+type BudgetService struct {
+	repo          repository.BudgetRepository
+	categories    repository.CategoryRepository
+	subscriptions repository.SubscriptionRepository
+}
+
+func NewBudgetService(
+	repo repository.BudgetRepository,
+	categories repository.CategoryRepository,
+	subscriptions repository.SubscriptionRepository,
+) *BudgetService {
+	return &BudgetService{repo: repo, categories: categories, subscriptions: subscriptions}
+}
+
+// BudgetStatus is the utilization of a budget by the subscriptions active today.
+type BudgetStatus struct {
+	Budget entity.Budget
+	// Spent is the normalized cost of the covered subscriptions over the budget period.
+	Spent     float64
+	Remaining float64
+	// Utilization is Spent as a percentage of the budget amount.
+	Utilization float64
+}
+
+func (s BudgetStatus) OverBudget() bool {
+	return s.Spent > s.Budget.Amount
+}
+
+func (s *BudgetService) CreateBudget(ctx context.Context, budget entity.Budget) (*entity.Budget, error) {
+	if userID, ok := ownerFromContext(ctx); ok {
+		budget.UserID = userID
+	}
+
+	err := s.validate(ctx, budget)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.repo.Create(ctx, budget)
+}
+
+func (s *BudgetService) GetBudget(ctx context.Context, id uint) (*entity.Budget, error) {
+	if id == 0 {
+		return nil, repository.ErrNotFoundBudget
+	}
+
+	budget, err := s.repo.Get(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if !canSee(ctx, budget.UserID, false) {
+		return nil, repository.ErrNotFoundBudget
+	}
+
+	return budget, nil
+}
+
+func (s *BudgetService) GetAllBudgets(ctx context.Context) (repository.Budgets, error) {
+	budgets, err := s.repo.GetAll(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if _, ok := ownerFromContext(ctx); !ok {
+		return budgets, nil
+	}
+
+	visible := make(repository.Budgets, 0, len(budgets))
+	for _, budget := range budgets {
+		if canSee(ctx, budget.UserID, false) {
+			visible = append(visible, budget)
+		}
+	}
+
+	return visible, nil
+}
+
+func (s *BudgetService) UpdateBudget(ctx context.Context, budget entity.Budget) (*entity.Budget, error) {
+	existing, err := s.GetBudget(ctx, budget.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	budget.UserID = existing.UserID
+
+	err = s.validate(ctx, budget)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.repo.Update(ctx, budget)
+}
+
+func (s *BudgetService) DeleteBudget(ctx context.Context, id uint) error {
+	if _, err := s.GetBudget(ctx, id); err != nil {
+		return err
+	}
+
+	return s.repo.Delete(ctx, id)
+}
+
+func (s *BudgetService) GetStatus(ctx context.Context, id uint) (*BudgetStatus, error) {
+	budget, err := s.GetBudget(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	subscriptions, err := s.subscriptions.GetAll(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	parents, err := s.parents(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	status := budgetStatus(*budget, subscriptions, parents, clock.Today(ctx))
+
+	return &status, nil
+}
+
+func (s *BudgetService) GetStatuses(ctx context.Context) ([]BudgetStatus, error) {
+	budgets, err := s.GetAllBudgets(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	subscriptions, err := s.subscriptions.GetAll(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	parents, err := s.parents(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	now := clock.Today(ctx)
+	statuses := make([]BudgetStatus, len(budgets))
+
+	for i, budget := range budgets {
+		statuses[i] = budgetStatus(budget, subscriptions, parents, now)
+	}
+
+	return statuses, nil
+}
+
+// Exceeded returns the budgets that the change of a subscription from before to after pushed over
+// budget. before is nil for a new subscription. Budgets that were over budget already are left out.
+func (s *BudgetService) Exceeded(ctx context.Context, before *entity.Subscription, after entity.Subscription) ([]BudgetStatus, error) {
+	budgets, err := s.repo.GetAll(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	subscriptions, err := s.subscriptions.GetAll(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	parents, err := s.parents(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	now := clock.Today(ctx)
+
+	var exceeded []BudgetStatus
+
+	for _, budget := range budgets {
+		if !budget.Covers(after, ancestry(parents, after.Category.ID)) {
+			continue
+		}
+
+		status := budgetStatus(budget, subscriptions, parents, now)
+		if !status.OverBudget() {
+			continue
+		}
+
+		previous := status.Spent - periodCost(budget, after, parents, now)
+		if before != nil {
+			previous += periodCost(budget, *before, parents, now)
+		}
+
+		if previous <= budget.Amount {
+			exceeded = append(exceeded, status)
+		}
+	}
+
+	return exceeded, nil
+}
+
+// validate checks the budget fields and that the caller has no other budget for the same category.
+func (s *BudgetService) validate(ctx context.Context, budget entity.Budget) error {
+	if budget.Amount <= 0 || !budget.Period.Valid() || budget.CurrencyCode == "" {
+		return ErrInvalidBudget
+	}
+
+	if budget.CategoryID != 0 {
+		category, err := s.categories.Get(ctx, budget.CategoryID)
+		if err != nil || !canSee(ctx, category.UserID, false) {
+			return ErrInvalidBudget
+		}
+	}
+
+	budgets, err := s.repo.GetAll(ctx)
+	if err != nil {
+		return err
+	}
+
+	for _, b := range budgets {
+		if b.ID != budget.ID && b.UserID == budget.UserID && b.CategoryID == budget.CategoryID {
+			return ErrBudgetExists
+		}
+	}
+
+	return nil
+}
+
+// parents maps every category to its parent, so budgets cover the subcategories of their category.
+func (s *BudgetService) parents(ctx context.Context) (map[uint]uint, error) {
+	categories, err := s.categories.GetAll(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return parentsOf(categories), nil
+}
+
+func budgetStatus(budget entity.Budget, subscriptions repository.Subscriptions, parents map[uint]uint, now time.Time) BudgetStatus {
+	var spent float64
+	for _, subscription := range subscriptions {
+		spent += periodCost(budget, subscription, parents, now)
+	}
+
+	return BudgetStatus{
+		Budget:      budget,
+		Spent:       roundAmount(spent),
+		Remaining:   roundAmount(budget.Amount - spent),
+		Utilization: roundAmount(spent / budget.Amount * 100),
+	}
+}
+
+// periodCost is the normalized cost of the subscription over the budget period, zero when the
+// budget doesn't cover it or it isn't active.
+func periodCost(budget entity.Budget, subscription entity.Subscription, parents map[uint]uint, now time.Time) float64 {
+	if !budget.Covers(subscription, ancestry(parents, subscription.Category.ID)) || !subscription.IsActive(now) {
+		return 0
+	}
+
+	return subscription.MonthlyCost(now) * budget.Period.Months()
+}
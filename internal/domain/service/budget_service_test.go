@@ -0,0 +1,169 @@
+package service_test
+
+import (
+	"context"
+	"testing"
+
+	"git.home/alex/go-subscriptions/internal/domain/entity"
+	"git.home/alex/go-subscriptions/internal/domain/repository"
+	"git.home/alex/go-subscriptions/internal/domain/service"
+	"git.home/alex/go-subscriptions/internal/repository/memory"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newBudgetFixture(t *testing.T) (*service.BudgetService, repository.SubscriptionRepository, *entity.Category) {
+	t.Helper()
+
+	ctx := context.Background()
+	categories := memory.NewCategoryRepository()
+	subscriptions := memory.NewSubscriptionRepository()
+
+	category, err := categories.Create(ctx, entity.Category{Name: "Video"})
+	require.NoError(t, err)
+
+	for _, s := range []entity.Subscription{
+		{Name: "Streaming", Price: 15, Category: *category, Currency: entity.USD, Cycle: entity.Monthly},
+		{Name: "Cloud", Price: 120, Currency: entity.USD, Cycle: entity.Yearly},
+		{Name: "Paused", Price: 50, Category: *category, Currency: entity.USD, Cycle: entity.Monthly, Status: entity.StatusPaused},
+		{Name: "Foreign", Price: 50, Category: *category, Currency: entity.Currency{Code: "EUR"}, Cycle: entity.Monthly},
+	} {
+		_, err = subscriptions.Create(ctx, s)
+		require.NoError(t, err)
+	}
+
+	return service.NewBudgetService(memory.NewBudgetRepository(), categories, subscriptions), subscriptions, category
+}
+
+func TestBudgetService_CreateBudget(t *testing.T) {
+	testCases := []struct {
+		name    string
+		budget  entity.Budget
+		wantErr error
+	}{
+		{name: "Category budget", budget: entity.Budget{CategoryID: 1, Period: entity.BudgetMonthly, Amount: 20, CurrencyCode: "USD"}},
+		{name: "Overall budget", budget: entity.Budget{Period: entity.BudgetYearly, Amount: 500, CurrencyCode: "USD"}},
+		{
+			name:    "Unknown category",
+			budget:  entity.Budget{CategoryID: 9, Period: entity.BudgetMonthly, Amount: 20, CurrencyCode: "USD"},
+			wantErr: service.ErrInvalidBudget,
+		},
+		{
+			name:    "Unknown period",
+			budget:  entity.Budget{Period: "weekly", Amount: 20, CurrencyCode: "USD"},
+			wantErr: service.ErrInvalidBudget,
+		},
+		{name: "No amount", budget: entity.Budget{Period: entity.BudgetMonthly, CurrencyCode: "USD"}, wantErr: service.ErrInvalidBudget},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			budgetService, _, _ := newBudgetFixture(t)
+
+			created, err := budgetService.CreateBudget(context.Background(), tc.budget)
+			if tc.wantErr != nil {
+				assert.ErrorIs(t, err, tc.wantErr)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, uint(1), created.ID)
+
+			_, err = budgetService.CreateBudget(context.Background(), tc.budget)
+			assert.ErrorIs(t, err, service.ErrBudgetExists)
+		})
+	}
+}
+
+func TestBudgetService_GetStatuses(t *testing.T) {
+	ctx := context.Background()
+	budgetService, _, category := newBudgetFixture(t)
+
+	categoryBudget := entity.Budget{CategoryID: category.ID, Period: entity.BudgetMonthly, Amount: 20, CurrencyCode: "USD"}
+
+	_, err := budgetService.CreateBudget(ctx, categoryBudget)
+	require.NoError(t, err)
+	_, err = budgetService.CreateBudget(ctx, entity.Budget{Period: entity.BudgetYearly, Amount: 250, CurrencyCode: "USD"})
+	require.NoError(t, err)
+
+	statuses, err := budgetService.GetStatuses(ctx)
+	require.NoError(t, err)
+	require.Len(t, statuses, 2)
+
+	assert.InDelta(t, 15, statuses[0].Spent, 0.001)
+	assert.InDelta(t, 5, statuses[0].Remaining, 0.001)
+	assert.InDelta(t, 75, statuses[0].Utilization, 0.001)
+	assert.False(t, statuses[0].OverBudget())
+
+	assert.InDelta(t, 300, statuses[1].Spent, 0.001)
+	assert.InDelta(t, -50, statuses[1].Remaining, 0.001)
+	assert.True(t, statuses[1].OverBudget())
+}
+
+func TestBudgetService_GetStatuses_Subcategories(t *testing.T) {
+	ctx := context.Background()
+	categories := memory.NewCategoryRepository()
+	subscriptions := memory.NewSubscriptionRepository()
+
+	entertainment, err := categories.Create(ctx, entity.Category{Name: "Entertainment"})
+	require.NoError(t, err)
+	video, err := categories.Create(ctx, entity.Category{Name: "Video", ParentID: entertainment.ID})
+	require.NoError(t, err)
+	series, err := categories.Create(ctx, entity.Category{Name: "Series", ParentID: video.ID})
+	require.NoError(t, err)
+
+	for _, s := range []entity.Subscription{
+		{Name: "Cinema", Price: 8, Category: *entertainment, Currency: entity.USD, Cycle: entity.Monthly},
+		{Name: "Streaming", Price: 15, Category: *video, Currency: entity.USD, Cycle: entity.Monthly},
+		{Name: "Anime", Price: 5, Category: *series, Currency: entity.USD, Cycle: entity.Monthly},
+	} {
+		_, err = subscriptions.Create(ctx, s)
+		require.NoError(t, err)
+	}
+
+	budgetService := service.NewBudgetService(memory.NewBudgetRepository(), categories, subscriptions)
+
+	for _, category := range []*entity.Category{entertainment, video, series} {
+		_, err = budgetService.CreateBudget(ctx, entity.Budget{
+			CategoryID: category.ID, Period: entity.BudgetMonthly, Amount: 100, CurrencyCode: "USD",
+		})
+		require.NoError(t, err)
+	}
+
+	statuses, err := budgetService.GetStatuses(ctx)
+	require.NoError(t, err)
+	require.Len(t, statuses, 3)
+
+	assert.InDelta(t, 28, statuses[0].Spent, 0.001)
+	assert.InDelta(t, 20, statuses[1].Spent, 0.001)
+	assert.InDelta(t, 5, statuses[2].Spent, 0.001)
+}
+
+func TestBudgetService_Exceeded(t *testing.T) {
+	ctx := context.Background()
+	budgetService, subscriptions, category := newBudgetFixture(t)
+
+	categoryBudget := entity.Budget{CategoryID: category.ID, Period: entity.BudgetMonthly, Amount: 20, CurrencyCode: "USD"}
+
+	_, err := budgetService.CreateBudget(ctx, categoryBudget)
+	require.NoError(t, err)
+
+	added, err := subscriptions.Create(ctx, entity.Subscription{
+		Name: "Music", Price: 10, Category: *category, Currency: entity.USD, Cycle: entity.Monthly,
+	})
+	require.NoError(t, err)
+
+	exceeded, err := budgetService.Exceeded(ctx, nil, *added)
+	require.NoError(t, err)
+	require.Len(t, exceeded, 1)
+	assert.InDelta(t, 25, exceeded[0].Spent, 0.001)
+
+	before := *added
+	added.Price = 12
+	_, err = subscriptions.Update(ctx, *added)
+	require.NoError(t, err)
+
+	exceeded, err = budgetService.Exceeded(ctx, &before, *added)
+	require.NoError(t, err)
+	assert.Empty(t, exceeded, "the budget was over already")
+}
@@ -3,30 +3,57 @@ package service
 import (
 	"context"
 	"errors"
+	"slices"
 
+	"git.home/alex/go-subscriptions/internal/clock"
 	"git.home/alex/go-subscriptions/internal/domain/entity"
 	"git.home/alex/go-subscriptions/internal/domain/repository"
 )
 
 var (
 	ErrInvalidCategory = errors.New("the category is not valid")
+	ErrCategoryCycle   = errors.New("the category can't be nested under itself")
 )
 
 type CategoryService struct {
-	repo repository.CategoryRepository
+	repo          repository.CategoryRepository
+	subscriptions repository.SubscriptionRepository
 }
 
-func NewCategoryService(repo repository.CategoryRepository) *CategoryService {
+func NewCategoryService(repo repository.CategoryRepository, subscriptions repository.SubscriptionRepository) *CategoryService {
 	return &CategoryService{
-		repo: repo,
+		repo:          repo,
+		subscriptions: subscriptions,
 	}
 }
 
+// CategoryNode is a category with its subcategories.
+type CategoryNode struct {
+	Category entity.Category
+	Children []CategoryNode
+}
+
+// CategoryReport is the spending of a category rolled up with all of its subcategories.
+type CategoryReport struct {
+	Category entity.Category
+	// Totals is the normalized monthly cost of the subscriptions per currency code.
+	Totals        map[string]float64
+	Subscriptions int
+}
+
 func (s *CategoryService) CreateCategory(ctx context.Context, category entity.Category) (*entity.Category, error) {
 	if category.Name == "" {
 		return nil, ErrInvalidCategory
 	}
 
+	if userID, ok := ownerFromContext(ctx); ok {
+		category.UserID = userID
+	}
+
+	if err := s.validateParent(ctx, category); err != nil {
+		return nil, err
+	}
+
 	return s.repo.Create(ctx, category)
 }
 
@@ -35,11 +62,36 @@ func (s *CategoryService) GetCategory(ctx context.Context, id uint) (*entity.Cat
 		return nil, repository.ErrNotFoundCategory
 	}
 
-	return s.repo.Get(ctx, id)
+	category, err := s.repo.Get(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if !canSee(ctx, category.UserID, false) {
+		return nil, repository.ErrNotFoundCategory
+	}
+
+	return category, nil
 }
 
 func (s *CategoryService) GetAllCategories(ctx context.Context) (repository.Categories, error) {
-	return s.repo.GetAll(ctx)
+	categories, err := s.repo.GetAll(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if _, ok := ownerFromContext(ctx); !ok {
+		return categories, nil
+	}
+
+	visible := make(repository.Categories, 0, len(categories))
+	for _, category := range categories {
+		if canSee(ctx, category.UserID, false) {
+			visible = append(visible, category)
+		}
+	}
+
+	return visible, nil
 }
 
 func (s *CategoryService) UpdateCategory(ctx context.Context, category entity.Category) (*entity.Category, error) {
@@ -47,6 +99,18 @@ func (s *CategoryService) UpdateCategory(ctx context.Context, category entity.Ca
 		return nil, ErrInvalidCategory
 	}
 
+	if userID, ok := ownerFromContext(ctx); ok {
+		if _, err := s.GetCategory(ctx, category.ID); err != nil {
+			return nil, err
+		}
+
+		category.UserID = userID
+	}
+
+	if err := s.validateParent(ctx, category); err != nil {
+		return nil, err
+	}
+
 	return s.repo.Update(ctx, category)
 }
 
@@ -55,5 +119,169 @@ func (s *CategoryService) DeleteCategory(ctx context.Context, id uint) error {
 		return repository.ErrNotFoundCategory
 	}
 
+	if _, ok := ownerFromContext(ctx); ok {
+		if _, err := s.GetCategory(ctx, id); err != nil {
+			return err
+		}
+	}
+
+	err := s.reparentChildren(ctx, id)
+	if err != nil {
+		return err
+	}
+
 	return s.repo.Delete(ctx, id)
 }
+
+// GetTree returns the visible categories nested under their parents. A category whose parent
+// isn't visible is returned at the top level.
+func (s *CategoryService) GetTree(ctx context.Context) ([]CategoryNode, error) {
+	categories, err := s.GetAllCategories(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	parents := parentsOf(categories)
+	children := make(map[uint]repository.Categories, len(categories))
+
+	for _, category := range categories {
+		parentID := category.ParentID
+		if _, ok := parents[parentID]; !ok {
+			parentID = 0
+		}
+
+		children[parentID] = append(children[parentID], category)
+	}
+
+	var build func(parentID uint) []CategoryNode
+	build = func(parentID uint) []CategoryNode {
+		nodes := make([]CategoryNode, 0, len(children[parentID]))
+		for _, category := range children[parentID] {
+			nodes = append(nodes, CategoryNode{Category: category, Children: build(category.ID)})
+		}
+
+		return nodes
+	}
+
+	return build(0), nil
+}
+
+// GetReport sums the normalized monthly cost of the subscriptions active today per category.
+// The cost of a subscription counts towards its category and every ancestor of it.
+func (s *CategoryService) GetReport(ctx context.Context) ([]CategoryReport, error) {
+	categories, err := s.GetAllCategories(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	subscriptions, err := s.subscriptions.GetAll(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	parents := parentsOf(categories)
+	index := make(map[uint]int, len(categories))
+	reports := make([]CategoryReport, len(categories))
+
+	for i, category := range categories {
+		index[category.ID] = i
+		reports[i] = CategoryReport{Category: category, Totals: make(map[string]float64)}
+	}
+
+	now := clock.Today(ctx)
+
+	for _, subscription := range subscriptions {
+		if !subscription.IsActive(now) || !canSee(ctx, subscription.UserID, false) {
+			continue
+		}
+
+		for _, id := range ancestry(parents, subscription.Category.ID) {
+			reports[index[id]].Totals[subscription.Currency.Code] += subscription.MonthlyCost(now)
+			reports[index[id]].Subscriptions++
+		}
+	}
+
+	for _, report := range reports {
+		roundTotals(report.Totals)
+	}
+
+	return reports, nil
+}
+
+// validateParent checks that the parent is a visible category and that the category isn't
+// one of its ancestors.
+func (s *CategoryService) validateParent(ctx context.Context, category entity.Category) error {
+	if category.ParentID == 0 {
+		return nil
+	}
+
+	categories, err := s.GetAllCategories(ctx)
+	if err != nil {
+		return err
+	}
+
+	parents := parentsOf(categories)
+	if _, ok := parents[category.ParentID]; !ok {
+		return ErrInvalidCategory
+	}
+
+	for _, id := range ancestry(parents, category.ParentID) {
+		if id == category.ID {
+			return ErrCategoryCycle
+		}
+	}
+
+	return nil
+}
+
+// reparentChildren moves the subcategories of a category about to be deleted up to its parent.
+func (s *CategoryService) reparentChildren(ctx context.Context, id uint) error {
+	categories, err := s.repo.GetAll(ctx)
+	if err != nil {
+		return err
+	}
+
+	parentID := parentsOf(categories)[id]
+
+	for _, category := range categories {
+		if category.ParentID != id {
+			continue
+		}
+
+		category.ParentID = parentID
+
+		_, err = s.repo.Update(ctx, category)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// parentsOf maps the ID of every category to the ID of its parent.
+func parentsOf(categories repository.Categories) map[uint]uint {
+	parents := make(map[uint]uint, len(categories))
+	for _, category := range categories {
+		parents[category.ID] = category.ParentID
+	}
+
+	return parents
+}
+
+// ancestry returns the category followed by its ancestors, up to the first one that isn't known.
+// It stops at a repeated ID, so a cycle in stored data can't loop forever.
+func ancestry(parents map[uint]uint, id uint) []uint {
+	var ids []uint
+
+	for id != 0 && !slices.Contains(ids, id) {
+		if _, ok := parents[id]; !ok {
+			break
+		}
+
+		ids = append(ids, id)
+		id = parents[id]
+	}
+
+	return ids
+}
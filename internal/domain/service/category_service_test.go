@@ -7,9 +7,11 @@ import (
 	"git.home/alex/go-subscriptions/internal/domain/entity"
 	"git.home/alex/go-subscriptions/internal/domain/repository"
 	"git.home/alex/go-subscriptions/internal/domain/service"
+	"git.home/alex/go-subscriptions/internal/repository/memory"
 	"git.home/alex/go-subscriptions/tests"
 	"git.home/alex/go-subscriptions/tests/mock_repository"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestCategoryService_CreateCategory(t *testing.T) {
@@ -46,7 +48,7 @@ func TestCategoryService_CreateCategory(t *testing.T) {
 			mockRepo := new(mock_repository.MockCategoryRepository)
 			mockRepo.On("Create", ctx, tc.category).Return(tc.wantResult, tc.wantErr)
 
-			categoryService := service.NewCategoryService(mockRepo)
+			categoryService := service.NewCategoryService(mockRepo, new(mock_repository.MockSubscriptionRepository))
 			result, err := categoryService.CreateCategory(ctx, tc.category)
 
 			if tc.wantErr != nil {
@@ -95,7 +97,7 @@ func TestCategoryService_GetCategory(t *testing.T) {
 			mockRepo := new(mock_repository.MockCategoryRepository)
 			mockRepo.On("Get", ctx, tc.id).Return(tc.wantResult, tc.wantErr)
 
-			categoryService := service.NewCategoryService(mockRepo)
+			categoryService := service.NewCategoryService(mockRepo, new(mock_repository.MockSubscriptionRepository))
 			result, err := categoryService.GetCategory(ctx, tc.id)
 
 			if tc.wantErr != nil {
@@ -140,7 +142,7 @@ func TestCategoryService_GetAllCategories(t *testing.T) {
 			mockRepo := new(mock_repository.MockCategoryRepository)
 			mockRepo.On("GetAll", ctx).Return(tc.wantResult, tc.wantErr)
 
-			categoryService := service.NewCategoryService(mockRepo)
+			categoryService := service.NewCategoryService(mockRepo, new(mock_repository.MockSubscriptionRepository))
 			result, err := categoryService.GetAllCategories(ctx)
 
 			if tc.wantErr != nil {
@@ -195,7 +197,7 @@ func TestCategoryService_UpdateCategory(t *testing.T) {
 			mockRepo := new(mock_repository.MockCategoryRepository)
 			mockRepo.On("Update", ctx, tc.category).Return(tc.wantResult, tc.wantErr)
 
-			categoryService := service.NewCategoryService(mockRepo)
+			categoryService := service.NewCategoryService(mockRepo, new(mock_repository.MockSubscriptionRepository))
 			result, err := categoryService.UpdateCategory(ctx, tc.category)
 
 			if tc.wantErr != nil {
@@ -238,9 +240,10 @@ func TestCategoryService_DeleteCategory(t *testing.T) {
 	for _, tc := range testCases {
 		t.Run(tc.name, func(t *testing.T) {
 			mockRepo := new(mock_repository.MockCategoryRepository)
+			mockRepo.On("GetAll", ctx).Return(repository.Categories{}, nil)
 			mockRepo.On("Delete", ctx, tc.id).Return(tc.wantErr)
 
-			categoryService := service.NewCategoryService(mockRepo)
+			categoryService := service.NewCategoryService(mockRepo, new(mock_repository.MockSubscriptionRepository))
 			err := categoryService.DeleteCategory(ctx, tc.id)
 
 			if tc.wantErr != nil {
@@ -253,3 +256,45 @@ func TestCategoryService_DeleteCategory(t *testing.T) {
 		})
 	}
 }
+
+func TestCategoryService_Hierarchy(t *testing.T) {
+	ctx := context.Background()
+	subscriptions := memory.NewSubscriptionRepository()
+	categoryService := service.NewCategoryService(memory.NewCategoryRepository(), subscriptions)
+
+	entertainment, err := categoryService.CreateCategory(ctx, entity.Category{Name: "Entertainment"})
+	require.NoError(t, err)
+	streaming, err := categoryService.CreateCategory(ctx, entity.Category{Name: "Streaming", ParentID: entertainment.ID})
+	require.NoError(t, err)
+	video, err := categoryService.CreateCategory(ctx, entity.Category{Name: "Video", ParentID: streaming.ID})
+	require.NoError(t, err)
+
+	_, err = categoryService.CreateCategory(ctx, entity.Category{Name: "Orphan", ParentID: 9})
+	assert.ErrorIs(t, err, service.ErrInvalidCategory)
+
+	entertainment.ParentID = video.ID
+	_, err = categoryService.UpdateCategory(ctx, *entertainment)
+	assert.ErrorIs(t, err, service.ErrCategoryCycle)
+
+	for _, s := range []entity.Subscription{
+		{Name: "Movies", Price: 10, Category: *video, Currency: entity.USD, Cycle: entity.Monthly},
+		{Name: "Music", Price: 120, Category: *streaming, Currency: entity.USD, Cycle: entity.Yearly},
+	} {
+		_, err = subscriptions.Create(ctx, s)
+		require.NoError(t, err)
+	}
+
+	reports, err := categoryService.GetReport(ctx)
+	require.NoError(t, err)
+	require.Len(t, reports, 3)
+	assert.InDelta(t, 20, reports[0].Totals["USD"], 0.001)
+	assert.Equal(t, 2, reports[0].Subscriptions)
+	assert.InDelta(t, 20, reports[1].Totals["USD"], 0.001)
+	assert.InDelta(t, 10, reports[2].Totals["USD"], 0.001)
+
+	require.NoError(t, categoryService.DeleteCategory(ctx, streaming.ID))
+
+	video, err = categoryService.GetCategory(ctx, video.ID)
+	require.NoError(t, err)
+	assert.Equal(t, entertainment.ID, video.ParentID, "children move up to the deleted category's parent")
+}
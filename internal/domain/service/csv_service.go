@@ -0,0 +1,405 @@
+package service
+
+import (
+	"context"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	"git.home/alex/go-subscriptions/internal/clock"
+	"git.home/alex/go-subscriptions/internal/domain/entity"
+)
+
+var (
+	ErrInvalidCSV = errors.New("the csv file is not valid")
+)
+
+const csvDateLayout = "2006-01-02"
+
+// CSV columns in export order. The export is lossy: tags, the payment method, the contract, the price
+// history, the household share and uploaded files are left out, and importing a row creates a
+// subscription without them.
+const (
+	csvColumnName            = "name"
+	csvColumnPrice           = "price"
+	csvColumnCurrency        = "currency"
+	csvColumnCycle           = "cycle"
+	csvColumnCategory        = "category"
+	csvColumnNextPaymentDate = "next_payment_date"
+	csvColumnNote            = "note"
+	csvColumnLogo            = "logo"
+	csvColumnStatus          = "status"
+	csvColumnTrialStartDate  = "trial_start_date"
+	csvColumnTrialEndDate    = "trial_end_date"
+	csvColumnResumeDate      = "resume_date"
+	csvColumnCancelDate      = "cancel_date"
+)
+
+var csvColumns = []string{
+	csvColumnName, csvColumnPrice, csvColumnCurrency, csvColumnCycle,
+	csvColumnCategory, csvColumnNextPaymentDate, csvColumnNote, csvColumnLogo,
+	csvColumnStatus, csvColumnTrialStartDate, csvColumnTrialEndDate, csvColumnResumeDate, csvColumnCancelDate,
+}
+
+var csvRequiredColumns = []string{csvColumnName, csvColumnPrice, csvColumnCurrency, csvColumnCycle}
+
+// csvAliases maps alternative spreadsheet headers to the canonical column names.
+var csvAliases = map[string]string{
+	"title":             csvColumnName,
+	"amount":            csvColumnPrice,
+	"cost":              csvColumnPrice,
+	"currency_code":     csvColumnCurrency,
+	"billing_cycle":     csvColumnCycle,
+	"period":            csvColumnCycle,
+	"next_payment":      csvColumnNextPaymentDate,
+	"next_payment_date": csvColumnNextPaymentDate,
+	"payment_date":      csvColumnNextPaymentDate,
+	"notes":             csvColumnNote,
+	"comment":           csvColumnNote,
+}
+
+type CSVRowError struct {
+	Row   int    `json:"row"`
+	Error string `json:"error"`
+}
+
+type CSVImportReport struct {
+	DryRun            bool          `json:"dry_run"`
+	Rows              int           `json:"rows"`
+	Imported          int           `json:"imported"`
+	CreatedCategories []string      `json:"created_categories"`
+	Errors            []CSVRowError `json:"errors"`
+}
+
+type CSVService struct {
+	categories    *CategoryService
+	currencies    *CurrencyService
+	cycles        *CycleService
+	subscriptions *SubscriptionService
+}
+
+func NewCSVService(
+	categories *CategoryService,
+	currencies *CurrencyService,
+	cycles *CycleService,
+	subscriptions *SubscriptionService,
+) *CSVService {
+	return &CSVService{
+		categories:    categories,
+		currencies:    currencies,
+		cycles:        cycles,
+		subscriptions: subscriptions,
+	}
+}
+
+// Export writes the visible subscriptions as CSV with a header row.
+func (s *CSVService) Export(ctx context.Context, w io.Writer) error {
+	subscriptions, err := s.subscriptions.GetAllSubscriptions(ctx)
+	if err != nil {
+		return err
+	}
+
+	cw := csv.NewWriter(w)
+
+	err = cw.Write(csvColumns)
+	if err != nil {
+		return err
+	}
+
+	now := clock.Today(ctx)
+
+	for _, subscription := range subscriptions {
+		err = cw.Write([]string{
+			subscription.Name,
+			strconv.FormatFloat(subscription.PriceAt(now), 'f', -1, 64),
+			subscription.Currency.Code,
+			subscription.Cycle.Name,
+			subscription.Category.Name,
+			formatCSVDate(subscription.NextPaymentDate),
+			subscription.Note,
+			subscription.Logo,
+			string(subscription.Status),
+			formatCSVDate(subscription.TrialStartDate),
+			formatCSVDate(subscription.TrialEndDate),
+			formatCSVDate(subscription.ResumeDate),
+			formatCSVDate(subscription.CancelDate),
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	cw.Flush()
+
+	return cw.Error()
+}
+
+// Import creates a subscription for every valid row. Rows that fail are reported and skipped.
+// In dry-run mode the rows are only validated and nothing is written.
+func (s *CSVService) Import(ctx context.Context, r io.Reader, dryRun bool) (*CSVImportReport, error) {
+	cr := csv.NewReader(r)
+	cr.FieldsPerRecord = -1
+	cr.TrimLeadingSpace = true
+
+	header, err := cr.Read()
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidCSV, err)
+	}
+
+	columns, err := csvHeader(header)
+	if err != nil {
+		return nil, err
+	}
+
+	resolver, err := s.newCSVResolver(ctx, dryRun)
+	if err != nil {
+		return nil, err
+	}
+
+	report := &CSVImportReport{DryRun: dryRun, CreatedCategories: []string{}, Errors: []CSVRowError{}}
+
+	// Row numbers count the header as row 1 to match spreadsheet line numbers.
+	for row := 2; ; row++ {
+		record, err := cr.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+
+		report.Rows++
+
+		if err == nil {
+			err = s.importRow(ctx, resolver, columns, record, dryRun)
+		}
+
+		if err != nil {
+			report.Errors = append(report.Errors, CSVRowError{Row: row, Error: err.Error()})
+			continue
+		}
+
+		report.Imported++
+	}
+
+	report.CreatedCategories = append(report.CreatedCategories, resolver.createdCategories...)
+
+	return report, nil
+}
+
+func (s *CSVService) importRow(ctx context.Context, resolver *csvResolver, columns map[string]int, record []string, dryRun bool) error {
+	field := func(column string) string {
+		i, ok := columns[column]
+		if !ok || i >= len(record) {
+			return ""
+		}
+
+		return strings.TrimSpace(record[i])
+	}
+
+	subscription, err := resolver.subscription(field)
+	if err != nil {
+		return err
+	}
+
+	if !validSubscription(subscription) {
+		return ErrInvalidSubscription
+	}
+
+	category, err := resolver.category(ctx, field(csvColumnCategory))
+	if err != nil {
+		return err
+	}
+
+	subscription.Category = category
+
+	if dryRun {
+		return nil
+	}
+
+	_, err = s.subscriptions.CreateSubscription(ctx, subscription)
+
+	return err
+}
+
+func csvHeader(header []string) (map[string]int, error) {
+	columns := make(map[string]int, len(header))
+
+	for i, name := range header {
+		name = strings.ToLower(strings.TrimSpace(name))
+		name = strings.NewReplacer(" ", "_", "-", "_").Replace(name)
+
+		if alias, ok := csvAliases[name]; ok {
+			name = alias
+		}
+
+		if _, ok := columns[name]; !ok {
+			columns[name] = i
+		}
+	}
+
+	for _, column := range csvRequiredColumns {
+		if _, ok := columns[column]; !ok {
+			return nil, fmt.Errorf("%w: missing column %q", ErrInvalidCSV, column)
+		}
+	}
+
+	return columns, nil
+}
+
+// csvResolver looks up currencies, cycles and categories by name once per import
+// and creates missing categories on demand.
+type csvResolver struct {
+	categoryService   *CategoryService
+	dryRun            bool
+	currencies        map[string]entity.Currency
+	cycles            map[string]entity.Cycle
+	categories        map[string]entity.Category
+	createdCategories []string
+}
+
+func (s *CSVService) newCSVResolver(ctx context.Context, dryRun bool) (*csvResolver, error) {
+	r := &csvResolver{
+		categoryService: s.categories,
+		dryRun:          dryRun,
+		currencies:      make(map[string]entity.Currency),
+		cycles:          make(map[string]entity.Cycle),
+		categories:      make(map[string]entity.Category),
+	}
+
+	currencies, err := s.currencies.GetAllCurrencies(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, currency := range currencies {
+		r.currencies[strings.ToLower(currency.Name)] = currency
+	}
+
+	// Codes win over names when both match.
+	for _, currency := range currencies {
+		r.currencies[strings.ToLower(currency.Code)] = currency
+	}
+
+	cycles, err := s.cycles.GetAllCycles(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, cycle := range cycles {
+		r.cycles[strings.ToLower(cycle.Name)] = cycle
+		r.cycles[strconv.FormatUint(uint64(cycle.ID), 10)] = cycle
+	}
+
+	categories, err := s.categories.GetAllCategories(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, category := range categories {
+		r.categories[strings.ToLower(category.Name)] = category
+	}
+
+	return r, nil
+}
+
+func (r *csvResolver) subscription(field func(string) string) (entity.Subscription, error) {
+	subscription := entity.Subscription{
+		Name: field(csvColumnName),
+		Note: field(csvColumnNote),
+		Logo: field(csvColumnLogo),
+	}
+
+	price, err := strconv.ParseFloat(strings.ReplaceAll(field(csvColumnPrice), ",", "."), 64)
+	if err != nil {
+		return subscription, fmt.Errorf("%w: price %q", ErrInvalidSubscription, field(csvColumnPrice))
+	}
+
+	subscription.Price = price
+
+	currency, ok := r.currencies[strings.ToLower(field(csvColumnCurrency))]
+	if !ok {
+		return subscription, fmt.Errorf("%w: currency %q", ErrInvalidSubscription, field(csvColumnCurrency))
+	}
+
+	subscription.Currency = currency
+
+	cycle, ok := r.cycles[strings.ToLower(field(csvColumnCycle))]
+	if !ok {
+		return subscription, fmt.Errorf("%w: cycle %q", ErrInvalidSubscription, field(csvColumnCycle))
+	}
+
+	subscription.Cycle = cycle
+
+	status := entity.SubscriptionStatus(strings.ToLower(field(csvColumnStatus)))
+	if !status.Valid() {
+		return subscription, fmt.Errorf("%w: status %q", ErrInvalidSubscription, field(csvColumnStatus))
+	}
+
+	subscription.Status = status
+
+	dates := []struct {
+		column string
+		name   string
+		date   *entity.PaymentDate
+	}{
+		{csvColumnNextPaymentDate, "next payment date", &subscription.NextPaymentDate},
+		{csvColumnTrialStartDate, "trial start date", &subscription.TrialStartDate},
+		{csvColumnTrialEndDate, "trial end date", &subscription.TrialEndDate},
+		{csvColumnResumeDate, "resume date", &subscription.ResumeDate},
+		{csvColumnCancelDate, "cancel date", &subscription.CancelDate},
+	}
+
+	for _, d := range dates {
+		value := field(d.column)
+		if value == "" {
+			continue
+		}
+
+		date, err := time.Parse(csvDateLayout, value)
+		if err != nil {
+			return subscription, fmt.Errorf("%w: %s %q", ErrInvalidSubscription, d.name, value)
+		}
+
+		*d.date = entity.PaymentDate(date)
+	}
+
+	return subscription, nil
+}
+
+// formatCSVDate leaves a date that isn't set empty.
+func formatCSVDate(date entity.PaymentDate) string {
+	if time.Time(date).IsZero() {
+		return ""
+	}
+
+	return time.Time(date).Format(csvDateLayout)
+}
+
+func (r *csvResolver) category(ctx context.Context, name string) (entity.Category, error) {
+	if name == "" {
+		return entity.Category{}, nil
+	}
+
+	key := strings.ToLower(name)
+	if category, ok := r.categories[key]; ok {
+		return category, nil
+	}
+
+	category := entity.Category{Name: name}
+
+	if !r.dryRun {
+		created, err := r.categoryService.CreateCategory(ctx, category)
+		if err != nil {
+			return entity.Category{}, err
+		}
+
+		category = *created
+	}
+
+	r.categories[key] = category
+	r.createdCategories = append(r.createdCategories, name)
+
+	return category, nil
+}
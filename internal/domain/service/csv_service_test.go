@@ -0,0 +1,202 @@
+package service_test
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"git.home/alex/go-subscriptions/internal/domain/entity"
+	"git.home/alex/go-subscriptions/internal/domain/service"
+	"git.home/alex/go-subscriptions/internal/repository/memory"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type csvFixture struct {
+	csv           *service.CSVService
+	categories    *service.CategoryService
+	subscriptions *service.SubscriptionService
+}
+
+func newCSVFixture(t *testing.T) csvFixture {
+	t.Helper()
+
+	ctx := context.Background()
+	f := csvFixture{
+		categories:    service.NewCategoryService(memory.NewCategoryRepository(), memory.NewSubscriptionRepository()),
+		subscriptions: service.NewSubscriptionService(memory.NewSubscriptionRepository()),
+	}
+	currencies := service.NewCurrencyService(memory.NewCurrencyRepository())
+	cycles := service.NewCycleService(memory.NewCycleRepository())
+
+	_, err := f.categories.CreateCategory(ctx, entity.Category{Name: "Streaming"})
+	require.NoError(t, err)
+	_, err = currencies.CreateCurrency(ctx, entity.USD)
+	require.NoError(t, err)
+	_, err = currencies.CreateCurrency(ctx, entity.Currency{Code: "EUR", Symbol: "€", Name: "Euro"})
+	require.NoError(t, err)
+	_, err = cycles.CreateCycle(ctx, entity.Monthly)
+	require.NoError(t, err)
+	_, err = cycles.CreateCycle(ctx, entity.Yearly)
+	require.NoError(t, err)
+
+	f.csv = service.NewCSVService(f.categories, currencies, cycles, f.subscriptions)
+
+	return f
+}
+
+const csvImportInput = `Name,Price,Currency,Billing Cycle,Category,Next Payment,Notes
+Netflix,"15,49",usd,monthly,streaming,2024-05-01,family plan
+Domain,12,Euro,Yearly,Hosting,,
+Gym,x,USD,Monthly,Sport,,
+Cloud,5,GBP,Monthly,Hosting,,
+Music,10,USD,Daily,Music,,
+Empty,0,USD,Monthly,,,
+Backup,3,USD,Monthly,hosting,2024-13-01,
+`
+
+func TestCSVService_Import(t *testing.T) {
+	testCases := []struct {
+		name   string
+		dryRun bool
+		want   int
+	}{
+		{name: "Dry run", dryRun: true, want: 0},
+		{name: "Import", dryRun: false, want: 2},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			f := newCSVFixture(t)
+			ctx := context.Background()
+
+			report, err := f.csv.Import(ctx, strings.NewReader(csvImportInput), tc.dryRun)
+			require.NoError(t, err)
+
+			assert.Equal(t, tc.dryRun, report.DryRun)
+			assert.Equal(t, 7, report.Rows)
+			assert.Equal(t, 2, report.Imported)
+			assert.Equal(t, []string{"Hosting"}, report.CreatedCategories)
+			assert.Equal(t, []service.CSVRowError{
+				{Row: 4, Error: `the subscription is invalid: price "x"`},
+				{Row: 5, Error: `the subscription is invalid: currency "GBP"`},
+				{Row: 6, Error: `the subscription is invalid: cycle "Daily"`},
+				{Row: 7, Error: "the subscription is invalid"},
+				{Row: 8, Error: `the subscription is invalid: next payment date "2024-13-01"`},
+			}, report.Errors)
+
+			subscriptions, err := f.subscriptions.GetAllSubscriptions(ctx)
+			require.NoError(t, err)
+			require.Len(t, subscriptions, tc.want)
+
+			categories, err := f.categories.GetAllCategories(ctx)
+			require.NoError(t, err)
+			assert.Len(t, categories, 1+tc.want/2)
+
+			if tc.dryRun {
+				return
+			}
+
+			netflix := subscriptions[0]
+			assert.Equal(t, "Netflix", netflix.Name)
+			assert.InDelta(t, 15.49, netflix.Price, 0.001)
+			assert.Equal(t, "USD", netflix.Currency.Code)
+			assert.Equal(t, "Monthly", netflix.Cycle.Name)
+			assert.Equal(t, "Streaming", netflix.Category.Name)
+			assert.Equal(t, "family plan", netflix.Note)
+			assert.Equal(t, time.Date(2024, 5, 1, 0, 0, 0, 0, time.UTC), time.Time(netflix.NextPaymentDate))
+
+			assert.Equal(t, "EUR", subscriptions[1].Currency.Code)
+			assert.Equal(t, "Hosting", subscriptions[1].Category.Name)
+		})
+	}
+}
+
+func TestCSVService_ImportMissingColumn(t *testing.T) {
+	f := newCSVFixture(t)
+
+	_, err := f.csv.Import(context.Background(), strings.NewReader("name,price,currency\nNetflix,10,USD\n"), false)
+	assert.ErrorIs(t, err, service.ErrInvalidCSV)
+
+	_, err = f.csv.Import(context.Background(), strings.NewReader(""), false)
+	assert.ErrorIs(t, err, service.ErrInvalidCSV)
+}
+
+const csvExportHeader = "name,price,currency,cycle,category,next_payment_date,note,logo," +
+	"status,trial_start_date,trial_end_date,resume_date,cancel_date"
+
+func TestCSVService_ExportRoundTrip(t *testing.T) {
+	f := newCSVFixture(t)
+	ctx := context.Background()
+
+	_, err := f.csv.Import(ctx, strings.NewReader(csvImportInput), false)
+	require.NoError(t, err)
+
+	var buf bytes.Buffer
+	require.NoError(t, f.csv.Export(ctx, &buf))
+
+	assert.Equal(t, csvExportHeader+`
+Netflix,15.49,USD,Monthly,Streaming,2024-05-01,family plan,,,,,,
+Domain,12,EUR,Yearly,Hosting,,,,,,,,
+`, buf.String())
+
+	g := newCSVFixture(t)
+
+	report, err := g.csv.Import(ctx, &buf, false)
+	require.NoError(t, err)
+	assert.Equal(t, 2, report.Imported)
+	assert.Empty(t, report.Errors)
+}
+
+func TestCSVService_ExportPriceChange(t *testing.T) {
+	f := newCSVFixture(t)
+	ctx := context.Background()
+
+	// The stored price predates a change that took effect since.
+	_, err := f.subscriptions.CreateSubscription(ctx, entity.Subscription{
+		Name: "Netflix", Price: 15.49, Currency: entity.USD, Cycle: entity.Monthly,
+		Prices: []entity.PriceChange{
+			{Price: 15.49},
+			{Price: 17.99, EffectiveDate: entity.PaymentDate(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC))},
+		},
+	})
+	require.NoError(t, err)
+
+	var buf bytes.Buffer
+	require.NoError(t, f.csv.Export(ctx, &buf))
+
+	assert.Equal(t, csvExportHeader+"\n"+
+		"Netflix,17.99,USD,Monthly,,,,,,,,,\n", buf.String())
+}
+
+func TestCSVService_ExportRoundTripStatus(t *testing.T) {
+	f := newCSVFixture(t)
+	ctx := context.Background()
+
+	_, err := f.subscriptions.CreateSubscription(ctx, entity.Subscription{
+		Name: "Netflix", Price: 15.49, Currency: entity.USD, Cycle: entity.Monthly,
+		Status:         entity.StatusTrial,
+		TrialStartDate: entity.PaymentDate(time.Date(2024, 4, 1, 0, 0, 0, 0, time.UTC)),
+		TrialEndDate:   entity.PaymentDate(time.Date(2024, 5, 1, 0, 0, 0, 0, time.UTC)),
+	})
+	require.NoError(t, err)
+
+	var buf bytes.Buffer
+	require.NoError(t, f.csv.Export(ctx, &buf))
+	assert.Contains(t, buf.String(), "Netflix,15.49,USD,Monthly,,,,,trial,2024-04-01,2024-05-01,,\n")
+
+	g := newCSVFixture(t)
+
+	report, err := g.csv.Import(ctx, &buf, false)
+	require.NoError(t, err)
+	assert.Equal(t, 1, report.Imported)
+
+	subscriptions, err := g.subscriptions.GetAllSubscriptions(ctx)
+	require.NoError(t, err)
+	require.Len(t, subscriptions, 1)
+	assert.Equal(t, entity.StatusTrial, subscriptions[0].Status)
+	assert.Equal(t, time.Date(2024, 4, 1, 0, 0, 0, 0, time.UTC), time.Time(subscriptions[0].TrialStartDate))
+	assert.Equal(t, time.Date(2024, 5, 1, 0, 0, 0, 0, time.UTC), time.Time(subscriptions[0].TrialEndDate))
+}
@@ -12,6 +12,8 @@ var (
 	ErrInvalidCurrency = errors.New("the currency is not valid")
 )
 
+// CurrencyService serves currencies as reference data shared by every user:
+// the codes form one namespace, so only admins create, update or delete them.
 type CurrencyService struct {
 	repo repository.CurrencyRepository
 }
@@ -27,6 +29,12 @@ func (s *CurrencyService) CreateCurrency(ctx context.Context, currency entity.Cu
 		return nil, ErrInvalidCurrency
 	}
 
+	if _, ok := ownerFromContext(ctx); ok {
+		return nil, ErrAccessDenied
+	}
+
+	currency.UserID = 0
+
 	return s.repo.Create(ctx, currency)
 }
 
@@ -47,6 +55,12 @@ func (s *CurrencyService) UpdateCurrency(ctx context.Context, currency entity.Cu
 		return nil, ErrInvalidCurrency
 	}
 
+	if _, ok := ownerFromContext(ctx); ok {
+		return nil, ErrAccessDenied
+	}
+
+	currency.UserID = 0
+
 	return s.repo.Update(ctx, currency)
 }
 
@@ -55,5 +69,9 @@ func (s *CurrencyService) DeleteCurrency(ctx context.Context, code string) error
 		return repository.ErrNotFoundCurrency
 	}
 
+	if _, ok := ownerFromContext(ctx); ok {
+		return ErrAccessDenied
+	}
+
 	return s.repo.Delete(ctx, code)
 }
@@ -3,6 +3,8 @@ package service
 import (
 	"context"
 	"errors"
+	"fmt"
+	"time"
 
 	"git.home/alex/go-subscriptions/internal/domain/entity"
 	"git.home/alex/go-subscriptions/internal/domain/repository"
@@ -21,8 +23,13 @@ func NewCycleService(repo repository.CycleRepository) *CycleService {
 }
 
 func (s *CycleService) CreateCycle(ctx context.Context, cycle entity.Cycle) (*entity.Cycle, error) {
-	if cycle.Name == "" {
-		return nil, ErrInvalidCycle
+	err := normalizeCycle(&cycle)
+	if err != nil {
+		return nil, err
+	}
+
+	if userID, ok := ownerFromContext(ctx); ok {
+		cycle.UserID = userID
 	}
 
 	return s.repo.Create(ctx, cycle)
@@ -33,18 +40,56 @@ func (s *CycleService) GetCycle(ctx context.Context, id uint) (*entity.Cycle, er
 		return nil, repository.ErrNotFoundCycle
 	}
 
-	return s.repo.Get(ctx, id)
+	cycle, err := s.repo.Get(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if !canSee(ctx, cycle.UserID, true) {
+		return nil, repository.ErrNotFoundCycle
+	}
+
+	return cycle, nil
 }
 
 func (s *CycleService) GetAllCycles(ctx context.Context) (repository.Cycles, error) {
-	return s.repo.GetAll(ctx)
+	cycles, err := s.repo.GetAll(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if _, ok := ownerFromContext(ctx); !ok {
+		return cycles, nil
+	}
+
+	visible := make(repository.Cycles, 0, len(cycles))
+	for _, cycle := range cycles {
+		if canSee(ctx, cycle.UserID, true) {
+			visible = append(visible, cycle)
+		}
+	}
+
+	return visible, nil
 }
 
 func (s *CycleService) UpdateCycle(ctx context.Context, cycle entity.Cycle) (*entity.Cycle, error) {
-	if cycle.ID == 0 || cycle.Name == "" {
+	if cycle.ID == 0 {
 		return nil, ErrInvalidCycle
 	}
 
+	err := normalizeCycle(&cycle)
+	if err != nil {
+		return nil, err
+	}
+
+	if userID, ok := ownerFromContext(ctx); ok {
+		if err := s.checkModifiable(ctx, cycle.ID); err != nil {
+			return nil, err
+		}
+
+		cycle.UserID = userID
+	}
+
 	return s.repo.Update(ctx, cycle)
 }
 
@@ -53,5 +98,58 @@ func (s *CycleService) DeleteCycle(ctx context.Context, id uint) error {
 		return repository.ErrNotFoundCycle
 	}
 
+	if _, ok := ownerFromContext(ctx); ok {
+		if err := s.checkModifiable(ctx, id); err != nil {
+			return err
+		}
+	}
+
 	return s.repo.Delete(ctx, id)
 }
+
+// checkModifiable makes sure a user only changes own cycles, not the global ones.
+func (s *CycleService) checkModifiable(ctx context.Context, id uint) error {
+	existing, err := s.GetCycle(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	if existing.UserID == 0 {
+		return ErrAccessDenied
+	}
+
+	return nil
+}
+
+// normalizeCycle validates the cycle and stores its rule in the canonical RRULE form. A rule cycle
+// has no days and must repeat, a calendar cycle lasts a month or a year.
+func normalizeCycle(cycle *entity.Cycle) error {
+	if cycle.Name == "" {
+		return ErrInvalidCycle
+	}
+
+	if cycle.Calendar && cycle.Days != entity.CalendarMonthDays && cycle.Days != entity.CalendarYearDays {
+		return fmt.Errorf("%w: a calendar cycle lasts %d or %d days", ErrInvalidCycle, entity.CalendarMonthDays, entity.CalendarYearDays)
+	}
+
+	if cycle.Rule == "" {
+		return nil
+	}
+
+	if cycle.Days != 0 {
+		return fmt.Errorf("%w: a cycle has either days or a rule", ErrInvalidCycle)
+	}
+
+	rule, err := entity.ParseRecurrence(cycle.Rule)
+	if err != nil {
+		return fmt.Errorf("%w: %w", ErrInvalidCycle, err)
+	}
+
+	if rule.Next(time.Now()).IsZero() {
+		return fmt.Errorf("%w: the rule never repeats", ErrInvalidCycle)
+	}
+
+	cycle.Rule = rule.String()
+
+	return nil
+}
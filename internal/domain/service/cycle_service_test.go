@@ -7,9 +7,11 @@ import (
 	"git.home/alex/go-subscriptions/internal/domain/entity"
 	"git.home/alex/go-subscriptions/internal/domain/repository"
 	"git.home/alex/go-subscriptions/internal/domain/service"
+	"git.home/alex/go-subscriptions/internal/repository/memory"
 	"git.home/alex/go-subscriptions/tests"
 	"git.home/alex/go-subscriptions/tests/mock_repository"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestCycleService_CreateCycle(t *testing.T) {
@@ -61,6 +63,56 @@ func TestCycleService_CreateCycle(t *testing.T) {
 	}
 }
 
+func TestCycleService_CreateCycleRule(t *testing.T) {
+	testCases := []struct {
+		name     string
+		cycle    entity.Cycle
+		wantRule string
+		wantErr  error
+	}{
+		{
+			name:     "Rule is normalized",
+			cycle:    entity.Cycle{Name: "Quarterly", Rule: "RRULE:freq=monthly;bysetpos=1;byday=MO,TU,WE,TH,FR;interval=3"},
+			wantRule: "FREQ=MONTHLY;INTERVAL=3;BYDAY=MO,TU,WE,TH,FR;BYSETPOS=1",
+		},
+		{
+			name:    "Days and rule",
+			cycle:   entity.Cycle{Name: "Quarterly", Days: 90, Rule: "FREQ=MONTHLY;INTERVAL=3"},
+			wantErr: service.ErrInvalidCycle,
+		},
+		{
+			name:    "Invalid rule",
+			cycle:   entity.Cycle{Name: "Quarterly", Rule: "FREQ=MONTHLY;COUNT=4"},
+			wantErr: service.ErrInvalidCycle,
+		},
+		{
+			name:    "Calendar cycle of neither a month nor a year",
+			cycle:   entity.Cycle{Name: "Quarterly", Days: 90, Calendar: true},
+			wantErr: service.ErrInvalidCycle,
+		},
+		{
+			name:    "Rule never repeats",
+			cycle:   entity.Cycle{Name: "Never", Rule: "FREQ=YEARLY;BYMONTH=2;BYMONTHDAY=30"},
+			wantErr: service.ErrInvalidCycle,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			cycleService := service.NewCycleService(memory.NewCycleRepository())
+
+			result, err := cycleService.CreateCycle(context.Background(), tc.cycle)
+			if tc.wantErr != nil {
+				assert.ErrorIs(t, err, tc.wantErr)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tc.wantRule, result.Rule)
+		})
+	}
+}
+
 func TestCycleService_GetCycle(t *testing.T) {
 	testCases := []struct {
 		name       string
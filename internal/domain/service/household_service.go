@@ -0,0 +1,414 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"math"
+
+	"git.home/alex/go-subscriptions/internal/clock"
+	"git.home/alex/go-subscriptions/internal/domain/entity"
+	"git.home/alex/go-subscriptions/internal/domain/repository"
+)
+
+var (
+	ErrInvalidHousehold     = errors.New("the household is not valid")
+	ErrInvalidShare         = errors.New("the subscription share is not valid")
+	ErrHouseholdInUse       = errors.New("the household still has shared subscriptions")
+	ErrHouseholdMemberInUse = errors.New("the household member still has a share in a subscription")
+)
+
+// shareTolerance absorbs rounding when comparing percentages and amounts.
+const shareTolerance = 0.01
+
+type HouseholdService struct {
+	repo          repository.HouseholdRepository
+	subscriptions repository.SubscriptionRepository
+}
+
+func NewHouseholdService(repo repository.HouseholdRepository, subscriptions repository.SubscriptionRepository) *HouseholdService {
+	return &HouseholdService{repo: repo, subscriptions: subscriptions}
+}
+
+func (s *HouseholdService) CreateHousehold(ctx context.Context, household entity.Household) (*entity.Household, error) {
+	if household.Name == "" {
+		return nil, ErrInvalidHousehold
+	}
+
+	for i := range household.Members {
+		if household.Members[i].Name == "" {
+			return nil, ErrInvalidHousehold
+		}
+
+		household.Members[i].ID = uint(i + 1)
+	}
+
+	if userID, ok := ownerFromContext(ctx); ok {
+		household.UserID = userID
+	}
+
+	return s.repo.Create(ctx, household)
+}
+
+// GetHousehold returns the household if the caller owns it or is one of its members.
+func (s *HouseholdService) GetHousehold(ctx context.Context, id uint) (*entity.Household, error) {
+	if id == 0 {
+		return nil, repository.ErrNotFoundHousehold
+	}
+
+	household, err := s.repo.Get(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if userID, ok := ownerFromContext(ctx); ok && !household.HasUser(userID) {
+		return nil, repository.ErrNotFoundHousehold
+	}
+
+	return household, nil
+}
+
+func (s *HouseholdService) GetAllHouseholds(ctx context.Context) (repository.Households, error) {
+	households, err := s.repo.GetAll(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	userID, ok := ownerFromContext(ctx)
+	if !ok {
+		return households, nil
+	}
+
+	visible := make(repository.Households, 0, len(households))
+	for _, household := range households {
+		if household.HasUser(userID) {
+			visible = append(visible, household)
+		}
+	}
+
+	return visible, nil
+}
+
+// UpdateHousehold renames the household. Members are managed with AddMember and RemoveMember.
+func (s *HouseholdService) UpdateHousehold(ctx context.Context, household entity.Household) (*entity.Household, error) {
+	if household.ID == 0 || household.Name == "" {
+		return nil, ErrInvalidHousehold
+	}
+
+	existing, err := s.getOwnHousehold(ctx, household.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	existing.Name = household.Name
+
+	return s.repo.Update(ctx, *existing)
+}
+
+func (s *HouseholdService) DeleteHousehold(ctx context.Context, id uint) error {
+	if _, err := s.getOwnHousehold(ctx, id); err != nil {
+		return err
+	}
+
+	shared, err := s.sharedSubscriptions(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	if len(shared) > 0 {
+		return ErrHouseholdInUse
+	}
+
+	return s.repo.Delete(ctx, id)
+}
+
+func (s *HouseholdService) AddMember(ctx context.Context, householdID uint, member entity.HouseholdMember) (*entity.Household, error) {
+	if member.Name == "" {
+		return nil, ErrInvalidHousehold
+	}
+
+	household, err := s.getOwnHousehold(ctx, householdID)
+	if err != nil {
+		return nil, err
+	}
+
+	var lastID uint
+	for _, m := range household.Members {
+		lastID = max(lastID, m.ID)
+	}
+
+	member.ID = lastID + 1
+	household.Members = append(household.Members, member)
+
+	return s.repo.Update(ctx, *household)
+}
+
+func (s *HouseholdService) RemoveMember(ctx context.Context, householdID, memberID uint) (*entity.Household, error) {
+	household, err := s.getOwnHousehold(ctx, householdID)
+	if err != nil {
+		return nil, err
+	}
+
+	if _, ok := household.Member(memberID); !ok {
+		return nil, ErrInvalidHousehold
+	}
+
+	shared, err := s.sharedSubscriptions(ctx, householdID)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, subscription := range shared {
+		for _, part := range subscription.Share.Parts {
+			if part.MemberID == memberID {
+				return nil, ErrHouseholdMemberInUse
+			}
+		}
+	}
+
+	members := make([]entity.HouseholdMember, 0, len(household.Members)-1)
+	for _, m := range household.Members {
+		if m.ID != memberID {
+			members = append(members, m)
+		}
+	}
+
+	household.Members = members
+
+	return s.repo.Update(ctx, *household)
+}
+
+// ShareSubscription splits the cost of one of the caller's subscriptions within a household.
+func (s *HouseholdService) ShareSubscription(
+	ctx context.Context,
+	subscriptionID uint,
+	share entity.SubscriptionShare,
+) (*entity.Subscription, error) {
+	subscription, err := s.getOwnSubscription(ctx, subscriptionID)
+	if err != nil {
+		return nil, err
+	}
+
+	household, err := s.GetHousehold(ctx, share.HouseholdID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = validateShare(share, *household, subscription.PriceAt(clock.Today(ctx))); err != nil {
+		return nil, err
+	}
+
+	subscription.Share = &share
+
+	return s.subscriptions.Update(ctx, *subscription)
+}
+
+func (s *HouseholdService) UnshareSubscription(ctx context.Context, subscriptionID uint) (*entity.Subscription, error) {
+	subscription, err := s.getOwnSubscription(ctx, subscriptionID)
+	if err != nil {
+		return nil, err
+	}
+
+	subscription.Share = nil
+
+	return s.subscriptions.Update(ctx, *subscription)
+}
+
+type HouseholdReport struct {
+	Household entity.Household
+	// Totals is the normalized monthly cost of all shared subscriptions per currency code.
+	Totals  map[string]float64
+	Members []MemberReport
+}
+
+type MemberReport struct {
+	Member entity.HouseholdMember
+	// Totals is the member's share of the normalized monthly cost per currency code.
+	Totals map[string]float64
+	Items  []MemberShare
+}
+
+type MemberShare struct {
+	Subscription entity.Subscription
+	MonthlyCost  float64
+	MonthlyShare float64
+}
+
+// GetReport computes each member's share of the normalized monthly cost of the subscriptions
+// shared within the household. Subscriptions that aren't active today are left out, and amounts
+// in different currencies are never summed together.
+func (s *HouseholdService) GetReport(ctx context.Context, householdID uint) (*HouseholdReport, error) {
+	household, err := s.GetHousehold(ctx, householdID)
+	if err != nil {
+		return nil, err
+	}
+
+	shared, err := s.sharedSubscriptions(ctx, householdID)
+	if err != nil {
+		return nil, err
+	}
+
+	report := &HouseholdReport{
+		Household: *household,
+		Totals:    make(map[string]float64),
+		Members:   make([]MemberReport, len(household.Members)),
+	}
+
+	index := make(map[uint]int, len(household.Members))
+	for i, m := range household.Members {
+		index[m.ID] = i
+		report.Members[i] = MemberReport{Member: m, Totals: make(map[string]float64)}
+	}
+
+	now := clock.Today(ctx)
+
+	for _, subscription := range shared {
+		if !subscription.IsActive(now) {
+			continue
+		}
+
+		monthlyCost := subscription.MonthlyCost(now)
+		report.Totals[subscription.Currency.Code] += monthlyCost
+
+		for memberID, amount := range splitShare(*subscription.Share, *household, subscription.PriceAt(now)) {
+			i, ok := index[memberID]
+			if !ok {
+				continue
+			}
+
+			monthlyShare := amount * subscription.Cycle.PerMonth()
+			report.Members[i].Totals[subscription.Currency.Code] += monthlyShare
+			report.Members[i].Items = append(report.Members[i].Items, MemberShare{
+				Subscription: subscription,
+				MonthlyCost:  roundAmount(monthlyCost),
+				MonthlyShare: roundAmount(monthlyShare),
+			})
+		}
+	}
+
+	roundTotals(report.Totals)
+	for _, m := range report.Members {
+		roundTotals(m.Totals)
+	}
+
+	return report, nil
+}
+
+func (s *HouseholdService) getOwnHousehold(ctx context.Context, id uint) (*entity.Household, error) {
+	household, err := s.GetHousehold(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if userID, ok := ownerFromContext(ctx); ok && household.UserID != userID {
+		return nil, ErrAccessDenied
+	}
+
+	return household, nil
+}
+
+func (s *HouseholdService) getOwnSubscription(ctx context.Context, id uint) (*entity.Subscription, error) {
+	subscription, err := s.subscriptions.Get(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if !canSee(ctx, subscription.UserID, false) {
+		return nil, repository.ErrNotFoundSubscription
+	}
+
+	return subscription, nil
+}
+
+// sharedSubscriptions reads the repository directly: members see the subscriptions of other users
+// shared with them.
+func (s *HouseholdService) sharedSubscriptions(ctx context.Context, householdID uint) (repository.Subscriptions, error) {
+	subscriptions, err := s.subscriptions.GetAll(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var shared repository.Subscriptions
+	for _, subscription := range subscriptions {
+		if subscription.Share != nil && subscription.Share.HouseholdID == householdID {
+			shared = append(shared, subscription)
+		}
+	}
+
+	return shared, nil
+}
+
+func validateShare(share entity.SubscriptionShare, household entity.Household, price float64) error {
+	seen := make(map[uint]bool, len(share.Parts))
+	var sum float64
+
+	for _, part := range share.Parts {
+		if _, ok := household.Member(part.MemberID); !ok || seen[part.MemberID] || part.Value < 0 {
+			return ErrInvalidShare
+		}
+
+		seen[part.MemberID] = true
+		sum += part.Value
+	}
+
+	switch share.Rule {
+	case entity.SplitEqual:
+		if len(share.Parts) == 0 && len(household.Members) == 0 {
+			return ErrInvalidShare
+		}
+	case entity.SplitPercentage:
+		if len(share.Parts) == 0 || math.Abs(sum-100) > shareTolerance {
+			return ErrInvalidShare
+		}
+	case entity.SplitFixed:
+		if len(share.Parts) == 0 || math.Abs(sum-price) > shareTolerance {
+			return ErrInvalidShare
+		}
+	default:
+		return ErrInvalidShare
+	}
+
+	return nil
+}
+
+// splitShare returns the amount each member pays per billing cycle.
+func splitShare(share entity.SubscriptionShare, household entity.Household, price float64) map[uint]float64 {
+	amounts := make(map[uint]float64)
+
+	switch share.Rule {
+	case entity.SplitEqual:
+		memberIDs := make([]uint, 0, len(household.Members))
+		for _, part := range share.Parts {
+			memberIDs = append(memberIDs, part.MemberID)
+		}
+
+		if len(memberIDs) == 0 {
+			for _, m := range household.Members {
+				memberIDs = append(memberIDs, m.ID)
+			}
+		}
+
+		for _, id := range memberIDs {
+			amounts[id] = price / float64(len(memberIDs))
+		}
+	case entity.SplitPercentage:
+		for _, part := range share.Parts {
+			amounts[part.MemberID] = price * part.Value / 100
+		}
+	case entity.SplitFixed:
+		for _, part := range share.Parts {
+			amounts[part.MemberID] = part.Value
+		}
+	}
+
+	return amounts
+}
+
+func roundAmount(amount float64) float64 {
+	return math.Round(amount*100) / 100
+}
+
+func roundTotals(totals map[string]float64) {
+	for code, amount := range totals {
+		totals[code] = roundAmount(amount)
+	}
+}
@@ -0,0 +1,328 @@
+package service_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"git.home/alex/go-subscriptions/internal/domain/entity"
+	"git.home/alex/go-subscriptions/internal/domain/repository"
+	"git.home/alex/go-subscriptions/internal/domain/service"
+	"git.home/alex/go-subscriptions/internal/repository/memory"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newHouseholdFixture(t *testing.T) (*service.HouseholdService, *entity.Household, *entity.Subscription) {
+	t.Helper()
+
+	ctx := context.Background()
+	subscriptions := memory.NewSubscriptionRepository()
+	householdService := service.NewHouseholdService(memory.NewHouseholdRepository(), subscriptions)
+
+	household, err := householdService.CreateHousehold(ctx, entity.Household{
+		Name: "Home",
+		Members: []entity.HouseholdMember{
+			{Name: "Alice"},
+			{Name: "Bob"},
+			{Name: "Carol"},
+		},
+	})
+	require.NoError(t, err)
+
+	subscription, err := subscriptions.Create(ctx, entity.Subscription{
+		Name:     "Streaming",
+		Price:    120,
+		Currency: entity.USD,
+		Cycle:    entity.Yearly,
+	})
+	require.NoError(t, err)
+
+	return householdService, household, subscription
+}
+
+func TestHouseholdService_CreateHousehold(t *testing.T) {
+	householdService, household, _ := newHouseholdFixture(t)
+
+	assert.Equal(t, []entity.HouseholdMember{
+		{ID: 1, Name: "Alice"},
+		{ID: 2, Name: "Bob"},
+		{ID: 3, Name: "Carol"},
+	}, household.Members)
+
+	_, err := householdService.CreateHousehold(context.Background(), entity.Household{Name: ""})
+	assert.ErrorIs(t, err, service.ErrInvalidHousehold)
+
+	_, err = householdService.CreateHousehold(context.Background(), entity.Household{
+		Name:    "Home",
+		Members: []entity.HouseholdMember{{Name: ""}},
+	})
+	assert.ErrorIs(t, err, service.ErrInvalidHousehold)
+}
+
+func TestHouseholdService_ShareSubscription(t *testing.T) {
+	testCases := []struct {
+		name    string
+		share   entity.SubscriptionShare
+		wantErr error
+	}{
+		{
+			name:  "Equal between all members",
+			share: entity.SubscriptionShare{HouseholdID: 1, Rule: entity.SplitEqual},
+		},
+		{
+			name: "Percentage",
+			share: entity.SubscriptionShare{
+				HouseholdID: 1,
+				Rule:        entity.SplitPercentage,
+				Parts:       []entity.SharePart{{MemberID: 1, Value: 50}, {MemberID: 2, Value: 50}},
+			},
+		},
+		{
+			name: "Percentage not summing to 100",
+			share: entity.SubscriptionShare{
+				HouseholdID: 1,
+				Rule:        entity.SplitPercentage,
+				Parts:       []entity.SharePart{{MemberID: 1, Value: 50}, {MemberID: 2, Value: 40}},
+			},
+			wantErr: service.ErrInvalidShare,
+		},
+		{
+			name: "Fixed",
+			share: entity.SubscriptionShare{
+				HouseholdID: 1,
+				Rule:        entity.SplitFixed,
+				Parts:       []entity.SharePart{{MemberID: 1, Value: 100}, {MemberID: 3, Value: 20}},
+			},
+		},
+		{
+			name: "Fixed not summing to the price",
+			share: entity.SubscriptionShare{
+				HouseholdID: 1,
+				Rule:        entity.SplitFixed,
+				Parts:       []entity.SharePart{{MemberID: 1, Value: 100}},
+			},
+			wantErr: service.ErrInvalidShare,
+		},
+		{
+			name: "Unknown member",
+			share: entity.SubscriptionShare{
+				HouseholdID: 1,
+				Rule:        entity.SplitEqual,
+				Parts:       []entity.SharePart{{MemberID: 10}},
+			},
+			wantErr: service.ErrInvalidShare,
+		},
+		{
+			name: "Duplicate member",
+			share: entity.SubscriptionShare{
+				HouseholdID: 1,
+				Rule:        entity.SplitEqual,
+				Parts:       []entity.SharePart{{MemberID: 1}, {MemberID: 1}},
+			},
+			wantErr: service.ErrInvalidShare,
+		},
+		{
+			name:    "Unknown rule",
+			share:   entity.SubscriptionShare{HouseholdID: 1, Rule: "random"},
+			wantErr: service.ErrInvalidShare,
+		},
+		{
+			name:    "Unknown household",
+			share:   entity.SubscriptionShare{HouseholdID: 10, Rule: entity.SplitEqual},
+			wantErr: repository.ErrNotFoundHousehold,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			householdService, _, subscription := newHouseholdFixture(t)
+
+			result, err := householdService.ShareSubscription(context.Background(), subscription.ID, tc.share)
+
+			if tc.wantErr != nil {
+				assert.ErrorIs(t, err, tc.wantErr)
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, &tc.share, result.Share)
+		})
+	}
+}
+
+func TestHouseholdService_ShareSubscription_PriceChange(t *testing.T) {
+	ctx := context.Background()
+	subscriptions := memory.NewSubscriptionRepository()
+	householdService := service.NewHouseholdService(memory.NewHouseholdRepository(), subscriptions)
+
+	household, err := householdService.CreateHousehold(ctx, entity.Household{
+		Name:    "Home",
+		Members: []entity.HouseholdMember{{Name: "Alice"}, {Name: "Bob"}},
+	})
+	require.NoError(t, err)
+
+	// The stored price predates a change that took effect since.
+	subscription, err := subscriptions.Create(ctx, entity.Subscription{
+		Name: "Streaming", Price: 120, Currency: entity.USD, Cycle: entity.Yearly,
+		Prices: []entity.PriceChange{
+			{Price: 120},
+			{Price: 150, EffectiveDate: entity.PaymentDate(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC))},
+		},
+	})
+	require.NoError(t, err)
+
+	share := entity.SubscriptionShare{
+		HouseholdID: household.ID,
+		Rule:        entity.SplitFixed,
+		Parts:       []entity.SharePart{{MemberID: 1, Value: 100}, {MemberID: 2, Value: 20}},
+	}
+
+	_, err = householdService.ShareSubscription(ctx, subscription.ID, share)
+	assert.ErrorIs(t, err, service.ErrInvalidShare)
+
+	share.Parts[1].Value = 50
+
+	_, err = householdService.ShareSubscription(ctx, subscription.ID, share)
+	assert.NoError(t, err)
+}
+
+func TestHouseholdService_GetReport(t *testing.T) {
+	testCases := []struct {
+		name          string
+		share         entity.SubscriptionShare
+		wantShares    []float64
+		wantItemCount []int
+	}{
+		{
+			name:          "Equal between all members",
+			share:         entity.SubscriptionShare{HouseholdID: 1, Rule: entity.SplitEqual},
+			wantShares:    []float64{3.33, 3.33, 3.33},
+			wantItemCount: []int{1, 1, 1},
+		},
+		{
+			name: "Equal between some members",
+			share: entity.SubscriptionShare{
+				HouseholdID: 1,
+				Rule:        entity.SplitEqual,
+				Parts:       []entity.SharePart{{MemberID: 1}, {MemberID: 2}},
+			},
+			wantShares:    []float64{5, 5, 0},
+			wantItemCount: []int{1, 1, 0},
+		},
+		{
+			name: "Percentage",
+			share: entity.SubscriptionShare{
+				HouseholdID: 1,
+				Rule:        entity.SplitPercentage,
+				Parts:       []entity.SharePart{{MemberID: 1, Value: 75}, {MemberID: 3, Value: 25}},
+			},
+			wantShares:    []float64{7.5, 0, 2.5},
+			wantItemCount: []int{1, 0, 1},
+		},
+		{
+			name: "Fixed",
+			share: entity.SubscriptionShare{
+				HouseholdID: 1,
+				Rule:        entity.SplitFixed,
+				Parts:       []entity.SharePart{{MemberID: 2, Value: 60}, {MemberID: 3, Value: 60}},
+			},
+			wantShares:    []float64{0, 5, 5},
+			wantItemCount: []int{0, 1, 1},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			householdService, household, subscription := newHouseholdFixture(t)
+			ctx := context.Background()
+
+			_, err := householdService.ShareSubscription(ctx, subscription.ID, tc.share)
+			require.NoError(t, err)
+
+			report, err := householdService.GetReport(ctx, household.ID)
+			require.NoError(t, err)
+
+			assert.Equal(t, map[string]float64{"USD": 10}, report.Totals)
+			for i, m := range report.Members {
+				assert.Equal(t, tc.wantShares[i], m.Totals["USD"], m.Member.Name)
+				assert.Len(t, m.Items, tc.wantItemCount[i], m.Member.Name)
+			}
+		})
+	}
+}
+
+func TestHouseholdService_GetReportSkipsInactive(t *testing.T) {
+	ctx := context.Background()
+	subscriptions := memory.NewSubscriptionRepository()
+	householdService := service.NewHouseholdService(memory.NewHouseholdRepository(), subscriptions)
+
+	household, err := householdService.CreateHousehold(ctx, entity.Household{Name: "Home", Members: []entity.HouseholdMember{{Name: "Alice"}}})
+	require.NoError(t, err)
+
+	share := &entity.SubscriptionShare{HouseholdID: household.ID, Rule: entity.SplitEqual}
+	for _, status := range []entity.SubscriptionStatus{entity.StatusActive, entity.StatusPaused, entity.StatusCancelled} {
+		_, err = subscriptions.Create(ctx, entity.Subscription{
+			Name:     string(status),
+			Price:    10,
+			Currency: entity.USD,
+			Cycle:    entity.Monthly,
+			Status:   status,
+			Share:    share,
+		})
+		require.NoError(t, err)
+	}
+
+	report, err := householdService.GetReport(ctx, household.ID)
+	require.NoError(t, err)
+
+	assert.Equal(t, map[string]float64{"USD": 10}, report.Totals)
+	assert.Len(t, report.Members[0].Items, 1)
+}
+
+func TestHouseholdService_RemoveMember(t *testing.T) {
+	householdService, household, subscription := newHouseholdFixture(t)
+	ctx := context.Background()
+
+	_, err := householdService.ShareSubscription(ctx, subscription.ID, entity.SubscriptionShare{
+		HouseholdID: household.ID,
+		Rule:        entity.SplitPercentage,
+		Parts:       []entity.SharePart{{MemberID: 1, Value: 50}, {MemberID: 2, Value: 50}},
+	})
+	require.NoError(t, err)
+
+	_, err = householdService.RemoveMember(ctx, household.ID, 1)
+	assert.ErrorIs(t, err, service.ErrHouseholdMemberInUse)
+
+	updated, err := householdService.RemoveMember(ctx, household.ID, 3)
+	assert.NoError(t, err)
+	assert.Len(t, updated.Members, 2)
+
+	err = householdService.DeleteHousehold(ctx, household.ID)
+	assert.ErrorIs(t, err, service.ErrHouseholdInUse)
+
+	_, err = householdService.UnshareSubscription(ctx, subscription.ID)
+	require.NoError(t, err)
+
+	err = householdService.DeleteHousehold(ctx, household.ID)
+	assert.NoError(t, err)
+}
+
+func TestHouseholdService_Visibility(t *testing.T) {
+	householdService := service.NewHouseholdService(memory.NewHouseholdRepository(), memory.NewSubscriptionRepository())
+
+	household, err := householdService.CreateHousehold(userContext(1), entity.Household{
+		Name:    "Home",
+		Members: []entity.HouseholdMember{{Name: "Alice", UserID: 1}, {Name: "Bob", UserID: 2}},
+	})
+	require.NoError(t, err)
+
+	_, err = householdService.GetHousehold(userContext(2), household.ID)
+	assert.NoError(t, err)
+
+	_, err = householdService.GetHousehold(userContext(3), household.ID)
+	assert.ErrorIs(t, err, repository.ErrNotFoundHousehold)
+
+	_, err = householdService.UpdateHousehold(userContext(2), entity.Household{ID: household.ID, Name: "Mine"})
+	assert.ErrorIs(t, err, service.ErrAccessDenied)
+}
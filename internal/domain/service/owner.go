@@ -0,0 +1,36 @@
+package service
+
+import (
+	"context"
+	"errors"
+
+	"git.home/alex/go-subscriptions/internal/auth"
+)
+
+var (
+	ErrAccessDenied = errors.New("access denied")
+)
+
+// ownerFromContext returns the ID of the user the call is made for. ok is false for admin calls
+// (authentication disabled, CLI, admin scope); such calls see all data. A principal that is neither
+// an admin nor bound to a user gets the zero ID, so it sees and creates the entities no user owns.
+func ownerFromContext(ctx context.Context) (id uint, ok bool) {
+	if auth.IsAdmin(ctx) {
+		return 0, false
+	}
+
+	principal, _ := auth.PrincipalFromContext(ctx)
+
+	return principal.UserID, true
+}
+
+// canSee reports whether the caller may read an entity owned by ownerID.
+// Entities without an owner are shared when shared is true.
+func canSee(ctx context.Context, ownerID uint, shared bool) bool {
+	userID, ok := ownerFromContext(ctx)
+	if !ok {
+		return true
+	}
+
+	return ownerID == userID || (shared && ownerID == 0)
+}
@@ -0,0 +1,143 @@
+package service_test
+
+import (
+	"context"
+	"testing"
+
+	"git.home/alex/go-subscriptions/internal/auth"
+	"git.home/alex/go-subscriptions/internal/domain/entity"
+	"git.home/alex/go-subscriptions/internal/domain/repository"
+	"git.home/alex/go-subscriptions/internal/domain/service"
+	"git.home/alex/go-subscriptions/internal/repository/memory"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func userContext(userID uint) context.Context {
+	return auth.WithPrincipal(context.Background(), &auth.Principal{UserID: userID, Scope: auth.ScopeReadWrite})
+}
+
+func TestOwnership_Categories(t *testing.T) {
+	categoryService := service.NewCategoryService(memory.NewCategoryRepository(), memory.NewSubscriptionRepository())
+	alice, bob := userContext(1), userContext(2)
+
+	aliceCategory, err := categoryService.CreateCategory(alice, entity.Category{Name: "Alice"})
+	require.NoError(t, err)
+	assert.Equal(t, uint(1), aliceCategory.UserID)
+
+	_, err = categoryService.CreateCategory(bob, entity.Category{Name: "Bob"})
+	require.NoError(t, err)
+
+	categories, err := categoryService.GetAllCategories(alice)
+	assert.NoError(t, err)
+	assert.Equal(t, repository.Categories{*aliceCategory}, categories)
+
+	categories, err = categoryService.GetAllCategories(context.Background())
+	assert.NoError(t, err)
+	assert.Len(t, categories, 2)
+
+	unbound := auth.WithPrincipal(context.Background(), &auth.Principal{Subject: "token", Scope: auth.ScopeReadWrite})
+	categories, err = categoryService.GetAllCategories(unbound)
+	assert.NoError(t, err)
+	assert.Empty(t, categories)
+
+	unowned, err := categoryService.CreateCategory(unbound, entity.Category{Name: "Unowned"})
+	require.NoError(t, err)
+	assert.Zero(t, unowned.UserID)
+
+	categories, err = categoryService.GetAllCategories(unbound)
+	assert.NoError(t, err)
+	assert.Equal(t, repository.Categories{*unowned}, categories)
+
+	_, err = categoryService.GetCategory(alice, unowned.ID)
+	assert.ErrorIs(t, err, repository.ErrNotFoundCategory)
+
+	_, err = categoryService.GetCategory(bob, aliceCategory.ID)
+	assert.ErrorIs(t, err, repository.ErrNotFoundCategory)
+
+	_, err = categoryService.UpdateCategory(bob, entity.Category{ID: aliceCategory.ID, Name: "Stolen"})
+	assert.ErrorIs(t, err, repository.ErrNotFoundCategory)
+
+	err = categoryService.DeleteCategory(bob, aliceCategory.ID)
+	assert.ErrorIs(t, err, repository.ErrNotFoundCategory)
+
+	err = categoryService.DeleteCategory(alice, aliceCategory.ID)
+	assert.NoError(t, err)
+}
+
+func TestOwnership_SharedCycles(t *testing.T) {
+	cycleService := service.NewCycleService(memory.NewCycleRepository())
+	alice, bob := userContext(1), userContext(2)
+
+	global, err := cycleService.CreateCycle(context.Background(), entity.Monthly)
+	require.NoError(t, err)
+
+	custom, err := cycleService.CreateCycle(alice, entity.Cycle{Name: "Fortnightly", Days: 14})
+	require.NoError(t, err)
+
+	cycles, err := cycleService.GetAllCycles(bob)
+	assert.NoError(t, err)
+	assert.Equal(t, repository.Cycles{*global}, cycles)
+
+	_, err = cycleService.GetCycle(bob, custom.ID)
+	assert.ErrorIs(t, err, repository.ErrNotFoundCycle)
+
+	_, err = cycleService.GetCycle(alice, global.ID)
+	assert.NoError(t, err)
+
+	_, err = cycleService.UpdateCycle(alice, entity.Cycle{ID: global.ID, Name: "Changed", Days: 1})
+	assert.ErrorIs(t, err, service.ErrAccessDenied)
+
+	err = cycleService.DeleteCycle(alice, global.ID)
+	assert.ErrorIs(t, err, service.ErrAccessDenied)
+
+	_, err = cycleService.UpdateCycle(alice, entity.Cycle{ID: custom.ID, Name: "Biweekly", Days: 14})
+	assert.NoError(t, err)
+}
+
+func TestOwnership_SharedCurrencies(t *testing.T) {
+	currencyService := service.NewCurrencyService(memory.NewCurrencyRepository())
+	alice, bob := userContext(1), userContext(2)
+
+	_, err := currencyService.CreateCurrency(context.Background(), entity.USD)
+	require.NoError(t, err)
+
+	_, err = currencyService.CreateCurrency(alice, entity.Currency{Code: "PTS", Symbol: "P", Name: "Points"})
+	assert.ErrorIs(t, err, service.ErrAccessDenied)
+
+	_, err = currencyService.GetCurrency(bob, entity.USD.Code)
+	assert.NoError(t, err)
+
+	_, err = currencyService.UpdateCurrency(alice, entity.Currency{Code: "USD", Symbol: "$", Name: "Dollar"})
+	assert.ErrorIs(t, err, service.ErrAccessDenied)
+
+	err = currencyService.DeleteCurrency(bob, entity.USD.Code)
+	assert.ErrorIs(t, err, service.ErrAccessDenied)
+}
+
+func TestOwnership_Subscriptions(t *testing.T) {
+	subscriptionService := service.NewSubscriptionService(memory.NewSubscriptionRepository())
+	alice, bob := userContext(1), userContext(2)
+
+	subscription, err := subscriptionService.CreateSubscription(alice, entity.Subscription{
+		Name:     "Music",
+		Price:    10,
+		Currency: entity.USD,
+		Cycle:    entity.Monthly,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, uint(1), subscription.UserID)
+
+	subscriptions, err := subscriptionService.GetAllSubscriptions(bob)
+	assert.NoError(t, err)
+	assert.Empty(t, subscriptions)
+
+	_, err = subscriptionService.GetSubscription(bob, subscription.ID)
+	assert.ErrorIs(t, err, repository.ErrNotFoundSubscription)
+
+	err = subscriptionService.DeleteSubscription(bob, subscription.ID)
+	assert.ErrorIs(t, err, repository.ErrNotFoundSubscription)
+
+	_, err = subscriptionService.GetSubscription(alice, subscription.ID)
+	assert.NoError(t, err)
+}
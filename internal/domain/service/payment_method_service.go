@@ -0,0 +1,269 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"time"
+	"unicode"
+
+	"git.home/alex/go-subscriptions/internal/clock"
+	"git.home/alex/go-subscriptions/internal/domain/entity"
+	"git.home/alex/go-subscriptions/internal/domain/repository"
+)
+
+var (
+	ErrInvalidPaymentMethod = errors.New("the payment method is not valid")
+)
+
+type PaymentMethodService struct {
+	repo          repository.PaymentMethodRepository
+	subscriptions repository.SubscriptionRepository
+}
+
+func NewPaymentMethodService(
+	repo repository.PaymentMethodRepository,
+	subscriptions repository.SubscriptionRepository,
+) *PaymentMethodService {
+	return &PaymentMethodService{
+		repo:          repo,
+		subscriptions: subscriptions,
+	}
+}
+
+type PaymentMethodReport struct {
+	PaymentMethod entity.PaymentMethod
+	// Totals is the normalized monthly cost of the subscriptions charged to the method per currency code.
+	Totals        map[string]float64
+	Subscriptions int
+}
+
+// ExpiringPaymentMethod is a payment method that expires soon with the subscriptions that are
+// still going to be charged to it after it expires.
+type ExpiringPaymentMethod struct {
+	PaymentMethod entity.PaymentMethod
+	Subscriptions repository.Subscriptions
+}
+
+func (s *PaymentMethodService) CreatePaymentMethod(ctx context.Context, method entity.PaymentMethod) (*entity.PaymentMethod, error) {
+	if !validPaymentMethod(method) {
+		return nil, ErrInvalidPaymentMethod
+	}
+
+	if userID, ok := ownerFromContext(ctx); ok {
+		method.UserID = userID
+	}
+
+	return s.repo.Create(ctx, method)
+}
+
+func (s *PaymentMethodService) GetPaymentMethod(ctx context.Context, id uint) (*entity.PaymentMethod, error) {
+	if id == 0 {
+		return nil, repository.ErrNotFoundPaymentMethod
+	}
+
+	method, err := s.repo.Get(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if !canSee(ctx, method.UserID, false) {
+		return nil, repository.ErrNotFoundPaymentMethod
+	}
+
+	return method, nil
+}
+
+func (s *PaymentMethodService) GetAllPaymentMethods(ctx context.Context) (repository.PaymentMethods, error) {
+	methods, err := s.repo.GetAll(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if _, ok := ownerFromContext(ctx); !ok {
+		return methods, nil
+	}
+
+	visible := make(repository.PaymentMethods, 0, len(methods))
+	for _, method := range methods {
+		if canSee(ctx, method.UserID, false) {
+			visible = append(visible, method)
+		}
+	}
+
+	return visible, nil
+}
+
+func (s *PaymentMethodService) UpdatePaymentMethod(ctx context.Context, method entity.PaymentMethod) (*entity.PaymentMethod, error) {
+	if method.ID == 0 || !validPaymentMethod(method) {
+		return nil, ErrInvalidPaymentMethod
+	}
+
+	if userID, ok := ownerFromContext(ctx); ok {
+		if _, err := s.GetPaymentMethod(ctx, method.ID); err != nil {
+			return nil, err
+		}
+
+		method.UserID = userID
+	}
+
+	return s.repo.Update(ctx, method)
+}
+
+// DeletePaymentMethod unlinks the subscriptions charged to the method before deleting it.
+func (s *PaymentMethodService) DeletePaymentMethod(ctx context.Context, id uint) error {
+	if id == 0 {
+		return repository.ErrNotFoundPaymentMethod
+	}
+
+	if _, ok := ownerFromContext(ctx); ok {
+		if _, err := s.GetPaymentMethod(ctx, id); err != nil {
+			return err
+		}
+	}
+
+	subscriptions, err := s.subscriptions.GetAll(ctx)
+	if err != nil {
+		return err
+	}
+
+	for _, subscription := range subscriptions {
+		if subscription.PaymentMethodID != id {
+			continue
+		}
+
+		subscription.PaymentMethodID = 0
+
+		_, err = s.subscriptions.Update(ctx, subscription)
+		if err != nil {
+			return err
+		}
+	}
+
+	return s.repo.Delete(ctx, id)
+}
+
+// LinkSubscription charges the subscription to the payment method; a zero method ID unlinks it.
+func (s *PaymentMethodService) LinkSubscription(ctx context.Context, subscriptionID, methodID uint) (*entity.Subscription, error) {
+	subscription, err := s.subscriptions.Get(ctx, subscriptionID)
+	if err != nil {
+		return nil, err
+	}
+
+	if !canSee(ctx, subscription.UserID, false) {
+		return nil, repository.ErrNotFoundSubscription
+	}
+
+	if methodID != 0 {
+		if _, err = s.GetPaymentMethod(ctx, methodID); err != nil {
+			return nil, err
+		}
+	}
+
+	subscription.PaymentMethodID = methodID
+
+	return s.subscriptions.Update(ctx, *subscription)
+}
+
+// GetReport groups the normalized monthly cost of the subscriptions active today by the payment
+// method they are charged to.
+func (s *PaymentMethodService) GetReport(ctx context.Context) ([]PaymentMethodReport, error) {
+	methods, err := s.GetAllPaymentMethods(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	subscriptions, err := s.subscriptions.GetAll(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	now := clock.Today(ctx)
+	reports := make([]PaymentMethodReport, len(methods))
+
+	for i, method := range methods {
+		reports[i] = PaymentMethodReport{PaymentMethod: method, Totals: make(map[string]float64)}
+
+		for _, subscription := range subscriptions {
+			if subscription.PaymentMethodID != method.ID || !subscription.IsActive(now) || !canSee(ctx, subscription.UserID, false) {
+				continue
+			}
+
+			reports[i].Totals[subscription.Currency.Code] += subscription.MonthlyCost(now)
+			reports[i].Subscriptions++
+		}
+
+		roundTotals(reports[i].Totals)
+	}
+
+	return reports, nil
+}
+
+// GetExpiring returns the payment methods that can't be charged on before, including those expired
+// already, that still have subscriptions billed to them within a year of the expiry. Methods without
+// such subscriptions are left out.
+func (s *PaymentMethodService) GetExpiring(ctx context.Context, now, before time.Time) ([]ExpiringPaymentMethod, error) {
+	methods, err := s.GetAllPaymentMethods(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	subscriptions, err := s.subscriptions.GetAll(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	expiring := make([]ExpiringPaymentMethod, 0)
+
+	for _, method := range methods {
+		if !method.ExpiresBefore(before) {
+			continue
+		}
+
+		from := method.ExpiresAt()
+		if from.Before(now) {
+			from = now
+		}
+
+		var charged repository.Subscriptions
+
+		for _, subscription := range subscriptions {
+			if subscription.PaymentMethodID != method.ID || !canSee(ctx, subscription.UserID, false) {
+				continue
+			}
+
+			if len(subscription.PaymentsBetween(from, from.AddDate(1, 0, 0))) > 0 {
+				charged = append(charged, subscription)
+			}
+		}
+
+		if len(charged) > 0 {
+			expiring = append(expiring, ExpiringPaymentMethod{PaymentMethod: method, Subscriptions: charged})
+		}
+	}
+
+	return expiring, nil
+}
+
+func validPaymentMethod(method entity.PaymentMethod) bool {
+	if method.Label == "" || !method.Type.Valid() {
+		return false
+	}
+
+	if method.LastFour != "" {
+		if len(method.LastFour) != 4 {
+			return false
+		}
+
+		for _, r := range method.LastFour {
+			if !unicode.IsDigit(r) {
+				return false
+			}
+		}
+	}
+
+	if method.Expires() {
+		return method.ExpiryMonth >= time.January && method.ExpiryMonth <= time.December
+	}
+
+	return method.ExpiryMonth == 0
+}
@@ -0,0 +1,106 @@
+package service_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"git.home/alex/go-subscriptions/internal/domain/entity"
+	"git.home/alex/go-subscriptions/internal/domain/service"
+	"git.home/alex/go-subscriptions/internal/repository/memory"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPaymentMethodService_CreatePaymentMethod(t *testing.T) {
+	testCases := []struct {
+		name    string
+		method  entity.PaymentMethod
+		wantErr error
+	}{
+		{
+			name:   "Card",
+			method: entity.PaymentMethod{Label: "Visa", Type: entity.PaymentCard, LastFour: "4242", ExpiryYear: 2027, ExpiryMonth: time.March},
+		},
+		{name: "Without expiry", method: entity.PaymentMethod{Label: "PayPal", Type: entity.PaymentWallet}},
+		{name: "No label", method: entity.PaymentMethod{Type: entity.PaymentCard}, wantErr: service.ErrInvalidPaymentMethod},
+		{name: "Unknown type", method: entity.PaymentMethod{Label: "Visa", Type: "cash"}, wantErr: service.ErrInvalidPaymentMethod},
+		{
+			name:    "Last four are not digits",
+			method:  entity.PaymentMethod{Label: "Visa", Type: entity.PaymentCard, LastFour: "42a2"},
+			wantErr: service.ErrInvalidPaymentMethod,
+		},
+		{
+			name:    "Expiry month without year",
+			method:  entity.PaymentMethod{Label: "Visa", Type: entity.PaymentCard, ExpiryMonth: time.March},
+			wantErr: service.ErrInvalidPaymentMethod,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			methodService := service.NewPaymentMethodService(memory.NewPaymentMethodRepository(), memory.NewSubscriptionRepository())
+
+			created, err := methodService.CreatePaymentMethod(context.Background(), tc.method)
+			if tc.wantErr != nil {
+				assert.ErrorIs(t, err, tc.wantErr)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, uint(1), created.ID)
+		})
+	}
+}
+
+func TestPaymentMethodService_GetExpiring(t *testing.T) {
+	ctx := context.Background()
+	subscriptions := memory.NewSubscriptionRepository()
+	methodService := service.NewPaymentMethodService(memory.NewPaymentMethodRepository(), subscriptions)
+	now := time.Date(2024, 5, 10, 0, 0, 0, 0, time.UTC)
+
+	expiring, err := methodService.CreatePaymentMethod(ctx, entity.PaymentMethod{
+		Label: "Visa", Type: entity.PaymentCard, ExpiryYear: 2024, ExpiryMonth: time.May,
+	})
+	require.NoError(t, err)
+	valid, err := methodService.CreatePaymentMethod(ctx, entity.PaymentMethod{
+		Label: "Mastercard", Type: entity.PaymentCard, ExpiryYear: 2030, ExpiryMonth: time.January,
+	})
+	require.NoError(t, err)
+
+	for _, s := range []entity.Subscription{
+		{Name: "Music", Price: 10, PaymentMethodID: expiring.ID},
+		{Name: "Video", Price: 15, PaymentMethodID: valid.ID},
+		{Name: "Cancelled", Price: 5, PaymentMethodID: expiring.ID, Status: entity.StatusCancelled},
+	} {
+		s.Currency = entity.USD
+		s.Cycle = entity.Monthly
+		s.NextPaymentDate = entity.PaymentDate(now.AddDate(0, 0, 5))
+
+		_, err = subscriptions.Create(ctx, s)
+		require.NoError(t, err)
+	}
+
+	methods, err := methodService.GetExpiring(ctx, now, now.AddDate(0, 0, 7))
+	assert.NoError(t, err)
+	assert.Empty(t, methods, "the card is valid until the end of May")
+
+	methods, err = methodService.GetExpiring(ctx, now, now.AddDate(0, 0, 30))
+	require.NoError(t, err)
+	require.Len(t, methods, 1)
+	assert.Equal(t, expiring.ID, methods[0].PaymentMethod.ID)
+	require.Len(t, methods[0].Subscriptions, 1)
+	assert.Equal(t, "Music", methods[0].Subscriptions[0].Name)
+
+	reports, err := methodService.GetReport(ctx)
+	require.NoError(t, err)
+	require.Len(t, reports, 2)
+	assert.Equal(t, 1, reports[0].Subscriptions)
+	assert.InDelta(t, 15, reports[1].Totals["USD"], 0.001)
+
+	require.NoError(t, methodService.DeletePaymentMethod(ctx, expiring.ID))
+
+	music, err := subscriptions.Get(ctx, 1)
+	require.NoError(t, err)
+	assert.Zero(t, music.PaymentMethodID, "deleting a payment method unlinks it")
+}
@@ -0,0 +1,884 @@
+package service
+
+import (
+	"bufio"
+	"context"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+	"unicode/utf8"
+
+	"git.home/alex/go-subscriptions/internal/domain/entity"
+	"git.home/alex/go-subscriptions/internal/domain/repository"
+)
+
+var (
+	ErrInvalidStatement       = errors.New("the statement file is not valid")
+	ErrUnknownStatementFormat = errors.New("unknown statement format")
+)
+
+type StatementFormat string
+
+// An empty format is detected from the content of the file. QFX is OFX under another name.
+const (
+	StatementFormatAuto StatementFormat = ""
+	StatementFormatCSV  StatementFormat = "csv"
+	StatementFormatOFX  StatementFormat = "ofx"
+	StatementFormatQFX  StatementFormat = "qfx"
+)
+
+const (
+	// statementAmountTolerance is how far a charge may stray from the usual amount of its merchant.
+	statementAmountTolerance = 0.2
+	// statementCycleTolerance is how far an existing cycle may be from the detected interval to match.
+	statementCycleTolerance = 0.05
+	statementMerchantWords  = 2
+	ofxDateLength           = 8
+)
+
+// StatementMapping tells which CSV columns hold what. Column names are matched case-insensitively.
+// Charges are negative amounts; credits are ignored.
+type StatementMapping struct {
+	DateColumn        string `json:"date_column"`
+	DescriptionColumn string `json:"description_column"`
+	AmountColumn      string `json:"amount_column"`
+	CurrencyColumn    string `json:"currency_column"`
+	DateLayout        string `json:"date_layout"`
+	Delimiter         string `json:"delimiter"`
+	DecimalComma      bool   `json:"decimal_comma"`
+	// Currency is used for rows without a currency column, and for OFX files without CURDEF.
+	Currency string `json:"currency"`
+}
+
+func DefaultStatementMapping() StatementMapping {
+	return StatementMapping{
+		DateColumn:        "date",
+		DescriptionColumn: "description",
+		AmountColumn:      "amount",
+		CurrencyColumn:    "currency",
+		DateLayout:        csvDateLayout,
+		Delimiter:         ",",
+	}
+}
+
+type StatementTransaction struct {
+	Date        time.Time
+	Description string
+	Amount      float64
+	Currency    string
+}
+
+// StatementProposal is a recurring charge found in a statement. CycleID is set when an existing
+// cycle matches the interval, otherwise the CycleName, CycleDays and CycleRule describe a cycle
+// that is created on confirmation. Tracked is set when a subscription with the same name exists.
+type StatementProposal struct {
+	Merchant        string  `json:"merchant"`
+	Name            string  `json:"name"`
+	Price           float64 `json:"price"`
+	CurrencyCode    string  `json:"currency_code"`
+	CycleID         uint    `json:"cycle_id"`
+	CycleName       string  `json:"cycle_name"`
+	CycleDays       uint    `json:"cycle_days"`
+	CycleRule       string  `json:"cycle_rule,omitempty"`
+	CycleCalendar   bool    `json:"cycle_calendar,omitempty"`
+	NextPaymentDate string  `json:"next_payment_date"`
+	Occurrences     int     `json:"occurrences"`
+	FirstCharge     string  `json:"first_charge"`
+	LastCharge      string  `json:"last_charge"`
+	Tracked         bool    `json:"tracked"`
+}
+
+type StatementScanReport struct {
+	Format       StatementFormat     `json:"format"`
+	Transactions int                 `json:"transactions"`
+	Skipped      int                 `json:"skipped"`
+	Proposals    []StatementProposal `json:"proposals"`
+}
+
+type StatementProposalError struct {
+	Proposal int    `json:"proposal"`
+	Error    string `json:"error"`
+}
+
+type StatementConfirmReport struct {
+	Created       int                      `json:"created"`
+	Subscriptions []uint                   `json:"subscriptions"`
+	CreatedCycles []string                 `json:"created_cycles"`
+	Errors        []StatementProposalError `json:"errors"`
+}
+
+// statementPeriod is a billing interval the detection recognises, with the range of days between
+// two charges that still counts as that interval.
+type statementPeriod struct {
+	cycle          entity.Cycle
+	minDays        int
+	maxDays        int
+	minOccurrences int
+}
+
+var statementPeriods = []statementPeriod{
+	{cycle: entity.Weekly, minDays: 6, maxDays: 8, minOccurrences: 3},
+	{cycle: entity.Monthly, minDays: 27, maxDays: 33, minOccurrences: 3},
+	{cycle: entity.Cycle{Name: "Quarterly", Rule: "FREQ=MONTHLY;INTERVAL=3"}, minDays: 88, maxDays: 94, minOccurrences: 2},
+	{cycle: entity.Yearly, minDays: 358, maxDays: 372, minOccurrences: 2},
+}
+
+type StatementService struct {
+	currencies    *CurrencyService
+	cycles        *CycleService
+	subscriptions *SubscriptionService
+}
+
+func NewStatementService(currencies *CurrencyService, cycles *CycleService, subscriptions *SubscriptionService) *StatementService {
+	return &StatementService{
+		currencies:    currencies,
+		cycles:        cycles,
+		subscriptions: subscriptions,
+	}
+}
+
+// Scan reads a bank statement and proposes a subscription for every merchant that charges about
+// the same amount at a regular interval. Nothing is written.
+func (s *StatementService) Scan(
+	ctx context.Context,
+	r io.Reader,
+	format StatementFormat,
+	mapping StatementMapping,
+) (*StatementScanReport, error) {
+	br := bufio.NewReader(r)
+
+	if format == StatementFormatAuto {
+		format = detectStatementFormat(br)
+	}
+
+	var (
+		transactions []StatementTransaction
+		skipped      int
+		err          error
+	)
+
+	switch format {
+	case StatementFormatCSV:
+		transactions, skipped, err = ParseStatementCSV(br, mapping)
+	case StatementFormatOFX, StatementFormatQFX:
+		format = StatementFormatOFX
+		transactions, skipped, err = ParseStatementOFX(br, mapping.Currency)
+	default:
+		return nil, fmt.Errorf("%w: %q", ErrUnknownStatementFormat, format)
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	proposals, err := s.propose(ctx, DetectRecurring(transactions))
+	if err != nil {
+		return nil, err
+	}
+
+	return &StatementScanReport{
+		Format:       format,
+		Transactions: len(transactions),
+		Skipped:      skipped,
+		Proposals:    proposals,
+	}, nil
+}
+
+// Confirm creates a subscription for every proposal, creating the proposed cycles that don't exist
+// yet. Proposals that fail are reported and skipped.
+func (s *StatementService) Confirm(ctx context.Context, proposals []StatementProposal) (*StatementConfirmReport, error) {
+	currencies, err := s.currencies.GetAllCurrencies(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	byCode := make(map[string]entity.Currency, len(currencies))
+	for _, currency := range currencies {
+		byCode[strings.ToUpper(currency.Code)] = currency
+	}
+
+	report := &StatementConfirmReport{Subscriptions: []uint{}, CreatedCycles: []string{}, Errors: []StatementProposalError{}}
+	createdCycles := make(map[string]entity.Cycle)
+
+	for i, proposal := range proposals {
+		subscription, err := s.confirm(ctx, proposal, byCode, createdCycles, report)
+		if err != nil {
+			report.Errors = append(report.Errors, StatementProposalError{Proposal: i, Error: err.Error()})
+			continue
+		}
+
+		report.Created++
+		report.Subscriptions = append(report.Subscriptions, subscription.ID)
+	}
+
+	return report, nil
+}
+
+func (s *StatementService) confirm(
+	ctx context.Context,
+	proposal StatementProposal,
+	currencies map[string]entity.Currency,
+	createdCycles map[string]entity.Cycle,
+	report *StatementConfirmReport,
+) (*entity.Subscription, error) {
+	subscription := entity.Subscription{Name: strings.TrimSpace(proposal.Name), Price: proposal.Price}
+
+	currency, ok := currencies[strings.ToUpper(proposal.CurrencyCode)]
+	if !ok {
+		return nil, fmt.Errorf("%w: currency %q", ErrInvalidSubscription, proposal.CurrencyCode)
+	}
+
+	subscription.Currency = currency
+
+	if proposal.NextPaymentDate != "" {
+		date, err := time.Parse(csvDateLayout, proposal.NextPaymentDate)
+		if err != nil {
+			return nil, fmt.Errorf("%w: next payment date %q", ErrInvalidSubscription, proposal.NextPaymentDate)
+		}
+
+		subscription.NextPaymentDate = entity.PaymentDate(date)
+	}
+
+	// Checked before the cycle, so an invalid proposal doesn't leave a new cycle behind.
+	if subscription.Name == "" || subscription.Price <= 0 {
+		return nil, ErrInvalidSubscription
+	}
+
+	cycle, err := s.proposalCycle(ctx, proposal, createdCycles, report)
+	if err != nil {
+		return nil, err
+	}
+
+	subscription.Cycle = *cycle
+
+	return s.subscriptions.CreateSubscription(ctx, subscription)
+}
+
+// proposalCycle returns the cycle of a proposal, creating a proposed cycle once per confirmation.
+func (s *StatementService) proposalCycle(
+	ctx context.Context,
+	proposal StatementProposal,
+	createdCycles map[string]entity.Cycle,
+	report *StatementConfirmReport,
+) (*entity.Cycle, error) {
+	if proposal.CycleID != 0 {
+		return s.cycles.GetCycle(ctx, proposal.CycleID)
+	}
+
+	proposed := entity.Cycle{Name: proposal.CycleName, Days: proposal.CycleDays, Rule: proposal.CycleRule, Calendar: proposal.CycleCalendar}
+	key := fmt.Sprintf("%s|%d|%s|%t", strings.ToLower(proposed.Name), proposed.Days, proposed.Rule, proposed.Calendar)
+
+	if cycle, ok := createdCycles[key]; ok {
+		return &cycle, nil
+	}
+
+	if proposed.Days == 0 && proposed.Rule == "" {
+		return nil, fmt.Errorf("%w: the proposal has no cycle", ErrInvalidSubscription)
+	}
+
+	cycle, err := s.cycles.CreateCycle(ctx, proposed)
+	if err != nil {
+		return nil, err
+	}
+
+	createdCycles[key] = *cycle
+	report.CreatedCycles = append(report.CreatedCycles, cycle.Name)
+
+	return cycle, nil
+}
+
+func (s *StatementService) propose(ctx context.Context, charges []RecurringCharge) ([]StatementProposal, error) {
+	cycles, err := s.cycles.GetAllCycles(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	currencies, err := s.currencies.GetAllCurrencies(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	subscriptions, err := s.subscriptions.GetAllSubscriptions(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	tracked := make([]string, 0, len(subscriptions))
+	for _, subscription := range subscriptions {
+		if key := merchantKey(subscription.Name); key != "" {
+			tracked = append(tracked, key)
+		}
+	}
+
+	proposals := make([]StatementProposal, 0, len(charges))
+
+	for _, charge := range charges {
+		proposal := StatementProposal{
+			Merchant:        charge.Merchant,
+			Name:            charge.Name,
+			Price:           charge.Price,
+			CurrencyCode:    charge.Currency,
+			CycleName:       charge.Cycle.Name,
+			CycleDays:       charge.Cycle.Days,
+			CycleRule:       charge.Cycle.Rule,
+			CycleCalendar:   charge.Cycle.Calendar,
+			NextPaymentDate: nextStatementCharge(charge).Format(csvDateLayout),
+			Occurrences:     charge.Occurrences,
+			FirstCharge:     charge.First.Format(csvDateLayout),
+			LastCharge:      charge.Last.Format(csvDateLayout),
+			Tracked:         isTracked(tracked, charge.Merchant),
+		}
+
+		for _, currency := range currencies {
+			if strings.EqualFold(currency.Code, charge.Currency) || strings.EqualFold(currency.Name, charge.Currency) {
+				proposal.CurrencyCode = currency.Code
+				break
+			}
+		}
+
+		if cycle, ok := matchingCycle(cycles, charge.Cycle); ok {
+			proposal.CycleID = cycle.ID
+			proposal.CycleName = cycle.Name
+			proposal.CycleDays = cycle.Days
+			proposal.CycleRule = cycle.Rule
+			proposal.CycleCalendar = cycle.Calendar
+		}
+
+		proposals = append(proposals, proposal)
+	}
+
+	return proposals, nil
+}
+
+// isTracked reports whether a subscription name matches the merchant. Banks often append a domain or
+// location to the name, e.g. NETFLIX.COM for Netflix, so a prefix is enough.
+func isTracked(tracked []string, merchant string) bool {
+	for _, key := range tracked {
+		if strings.HasPrefix(merchant, key) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// matchingCycle returns the existing cycle closest to the detected one, if it repeats about as often.
+func matchingCycle(cycles repository.Cycles, detected entity.Cycle) (entity.Cycle, bool) {
+	want := detected.PerMonth()
+
+	var (
+		best     entity.Cycle
+		found    bool
+		bestDiff float64
+	)
+
+	for _, cycle := range cycles {
+		diff := math.Abs(cycle.PerMonth()-want) / want
+		if diff <= statementCycleTolerance && (!found || diff < bestDiff) {
+			best, found, bestDiff = cycle, true, diff
+		}
+	}
+
+	return best, found
+}
+
+// RecurringCharge is a merchant that charged about the same amount at a regular interval.
+type RecurringCharge struct {
+	Merchant    string
+	Name        string
+	Price       float64
+	Currency    string
+	Cycle       entity.Cycle
+	Occurrences int
+	First       time.Time
+	Last        time.Time
+}
+
+// DetectRecurring groups the charges by merchant and currency and keeps the groups whose amounts
+// stay within 20% of their median and whose intervals all fit one billing period. The price is the
+// latest charge, so a recent price rise is picked up.
+func DetectRecurring(transactions []StatementTransaction) []RecurringCharge {
+	type group struct {
+		merchant string
+		currency string
+		charges  []StatementTransaction
+	}
+
+	var order []string
+
+	groups := make(map[string]*group)
+
+	for _, transaction := range transactions {
+		if transaction.Amount >= 0 {
+			continue
+		}
+
+		merchant := merchantKey(transaction.Description)
+		if merchant == "" {
+			continue
+		}
+
+		currency := strings.ToUpper(transaction.Currency)
+		key := merchant + "|" + currency
+
+		g, ok := groups[key]
+		if !ok {
+			g = &group{merchant: merchant, currency: currency}
+			groups[key] = g
+			order = append(order, key)
+		}
+
+		g.charges = append(g.charges, transaction)
+	}
+
+	var recurring []RecurringCharge
+
+	for _, key := range order {
+		g := groups[key]
+
+		sort.SliceStable(g.charges, func(i, j int) bool { return g.charges[i].Date.Before(g.charges[j].Date) })
+
+		period, ok := detectPeriod(g.charges)
+		if !ok {
+			continue
+		}
+
+		first, last := g.charges[0], g.charges[len(g.charges)-1]
+
+		recurring = append(recurring, RecurringCharge{
+			Merchant:    g.merchant,
+			Name:        merchantName(last.Description),
+			Price:       math.Round(-last.Amount*100) / 100,
+			Currency:    g.currency,
+			Cycle:       period.cycle,
+			Occurrences: len(g.charges),
+			First:       first.Date,
+			Last:        last.Date,
+		})
+	}
+
+	return recurring
+}
+
+func detectPeriod(charges []StatementTransaction) (statementPeriod, bool) {
+	if len(charges) < 2 {
+		return statementPeriod{}, false
+	}
+
+	amounts := make([]float64, len(charges))
+	for i, charge := range charges {
+		amounts[i] = -charge.Amount
+	}
+
+	usual := median(amounts)
+	for _, amount := range amounts {
+		if math.Abs(amount-usual) > usual*statementAmountTolerance {
+			return statementPeriod{}, false
+		}
+	}
+
+	intervals := make([]float64, len(charges)-1)
+	for i := 1; i < len(charges); i++ {
+		intervals[i-1] = math.Round(charges[i].Date.Sub(charges[i-1].Date).Hours() / 24)
+	}
+
+	for _, period := range statementPeriods {
+		if len(charges) < period.minOccurrences {
+			continue
+		}
+
+		fits := true
+		for _, days := range intervals {
+			if days < float64(period.minDays) || days > float64(period.maxDays) {
+				fits = false
+				break
+			}
+		}
+
+		if fits {
+			return period, true
+		}
+	}
+
+	return statementPeriod{}, false
+}
+
+func median(values []float64) float64 {
+	sorted := append([]float64(nil), values...)
+	sort.Float64s(sorted)
+
+	mid := len(sorted) / 2
+	if len(sorted)%2 == 0 {
+		return (sorted[mid-1] + sorted[mid]) / 2
+	}
+
+	return sorted[mid]
+}
+
+// merchantWords returns the first words of a bank description, leaving out the words with digits
+// that banks add for card numbers, references and dates.
+func merchantWords(description string) []string {
+	var words []string
+
+	for _, word := range strings.Fields(description) {
+		if strings.ContainsFunc(word, unicode.IsDigit) {
+			continue
+		}
+
+		word = strings.TrimFunc(word, func(r rune) bool { return !unicode.IsLetter(r) })
+		if word == "" {
+			continue
+		}
+
+		words = append(words, word)
+		if len(words) == statementMerchantWords {
+			break
+		}
+	}
+
+	return words
+}
+
+// merchantKey identifies the merchant of a description, ignoring case and punctuation.
+func merchantKey(description string) string {
+	words := merchantWords(description)
+	for i, word := range words {
+		words[i] = strings.Map(func(r rune) rune {
+			if !unicode.IsLetter(r) {
+				return -1
+			}
+
+			return unicode.ToUpper(r)
+		}, word)
+	}
+
+	return strings.Join(words, " ")
+}
+
+// merchantName makes a subscription name of a description; shouted words are capitalised.
+func merchantName(description string) string {
+	words := merchantWords(description)
+	for i, word := range words {
+		if word == strings.ToUpper(word) {
+			first, size := utf8.DecodeRuneInString(word)
+			words[i] = string(first) + strings.ToLower(word[size:])
+		}
+	}
+
+	return strings.Join(words, " ")
+}
+
+func detectStatementFormat(br *bufio.Reader) StatementFormat {
+	head, _ := br.Peek(1024)
+	upper := strings.ToUpper(string(head))
+
+	if strings.Contains(upper, "OFXHEADER") || strings.Contains(upper, "<OFX>") {
+		return StatementFormatOFX
+	}
+
+	return StatementFormatCSV
+}
+
+// ParseStatementCSV reads the transactions of a CSV statement with a header row. Rows that can't
+// be read are counted as skipped.
+func ParseStatementCSV(r io.Reader, mapping StatementMapping) ([]StatementTransaction, int, error) {
+	mapping = withStatementDefaults(mapping)
+
+	cr := csv.NewReader(r)
+	cr.FieldsPerRecord = -1
+	cr.TrimLeadingSpace = true
+	cr.LazyQuotes = true
+
+	if mapping.Delimiter == `\t` {
+		mapping.Delimiter = "\t"
+	}
+
+	delimiter, size := utf8.DecodeRuneInString(mapping.Delimiter)
+	if size != len(mapping.Delimiter) {
+		return nil, 0, fmt.Errorf("%w: delimiter %q", ErrInvalidStatement, mapping.Delimiter)
+	}
+
+	cr.Comma = delimiter
+
+	header, err := cr.Read()
+	if err != nil {
+		return nil, 0, fmt.Errorf("%w: %w", ErrInvalidStatement, err)
+	}
+
+	columns := make(map[string]int, len(header))
+	for i, name := range header {
+		name = strings.ToLower(strings.TrimSpace(strings.TrimPrefix(name, "\ufeff")))
+		if _, ok := columns[name]; !ok {
+			columns[name] = i
+		}
+	}
+
+	for _, column := range []string{mapping.DateColumn, mapping.DescriptionColumn, mapping.AmountColumn} {
+		if _, ok := columns[strings.ToLower(column)]; !ok {
+			return nil, 0, fmt.Errorf("%w: missing column %q", ErrInvalidStatement, column)
+		}
+	}
+
+	var (
+		transactions []StatementTransaction
+		skipped      int
+	)
+
+	for {
+		record, err := cr.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+
+		if err != nil {
+			skipped++
+			continue
+		}
+
+		field := func(column string) string {
+			i, ok := columns[strings.ToLower(column)]
+			if column == "" || !ok || i >= len(record) {
+				return ""
+			}
+
+			return strings.TrimSpace(record[i])
+		}
+
+		transaction, err := statementTransaction(field, mapping)
+		if err != nil {
+			skipped++
+			continue
+		}
+
+		transactions = append(transactions, transaction)
+	}
+
+	return transactions, skipped, nil
+}
+
+func withStatementDefaults(mapping StatementMapping) StatementMapping {
+	defaults := DefaultStatementMapping()
+
+	if mapping.DateColumn == "" {
+		mapping.DateColumn = defaults.DateColumn
+	}
+
+	if mapping.DescriptionColumn == "" {
+		mapping.DescriptionColumn = defaults.DescriptionColumn
+	}
+
+	if mapping.AmountColumn == "" {
+		mapping.AmountColumn = defaults.AmountColumn
+	}
+
+	if mapping.CurrencyColumn == "" {
+		mapping.CurrencyColumn = defaults.CurrencyColumn
+	}
+
+	if mapping.DateLayout == "" {
+		mapping.DateLayout = defaults.DateLayout
+	}
+
+	if mapping.Delimiter == "" {
+		mapping.Delimiter = defaults.Delimiter
+	}
+
+	return mapping
+}
+
+func statementTransaction(field func(string) string, mapping StatementMapping) (StatementTransaction, error) {
+	date, err := time.Parse(mapping.DateLayout, field(mapping.DateColumn))
+	if err != nil {
+		return StatementTransaction{}, err
+	}
+
+	amount, err := parseStatementAmount(field(mapping.AmountColumn), mapping.DecimalComma)
+	if err != nil {
+		return StatementTransaction{}, err
+	}
+
+	currency := field(mapping.CurrencyColumn)
+	if currency == "" {
+		currency = mapping.Currency
+	}
+
+	return StatementTransaction{
+		Date:        date,
+		Description: field(mapping.DescriptionColumn),
+		Amount:      amount,
+		Currency:    currency,
+	}, nil
+}
+
+// nextStatementCharge counts cycles from the first charge rather than the last, so a charge booked a
+// few days early or late doesn't shift the proposal and the billing day of month is kept.
+func nextStatementCharge(charge RecurringCharge) time.Time {
+	distance := func(date time.Time) time.Duration {
+		d := date.Sub(charge.Last)
+		if d < 0 {
+			return -d
+		}
+
+		return d
+	}
+
+	// Stop at the first date after the cycle the last charge belongs to.
+	dates := charge.Cycle.Dates(charge.First)
+
+	date, next := charge.First, dates.Next()
+	for !next.IsZero() && distance(next) < distance(date) {
+		date, next = next, dates.Next()
+	}
+
+	return next
+}
+
+// parseStatementAmount reads amounts like "-1,234.56", "(12.00)" or "-12,99 €". Without decimalComma
+// a comma is taken as the decimal mark when it is the last separator and one or two digits follow it,
+// so "-12,99" is -12.99 but "1,234" is 1234. With decimalComma dots are always thousands separators.
+func parseStatementAmount(value string, decimalComma bool) (float64, error) {
+	negative := strings.HasPrefix(value, "(") && strings.HasSuffix(value, ")")
+
+	value = strings.Map(func(r rune) rune {
+		if unicode.IsDigit(r) || r == '-' || r == '+' || r == '.' || r == ',' {
+			return r
+		}
+
+		return -1
+	}, value)
+
+	if !decimalComma {
+		comma := strings.LastIndex(value, ",")
+		decimalComma = comma > strings.LastIndex(value, ".") && len(value)-comma-1 <= 2
+	}
+
+	if decimalComma {
+		value = strings.ReplaceAll(value, ".", "")
+		value = strings.ReplaceAll(value, ",", ".")
+	} else {
+		value = strings.ReplaceAll(value, ",", "")
+	}
+
+	amount, err := strconv.ParseFloat(value, 64)
+	if err != nil {
+		return 0, err
+	}
+
+	if negative {
+		amount = -math.Abs(amount)
+	}
+
+	return amount, nil
+}
+
+// ParseStatementOFX reads the STMTTRN entries of an OFX or QFX file. Both the SGML flavour, where
+// the closing tags of values are left out, and the XML flavour are read. Entries without a valid
+// date or amount are counted as skipped.
+func ParseStatementOFX(r io.Reader, currency string) ([]StatementTransaction, int, error) {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	content := string(data)
+	if !strings.Contains(strings.ToUpper(content), "<OFX>") {
+		return nil, 0, fmt.Errorf("%w: no OFX element", ErrInvalidStatement)
+	}
+
+	var (
+		transactions []StatementTransaction
+		skipped      int
+		current      map[string]string
+	)
+
+	for _, tag := range ofxTags(content) {
+		switch tag.name {
+		case "CURDEF":
+			currency = tag.value
+		case "STMTTRN":
+			current = make(map[string]string)
+		case "/STMTTRN":
+			if current == nil {
+				continue
+			}
+
+			transaction, err := ofxTransaction(current, currency)
+			if err != nil {
+				skipped++
+			} else {
+				transactions = append(transactions, transaction)
+			}
+
+			current = nil
+		default:
+			if current != nil && tag.value != "" {
+				current[tag.name] = tag.value
+			}
+		}
+	}
+
+	return transactions, skipped, nil
+}
+
+type ofxTag struct {
+	name  string
+	value string
+}
+
+// ofxTags splits OFX content into its tags, each with the text up to the next tag as value.
+func ofxTags(content string) []ofxTag {
+	var tags []ofxTag
+
+	for {
+		start := strings.IndexByte(content, '<')
+		if start < 0 {
+			return tags
+		}
+
+		end := strings.IndexByte(content[start:], '>')
+		if end < 0 {
+			return tags
+		}
+
+		name := strings.ToUpper(strings.TrimSpace(content[start+1 : start+end]))
+		content = content[start+end+1:]
+
+		value := content
+		if next := strings.IndexByte(content, '<'); next >= 0 {
+			value = content[:next]
+		}
+
+		tags = append(tags, ofxTag{name: name, value: strings.TrimSpace(value)})
+	}
+}
+
+func ofxTransaction(fields map[string]string, currency string) (StatementTransaction, error) {
+	posted := fields["DTPOSTED"]
+	if len(posted) < ofxDateLength {
+		return StatementTransaction{}, fmt.Errorf("%w: date %q", ErrInvalidStatement, posted)
+	}
+
+	date, err := time.Parse("20060102", posted[:ofxDateLength])
+	if err != nil {
+		return StatementTransaction{}, err
+	}
+
+	amount, err := parseStatementAmount(fields["TRNAMT"], false)
+	if err != nil {
+		return StatementTransaction{}, err
+	}
+
+	description := fields["NAME"]
+	if description == "" {
+		description = fields["MEMO"]
+	}
+
+	return StatementTransaction{Date: date, Description: description, Amount: amount, Currency: currency}, nil
+}
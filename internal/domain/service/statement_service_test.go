@@ -0,0 +1,217 @@
+package service_test
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"git.home/alex/go-subscriptions/internal/domain/entity"
+	"git.home/alex/go-subscriptions/internal/domain/repository"
+	"git.home/alex/go-subscriptions/internal/domain/service"
+	"git.home/alex/go-subscriptions/internal/repository/memory"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type statementFixture struct {
+	statements    *service.StatementService
+	cycles        *service.CycleService
+	monthly       *entity.Cycle
+	yearly        *entity.Cycle
+	subscriptions *service.SubscriptionService
+}
+
+func newStatementFixture(t *testing.T) statementFixture {
+	t.Helper()
+
+	ctx := context.Background()
+	f := statementFixture{
+		cycles:        service.NewCycleService(memory.NewCycleRepository()),
+		subscriptions: service.NewSubscriptionService(memory.NewSubscriptionRepository()),
+	}
+	currencies := service.NewCurrencyService(memory.NewCurrencyRepository())
+
+	_, err := currencies.CreateCurrency(ctx, entity.USD)
+	require.NoError(t, err)
+	_, err = currencies.CreateCurrency(ctx, entity.Currency{Code: "EUR", Symbol: "€", Name: "Euro"})
+	require.NoError(t, err)
+	f.monthly, err = f.cycles.CreateCycle(ctx, entity.Monthly)
+	require.NoError(t, err)
+	f.yearly, err = f.cycles.CreateCycle(ctx, entity.Yearly)
+	require.NoError(t, err)
+	_, err = f.subscriptions.CreateSubscription(ctx, entity.Subscription{
+		Name: "Spotify", Price: 9.99, Currency: entity.USD, Cycle: entity.Monthly,
+	})
+	require.NoError(t, err)
+
+	f.statements = service.NewStatementService(currencies, f.cycles, f.subscriptions)
+
+	return f
+}
+
+const statementCSV = `Booking Date;Payee;Value;Ccy
+03.01.2024;NETFLIX.COM 866-579-7172;-15,49;EUR
+05.01.2024;Salary ACME Corp;2.500,00;EUR
+12.01.2024;SPOTIFY P1A2B3C4;-9,99;USD
+03.02.2024;NETFLIX.COM 866-579-7172;-15,49;EUR
+12.02.2024;SPOTIFY P9Z8Y7X6;-9,99;USD
+14.02.2024;Bakery Miller;-4,20;EUR
+04.03.2024;NETFLIX.COM 866-579-7172;-17,99;EUR
+12.03.2024;SPOTIFY P5Q4R3S2;-9,99;USD
+20.03.2024;Bakery Miller;-12,80;EUR
+02.04.2024;Gym Weekly;-5,00;EUR
+09.04.2024;Gym Weekly;-5,00;EUR
+16.04.2024;Gym Weekly;-5,00;EUR
+not a date;Broken;-1,00;EUR
+`
+
+const statementOFX = `OFXHEADER:100
+DATA:OFXSGML
+VERSION:102
+
+<OFX>
+<BANKMSGSRSV1><STMTTRNRS><STMTRS>
+<CURDEF>USD
+<BANKTRANLIST>
+<STMTTRN><TRNTYPE>DEBIT<DTPOSTED>20220815120000[-5:EST]<TRNAMT>-99.00<FITID>1<NAME>Amazon Prime*2K4L</STMTTRN>
+<STMTTRN><TRNTYPE>DEBIT<DTPOSTED>20230814<TRNAMT>-99.00<FITID>2<NAME>Amazon Prime*8Z1Q</STMTTRN>
+<STMTTRN><TRNTYPE>DEBIT<DTPOSTED>20230901<TRNAMT>-30.00<FITID>3<MEMO>ISP QUARTERLY</STMTTRN>
+<STMTTRN><TRNTYPE>DEBIT<DTPOSTED>20231201<TRNAMT>-30.00<FITID>4<MEMO>ISP QUARTERLY</STMTTRN>
+<STMTTRN><TRNTYPE>DEBIT<DTPOSTED>bad<TRNAMT>-30.00<FITID>5<MEMO>ISP QUARTERLY</STMTTRN>
+</BANKTRANLIST>
+</STMTRS></STMTTRNRS></BANKMSGSRSV1>
+</OFX>
+`
+
+func TestStatementService_Scan(t *testing.T) {
+	f := newStatementFixture(t)
+	ctx := context.Background()
+
+	mapping := service.StatementMapping{
+		DateColumn:        "booking date",
+		DescriptionColumn: "Payee",
+		AmountColumn:      "Value",
+		CurrencyColumn:    "Ccy",
+		DateLayout:        "02.01.2006",
+		Delimiter:         ";",
+		DecimalComma:      true,
+	}
+
+	report, err := f.statements.Scan(ctx, strings.NewReader(statementCSV), service.StatementFormatAuto, mapping)
+	require.NoError(t, err)
+
+	assert.Equal(t, service.StatementFormatCSV, report.Format)
+	assert.Equal(t, 12, report.Transactions)
+	assert.Equal(t, 1, report.Skipped)
+	assert.Equal(t, []service.StatementProposal{
+		{
+			Merchant: "NETFLIXCOM", Name: "Netflix.com", Price: 17.99, CurrencyCode: "EUR",
+			CycleID: f.monthly.ID, CycleName: "Monthly", CycleDays: 30, CycleCalendar: true, NextPaymentDate: "2024-04-03",
+			Occurrences: 3, FirstCharge: "2024-01-03", LastCharge: "2024-03-04",
+		},
+		{
+			Merchant: "SPOTIFY", Name: "Spotify", Price: 9.99, CurrencyCode: "USD",
+			CycleID: f.monthly.ID, CycleName: "Monthly", CycleDays: 30, CycleCalendar: true, NextPaymentDate: "2024-04-12",
+			Occurrences: 3, FirstCharge: "2024-01-12", LastCharge: "2024-03-12", Tracked: true,
+		},
+		{
+			Merchant: "GYM WEEKLY", Name: "Gym Weekly", Price: 5, CurrencyCode: "EUR",
+			CycleName: "Weekly", CycleDays: 7, NextPaymentDate: "2024-04-23",
+			Occurrences: 3, FirstCharge: "2024-04-02", LastCharge: "2024-04-16",
+		},
+	}, report.Proposals)
+
+	report, err = f.statements.Scan(ctx, strings.NewReader(statementOFX), service.StatementFormatQFX, service.StatementMapping{})
+	require.NoError(t, err)
+
+	assert.Equal(t, service.StatementFormatOFX, report.Format)
+	assert.Equal(t, 4, report.Transactions)
+	assert.Equal(t, 1, report.Skipped)
+	assert.Equal(t, []service.StatementProposal{
+		{
+			Merchant: "AMAZON", Name: "Amazon", Price: 99, CurrencyCode: "USD",
+			CycleID: f.yearly.ID, CycleName: "Yearly", CycleDays: 365, CycleCalendar: true, NextPaymentDate: "2024-08-15",
+			Occurrences: 2, FirstCharge: "2022-08-15", LastCharge: "2023-08-14",
+		},
+		{
+			Merchant: "ISP QUARTERLY", Name: "Isp Quarterly", Price: 30, CurrencyCode: "USD",
+			CycleName: "Quarterly", CycleRule: "FREQ=MONTHLY;INTERVAL=3", NextPaymentDate: "2024-03-01",
+			Occurrences: 2, FirstCharge: "2023-09-01", LastCharge: "2023-12-01",
+		},
+	}, report.Proposals)
+
+	_, err = f.statements.Scan(ctx, strings.NewReader(statementCSV), "pdf", mapping)
+	assert.ErrorIs(t, err, service.ErrUnknownStatementFormat)
+
+	_, err = f.statements.Scan(ctx, strings.NewReader(statementCSV), service.StatementFormatCSV, service.StatementMapping{})
+	assert.ErrorIs(t, err, service.ErrInvalidStatement)
+}
+
+func TestParseStatementCSV_Amounts(t *testing.T) {
+	testCases := []struct {
+		name         string
+		amount       string
+		decimalComma bool
+		expected     float64
+	}{
+		{name: "Decimal point", amount: "-1,234.56", expected: -1234.56},
+		{name: "Detected decimal comma", amount: "-12,99 €", expected: -12.99},
+		{name: "Detected decimal comma with thousands", amount: "-1.234,5", expected: -1234.5},
+		{name: "Thousands comma", amount: "-1,234", expected: -1234},
+		{name: "Parentheses", amount: "(12.00)", expected: -12},
+		{name: "Decimal comma", amount: "-1.234,56", decimalComma: true, expected: -1234.56},
+		{name: "Decimal comma with currency", amount: "-12,99 €", decimalComma: true, expected: -12.99},
+		{name: "Decimal comma thousands dot", amount: "-1.234", decimalComma: true, expected: -1234},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			mapping := service.DefaultStatementMapping()
+			mapping.Delimiter = ";"
+			mapping.DecimalComma = tc.decimalComma
+
+			transactions, skipped, err := service.ParseStatementCSV(
+				strings.NewReader("date;description;amount\n2024-01-05;Netflix;"+tc.amount+"\n"), mapping)
+			require.NoError(t, err)
+
+			assert.Zero(t, skipped)
+			require.Len(t, transactions, 1)
+			assert.InDelta(t, tc.expected, transactions[0].Amount, 1e-9)
+		})
+	}
+}
+
+func TestStatementService_Confirm(t *testing.T) {
+	f := newStatementFixture(t)
+	ctx := context.Background()
+
+	report, err := f.statements.Confirm(ctx, []service.StatementProposal{
+		{Name: "Netflix", Price: 17.99, CurrencyCode: "eur", CycleID: f.monthly.ID, NextPaymentDate: "2024-04-04"},
+		{Name: "ISP", Price: 30, CurrencyCode: "USD", CycleName: "Quarterly", CycleRule: "FREQ=MONTHLY;INTERVAL=3"},
+		{Name: "Router rent", Price: 5, CurrencyCode: "USD", CycleName: "Quarterly", CycleRule: "FREQ=MONTHLY;INTERVAL=3"},
+		{Name: "Unknown", Price: 1, CurrencyCode: "XYZ", CycleID: f.monthly.ID},
+		{Name: "", Price: 1, CurrencyCode: "USD", CycleName: "Biweekly", CycleDays: 14},
+		{Name: "Gone", Price: 1, CurrencyCode: "USD", CycleID: 99},
+	})
+	require.NoError(t, err)
+
+	assert.Equal(t, 3, report.Created)
+	assert.Equal(t, []uint{2, 3, 4}, report.Subscriptions)
+	assert.Equal(t, []string{"Quarterly"}, report.CreatedCycles)
+	assert.Equal(t, []service.StatementProposalError{
+		{Proposal: 3, Error: `the subscription is invalid: currency "XYZ"`},
+		{Proposal: 4, Error: "the subscription is invalid"},
+		{Proposal: 5, Error: repository.ErrNotFoundCycle.Error()},
+	}, report.Errors)
+
+	netflix, err := f.subscriptions.GetSubscription(ctx, 2)
+	require.NoError(t, err)
+	assert.Equal(t, "EUR", netflix.Currency.Code)
+	assert.Equal(t, f.monthly.ID, netflix.Cycle.ID)
+	assert.Equal(t, entity.PaymentDate(time.Date(2024, 4, 4, 0, 0, 0, 0, time.UTC)), netflix.NextPaymentDate)
+
+	cycles, err := f.cycles.GetAllCycles(ctx)
+	require.NoError(t, err)
+	assert.Len(t, cycles, 3)
+}
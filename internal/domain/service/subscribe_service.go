@@ -3,7 +3,9 @@ package service
 import (
 	"context"
 	"errors"
+	"time"
 
+	"git.home/alex/go-subscriptions/internal/clock"
 	"git.home/alex/go-subscriptions/internal/domain/entity"
 	"git.home/alex/go-subscriptions/internal/domain/repository"
 )
@@ -13,45 +15,117 @@ var (
 )
 
 type SubscriptionService struct {
-	repo repository.SubscriptionRepository
+	repo        repository.SubscriptionRepository
+	attachments *AttachmentService
 }
 
 func NewSubscriptionService(repo repository.SubscriptionRepository) *SubscriptionService {
 	return &SubscriptionService{repo: repo}
 }
 
+// SetAttachmentService makes DeleteSubscription remove the files attached to the subscription as well.
+func (s *SubscriptionService) SetAttachmentService(attachments *AttachmentService) {
+	s.attachments = attachments
+}
+
 func (s *SubscriptionService) CreateSubscription(ctx context.Context, subscription entity.Subscription) (*entity.Subscription, error) {
-	if subscription.Price <= 0 || subscription.Name == "" {
+	if !validSubscription(subscription) {
 		return nil, ErrInvalidSubscription
 	}
 
-	if subscription.Currency.Code == "" || subscription.Cycle.ID == 0 {
-		return nil, ErrInvalidSubscription
+	if userID, ok := ownerFromContext(ctx); ok {
+		subscription.UserID = userID
 	}
 
 	return s.repo.Create(ctx, subscription)
 }
 
 func (s *SubscriptionService) GetSubscription(ctx context.Context, id uint) (*entity.Subscription, error) {
-	return s.repo.Get(ctx, id)
+	subscription, err := s.repo.Get(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if !canSee(ctx, subscription.UserID, false) {
+		return nil, repository.ErrNotFoundSubscription
+	}
+
+	return subscription, nil
 }
 
 func (s *SubscriptionService) GetAllSubscriptions(ctx context.Context) (repository.Subscriptions, error) {
-	return s.repo.GetAll(ctx)
+	subscriptions, err := s.repo.GetAll(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if _, ok := ownerFromContext(ctx); !ok {
+		return subscriptions, nil
+	}
+
+	visible := make(repository.Subscriptions, 0, len(subscriptions))
+	for _, subscription := range subscriptions {
+		if canSee(ctx, subscription.UserID, false) {
+			visible = append(visible, subscription)
+		}
+	}
+
+	return visible, nil
 }
 
 func (s *SubscriptionService) UpdateSubscription(ctx context.Context, subscription entity.Subscription) (*entity.Subscription, error) {
-	if subscription.ID == 0 || subscription.Price <= 0 || subscription.Name == "" {
+	if subscription.ID == 0 || !validSubscription(subscription) {
 		return nil, ErrInvalidSubscription
 	}
 
-	if subscription.Currency.Code == "" || subscription.Cycle.ID == 0 {
-		return nil, ErrInvalidSubscription
+	stored, err := s.GetSubscription(ctx, subscription.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	if userID, ok := ownerFromContext(ctx); ok {
+		subscription.UserID = userID
+	}
+
+	// The price history is kept by the service; a new price takes effect today.
+	now := clock.Today(ctx)
+	subscription.Prices = stored.Prices
+
+	if subscription.Price != stored.PriceAt(now) {
+		subscription.Prices = stored.WithPrice(subscription.Price, now)
 	}
 
 	return s.repo.Update(ctx, subscription)
 }
 
 func (s *SubscriptionService) DeleteSubscription(ctx context.Context, id uint) error {
+	if _, ok := ownerFromContext(ctx); ok {
+		if _, err := s.GetSubscription(ctx, id); err != nil {
+			return err
+		}
+	}
+
+	// The attachments go first: a storage that removes them with the subscription would leave their files behind.
+	if s.attachments != nil {
+		err := s.attachments.deleteAll(ctx, id)
+		if err != nil {
+			return err
+		}
+	}
+
 	return s.repo.Delete(ctx, id)
 }
+
+func validSubscription(subscription entity.Subscription) bool {
+	trialStart, trialEnd := time.Time(subscription.TrialStartDate), time.Time(subscription.TrialEndDate)
+	if subscription.Status == entity.StatusTrial && trialEnd.IsZero() {
+		return false
+	}
+
+	if !trialStart.IsZero() && !trialEnd.IsZero() && !trialStart.Before(trialEnd) {
+		return false
+	}
+
+	return subscription.Price > 0 && subscription.Name != "" &&
+		subscription.Currency.Code != "" && subscription.Cycle.ID != 0 && subscription.Status.Valid()
+}
@@ -303,7 +303,9 @@ func TestSubscriptionService_UpdateSubscription(t *testing.T) {
 
 	for _, tc := range testCases {
 		t.Run(tc.name, func(t *testing.T) {
+			stored := tc.subscription
 			mockRepo := new(mock_repository.MockSubscriptionRepository)
+			mockRepo.On("Get", ctx, tc.subscription.ID).Return(&stored, nil)
 			mockRepo.On("Update", ctx, tc.subscription).Return(tc.wantResult, tc.wantErr)
 
 			subscriptionService := service.NewSubscriptionService(mockRepo)
@@ -0,0 +1,78 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"time"
+
+	"git.home/alex/go-subscriptions/internal/domain/entity"
+)
+
+var (
+	ErrInvalidContract = errors.New("the contract is not valid")
+)
+
+type DeadlineKind string
+
+const (
+	// DeadlineCancelBy is the last day to give notice before an auto-renewing contract renews.
+	DeadlineCancelBy DeadlineKind = "cancel_by"
+	// DeadlineContractEnd is the day a contract that doesn't renew expires the subscription.
+	DeadlineContractEnd DeadlineKind = "contract_end"
+)
+
+type ContractDeadline struct {
+	Subscription entity.Subscription
+	Kind         DeadlineKind
+	Date         time.Time
+}
+
+// SetContract puts the subscription under the contract; a nil contract removes it.
+func (s *SubscriptionService) SetContract(ctx context.Context, id uint, contract *entity.Contract) (*entity.Subscription, error) {
+	if contract != nil && !contract.Valid() {
+		return nil, ErrInvalidContract
+	}
+
+	subscription, err := s.GetSubscription(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	subscription.Contract = contract
+
+	return s.repo.Update(ctx, *subscription)
+}
+
+// ContractDeadlines returns the notice deadlines and contract ends between from and to, both included,
+// ordered by date. Subscriptions cancelled or expired on from are left out.
+func (s *SubscriptionService) ContractDeadlines(ctx context.Context, from, to time.Time) ([]ContractDeadline, error) {
+	subscriptions, err := s.GetAllSubscriptions(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	deadlines := make([]ContractDeadline, 0)
+
+	for _, subscription := range subscriptions {
+		contract := subscription.Contract
+
+		status := subscription.StatusAt(from)
+		if contract == nil || status == entity.StatusCancelled || status == entity.StatusExpired {
+			continue
+		}
+
+		for _, date := range contract.CancelDeadlines(from, to) {
+			deadlines = append(deadlines, ContractDeadline{Subscription: subscription, Kind: DeadlineCancelBy, Date: date})
+		}
+
+		end := time.Time(contract.EndDate)
+		if !contract.AutoRenew && !end.Before(from) && !end.After(to) {
+			deadlines = append(deadlines, ContractDeadline{Subscription: subscription, Kind: DeadlineContractEnd, Date: end})
+		}
+	}
+
+	sort.SliceStable(deadlines, func(i, j int) bool { return deadlines[i].Date.Before(deadlines[j].Date) })
+
+	return deadlines, nil
+}
@@ -0,0 +1,77 @@
+package service_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"git.home/alex/go-subscriptions/internal/domain/entity"
+	"git.home/alex/go-subscriptions/internal/domain/service"
+	"git.home/alex/go-subscriptions/internal/repository/memory"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSubscriptionService_ContractDeadlines(t *testing.T) {
+	ctx := context.Background()
+	s := service.NewSubscriptionService(memory.NewSubscriptionRepository())
+	date := func(y int, m time.Month, d int) entity.PaymentDate {
+		return entity.PaymentDate(time.Date(y, m, d, 0, 0, 0, 0, time.UTC))
+	}
+
+	for _, subscription := range []entity.Subscription{
+		{Name: "Mobile", NextPaymentDate: date(2024, 5, 20)},
+		{Name: "Gym", NextPaymentDate: date(2024, 5, 3)},
+		{Name: "Internet", NextPaymentDate: date(2024, 5, 5)},
+	} {
+		subscription.Price = 20
+		subscription.Currency = entity.USD
+		subscription.Cycle = entity.Monthly
+
+		_, err := s.CreateSubscription(ctx, subscription)
+		require.NoError(t, err)
+	}
+
+	_, err := s.SetContract(ctx, 1, &entity.Contract{
+		StartDate:  date(2023, 6, 15),
+		EndDate:    date(2024, 6, 15),
+		NoticeDays: 30,
+		AutoRenew:  true,
+	})
+	require.NoError(t, err)
+	_, err = s.SetContract(ctx, 2, &entity.Contract{StartDate: date(2023, 6, 1), EndDate: date(2024, 6, 1)})
+	require.NoError(t, err)
+
+	_, err = s.SetContract(ctx, 3, &entity.Contract{StartDate: date(2024, 6, 1), EndDate: date(2024, 6, 1)})
+	assert.ErrorIs(t, err, service.ErrInvalidContract)
+
+	from := time.Date(2024, 5, 1, 0, 0, 0, 0, time.UTC)
+
+	deadlines, err := s.ContractDeadlines(ctx, from, from.AddDate(0, 0, 45))
+	require.NoError(t, err)
+
+	type deadline struct {
+		name string
+		kind service.DeadlineKind
+		date string
+	}
+
+	got := make([]deadline, len(deadlines))
+	for i, d := range deadlines {
+		got[i] = deadline{d.Subscription.Name, d.Kind, d.Date.Format(time.DateOnly)}
+	}
+
+	assert.Equal(t, []deadline{
+		{"Mobile", service.DeadlineCancelBy, "2024-05-16"},
+		{"Gym", service.DeadlineContractEnd, "2024-06-01"},
+	}, got)
+
+	payments, err := s.UpcomingPayments(ctx, from, from.AddDate(0, 2, 0))
+	require.NoError(t, err)
+
+	for _, p := range payments {
+		if p.Subscription.Name == "Gym" {
+			assert.True(t, p.Date.Before(time.Time(date(2024, 6, 1))), "a fixed-term contract stops the billing on its end date")
+		}
+	}
+}
@@ -0,0 +1,97 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"time"
+
+	"git.home/alex/go-subscriptions/internal/clock"
+	"git.home/alex/go-subscriptions/internal/domain/entity"
+)
+
+var (
+	ErrInvalidPrice = errors.New("the price is not valid")
+)
+
+type PriceIncrease struct {
+	Subscription  entity.Subscription
+	OldPrice      float64
+	NewPrice      float64
+	EffectiveDate time.Time
+}
+
+// ChangePrice records a price effective from effectiveDate on, today when it is zero. Future dates
+// schedule an announced change; past dates correct the history.
+func (s *SubscriptionService) ChangePrice(
+	ctx context.Context,
+	id uint,
+	price float64,
+	effectiveDate time.Time,
+) (*entity.Subscription, error) {
+	if price <= 0 {
+		return nil, ErrInvalidPrice
+	}
+
+	subscription, err := s.GetSubscription(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	now := clock.Today(ctx)
+	if effectiveDate.IsZero() {
+		effectiveDate = now
+	}
+
+	subscription.Prices = subscription.WithPrice(price, effectiveDate)
+	subscription.Price = subscription.PriceAt(now)
+
+	return s.repo.Update(ctx, *subscription)
+}
+
+// PriceHistory returns the prices of the subscription ordered by effective date. A subscription
+// whose price never changed has a single entry without a date.
+func (s *SubscriptionService) PriceHistory(ctx context.Context, id uint) ([]entity.PriceChange, error) {
+	subscription, err := s.GetSubscription(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(subscription.Prices) == 0 {
+		return []entity.PriceChange{{Price: subscription.Price}}, nil
+	}
+
+	return subscription.Prices, nil
+}
+
+// PriceIncreases returns the price rises that took effect between from and to, the latest first.
+func (s *SubscriptionService) PriceIncreases(ctx context.Context, from, to time.Time) ([]PriceIncrease, error) {
+	subscriptions, err := s.GetAllSubscriptions(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var increases []PriceIncrease
+
+	for _, subscription := range subscriptions {
+		for i := 1; i < len(subscription.Prices); i++ {
+			previous, change := subscription.Prices[i-1], subscription.Prices[i]
+			date := time.Time(change.EffectiveDate)
+
+			if change.Price <= previous.Price || date.Before(from) || date.After(to) {
+				continue
+			}
+
+			increases = append(increases, PriceIncrease{
+				Subscription:  subscription,
+				OldPrice:      previous.Price,
+				NewPrice:      change.Price,
+				EffectiveDate: date,
+			})
+		}
+	}
+
+	sort.SliceStable(increases, func(i, j int) bool { return increases[i].EffectiveDate.After(increases[j].EffectiveDate) })
+
+	return increases, nil
+}
@@ -0,0 +1,77 @@
+package service_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"git.home/alex/go-subscriptions/internal/domain/entity"
+	"git.home/alex/go-subscriptions/internal/domain/service"
+	"git.home/alex/go-subscriptions/internal/repository/memory"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSubscriptionService_ChangePrice(t *testing.T) {
+	ctx := context.Background()
+	s := service.NewSubscriptionService(memory.NewSubscriptionRepository())
+
+	created, err := s.CreateSubscription(ctx, entity.Subscription{Name: "Test", Price: 10, Currency: entity.USD, Cycle: entity.Monthly})
+	require.NoError(t, err)
+
+	history, err := s.PriceHistory(ctx, created.ID)
+	require.NoError(t, err)
+	assert.Equal(t, []entity.PriceChange{{Price: 10}}, history)
+
+	_, err = s.ChangePrice(ctx, created.ID, 0, time.Time{})
+	assert.ErrorIs(t, err, service.ErrInvalidPrice)
+
+	changed, err := s.ChangePrice(ctx, created.ID, 15, time.Now().AddDate(0, 1, 0))
+	require.NoError(t, err)
+	assert.InDelta(t, 10, changed.Price, 0.001, "an announced price is not in effect yet")
+
+	updated := *changed
+	updated.Price = 12
+
+	result, err := s.UpdateSubscription(ctx, updated)
+	require.NoError(t, err)
+	assert.InDelta(t, 12, result.Price, 0.001)
+
+	history, err = s.PriceHistory(ctx, created.ID)
+	require.NoError(t, err)
+	require.Len(t, history, 3)
+	assert.InDelta(t, 10, history[0].Price, 0.001)
+	assert.InDelta(t, 12, history[1].Price, 0.001)
+	assert.InDelta(t, 15, history[2].Price, 0.001)
+}
+
+func TestSubscriptionService_PriceIncreases(t *testing.T) {
+	ctx := context.Background()
+	s := service.NewSubscriptionService(memory.NewSubscriptionRepository())
+	day := func(d int) entity.PaymentDate { return entity.PaymentDate(time.Date(2024, 5, d, 0, 0, 0, 0, time.UTC)) }
+
+	for _, prices := range [][]entity.PriceChange{
+		{{Price: 10}, {Price: 12, EffectiveDate: day(5)}, {Price: 9, EffectiveDate: day(10)}},
+		{{Price: 5}, {Price: 6, EffectiveDate: day(8)}, {Price: 7, EffectiveDate: day(25)}},
+		{{Price: 3}, {Price: 4, EffectiveDate: day(1)}},
+	} {
+		_, err := s.CreateSubscription(ctx, entity.Subscription{
+			Name:     "Test",
+			Price:    prices[len(prices)-1].Price,
+			Currency: entity.USD,
+			Cycle:    entity.Monthly,
+			Prices:   prices,
+		})
+		require.NoError(t, err)
+	}
+
+	increases, err := s.PriceIncreases(ctx, time.Time(day(2)), time.Time(day(20)))
+	require.NoError(t, err)
+	require.Len(t, increases, 2)
+
+	assert.Equal(t, uint(2), increases[0].Subscription.ID)
+	assert.InDelta(t, 5, increases[0].OldPrice, 0.001)
+	assert.InDelta(t, 6, increases[0].NewPrice, 0.001)
+	assert.Equal(t, uint(1), increases[1].Subscription.ID)
+	assert.Equal(t, time.Time(day(5)), increases[1].EffectiveDate)
+}
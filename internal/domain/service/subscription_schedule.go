@@ -0,0 +1,70 @@
+package service
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"git.home/alex/go-subscriptions/internal/domain/entity"
+	"git.home/alex/go-subscriptions/internal/domain/repository"
+)
+
+type UpcomingPayment struct {
+	Subscription entity.Subscription
+	Date         time.Time
+	Amount       float64
+	// FirstAfterTrial marks the charge that converts a trial into a paid subscription.
+	FirstAfterTrial bool
+}
+
+// UpcomingPayments projects the charges of the visible subscriptions between from and to, ordered by date.
+// Paused, cancelled and expired subscriptions are only charged on the days they are active, and every
+// charge uses the price in effect on its date.
+func (s *SubscriptionService) UpcomingPayments(ctx context.Context, from, to time.Time) ([]UpcomingPayment, error) {
+	subscriptions, err := s.GetAllSubscriptions(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var payments []UpcomingPayment
+
+	for _, subscription := range subscriptions {
+		for _, date := range subscription.PaymentsBetween(from, to) {
+			payments = append(payments, UpcomingPayment{
+				Subscription:    subscription,
+				Date:            date,
+				Amount:          subscription.PriceAt(date),
+				FirstAfterTrial: subscription.IsFirstPaidCharge(date),
+			})
+		}
+	}
+
+	sort.SliceStable(payments, func(i, j int) bool { return payments[i].Date.Before(payments[j].Date) })
+
+	return payments, nil
+}
+
+// EndingTrials returns the trials that end between from and to, the soonest first.
+func (s *SubscriptionService) EndingTrials(ctx context.Context, from, to time.Time) (repository.Subscriptions, error) {
+	subscriptions, err := s.GetAllSubscriptions(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	trials := make(repository.Subscriptions, 0)
+
+	for _, subscription := range subscriptions {
+		end := time.Time(subscription.TrialEndDate)
+		if subscription.Status != entity.StatusTrial || end.Before(from) || end.After(to) {
+			continue
+		}
+
+		trials = append(trials, subscription)
+	}
+
+	sort.SliceStable(trials, func(i, j int) bool {
+		return time.Time(trials[i].TrialEndDate).Before(time.Time(trials[j].TrialEndDate))
+	})
+
+	return trials, nil
+}
@@ -0,0 +1,135 @@
+package service_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"git.home/alex/go-subscriptions/internal/domain/entity"
+	"git.home/alex/go-subscriptions/internal/domain/service"
+	"git.home/alex/go-subscriptions/internal/repository/memory"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newScheduleFixture(t *testing.T) *service.SubscriptionService {
+	t.Helper()
+
+	ctx := context.Background()
+	s := service.NewSubscriptionService(memory.NewSubscriptionRepository())
+	date := func(m time.Month, d int) entity.PaymentDate {
+		return entity.PaymentDate(time.Date(2024, m, d, 0, 0, 0, 0, time.UTC))
+	}
+
+	for _, subscription := range []entity.Subscription{
+		{Name: "Music", Price: 10, NextPaymentDate: date(5, 20)},
+		{Name: "Video", Price: 15, Status: entity.StatusTrial, TrialStartDate: date(5, 1), TrialEndDate: date(5, 8)},
+		{Name: "Storage", Price: 3, Status: entity.StatusTrial, TrialEndDate: date(6, 30)},
+		{Name: "News", Price: 5, NextPaymentDate: date(5, 3), Status: entity.StatusCancelled, CancelDate: date(5, 1)},
+	} {
+		subscription.Currency = entity.USD
+		subscription.Cycle = entity.Monthly
+
+		_, err := s.CreateSubscription(ctx, subscription)
+		require.NoError(t, err)
+	}
+
+	return s
+}
+
+func TestSubscriptionService_UpcomingPayments(t *testing.T) {
+	s := newScheduleFixture(t)
+	from := time.Date(2024, 5, 1, 0, 0, 0, 0, time.UTC)
+
+	payments, err := s.UpcomingPayments(context.Background(), from, from.AddDate(0, 0, 30))
+	require.NoError(t, err)
+
+	type payment struct {
+		name            string
+		date            string
+		amount          float64
+		firstAfterTrial bool
+	}
+
+	got := make([]payment, len(payments))
+	for i, p := range payments {
+		got[i] = payment{p.Subscription.Name, p.Date.Format(time.DateOnly), p.Amount, p.FirstAfterTrial}
+	}
+
+	assert.Equal(t, []payment{
+		{"Video", "2024-05-08", 15, true},
+		{"Music", "2024-05-20", 10, false},
+	}, got)
+}
+
+func TestSubscriptionService_EndingTrials(t *testing.T) {
+	s := newScheduleFixture(t)
+	from := time.Date(2024, 5, 1, 0, 0, 0, 0, time.UTC)
+
+	testCases := []struct {
+		name string
+		days int
+		want []string
+	}{
+		{name: "Within a week", days: 7, want: []string{"Video"}},
+		{name: "Within two months", days: 60, want: []string{"Video", "Storage"}},
+		{name: "Today only", days: 0, want: []string{}},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			trials, err := s.EndingTrials(context.Background(), from, from.AddDate(0, 0, tc.days))
+			require.NoError(t, err)
+
+			names := make([]string, len(trials))
+			for i, trial := range trials {
+				names[i] = trial.Name
+			}
+
+			assert.Equal(t, tc.want, names)
+		})
+	}
+}
+
+func TestSubscriptionService_CreateTrialEndingBeforeStart(t *testing.T) {
+	s := service.NewSubscriptionService(memory.NewSubscriptionRepository())
+	date := entity.PaymentDate(time.Date(2024, 5, 1, 0, 0, 0, 0, time.UTC))
+
+	_, err := s.CreateSubscription(context.Background(), entity.Subscription{
+		Name:           "Test",
+		Price:          10,
+		Currency:       entity.USD,
+		Cycle:          entity.Monthly,
+		Status:         entity.StatusTrial,
+		TrialStartDate: date,
+		TrialEndDate:   date,
+	})
+
+	assert.ErrorIs(t, err, service.ErrInvalidSubscription)
+}
+
+func TestSubscriptionService_UpcomingPaymentsRuleCycle(t *testing.T) {
+	ctx := context.Background()
+	s := service.NewSubscriptionService(memory.NewSubscriptionRepository())
+
+	_, err := s.CreateSubscription(ctx, entity.Subscription{
+		Name:            "Accounting",
+		Price:           30,
+		Currency:        entity.USD,
+		Cycle:           entity.Cycle{ID: 4, Name: "Quarterly", Rule: "FREQ=MONTHLY;INTERVAL=3;BYDAY=MO,TU,WE,TH,FR;BYSETPOS=1"},
+		NextPaymentDate: entity.PaymentDate(time.Date(2024, 4, 1, 0, 0, 0, 0, time.UTC)),
+	})
+	require.NoError(t, err)
+
+	from := time.Date(2024, 4, 1, 0, 0, 0, 0, time.UTC)
+
+	payments, err := s.UpcomingPayments(ctx, from, from.AddDate(1, 0, 0))
+	require.NoError(t, err)
+
+	dates := make([]string, len(payments))
+	for i, p := range payments {
+		dates[i] = p.Date.Format(time.DateOnly)
+	}
+
+	assert.Equal(t, []string{"2024-04-01", "2024-07-01", "2024-10-01", "2025-01-01", "2025-04-01"}, dates)
+}
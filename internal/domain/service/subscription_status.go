@@ -0,0 +1,75 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"git.home/alex/go-subscriptions/internal/clock"
+	"git.home/alex/go-subscriptions/internal/domain/entity"
+)
+
+var (
+	ErrInvalidTransition = errors.New("the subscription can't change to this state")
+	ErrInvalidStatusDate = errors.New("the status date is not valid")
+)
+
+// Activate converts a trial, resumes a paused subscription or reactivates a cancelled or expired one.
+func (s *SubscriptionService) Activate(ctx context.Context, id uint) (*entity.Subscription, error) {
+	return s.transition(ctx, id, entity.StatusActive, func(*entity.Subscription) {})
+}
+
+// Pause stops the billing until resumeDate. A zero resumeDate pauses until the subscription is activated.
+func (s *SubscriptionService) Pause(ctx context.Context, id uint, resumeDate time.Time) (*entity.Subscription, error) {
+	if !resumeDate.IsZero() && !resumeDate.After(clock.Today(ctx)) {
+		return nil, ErrInvalidStatusDate
+	}
+
+	return s.transition(ctx, id, entity.StatusPaused, func(subscription *entity.Subscription) {
+		subscription.ResumeDate = entity.PaymentDate(resumeDate)
+	})
+}
+
+// Cancel ends the billing from effectiveDate on; until then the subscription stays active.
+// A zero effectiveDate cancels it today.
+func (s *SubscriptionService) Cancel(ctx context.Context, id uint, effectiveDate time.Time) (*entity.Subscription, error) {
+	if effectiveDate.IsZero() {
+		effectiveDate = clock.Today(ctx)
+	}
+
+	return s.transition(ctx, id, entity.StatusCancelled, func(subscription *entity.Subscription) {
+		subscription.CancelDate = entity.PaymentDate(effectiveDate)
+	})
+}
+
+// Expire ends a subscription that ran out on its own, e.g. a trial that wasn't converted.
+func (s *SubscriptionService) Expire(ctx context.Context, id uint) (*entity.Subscription, error) {
+	return s.transition(ctx, id, entity.StatusExpired, func(*entity.Subscription) {})
+}
+
+// transition checks the change against both the stored state and the state in effect today, so a
+// lapsed trial can still be converted and a scheduled cancellation can be withdrawn.
+func (s *SubscriptionService) transition(
+	ctx context.Context,
+	id uint,
+	to entity.SubscriptionStatus,
+	apply func(subscription *entity.Subscription),
+) (*entity.Subscription, error) {
+	subscription, err := s.GetSubscription(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if !subscription.Status.CanBecome(to) && !subscription.StatusAt(clock.Today(ctx)).CanBecome(to) {
+		return nil, ErrInvalidTransition
+	}
+
+	subscription.Status = to
+	subscription.TrialEndDate = entity.PaymentDate{}
+	subscription.ResumeDate = entity.PaymentDate{}
+	subscription.CancelDate = entity.PaymentDate{}
+
+	apply(subscription)
+
+	return s.repo.Update(ctx, *subscription)
+}
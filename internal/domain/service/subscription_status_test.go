@@ -0,0 +1,143 @@
+package service_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"git.home/alex/go-subscriptions/internal/domain/entity"
+	"git.home/alex/go-subscriptions/internal/domain/service"
+	"git.home/alex/go-subscriptions/internal/repository/memory"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSubscriptionService_Transitions(t *testing.T) {
+	tomorrow := time.Now().AddDate(0, 0, 1)
+	yesterday := time.Now().AddDate(0, 0, -1)
+
+	pause := func(resume time.Time) func(*service.SubscriptionService, uint) (*entity.Subscription, error) {
+		return func(s *service.SubscriptionService, id uint) (*entity.Subscription, error) {
+			return s.Pause(context.Background(), id, resume)
+		}
+	}
+	cancel := func(effective time.Time) func(*service.SubscriptionService, uint) (*entity.Subscription, error) {
+		return func(s *service.SubscriptionService, id uint) (*entity.Subscription, error) {
+			return s.Cancel(context.Background(), id, effective)
+		}
+	}
+	activate := func(s *service.SubscriptionService, id uint) (*entity.Subscription, error) {
+		return s.Activate(context.Background(), id)
+	}
+	expire := func(s *service.SubscriptionService, id uint) (*entity.Subscription, error) {
+		return s.Expire(context.Background(), id)
+	}
+
+	testCases := []struct {
+		name       string
+		from       entity.Subscription
+		transition func(*service.SubscriptionService, uint) (*entity.Subscription, error)
+		wantStatus entity.SubscriptionStatus
+		wantActive bool
+		wantErr    error
+	}{
+		{
+			name:       "Convert a trial",
+			from:       entity.Subscription{Status: entity.StatusTrial, TrialEndDate: entity.PaymentDate(tomorrow)},
+			transition: activate,
+			wantStatus: entity.StatusActive,
+			wantActive: true,
+		},
+		{
+			name:       "Pause until tomorrow",
+			transition: pause(tomorrow),
+			wantStatus: entity.StatusPaused,
+		},
+		{
+			name:       "Resume date in the past",
+			transition: pause(yesterday),
+			wantErr:    service.ErrInvalidStatusDate,
+		},
+		{
+			name:       "Cancel today",
+			transition: cancel(time.Time{}),
+			wantStatus: entity.StatusCancelled,
+		},
+		{
+			name:       "Cancel at the end of the period",
+			transition: cancel(tomorrow),
+			wantStatus: entity.StatusCancelled,
+			wantActive: true,
+		},
+		{
+			name:       "Withdraw a scheduled cancellation",
+			from:       entity.Subscription{Status: entity.StatusCancelled, CancelDate: entity.PaymentDate(tomorrow)},
+			transition: activate,
+			wantStatus: entity.StatusActive,
+			wantActive: true,
+		},
+		{
+			name:       "Reactivate an expired subscription",
+			from:       entity.Subscription{Status: entity.StatusExpired},
+			transition: activate,
+			wantStatus: entity.StatusActive,
+			wantActive: true,
+		},
+		{
+			name:       "Pause an expired subscription",
+			from:       entity.Subscription{Status: entity.StatusExpired},
+			transition: pause(tomorrow),
+			wantErr:    service.ErrInvalidTransition,
+		},
+		{
+			name:       "Activate an active subscription",
+			transition: activate,
+			wantErr:    service.ErrInvalidTransition,
+		},
+		{
+			name:       "Expire a paused subscription",
+			from:       entity.Subscription{Status: entity.StatusPaused},
+			transition: expire,
+			wantStatus: entity.StatusExpired,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			s := service.NewSubscriptionService(memory.NewSubscriptionRepository())
+
+			subscription := tc.from
+			subscription.Name = "Test"
+			subscription.Price = 10
+			subscription.Currency = entity.USD
+			subscription.Cycle = entity.Monthly
+
+			created, err := s.CreateSubscription(context.Background(), subscription)
+			require.NoError(t, err)
+
+			result, err := tc.transition(s, created.ID)
+			if tc.wantErr != nil {
+				assert.ErrorIs(t, err, tc.wantErr)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tc.wantStatus, result.Status)
+			assert.Equal(t, tc.wantActive, result.IsActive(time.Now()))
+		})
+	}
+}
+
+func TestSubscriptionService_CreateTrialWithoutEndDate(t *testing.T) {
+	s := service.NewSubscriptionService(memory.NewSubscriptionRepository())
+
+	_, err := s.CreateSubscription(context.Background(), entity.Subscription{
+		Name:     "Test",
+		Price:    10,
+		Currency: entity.USD,
+		Cycle:    entity.Monthly,
+		Status:   entity.StatusTrial,
+	})
+
+	assert.ErrorIs(t, err, service.ErrInvalidSubscription)
+}
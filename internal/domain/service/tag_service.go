@@ -0,0 +1,209 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"slices"
+
+	"git.home/alex/go-subscriptions/internal/clock"
+	"git.home/alex/go-subscriptions/internal/domain/entity"
+	"git.home/alex/go-subscriptions/internal/domain/repository"
+)
+
+var (
+	ErrInvalidTag = errors.New("the tag is not valid")
+)
+
+type TagService struct {
+	repo          repository.TagRepository
+	subscriptions repository.SubscriptionRepository
+}
+
+func NewTagService(repo repository.TagRepository, subscriptions repository.SubscriptionRepository) *TagService {
+	return &TagService{
+		repo:          repo,
+		subscriptions: subscriptions,
+	}
+}
+
+func (s *TagService) CreateTag(ctx context.Context, tag entity.Tag) (*entity.Tag, error) {
+	if tag.Name == "" {
+		return nil, ErrInvalidTag
+	}
+
+	if userID, ok := ownerFromContext(ctx); ok {
+		tag.UserID = userID
+	}
+
+	return s.repo.Create(ctx, tag)
+}
+
+func (s *TagService) GetTag(ctx context.Context, id uint) (*entity.Tag, error) {
+	if id == 0 {
+		return nil, repository.ErrNotFoundTag
+	}
+
+	tag, err := s.repo.Get(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if !canSee(ctx, tag.UserID, false) {
+		return nil, repository.ErrNotFoundTag
+	}
+
+	return tag, nil
+}
+
+func (s *TagService) GetAllTags(ctx context.Context) (repository.Tags, error) {
+	tags, err := s.repo.GetAll(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if _, ok := ownerFromContext(ctx); !ok {
+		return tags, nil
+	}
+
+	visible := make(repository.Tags, 0, len(tags))
+	for _, tag := range tags {
+		if canSee(ctx, tag.UserID, false) {
+			visible = append(visible, tag)
+		}
+	}
+
+	return visible, nil
+}
+
+func (s *TagService) UpdateTag(ctx context.Context, tag entity.Tag) (*entity.Tag, error) {
+	if tag.ID == 0 || tag.Name == "" {
+		return nil, ErrInvalidTag
+	}
+
+	if userID, ok := ownerFromContext(ctx); ok {
+		if _, err := s.GetTag(ctx, tag.ID); err != nil {
+			return nil, err
+		}
+
+		tag.UserID = userID
+	}
+
+	return s.repo.Update(ctx, tag)
+}
+
+// DeleteTag removes the tag from the subscriptions carrying it before deleting it.
+func (s *TagService) DeleteTag(ctx context.Context, id uint) error {
+	if id == 0 {
+		return repository.ErrNotFoundTag
+	}
+
+	if _, ok := ownerFromContext(ctx); ok {
+		if _, err := s.GetTag(ctx, id); err != nil {
+			return err
+		}
+	}
+
+	subscriptions, err := s.subscriptions.GetAll(ctx)
+	if err != nil {
+		return err
+	}
+
+	for _, subscription := range subscriptions {
+		if !subscription.HasTags(id) {
+			continue
+		}
+
+		subscription.TagIDs = slices.DeleteFunc(slices.Clone(subscription.TagIDs), func(tagID uint) bool { return tagID == id })
+
+		_, err = s.subscriptions.Update(ctx, subscription)
+		if err != nil {
+			return err
+		}
+	}
+
+	return s.repo.Delete(ctx, id)
+}
+
+func (s *TagService) TagSubscription(ctx context.Context, subscriptionID, tagID uint) (*entity.Subscription, error) {
+	subscription, err := s.getOwnSubscription(ctx, subscriptionID)
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err = s.GetTag(ctx, tagID); err != nil {
+		return nil, err
+	}
+
+	if subscription.HasTags(tagID) {
+		return subscription, nil
+	}
+
+	subscription.TagIDs = append(slices.Clone(subscription.TagIDs), tagID)
+
+	return s.subscriptions.Update(ctx, *subscription)
+}
+
+func (s *TagService) UntagSubscription(ctx context.Context, subscriptionID, tagID uint) (*entity.Subscription, error) {
+	subscription, err := s.getOwnSubscription(ctx, subscriptionID)
+	if err != nil {
+		return nil, err
+	}
+
+	subscription.TagIDs = slices.DeleteFunc(slices.Clone(subscription.TagIDs), func(id uint) bool { return id == tagID })
+
+	return s.subscriptions.Update(ctx, *subscription)
+}
+
+type TagReport struct {
+	Tag entity.Tag
+	// Totals is the normalized monthly cost of the tagged subscriptions per currency code.
+	Totals        map[string]float64
+	Subscriptions int
+}
+
+// GetReport groups the normalized monthly cost of the subscriptions active today by tag. A
+// subscription with several tags counts towards each of them, so the totals don't add up.
+func (s *TagService) GetReport(ctx context.Context) ([]TagReport, error) {
+	tags, err := s.GetAllTags(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	subscriptions, err := s.subscriptions.GetAll(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	now := clock.Today(ctx)
+	reports := make([]TagReport, len(tags))
+
+	for i, tag := range tags {
+		reports[i] = TagReport{Tag: tag, Totals: make(map[string]float64)}
+
+		for _, subscription := range subscriptions {
+			if !subscription.HasTags(tag.ID) || !subscription.IsActive(now) || !canSee(ctx, subscription.UserID, false) {
+				continue
+			}
+
+			reports[i].Totals[subscription.Currency.Code] += subscription.MonthlyCost(now)
+			reports[i].Subscriptions++
+		}
+
+		roundTotals(reports[i].Totals)
+	}
+
+	return reports, nil
+}
+
+func (s *TagService) getOwnSubscription(ctx context.Context, id uint) (*entity.Subscription, error) {
+	subscription, err := s.subscriptions.Get(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if !canSee(ctx, subscription.UserID, false) {
+		return nil, repository.ErrNotFoundSubscription
+	}
+
+	return subscription, nil
+}
@@ -0,0 +1,65 @@
+package service_test
+
+import (
+	"context"
+	"testing"
+
+	"git.home/alex/go-subscriptions/internal/domain/entity"
+	"git.home/alex/go-subscriptions/internal/domain/repository"
+	"git.home/alex/go-subscriptions/internal/domain/service"
+	"git.home/alex/go-subscriptions/internal/repository/memory"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTagService_TagSubscription(t *testing.T) {
+	ctx := context.Background()
+	subscriptions := memory.NewSubscriptionRepository()
+	tagService := service.NewTagService(memory.NewTagRepository(), subscriptions)
+
+	work, err := tagService.CreateTag(ctx, entity.Tag{Name: "Work"})
+	require.NoError(t, err)
+	family, err := tagService.CreateTag(ctx, entity.Tag{Name: "Family"})
+	require.NoError(t, err)
+
+	for _, s := range []entity.Subscription{
+		{Name: "Office", Price: 10, Currency: entity.USD, Cycle: entity.Monthly},
+		{Name: "Cloud", Price: 120, Currency: entity.USD, Cycle: entity.Yearly},
+		{Name: "Paused", Price: 50, Currency: entity.USD, Cycle: entity.Monthly, Status: entity.StatusPaused},
+	} {
+		created, err := subscriptions.Create(ctx, s)
+		require.NoError(t, err)
+
+		_, err = tagService.TagSubscription(ctx, created.ID, work.ID)
+		require.NoError(t, err)
+	}
+
+	subscription, err := tagService.TagSubscription(ctx, 2, family.ID)
+	require.NoError(t, err)
+	assert.Equal(t, []uint{work.ID, family.ID}, subscription.TagIDs)
+
+	subscription, err = tagService.TagSubscription(ctx, 2, family.ID)
+	require.NoError(t, err)
+	assert.Equal(t, []uint{work.ID, family.ID}, subscription.TagIDs, "tagging twice is a no-op")
+
+	_, err = tagService.TagSubscription(ctx, 2, 9)
+	assert.ErrorIs(t, err, repository.ErrNotFoundTag)
+
+	reports, err := tagService.GetReport(ctx)
+	require.NoError(t, err)
+	require.Len(t, reports, 2)
+	assert.Equal(t, 2, reports[0].Subscriptions)
+	assert.InDelta(t, 20, reports[0].Totals["USD"], 0.001)
+	assert.Equal(t, 1, reports[1].Subscriptions)
+	assert.InDelta(t, 10, reports[1].Totals["USD"], 0.001)
+
+	subscription, err = tagService.UntagSubscription(ctx, 2, work.ID)
+	require.NoError(t, err)
+	assert.Equal(t, []uint{family.ID}, subscription.TagIDs)
+
+	require.NoError(t, tagService.DeleteTag(ctx, family.ID))
+
+	subscription, err = subscriptions.Get(ctx, 2)
+	require.NoError(t, err)
+	assert.Empty(t, subscription.TagIDs, "deleting a tag detaches it")
+}
@@ -0,0 +1,122 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"time"
+
+	"git.home/alex/go-subscriptions/internal/clock"
+	"git.home/alex/go-subscriptions/internal/domain/entity"
+	"git.home/alex/go-subscriptions/internal/domain/repository"
+	"golang.org/x/crypto/bcrypt"
+)
+
+var (
+	ErrInvalidUser        = errors.New("the user is not valid")
+	ErrWeakPassword       = errors.New("the password must be at least 8 characters long")
+	ErrInvalidCredentials = errors.New("invalid username or password")
+	ErrInvalidTimezone    = errors.New("the time zone is not valid")
+)
+
+const minPasswordLength = 8
+
+// dummyPasswordHash is compared against when the user is unknown, so that a login takes as long
+// whether the username exists or not. It has the cost of the hashes RegisterUser creates.
+const dummyPasswordHash = "$2a$10$r7TwRVGCAiLhoO.3o958GOAtmxCuqwRPjlIee6YtG9deloMB0ksSy"
+
+type UserService struct {
+	repo repository.UserRepository
+}
+
+func NewUserService(repo repository.UserRepository) *UserService {
+	return &UserService{repo: repo}
+}
+
+func (s *UserService) RegisterUser(ctx context.Context, username, password string) (*entity.User, error) {
+	username = strings.TrimSpace(username)
+	if username == "" {
+		return nil, ErrInvalidUser
+	}
+
+	if len(password) < minPasswordLength {
+		return nil, ErrWeakPassword
+	}
+
+	hash, err := bcrypt.GenerateFromPassword([]byte(password), bcrypt.DefaultCost)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.repo.Create(ctx, entity.User{Username: username, PasswordHash: string(hash)})
+}
+
+// Authenticate returns the user when the password matches. Unknown users and wrong passwords
+// produce the same error, after the same bcrypt work, so that usernames can't be enumerated.
+func (s *UserService) Authenticate(ctx context.Context, username, password string) (*entity.User, error) {
+	user, err := s.repo.GetByUsername(ctx, strings.TrimSpace(username))
+	if errors.Is(err, repository.ErrNotFoundUser) {
+		_ = bcrypt.CompareHashAndPassword([]byte(dummyPasswordHash), []byte(password))
+
+		return nil, ErrInvalidCredentials
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	if bcrypt.CompareHashAndPassword([]byte(user.PasswordHash), []byte(password)) != nil {
+		return nil, ErrInvalidCredentials
+	}
+
+	return user, nil
+}
+
+func (s *UserService) GetUser(ctx context.Context, id uint) (*entity.User, error) {
+	if id == 0 {
+		return nil, repository.ErrNotFoundUser
+	}
+
+	return s.repo.Get(ctx, id)
+}
+
+// GetCurrentUser returns the user the call is made for.
+func (s *UserService) GetCurrentUser(ctx context.Context) (*entity.User, error) {
+	userID, ok := ownerFromContext(ctx)
+	if !ok {
+		return nil, repository.ErrNotFoundUser
+	}
+
+	return s.repo.Get(ctx, userID)
+}
+
+// SetTimezone changes the time zone of the current user; an empty name falls back to the default.
+func (s *UserService) SetTimezone(ctx context.Context, name string) (*entity.User, error) {
+	if name != "" {
+		if _, err := clock.LoadLocation(name); err != nil {
+			return nil, ErrInvalidTimezone
+		}
+	}
+
+	user, err := s.GetCurrentUser(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	user.Timezone = name
+
+	return s.repo.Update(ctx, *user)
+}
+
+// Location returns the time zone of the user the call is made for. ok is false when the call is not
+// bound to a user or the user hasn't set a zone.
+func (s *UserService) Location(ctx context.Context) (loc *time.Location, ok bool) {
+	user, err := s.GetCurrentUser(ctx)
+	if err != nil || user.Timezone == "" {
+		return nil, false
+	}
+
+	loc, err = clock.LoadLocation(user.Timezone)
+
+	return loc, err == nil
+}
@@ -0,0 +1,124 @@
+package service_test
+
+import (
+	"context"
+	"testing"
+
+	"git.home/alex/go-subscriptions/internal/auth"
+	"git.home/alex/go-subscriptions/internal/domain/repository"
+	"git.home/alex/go-subscriptions/internal/domain/service"
+	"git.home/alex/go-subscriptions/internal/repository/memory"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUserService_RegisterUser(t *testing.T) {
+	testCases := []struct {
+		name     string
+		username string
+		password string
+		wantErr  error
+	}{
+		{name: "Valid user", username: "alice", password: "correct horse"},
+		{name: "Duplicate user", username: "alice", password: "correct horse", wantErr: repository.ErrAlreadyExistsUser},
+		{name: "Empty username", username: " ", password: "correct horse", wantErr: service.ErrInvalidUser},
+		{name: "Short password", username: "bob", password: "short", wantErr: service.ErrWeakPassword},
+	}
+
+	userService := service.NewUserService(memory.NewUserRepository())
+	ctx := context.Background()
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			user, err := userService.RegisterUser(ctx, tc.username, tc.password)
+
+			if tc.wantErr != nil {
+				assert.ErrorIs(t, err, tc.wantErr)
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, tc.username, user.Username)
+			assert.NotEqual(t, tc.password, user.PasswordHash)
+		})
+	}
+}
+
+func TestUserService_Authenticate(t *testing.T) {
+	userService := service.NewUserService(memory.NewUserRepository())
+	ctx := context.Background()
+
+	registered, err := userService.RegisterUser(ctx, "alice", "correct horse")
+	require.NoError(t, err)
+
+	testCases := []struct {
+		name     string
+		username string
+		password string
+		wantErr  error
+	}{
+		{name: "Valid credentials", username: "alice", password: "correct horse"},
+		{name: "Wrong password", username: "alice", password: "battery staple", wantErr: service.ErrInvalidCredentials},
+		{name: "Unknown user", username: "bob", password: "correct horse", wantErr: service.ErrInvalidCredentials},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			user, err := userService.Authenticate(ctx, tc.username, tc.password)
+
+			if tc.wantErr != nil {
+				assert.ErrorIs(t, err, tc.wantErr)
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, registered, user)
+		})
+	}
+}
+
+func TestUserService_GetCurrentUser(t *testing.T) {
+	userService := service.NewUserService(memory.NewUserRepository())
+	ctx := context.Background()
+
+	registered, err := userService.RegisterUser(ctx, "alice", "correct horse")
+	require.NoError(t, err)
+
+	user, err := userService.GetCurrentUser(auth.WithPrincipal(ctx, &auth.Principal{UserID: registered.ID}))
+	assert.NoError(t, err)
+	assert.Equal(t, registered, user)
+
+	_, err = userService.GetCurrentUser(ctx)
+	assert.ErrorIs(t, err, repository.ErrNotFoundUser)
+}
+
+func TestUserService_SetTimezone(t *testing.T) {
+	userService := service.NewUserService(memory.NewUserRepository())
+	ctx := context.Background()
+
+	registered, err := userService.RegisterUser(ctx, "alice", "correct horse")
+	require.NoError(t, err)
+
+	userCtx := auth.WithPrincipal(ctx, &auth.Principal{UserID: registered.ID})
+
+	_, ok := userService.Location(userCtx)
+	assert.False(t, ok)
+
+	_, err = userService.SetTimezone(userCtx, "Mars/Olympus_Mons")
+	assert.ErrorIs(t, err, service.ErrInvalidTimezone)
+
+	user, err := userService.SetTimezone(userCtx, "Europe/Berlin")
+	require.NoError(t, err)
+	assert.Equal(t, "Europe/Berlin", user.Timezone)
+
+	loc, ok := userService.Location(userCtx)
+	require.True(t, ok)
+	assert.Equal(t, "Europe/Berlin", loc.String())
+
+	_, ok = userService.Location(ctx)
+	assert.False(t, ok)
+
+	user, err = userService.SetTimezone(userCtx, "")
+	require.NoError(t, err)
+	assert.Empty(t, user.Timezone)
+}
@@ -1,10 +1,14 @@
 package factory
 
 import (
+	"database/sql"
 	"errors"
 
+	"git.home/alex/go-subscriptions/internal/blobstore"
 	"git.home/alex/go-subscriptions/internal/domain/repository"
+	"git.home/alex/go-subscriptions/internal/repository/instrumented"
 	"git.home/alex/go-subscriptions/internal/repository/memory"
+	"git.home/alex/go-subscriptions/internal/repository/sqlite"
 )
 
 var (
@@ -16,6 +20,15 @@ type RepositoryFactory struct {
 	repository.CurrencyRepository
 	repository.CycleRepository
 	repository.SubscriptionRepository
+	repository.UserRepository
+	repository.HouseholdRepository
+	repository.BudgetRepository
+	repository.TagRepository
+	repository.PaymentMethodRepository
+	repository.AssetRepository
+	repository.AttachmentRepository
+	// Blobs holds the content of uploaded files, the repositories only their metadata.
+	Blobs blobstore.Store
 }
 
 type RepositoryConfiguration func(rf *RepositoryFactory) error
@@ -40,6 +53,14 @@ func WithMemoryRepository() RepositoryConfiguration {
 		rf.CurrencyRepository = memory.NewCurrencyRepository()
 		rf.CycleRepository = memory.NewCycleRepository()
 		rf.SubscriptionRepository = memory.NewSubscriptionRepository()
+		rf.UserRepository = memory.NewUserRepository()
+		rf.HouseholdRepository = memory.NewHouseholdRepository()
+		rf.BudgetRepository = memory.NewBudgetRepository()
+		rf.TagRepository = memory.NewTagRepository()
+		rf.PaymentMethodRepository = memory.NewPaymentMethodRepository()
+		rf.AssetRepository = memory.NewAssetRepository()
+		rf.AttachmentRepository = memory.NewAttachmentRepository()
+		rf.Blobs = blobstore.NewMemory()
 		return nil
 	}
 }
@@ -50,8 +71,47 @@ func WithRedisRepository() RepositoryConfiguration {
 	}
 }
 
-func WithSqliteRepository() RepositoryConfiguration {
+// WithSqliteRepository keeps the entities in the migrated database. It has no blob store of its own, so
+// WithBlobStore must follow it.
+func WithSqliteRepository(db *sql.DB) RepositoryConfiguration {
 	return func(rf *RepositoryFactory) error {
-		return ErrNotImplemented
+		rf.CategoryRepository = sqlite.NewCategoryRepository(db)
+		rf.CurrencyRepository = sqlite.NewCurrencyRepository(db)
+		rf.CycleRepository = sqlite.NewCycleRepository(db)
+		rf.SubscriptionRepository = sqlite.NewSubscriptionRepository(db)
+		rf.UserRepository = sqlite.NewUserRepository(db)
+		rf.HouseholdRepository = sqlite.NewHouseholdRepository(db)
+		rf.BudgetRepository = sqlite.NewBudgetRepository(db)
+		rf.TagRepository = sqlite.NewTagRepository(db)
+		rf.PaymentMethodRepository = sqlite.NewPaymentMethodRepository(db)
+		rf.AssetRepository = sqlite.NewAssetRepository(db)
+		rf.AttachmentRepository = sqlite.NewAttachmentRepository(db)
+		return nil
+	}
+}
+
+// WithBlobStore replaces the blob store of the storage option, so it must come after it.
+func WithBlobStore(store blobstore.Store) RepositoryConfiguration {
+	return func(rf *RepositoryFactory) error {
+		rf.Blobs = store
+		return nil
+	}
+}
+
+// WithInstrumentation wraps the repositories configured so far, so it must come after the storage option.
+func WithInstrumentation(o instrumented.Observer, backend string) RepositoryConfiguration {
+	return func(rf *RepositoryFactory) error {
+		rf.CategoryRepository = instrumented.NewCategoryRepository(rf.CategoryRepository, o, backend)
+		rf.CurrencyRepository = instrumented.NewCurrencyRepository(rf.CurrencyRepository, o, backend)
+		rf.CycleRepository = instrumented.NewCycleRepository(rf.CycleRepository, o, backend)
+		rf.SubscriptionRepository = instrumented.NewSubscriptionRepository(rf.SubscriptionRepository, o, backend)
+		rf.UserRepository = instrumented.NewUserRepository(rf.UserRepository, o, backend)
+		rf.HouseholdRepository = instrumented.NewHouseholdRepository(rf.HouseholdRepository, o, backend)
+		rf.BudgetRepository = instrumented.NewBudgetRepository(rf.BudgetRepository, o, backend)
+		rf.TagRepository = instrumented.NewTagRepository(rf.TagRepository, o, backend)
+		rf.PaymentMethodRepository = instrumented.NewPaymentMethodRepository(rf.PaymentMethodRepository, o, backend)
+		rf.AssetRepository = instrumented.NewAssetRepository(rf.AssetRepository, o, backend)
+		rf.AttachmentRepository = instrumented.NewAttachmentRepository(rf.AttachmentRepository, o, backend)
+		return nil
 	}
 }
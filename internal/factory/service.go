@@ -5,11 +5,20 @@ import (
 )
 
 type ServiceFactory struct {
-	repositoryFactory   *RepositoryFactory
-	CategoryService     *service.CategoryService
-	CurrencyService     *service.CurrencyService
-	CycleService        *service.CycleService
-	SubscriptionService *service.SubscriptionService
+	repositoryFactory    *RepositoryFactory
+	CategoryService      *service.CategoryService
+	CurrencyService      *service.CurrencyService
+	CycleService         *service.CycleService
+	SubscriptionService  *service.SubscriptionService
+	UserService          *service.UserService
+	HouseholdService     *service.HouseholdService
+	BudgetService        *service.BudgetService
+	TagService           *service.TagService
+	PaymentMethodService *service.PaymentMethodService
+	AssetService         *service.AssetService
+	AttachmentService    *service.AttachmentService
+	CSVService           *service.CSVService
+	StatementService     *service.StatementService
 }
 
 type ServiceConfiguration func(sf *ServiceFactory) error
@@ -37,7 +46,7 @@ func WithRepositoryFactory(repositoryFactory *RepositoryFactory) ServiceConfigur
 
 func WithCategoryService() ServiceConfiguration {
 	return func(sf *ServiceFactory) error {
-		sf.CategoryService = service.NewCategoryService(sf.repositoryFactory.CategoryRepository)
+		sf.CategoryService = service.NewCategoryService(sf.repositoryFactory.CategoryRepository, sf.repositoryFactory.SubscriptionRepository)
 		return nil
 	}
 }
@@ -62,3 +71,94 @@ func WithSubscriptionService() ServiceConfiguration {
 		return nil
 	}
 }
+
+func WithUserService() ServiceConfiguration {
+	return func(sf *ServiceFactory) error {
+		sf.UserService = service.NewUserService(sf.repositoryFactory.UserRepository)
+		return nil
+	}
+}
+
+func WithHouseholdService() ServiceConfiguration {
+	return func(sf *ServiceFactory) error {
+		sf.HouseholdService = service.NewHouseholdService(
+			sf.repositoryFactory.HouseholdRepository,
+			sf.repositoryFactory.SubscriptionRepository,
+		)
+		return nil
+	}
+}
+
+func WithBudgetService() ServiceConfiguration {
+	return func(sf *ServiceFactory) error {
+		sf.BudgetService = service.NewBudgetService(
+			sf.repositoryFactory.BudgetRepository,
+			sf.repositoryFactory.CategoryRepository,
+			sf.repositoryFactory.SubscriptionRepository,
+		)
+		return nil
+	}
+}
+
+func WithTagService() ServiceConfiguration {
+	return func(sf *ServiceFactory) error {
+		sf.TagService = service.NewTagService(sf.repositoryFactory.TagRepository, sf.repositoryFactory.SubscriptionRepository)
+		return nil
+	}
+}
+
+func WithPaymentMethodService() ServiceConfiguration {
+	return func(sf *ServiceFactory) error {
+		sf.PaymentMethodService = service.NewPaymentMethodService(
+			sf.repositoryFactory.PaymentMethodRepository,
+			sf.repositoryFactory.SubscriptionRepository,
+		)
+		return nil
+	}
+}
+
+func WithAssetService(maxSize int64) ServiceConfiguration {
+	return func(sf *ServiceFactory) error {
+		sf.AssetService = service.NewAssetService(
+			sf.repositoryFactory.AssetRepository,
+			sf.repositoryFactory.SubscriptionRepository,
+			sf.repositoryFactory.Blobs,
+			maxSize,
+		)
+		return nil
+	}
+}
+
+// WithAttachmentService must come after the subscription service, which then deletes attachments with their subscription.
+func WithAttachmentService(maxSize int64) ServiceConfiguration {
+	return func(sf *ServiceFactory) error {
+		sf.AttachmentService = service.NewAttachmentService(
+			sf.repositoryFactory.AttachmentRepository,
+			sf.repositoryFactory.SubscriptionRepository,
+			sf.repositoryFactory.Blobs,
+			maxSize,
+		)
+
+		if sf.SubscriptionService != nil {
+			sf.SubscriptionService.SetAttachmentService(sf.AttachmentService)
+		}
+
+		return nil
+	}
+}
+
+// WithCSVService must come after the category, currency, cycle and subscription services.
+func WithCSVService() ServiceConfiguration {
+	return func(sf *ServiceFactory) error {
+		sf.CSVService = service.NewCSVService(sf.CategoryService, sf.CurrencyService, sf.CycleService, sf.SubscriptionService)
+		return nil
+	}
+}
+
+// WithStatementService must come after the currency, cycle and subscription services.
+func WithStatementService() ServiceConfiguration {
+	return func(sf *ServiceFactory) error {
+		sf.StatementService = service.NewStatementService(sf.CurrencyService, sf.CycleService, sf.SubscriptionService)
+		return nil
+	}
+}
@@ -0,0 +1,176 @@
+package health
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"git.home/alex/go-subscriptions/internal/version"
+)
+
+// Status values of the health+json draft (draft-inadarei-api-health-check).
+type Status string
+
+const (
+	StatusPass Status = "pass"
+	StatusWarn Status = "warn"
+	StatusFail Status = "fail"
+)
+
+const defaultTimeout = 2 * time.Second
+
+// CheckFunc reports a dependency as healthy by returning nil.
+type CheckFunc func(ctx context.Context) error
+
+// Registry holds the readiness checks. Components, e.g. the storage backend, register their
+// checks at startup; Run executes all of them concurrently.
+type Registry struct {
+	mu      sync.RWMutex
+	checks  []check
+	timeout time.Duration
+}
+
+type check struct {
+	component     string
+	componentType string
+	critical      bool
+	fn            CheckFunc
+}
+
+type Configuration func(r *Registry) error
+
+// Result is the response body of the readiness endpoint.
+type Result struct {
+	Status  Status                   `json:"status"`
+	Version string                   `json:"version"`
+	Checks  map[string][]CheckResult `json:"checks,omitempty"`
+}
+
+type CheckResult struct {
+	ComponentType string    `json:"componentType,omitempty"`
+	ObservedValue float64   `json:"observedValue"`
+	ObservedUnit  string    `json:"observedUnit"`
+	Status        Status    `json:"status"`
+	Time          time.Time `json:"time"`
+	Output        string    `json:"output,omitempty"`
+}
+
+func NewRegistry(cfgs ...Configuration) (*Registry, error) {
+	r := &Registry{timeout: defaultTimeout}
+
+	// Apply all Configurations passed in
+	for _, cfg := range cfgs {
+		err := cfg(r)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return r, nil
+}
+
+// WithTimeout limits how long a single check may take before it counts as failed.
+func WithTimeout(timeout time.Duration) Configuration {
+	return func(r *Registry) error {
+		r.timeout = timeout
+		return nil
+	}
+}
+
+// Register adds a check whose failure makes the service not ready.
+func (r *Registry) Register(component, componentType string, fn CheckFunc) {
+	r.add(check{component: component, componentType: componentType, critical: true, fn: fn})
+}
+
+// RegisterOptional adds a check whose failure only degrades the status to warn.
+func (r *Registry) RegisterOptional(component, componentType string, fn CheckFunc) {
+	r.add(check{component: component, componentType: componentType, fn: fn})
+}
+
+func (r *Registry) add(c check) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.checks = append(r.checks, c)
+}
+
+// Run executes every check and aggregates the results. The response time of every check is
+// reported under "<component>:responseTime" in milliseconds.
+func (r *Registry) Run(ctx context.Context) Result {
+	r.mu.RLock()
+	checks := append([]check(nil), r.checks...)
+	r.mu.RUnlock()
+
+	results := make([]CheckResult, len(checks))
+
+	var wg sync.WaitGroup
+	for i, c := range checks {
+		wg.Add(1)
+
+		go func(i int, c check) {
+			defer wg.Done()
+			results[i] = r.run(ctx, c)
+		}(i, c)
+	}
+
+	wg.Wait()
+
+	result := Result{Status: StatusPass, Version: version.Version, Checks: make(map[string][]CheckResult)}
+
+	for i, c := range checks {
+		key := c.component + ":responseTime"
+		result.Checks[key] = append(result.Checks[key], results[i])
+		result.Status = worst(result.Status, results[i].Status)
+	}
+
+	return result
+}
+
+func (r *Registry) run(ctx context.Context, c check) CheckResult {
+	ctx, cancel := context.WithTimeout(ctx, r.timeout)
+	defer cancel()
+
+	start := time.Now()
+	done := make(chan error, 1)
+
+	go func() { done <- c.fn(ctx) }()
+
+	var err error
+	select {
+	case err = <-done:
+	case <-ctx.Done():
+		// The check ignores its context, don't wait for it.
+		err = ctx.Err()
+	}
+
+	result := CheckResult{
+		ComponentType: c.componentType,
+		ObservedValue: float64(time.Since(start).Microseconds()) / 1000,
+		ObservedUnit:  "ms",
+		Status:        StatusPass,
+		Time:          start.UTC(),
+	}
+
+	if err != nil {
+		result.Status = StatusWarn
+		if c.critical {
+			result.Status = StatusFail
+		}
+
+		result.Output = err.Error()
+	}
+
+	return result
+}
+
+func worst(a, b Status) Status {
+	if a == StatusFail || b == StatusFail {
+		return StatusFail
+	}
+
+	if a == StatusWarn || b == StatusWarn {
+		return StatusWarn
+	}
+
+	return StatusPass
+}
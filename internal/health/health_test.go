@@ -0,0 +1,88 @@
+package health_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"git.home/alex/go-subscriptions/internal/health"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRegistry_Run(t *testing.T) {
+	pass := func(context.Context) error { return nil }
+	fail := func(context.Context) error { return errors.New("connection refused") }
+	hang := func(context.Context) error {
+		time.Sleep(time.Second)
+		return nil
+	}
+
+	testCases := []struct {
+		name       string
+		register   func(r *health.Registry)
+		wantStatus health.Status
+		wantOutput map[string]string
+	}{
+		{
+			name:       "no checks",
+			register:   func(*health.Registry) {},
+			wantStatus: health.StatusPass,
+		},
+		{
+			name: "all checks pass",
+			register: func(r *health.Registry) {
+				r.Register("storage", "datastore", pass)
+				r.RegisterOptional("notifier", "system", pass)
+			},
+			wantStatus: health.StatusPass,
+			wantOutput: map[string]string{"storage:responseTime": "", "notifier:responseTime": ""},
+		},
+		{
+			name: "optional check fails",
+			register: func(r *health.Registry) {
+				r.Register("storage", "datastore", pass)
+				r.RegisterOptional("notifier", "system", fail)
+			},
+			wantStatus: health.StatusWarn,
+			wantOutput: map[string]string{"storage:responseTime": "", "notifier:responseTime": "connection refused"},
+		},
+		{
+			name: "critical check fails",
+			register: func(r *health.Registry) {
+				r.Register("storage", "datastore", fail)
+				r.RegisterOptional("notifier", "system", fail)
+			},
+			wantStatus: health.StatusFail,
+			wantOutput: map[string]string{"storage:responseTime": "connection refused", "notifier:responseTime": "connection refused"},
+		},
+		{
+			name: "check times out",
+			register: func(r *health.Registry) {
+				r.Register("storage", "datastore", hang)
+			},
+			wantStatus: health.StatusFail,
+			wantOutput: map[string]string{"storage:responseTime": context.DeadlineExceeded.Error()},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			r, err := health.NewRegistry(health.WithTimeout(50 * time.Millisecond))
+			require.NoError(t, err)
+
+			tc.register(r)
+			result := r.Run(context.Background())
+
+			assert.Equal(t, tc.wantStatus, result.Status)
+			assert.Len(t, result.Checks, len(tc.wantOutput))
+
+			for key, output := range tc.wantOutput {
+				require.Len(t, result.Checks[key], 1)
+				assert.Equal(t, output, result.Checks[key][0].Output)
+				assert.Equal(t, "ms", result.Checks[key][0].ObservedUnit)
+			}
+		})
+	}
+}
@@ -0,0 +1,39 @@
+package metrics_test
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"git.home/alex/go-subscriptions/internal/domain/entity"
+	"git.home/alex/go-subscriptions/internal/metrics"
+	"git.home/alex/go-subscriptions/internal/repository/memory"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSubscriptionCollector(t *testing.T) {
+	ctx := context.Background()
+	repo := memory.NewSubscriptionRepository()
+	monthly := entity.Cycle{ID: 1, Name: "Monthly", Days: 30}
+	yearly := entity.Cycle{ID: 2, Name: "Yearly", Days: 365}
+
+	for _, s := range []entity.Subscription{
+		{Name: "Music", Price: 10, Cycle: monthly, Currency: entity.Currency{Code: "USD"}},
+		{Name: "Video", Price: 5, Cycle: monthly, Currency: entity.Currency{Code: "USD"}},
+		{Name: "Storage", Price: 120, Cycle: yearly, Currency: entity.Currency{Code: "EUR"}},
+	} {
+		_, err := repo.Create(ctx, s)
+		require.NoError(t, err)
+	}
+
+	c := metrics.NewSubscriptionCollector(ctx, repo)
+
+	assert.Equal(t, 3, testutil.CollectAndCount(c))
+	assert.NoError(t, testutil.CollectAndCompare(c, strings.NewReader(`
+# HELP subscriptions_active_subscriptions Number of active subscriptions.
+# TYPE subscriptions_active_subscriptions gauge
+subscriptions_active_subscriptions 3
+`), "subscriptions_active_subscriptions"))
+}
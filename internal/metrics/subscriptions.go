@@ -0,0 +1,67 @@
+package metrics
+
+import (
+	"context"
+
+	"git.home/alex/go-subscriptions/internal/clock"
+	"git.home/alex/go-subscriptions/internal/domain/repository"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// SubscriptionCollector computes the business gauges from the repository on every scrape.
+type SubscriptionCollector struct {
+	ctx          context.Context
+	repository   repository.SubscriptionRepository
+	active       *prometheus.Desc
+	monthlySpend *prometheus.Desc
+}
+
+func NewSubscriptionCollector(ctx context.Context, r repository.SubscriptionRepository) *SubscriptionCollector {
+	return &SubscriptionCollector{
+		ctx:        ctx,
+		repository: r,
+		active: prometheus.NewDesc(
+			prometheus.BuildFQName(namespace, "", "active_subscriptions"),
+			"Number of active subscriptions.",
+			nil, nil,
+		),
+		monthlySpend: prometheus.NewDesc(
+			prometheus.BuildFQName(namespace, "", "monthly_spend"),
+			"Price of the active subscriptions normalized to a month, by currency.",
+			[]string{"currency"}, nil,
+		),
+	}
+}
+
+func (c *SubscriptionCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- c.active
+	ch <- c.monthlySpend
+}
+
+func (c *SubscriptionCollector) Collect(ch chan<- prometheus.Metric) {
+	subscriptions, err := c.repository.GetAll(c.ctx)
+	if err != nil {
+		ch <- prometheus.NewInvalidMetric(c.active, err)
+		return
+	}
+
+	// Dates are compared as calendar days, like the handlers do.
+	now := clock.Today(c.ctx)
+	active := 0
+	spend := make(map[string]float64)
+
+	for _, s := range subscriptions {
+		if !s.IsActive(now) {
+			continue
+		}
+
+		active++
+		spend[s.Currency.Code] += s.MonthlyCost(now)
+	}
+
+	ch <- prometheus.MustNewConstMetric(c.active, prometheus.GaugeValue, float64(active))
+
+	for code, value := range spend {
+		ch <- prometheus.MustNewConstMetric(c.monthlySpend, prometheus.GaugeValue, value, code)
+	}
+}
@@ -0,0 +1,464 @@
+package migration
+
+import (
+	"context"
+	"crypto/rand"
+	"database/sql"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"regexp"
+	"sync"
+	"time"
+)
+
+const (
+	defaultTable          = "schema_migrations"
+	defaultLockTimeout    = 30 * time.Second
+	defaultStaleLockAfter = 10 * time.Minute
+	lockRetryInterval     = 100 * time.Millisecond
+)
+
+var tableName = regexp.MustCompile(`^[A-Za-z_]\w*$`)
+
+// Migrator applies migrations to a SQL database. The applied versions and their checksums are
+// recorded in a migrations table; a lock table keeps two processes from migrating at once.
+// The statements use "?" placeholders.
+//
+// The lock is refreshed while the migrations run, so only the lock of a crashed process goes stale,
+// and every migration is recorded in the same transaction that confirms the lock is still held.
+type Migrator struct {
+	db             *sql.DB
+	migrations     []Migration
+	table          string
+	lockTimeout    time.Duration
+	staleLockAfter time.Duration
+}
+
+type Configuration func(m *Migrator) error
+
+type Status struct {
+	Version   uint
+	Name      string
+	Applied   bool
+	AppliedAt time.Time
+}
+
+type appliedMigration struct {
+	name      string
+	checksum  string
+	appliedAt time.Time
+}
+
+func New(db *sql.DB, fsys fs.FS, cfgs ...Configuration) (*Migrator, error) {
+	migrations, err := Load(fsys)
+	if err != nil {
+		return nil, err
+	}
+
+	m := &Migrator{
+		db:             db,
+		migrations:     migrations,
+		table:          defaultTable,
+		lockTimeout:    defaultLockTimeout,
+		staleLockAfter: defaultStaleLockAfter,
+	}
+
+	for _, cfg := range cfgs {
+		err := cfg(m)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return m, nil
+}
+
+func WithTable(name string) Configuration {
+	return func(m *Migrator) error {
+		if !tableName.MatchString(name) {
+			return fmt.Errorf("%w: table name %q", ErrInvalidMigration, name)
+		}
+
+		m.table = name
+		return nil
+	}
+}
+
+// WithLockTimeout sets how long to wait for another process to release the lock.
+func WithLockTimeout(timeout time.Duration) Configuration {
+	return func(m *Migrator) error {
+		m.lockTimeout = timeout
+		return nil
+	}
+}
+
+// WithStaleLockAfter sets the age after which a lock left by a crashed process is taken over.
+// A running migrator refreshes its lock three times within that age.
+func WithStaleLockAfter(d time.Duration) Configuration {
+	return func(m *Migrator) error {
+		m.staleLockAfter = d
+		return nil
+	}
+}
+
+// Up applies every pending migration in order and returns the applied ones.
+func (m *Migrator) Up(ctx context.Context) ([]Migration, error) {
+	var done []Migration
+
+	err := m.locked(ctx, func(ctx context.Context, l *lease, applied map[uint]appliedMigration) error {
+		for _, migration := range m.migrations {
+			if _, ok := applied[migration.Version]; ok {
+				continue
+			}
+
+			err := m.apply(ctx, l, migration)
+			if err != nil {
+				return err
+			}
+
+			done = append(done, migration)
+		}
+
+		return nil
+	})
+
+	return done, err
+}
+
+// Down rolls back the last steps applied migrations, newest first.
+func (m *Migrator) Down(ctx context.Context, steps int) ([]Migration, error) {
+	var done []Migration
+
+	err := m.locked(ctx, func(ctx context.Context, l *lease, applied map[uint]appliedMigration) error {
+		for i := len(m.migrations) - 1; i >= 0 && len(done) < steps; i-- {
+			migration := m.migrations[i]
+			if _, ok := applied[migration.Version]; !ok {
+				continue
+			}
+
+			err := m.revert(ctx, l, migration)
+			if err != nil {
+				return err
+			}
+
+			done = append(done, migration)
+		}
+
+		if len(done) == 0 {
+			return ErrNothingToRollback
+		}
+
+		return nil
+	})
+
+	return done, err
+}
+
+// Status lists every known migration and whether it is applied.
+func (m *Migrator) Status(ctx context.Context) ([]Status, error) {
+	err := m.ensureTables(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	applied, err := m.applied(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	err = m.verify(applied)
+	if err != nil {
+		return nil, err
+	}
+
+	statuses := make([]Status, 0, len(m.migrations))
+	for _, migration := range m.migrations {
+		a, ok := applied[migration.Version]
+		statuses = append(statuses, Status{
+			Version:   migration.Version,
+			Name:      migration.Name,
+			Applied:   ok,
+			AppliedAt: a.appliedAt,
+		})
+	}
+
+	return statuses, nil
+}
+
+// locked runs fn holding the lock, after checking the applied migrations against the files.
+// The context passed to fn is canceled when the lock is lost.
+func (m *Migrator) locked(
+	ctx context.Context,
+	fn func(ctx context.Context, l *lease, applied map[uint]appliedMigration) error,
+) error {
+	err := m.ensureTables(ctx)
+	if err != nil {
+		return err
+	}
+
+	l, err := m.lock(ctx)
+	if err != nil {
+		return err
+	}
+
+	defer l.release()
+
+	ctx, cancel := context.WithCancelCause(ctx)
+
+	var wg sync.WaitGroup
+
+	wg.Add(1)
+
+	go func() {
+		defer wg.Done()
+		l.keepAlive(ctx, cancel, max(m.staleLockAfter/3, lockRetryInterval))
+	}()
+
+	defer func() {
+		cancel(nil)
+		wg.Wait()
+	}()
+
+	applied, err := m.applied(ctx)
+	if err != nil {
+		return err
+	}
+
+	err = m.verify(applied)
+	if err != nil {
+		return err
+	}
+
+	err = fn(ctx, l, applied)
+	if err != nil && errors.Is(context.Cause(ctx), ErrLockLost) {
+		return fmt.Errorf("%w: %w", ErrLockLost, err)
+	}
+
+	return err
+}
+
+func (m *Migrator) ensureTables(ctx context.Context) error {
+	_, err := m.db.ExecContext(ctx, `CREATE TABLE IF NOT EXISTS `+m.table+` (
+		version INTEGER PRIMARY KEY,
+		name TEXT NOT NULL,
+		checksum TEXT NOT NULL,
+		applied_at TEXT NOT NULL
+	)`)
+	if err != nil {
+		return err
+	}
+
+	_, err = m.db.ExecContext(ctx, `CREATE TABLE IF NOT EXISTS `+m.table+`_lock (
+		id INTEGER PRIMARY KEY,
+		owner TEXT NOT NULL,
+		locked_at INTEGER NOT NULL
+	)`)
+
+	return err
+}
+
+func (m *Migrator) lock(ctx context.Context) (*lease, error) {
+	token := make([]byte, 8)
+
+	_, err := rand.Read(token)
+	if err != nil {
+		return nil, err
+	}
+
+	owner := fmt.Sprintf("pid %d %s", os.Getpid(), hex.EncodeToString(token))
+	if host, err := os.Hostname(); err == nil {
+		owner = host + " " + owner
+	}
+
+	deadline := time.Now().Add(m.lockTimeout)
+
+	for {
+		now := time.Now()
+
+		// locked_at is in unix nanoseconds, which compare in time order. The cast also reads a lock left
+		// by an older version, which stored an RFC 3339 timestamp, as its year and so as stale.
+		_, err := m.db.ExecContext(ctx, `DELETE FROM `+m.table+`_lock WHERE CAST(locked_at AS INTEGER) < ?`,
+			now.Add(-m.staleLockAfter).UnixNano())
+		if err != nil {
+			return nil, err
+		}
+
+		_, err = m.db.ExecContext(ctx, `INSERT INTO `+m.table+`_lock (id, owner, locked_at) VALUES (1, ?, ?)`,
+			owner, now.UnixNano())
+		if err == nil {
+			return &lease{db: m.db, table: m.table + "_lock", owner: owner}, nil
+		}
+
+		if time.Now().After(deadline) {
+			return nil, fmt.Errorf("%w: %w", ErrLocked, err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-time.After(lockRetryInterval):
+		}
+	}
+}
+
+// lease is the lock row taken by one Up or Down. Only the owner refreshes or deletes it.
+type lease struct {
+	db    *sql.DB
+	table string
+	owner string
+}
+
+type execer interface {
+	ExecContext(ctx context.Context, query string, args ...any) (sql.Result, error)
+}
+
+// refresh moves locked_at forward, or returns ErrLockLost when another process took the lock over.
+func (l *lease) refresh(ctx context.Context, db execer) error {
+	result, err := db.ExecContext(ctx, `UPDATE `+l.table+` SET locked_at = ? WHERE id = 1 AND owner = ?`,
+		time.Now().UnixNano(), l.owner)
+	if err != nil {
+		return err
+	}
+
+	n, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if n == 0 {
+		return ErrLockLost
+	}
+
+	return nil
+}
+
+// keepAlive refreshes the lock every interval until ctx is done. A lost lock cancels ctx.
+func (l *lease) keepAlive(ctx context.Context, cancel context.CancelCauseFunc, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			// Other errors are retried on the next tick.
+			if err := l.refresh(ctx, l.db); errors.Is(err, ErrLockLost) {
+				cancel(err)
+				return
+			}
+		}
+	}
+}
+
+func (l *lease) release() {
+	// The caller's context may be done already, the lock must be released anyway.
+	_, _ = l.db.ExecContext(context.Background(), `DELETE FROM `+l.table+` WHERE id = 1 AND owner = ?`, l.owner)
+}
+
+func (m *Migrator) applied(ctx context.Context) (map[uint]appliedMigration, error) {
+	rows, err := m.db.QueryContext(ctx, `SELECT version, name, checksum, applied_at FROM `+m.table)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	applied := make(map[uint]appliedMigration)
+
+	for rows.Next() {
+		var (
+			version   uint
+			a         appliedMigration
+			appliedAt string
+		)
+
+		err = rows.Scan(&version, &a.name, &a.checksum, &appliedAt)
+		if err != nil {
+			return nil, err
+		}
+
+		a.appliedAt, _ = time.Parse(time.RFC3339Nano, appliedAt)
+		applied[version] = a
+	}
+
+	return applied, rows.Err()
+}
+
+func (m *Migrator) verify(applied map[uint]appliedMigration) error {
+	known := make(map[uint]Migration, len(m.migrations))
+	for _, migration := range m.migrations {
+		known[migration.Version] = migration
+	}
+
+	for version, a := range applied {
+		migration, ok := known[version]
+		if !ok {
+			return fmt.Errorf("%w: %04d_%s", ErrUnknownMigration, version, a.name)
+		}
+
+		if migration.Checksum != a.checksum {
+			return fmt.Errorf("%w: %04d_%s", ErrChecksumMismatch, version, migration.Name)
+		}
+	}
+
+	return nil
+}
+
+// apply runs the migration and records it in one transaction, so a failing migration leaves no trace.
+func (m *Migrator) apply(ctx context.Context, l *lease, migration Migration) error {
+	return m.inTx(ctx, func(tx *sql.Tx) error {
+		err := l.refresh(ctx, tx)
+		if err != nil {
+			return err
+		}
+
+		_, err = tx.ExecContext(ctx, migration.Up)
+		if err != nil {
+			return fmt.Errorf("apply %04d_%s: %w", migration.Version, migration.Name, err)
+		}
+
+		_, err = tx.ExecContext(ctx, `INSERT INTO `+m.table+` (version, name, checksum, applied_at) VALUES (?, ?, ?, ?)`,
+			migration.Version, migration.Name, migration.Checksum, time.Now().UTC().Format(time.RFC3339Nano))
+
+		return err
+	})
+}
+
+func (m *Migrator) revert(ctx context.Context, l *lease, migration Migration) error {
+	if migration.Down == "" {
+		return fmt.Errorf("%w: %04d_%s has no down file", ErrInvalidMigration, migration.Version, migration.Name)
+	}
+
+	return m.inTx(ctx, func(tx *sql.Tx) error {
+		err := l.refresh(ctx, tx)
+		if err != nil {
+			return err
+		}
+
+		_, err = tx.ExecContext(ctx, migration.Down)
+		if err != nil {
+			return fmt.Errorf("roll back %04d_%s: %w", migration.Version, migration.Name, err)
+		}
+
+		_, err = tx.ExecContext(ctx, `DELETE FROM `+m.table+` WHERE version = ?`, migration.Version)
+
+		return err
+	})
+}
+
+func (m *Migrator) inTx(ctx context.Context, fn func(tx *sql.Tx) error) error {
+	tx, err := m.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+
+	err = fn(tx)
+	if err != nil {
+		return errors.Join(err, tx.Rollback())
+	}
+
+	return tx.Commit()
+}
@@ -0,0 +1,250 @@
+package migration_test
+
+import (
+	"context"
+	"database/sql"
+	"path/filepath"
+	"testing"
+	"testing/fstest"
+	"time"
+
+	"git.home/alex/go-subscriptions/internal/migration"
+	"git.home/alex/go-subscriptions/internal/repository/sqlite"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var testMigrations = fstest.MapFS{
+	"0001_create_categories.up.sql":   {Data: []byte("CREATE TABLE categories (id INTEGER PRIMARY KEY, name TEXT NOT NULL);")},
+	"0001_create_categories.down.sql": {Data: []byte("DROP TABLE categories;")},
+	"0002_add_color.up.sql":           {Data: []byte("ALTER TABLE categories ADD COLUMN color TEXT NOT NULL DEFAULT '';")},
+	"0002_add_color.down.sql":         {Data: []byte("ALTER TABLE categories DROP COLUMN color;")},
+	"README.md":                       {Data: []byte("ignored")},
+}
+
+func openDB(t *testing.T) *sql.DB {
+	t.Helper()
+
+	db, err := sqlite.Open(filepath.Join(t.TempDir(), "test.db"))
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = db.Close() })
+
+	return db
+}
+
+func newMigrator(t *testing.T, db *sql.DB, fsys fstest.MapFS, cfgs ...migration.Configuration) *migration.Migrator {
+	t.Helper()
+
+	m, err := migration.New(db, fsys, cfgs...)
+	require.NoError(t, err)
+
+	return m
+}
+
+func appliedVersions(t *testing.T, m *migration.Migrator) []uint {
+	t.Helper()
+
+	statuses, err := m.Status(context.Background())
+	require.NoError(t, err)
+
+	var versions []uint
+	for _, s := range statuses {
+		if s.Applied {
+			versions = append(versions, s.Version)
+		}
+	}
+
+	return versions
+}
+
+func TestMigrator_UpDown(t *testing.T) {
+	ctx := context.Background()
+	db := openDB(t)
+	m := newMigrator(t, db, testMigrations)
+
+	assert.Empty(t, appliedVersions(t, m))
+
+	applied, err := m.Up(ctx)
+	require.NoError(t, err)
+	assert.Len(t, applied, 2)
+	assert.Equal(t, []uint{1, 2}, appliedVersions(t, m))
+
+	_, err = db.ExecContext(ctx, "INSERT INTO categories (name, color) VALUES ('Music', 'red')")
+	require.NoError(t, err)
+
+	applied, err = m.Up(ctx)
+	require.NoError(t, err)
+	assert.Empty(t, applied)
+
+	reverted, err := m.Down(ctx, 1)
+	require.NoError(t, err)
+	require.Len(t, reverted, 1)
+	assert.Equal(t, "add_color", reverted[0].Name)
+	assert.Equal(t, []uint{1}, appliedVersions(t, m))
+
+	_, err = m.Down(ctx, 5)
+	require.NoError(t, err)
+	assert.Empty(t, appliedVersions(t, m))
+
+	_, err = m.Down(ctx, 1)
+	assert.ErrorIs(t, err, migration.ErrNothingToRollback)
+}
+
+func TestMigrator_FailedMigrationIsRolledBack(t *testing.T) {
+	ctx := context.Background()
+	db := openDB(t)
+
+	fsys := fstest.MapFS{
+		"0001_create_categories.up.sql": testMigrations["0001_create_categories.up.sql"],
+		"0002_broken.up.sql":            {Data: []byte("CREATE TABLE tags (id INTEGER PRIMARY KEY); SELECT * FROM missing;")},
+	}
+	m := newMigrator(t, db, fsys)
+
+	_, err := m.Up(ctx)
+	require.Error(t, err)
+	assert.Equal(t, []uint{1}, appliedVersions(t, m))
+
+	var count int
+	require.NoError(t, db.QueryRowContext(ctx, "SELECT count(*) FROM sqlite_master WHERE name = 'tags'").Scan(&count))
+	assert.Zero(t, count)
+
+	// The lock is released after a failure.
+	_, err = m.Up(ctx)
+	assert.NotErrorIs(t, err, migration.ErrLocked)
+}
+
+func TestMigrator_Verify(t *testing.T) {
+	ctx := context.Background()
+
+	testCases := []struct {
+		name    string
+		fsys    fstest.MapFS
+		wantErr error
+	}{
+		{
+			name: "Modified migration",
+			fsys: fstest.MapFS{
+				"0001_create_categories.up.sql": {Data: []byte("CREATE TABLE categories (id INTEGER PRIMARY KEY);")},
+				"0002_add_color.up.sql":         testMigrations["0002_add_color.up.sql"],
+			},
+			wantErr: migration.ErrChecksumMismatch,
+		},
+		{
+			name: "Missing migration",
+			fsys: fstest.MapFS{
+				"0001_create_categories.up.sql": testMigrations["0001_create_categories.up.sql"],
+			},
+			wantErr: migration.ErrUnknownMigration,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			db := openDB(t)
+
+			_, err := newMigrator(t, db, testMigrations).Up(ctx)
+			require.NoError(t, err)
+
+			m := newMigrator(t, db, tc.fsys)
+
+			_, err = m.Up(ctx)
+			assert.ErrorIs(t, err, tc.wantErr)
+
+			_, err = m.Status(ctx)
+			assert.ErrorIs(t, err, tc.wantErr)
+		})
+	}
+}
+
+func TestMigrator_Lock(t *testing.T) {
+	ctx := context.Background()
+	db := openDB(t)
+
+	m := newMigrator(t, db, testMigrations, migration.WithLockTimeout(200*time.Millisecond))
+	_, err := m.Status(ctx)
+	require.NoError(t, err)
+
+	_, err = db.ExecContext(ctx, "INSERT INTO schema_migrations_lock (id, owner, locked_at) VALUES (1, 'other', ?)",
+		time.Now().UnixNano())
+	require.NoError(t, err)
+
+	_, err = m.Up(ctx)
+	assert.ErrorIs(t, err, migration.ErrLocked)
+
+	stale := newMigrator(t, db, testMigrations, migration.WithStaleLockAfter(time.Nanosecond))
+
+	_, err = stale.Up(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, []uint{1, 2}, appliedVersions(t, m))
+}
+
+func TestMigrator_LegacyLock(t *testing.T) {
+	ctx := context.Background()
+	db := openDB(t)
+
+	m := newMigrator(t, db, testMigrations, migration.WithLockTimeout(200*time.Millisecond))
+	_, err := m.Status(ctx)
+	require.NoError(t, err)
+
+	// Older versions stored locked_at as RFC 3339 text; such a lock is older than any live one.
+	_, err = db.ExecContext(ctx, "INSERT INTO schema_migrations_lock (id, owner, locked_at) VALUES (1, 'other', ?)",
+		time.Now().UTC().Format(time.RFC3339Nano))
+	require.NoError(t, err)
+
+	_, err = m.Up(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, []uint{1, 2}, appliedVersions(t, m))
+}
+
+func TestMigrator_LockLost(t *testing.T) {
+	ctx := context.Background()
+	db := openDB(t)
+
+	m := newMigrator(t, db, testMigrations)
+	_, err := m.Status(ctx)
+	require.NoError(t, err)
+
+	// Another process takes the lock over once the first migration is recorded.
+	_, err = db.ExecContext(ctx, `CREATE TRIGGER take_over AFTER INSERT ON schema_migrations
+		BEGIN UPDATE schema_migrations_lock SET owner = 'other'; END`)
+	require.NoError(t, err)
+
+	done, err := m.Up(ctx)
+	assert.ErrorIs(t, err, migration.ErrLockLost)
+	assert.Len(t, done, 1)
+	assert.Equal(t, []uint{1}, appliedVersions(t, m))
+
+	var owner string
+	require.NoError(t, db.QueryRowContext(ctx, "SELECT owner FROM schema_migrations_lock WHERE id = 1").Scan(&owner))
+	assert.Equal(t, "other", owner)
+}
+
+func TestLoad_Errors(t *testing.T) {
+	testCases := []struct {
+		name string
+		fsys fstest.MapFS
+	}{
+		{
+			name: "Without up file",
+			fsys: fstest.MapFS{"0001_init.down.sql": {Data: []byte("DROP TABLE a;")}},
+		},
+		{
+			name: "Duplicate version",
+			fsys: fstest.MapFS{
+				"0001_init.up.sql":  {Data: []byte("CREATE TABLE a (id INTEGER);")},
+				"0001_other.up.sql": {Data: []byte("CREATE TABLE b (id INTEGER);")},
+			},
+		},
+		{
+			name: "Version zero",
+			fsys: fstest.MapFS{"0000_init.up.sql": {Data: []byte("CREATE TABLE a (id INTEGER);")}},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := migration.Load(tc.fsys)
+			assert.ErrorIs(t, err, migration.ErrInvalidMigration)
+		})
+	}
+}
@@ -0,0 +1,48 @@
+package instrumented
+
+import (
+	"context"
+	"time"
+
+	"git.home/alex/go-subscriptions/internal/domain/entity"
+	"git.home/alex/go-subscriptions/internal/domain/repository"
+)
+
+type AssetRepository struct {
+	next repository.AssetRepository
+	observer
+}
+
+func NewAssetRepository(next repository.AssetRepository, o Observer, backend string) *AssetRepository {
+	return &AssetRepository{next: next, observer: observer{Observer: o, backend: backend, repository: "asset"}}
+}
+
+func (r *AssetRepository) Create(ctx context.Context, asset entity.Asset) (_ *entity.Asset, err error) {
+	defer r.observe("create", time.Now(), &err)
+
+	return r.next.Create(ctx, asset)
+}
+
+func (r *AssetRepository) Get(ctx context.Context, id uint) (_ *entity.Asset, err error) {
+	defer r.observe("get", time.Now(), &err)
+
+	return r.next.Get(ctx, id)
+}
+
+func (r *AssetRepository) GetAll(ctx context.Context) (_ repository.Assets, err error) {
+	defer r.observe("get_all", time.Now(), &err)
+
+	return r.next.GetAll(ctx)
+}
+
+func (r *AssetRepository) Delete(ctx context.Context, id uint) (err error) {
+	defer r.observe("delete", time.Now(), &err)
+
+	return r.next.Delete(ctx, id)
+}
+
+func (r *AssetRepository) Restore(ctx context.Context, asset entity.Asset) (_ *entity.Asset, err error) {
+	defer r.observe("restore", time.Now(), &err)
+
+	return r.next.Restore(ctx, asset)
+}
@@ -0,0 +1,48 @@
+package instrumented
+
+import (
+	"context"
+	"time"
+
+	"git.home/alex/go-subscriptions/internal/domain/entity"
+	"git.home/alex/go-subscriptions/internal/domain/repository"
+)
+
+type AttachmentRepository struct {
+	next repository.AttachmentRepository
+	observer
+}
+
+func NewAttachmentRepository(next repository.AttachmentRepository, o Observer, backend string) *AttachmentRepository {
+	return &AttachmentRepository{next: next, observer: observer{Observer: o, backend: backend, repository: "attachment"}}
+}
+
+func (r *AttachmentRepository) Create(ctx context.Context, attachment entity.Attachment) (_ *entity.Attachment, err error) {
+	defer r.observe("create", time.Now(), &err)
+
+	return r.next.Create(ctx, attachment)
+}
+
+func (r *AttachmentRepository) Get(ctx context.Context, id uint) (_ *entity.Attachment, err error) {
+	defer r.observe("get", time.Now(), &err)
+
+	return r.next.Get(ctx, id)
+}
+
+func (r *AttachmentRepository) GetAll(ctx context.Context) (_ repository.Attachments, err error) {
+	defer r.observe("get_all", time.Now(), &err)
+
+	return r.next.GetAll(ctx)
+}
+
+func (r *AttachmentRepository) Delete(ctx context.Context, id uint) (err error) {
+	defer r.observe("delete", time.Now(), &err)
+
+	return r.next.Delete(ctx, id)
+}
+
+func (r *AttachmentRepository) Restore(ctx context.Context, attachment entity.Attachment) (_ *entity.Attachment, err error) {
+	defer r.observe("restore", time.Now(), &err)
+
+	return r.next.Restore(ctx, attachment)
+}
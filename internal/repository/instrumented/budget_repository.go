@@ -0,0 +1,54 @@
+package instrumented
+
+import (
+	"context"
+	"time"
+
+	"git.home/alex/go-subscriptions/internal/domain/entity"
+	"git.home/alex/go-subscriptions/internal/domain/repository"
+)
+
+type BudgetRepository struct {
+	next repository.BudgetRepository
+	observer
+}
+
+func NewBudgetRepository(next repository.BudgetRepository, o Observer, backend string) *BudgetRepository {
+	return &BudgetRepository{next: next, observer: observer{Observer: o, backend: backend, repository: "budget"}}
+}
+
+func (r *BudgetRepository) Create(ctx context.Context, budget entity.Budget) (_ *entity.Budget, err error) {
+	defer r.observe("create", time.Now(), &err)
+
+	return r.next.Create(ctx, budget)
+}
+
+func (r *BudgetRepository) Get(ctx context.Context, id uint) (_ *entity.Budget, err error) {
+	defer r.observe("get", time.Now(), &err)
+
+	return r.next.Get(ctx, id)
+}
+
+func (r *BudgetRepository) GetAll(ctx context.Context) (_ repository.Budgets, err error) {
+	defer r.observe("get_all", time.Now(), &err)
+
+	return r.next.GetAll(ctx)
+}
+
+func (r *BudgetRepository) Update(ctx context.Context, budget entity.Budget) (_ *entity.Budget, err error) {
+	defer r.observe("update", time.Now(), &err)
+
+	return r.next.Update(ctx, budget)
+}
+
+func (r *BudgetRepository) Delete(ctx context.Context, id uint) (err error) {
+	defer r.observe("delete", time.Now(), &err)
+
+	return r.next.Delete(ctx, id)
+}
+
+func (r *BudgetRepository) Restore(ctx context.Context, budget entity.Budget) (_ *entity.Budget, err error) {
+	defer r.observe("restore", time.Now(), &err)
+
+	return r.next.Restore(ctx, budget)
+}
@@ -0,0 +1,54 @@
+package instrumented
+
+import (
+	"context"
+	"time"
+
+	"git.home/alex/go-subscriptions/internal/domain/entity"
+	"git.home/alex/go-subscriptions/internal/domain/repository"
+)
+
+type CategoryRepository struct {
+	next repository.CategoryRepository
+	observer
+}
+
+func NewCategoryRepository(next repository.CategoryRepository, o Observer, backend string) *CategoryRepository {
+	return &CategoryRepository{next: next, observer: observer{Observer: o, backend: backend, repository: "category"}}
+}
+
+func (r *CategoryRepository) Create(ctx context.Context, category entity.Category) (_ *entity.Category, err error) {
+	defer r.observe("create", time.Now(), &err)
+
+	return r.next.Create(ctx, category)
+}
+
+func (r *CategoryRepository) Get(ctx context.Context, id uint) (_ *entity.Category, err error) {
+	defer r.observe("get", time.Now(), &err)
+
+	return r.next.Get(ctx, id)
+}
+
+func (r *CategoryRepository) GetAll(ctx context.Context) (_ repository.Categories, err error) {
+	defer r.observe("get_all", time.Now(), &err)
+
+	return r.next.GetAll(ctx)
+}
+
+func (r *CategoryRepository) Update(ctx context.Context, category entity.Category) (_ *entity.Category, err error) {
+	defer r.observe("update", time.Now(), &err)
+
+	return r.next.Update(ctx, category)
+}
+
+func (r *CategoryRepository) Delete(ctx context.Context, id uint) (err error) {
+	defer r.observe("delete", time.Now(), &err)
+
+	return r.next.Delete(ctx, id)
+}
+
+func (r *CategoryRepository) Restore(ctx context.Context, category entity.Category) (_ *entity.Category, err error) {
+	defer r.observe("restore", time.Now(), &err)
+
+	return r.next.Restore(ctx, category)
+}
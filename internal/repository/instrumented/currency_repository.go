@@ -0,0 +1,54 @@
+package instrumented
+
+import (
+	"context"
+	"time"
+
+	"git.home/alex/go-subscriptions/internal/domain/entity"
+	"git.home/alex/go-subscriptions/internal/domain/repository"
+)
+
+type CurrencyRepository struct {
+	next repository.CurrencyRepository
+	observer
+}
+
+func NewCurrencyRepository(next repository.CurrencyRepository, o Observer, backend string) *CurrencyRepository {
+	return &CurrencyRepository{next: next, observer: observer{Observer: o, backend: backend, repository: "currency"}}
+}
+
+func (r *CurrencyRepository) Create(ctx context.Context, currency entity.Currency) (_ *entity.Currency, err error) {
+	defer r.observe("create", time.Now(), &err)
+
+	return r.next.Create(ctx, currency)
+}
+
+func (r *CurrencyRepository) Get(ctx context.Context, code string) (_ *entity.Currency, err error) {
+	defer r.observe("get", time.Now(), &err)
+
+	return r.next.Get(ctx, code)
+}
+
+func (r *CurrencyRepository) GetAll(ctx context.Context) (_ repository.Currencies, err error) {
+	defer r.observe("get_all", time.Now(), &err)
+
+	return r.next.GetAll(ctx)
+}
+
+func (r *CurrencyRepository) Update(ctx context.Context, currency entity.Currency) (_ *entity.Currency, err error) {
+	defer r.observe("update", time.Now(), &err)
+
+	return r.next.Update(ctx, currency)
+}
+
+func (r *CurrencyRepository) Delete(ctx context.Context, code string) (err error) {
+	defer r.observe("delete", time.Now(), &err)
+
+	return r.next.Delete(ctx, code)
+}
+
+func (r *CurrencyRepository) Restore(ctx context.Context, currency entity.Currency) (_ *entity.Currency, err error) {
+	defer r.observe("restore", time.Now(), &err)
+
+	return r.next.Restore(ctx, currency)
+}
@@ -0,0 +1,54 @@
+package instrumented
+
+import (
+	"context"
+	"time"
+
+	"git.home/alex/go-subscriptions/internal/domain/entity"
+	"git.home/alex/go-subscriptions/internal/domain/repository"
+)
+
+type CycleRepository struct {
+	next repository.CycleRepository
+	observer
+}
+
+func NewCycleRepository(next repository.CycleRepository, o Observer, backend string) *CycleRepository {
+	return &CycleRepository{next: next, observer: observer{Observer: o, backend: backend, repository: "cycle"}}
+}
+
+func (r *CycleRepository) Create(ctx context.Context, cycle entity.Cycle) (_ *entity.Cycle, err error) {
+	defer r.observe("create", time.Now(), &err)
+
+	return r.next.Create(ctx, cycle)
+}
+
+func (r *CycleRepository) Get(ctx context.Context, id uint) (_ *entity.Cycle, err error) {
+	defer r.observe("get", time.Now(), &err)
+
+	return r.next.Get(ctx, id)
+}
+
+func (r *CycleRepository) GetAll(ctx context.Context) (_ repository.Cycles, err error) {
+	defer r.observe("get_all", time.Now(), &err)
+
+	return r.next.GetAll(ctx)
+}
+
+func (r *CycleRepository) Update(ctx context.Context, cycle entity.Cycle) (_ *entity.Cycle, err error) {
+	defer r.observe("update", time.Now(), &err)
+
+	return r.next.Update(ctx, cycle)
+}
+
+func (r *CycleRepository) Delete(ctx context.Context, id uint) (err error) {
+	defer r.observe("delete", time.Now(), &err)
+
+	return r.next.Delete(ctx, id)
+}
+
+func (r *CycleRepository) Restore(ctx context.Context, cycle entity.Cycle) (_ *entity.Cycle, err error) {
+	defer r.observe("restore", time.Now(), &err)
+
+	return r.next.Restore(ctx, cycle)
+}
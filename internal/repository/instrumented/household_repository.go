@@ -0,0 +1,54 @@
+package instrumented
+
+import (
+	"context"
+	"time"
+
+	"git.home/alex/go-subscriptions/internal/domain/entity"
+	"git.home/alex/go-subscriptions/internal/domain/repository"
+)
+
+type HouseholdRepository struct {
+	next repository.HouseholdRepository
+	observer
+}
+
+func NewHouseholdRepository(next repository.HouseholdRepository, o Observer, backend string) *HouseholdRepository {
+	return &HouseholdRepository{next: next, observer: observer{Observer: o, backend: backend, repository: "household"}}
+}
+
+func (r *HouseholdRepository) Create(ctx context.Context, household entity.Household) (_ *entity.Household, err error) {
+	defer r.observe("create", time.Now(), &err)
+
+	return r.next.Create(ctx, household)
+}
+
+func (r *HouseholdRepository) Get(ctx context.Context, id uint) (_ *entity.Household, err error) {
+	defer r.observe("get", time.Now(), &err)
+
+	return r.next.Get(ctx, id)
+}
+
+func (r *HouseholdRepository) GetAll(ctx context.Context) (_ repository.Households, err error) {
+	defer r.observe("get_all", time.Now(), &err)
+
+	return r.next.GetAll(ctx)
+}
+
+func (r *HouseholdRepository) Update(ctx context.Context, household entity.Household) (_ *entity.Household, err error) {
+	defer r.observe("update", time.Now(), &err)
+
+	return r.next.Update(ctx, household)
+}
+
+func (r *HouseholdRepository) Delete(ctx context.Context, id uint) (err error) {
+	defer r.observe("delete", time.Now(), &err)
+
+	return r.next.Delete(ctx, id)
+}
+
+func (r *HouseholdRepository) Restore(ctx context.Context, household entity.Household) (_ *entity.Household, err error) {
+	defer r.observe("restore", time.Now(), &err)
+
+	return r.next.Restore(ctx, household)
+}
@@ -0,0 +1,18 @@
+package instrumented
+
+import "time"
+
+// Observer receives the latency and result of every repository operation.
+type Observer interface {
+	ObserveRepository(backend, repository, operation string, d time.Duration, err error)
+}
+
+type observer struct {
+	Observer
+	backend    string
+	repository string
+}
+
+func (o observer) observe(operation string, start time.Time, err *error) {
+	o.ObserveRepository(o.backend, o.repository, operation, time.Since(start), *err)
+}
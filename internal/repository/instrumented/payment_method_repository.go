@@ -0,0 +1,54 @@
+package instrumented
+
+import (
+	"context"
+	"time"
+
+	"git.home/alex/go-subscriptions/internal/domain/entity"
+	"git.home/alex/go-subscriptions/internal/domain/repository"
+)
+
+type PaymentMethodRepository struct {
+	next repository.PaymentMethodRepository
+	observer
+}
+
+func NewPaymentMethodRepository(next repository.PaymentMethodRepository, o Observer, backend string) *PaymentMethodRepository {
+	return &PaymentMethodRepository{next: next, observer: observer{Observer: o, backend: backend, repository: "payment_method"}}
+}
+
+func (r *PaymentMethodRepository) Create(ctx context.Context, paymentMethod entity.PaymentMethod) (_ *entity.PaymentMethod, err error) {
+	defer r.observe("create", time.Now(), &err)
+
+	return r.next.Create(ctx, paymentMethod)
+}
+
+func (r *PaymentMethodRepository) Get(ctx context.Context, id uint) (_ *entity.PaymentMethod, err error) {
+	defer r.observe("get", time.Now(), &err)
+
+	return r.next.Get(ctx, id)
+}
+
+func (r *PaymentMethodRepository) GetAll(ctx context.Context) (_ repository.PaymentMethods, err error) {
+	defer r.observe("get_all", time.Now(), &err)
+
+	return r.next.GetAll(ctx)
+}
+
+func (r *PaymentMethodRepository) Update(ctx context.Context, paymentMethod entity.PaymentMethod) (_ *entity.PaymentMethod, err error) {
+	defer r.observe("update", time.Now(), &err)
+
+	return r.next.Update(ctx, paymentMethod)
+}
+
+func (r *PaymentMethodRepository) Delete(ctx context.Context, id uint) (err error) {
+	defer r.observe("delete", time.Now(), &err)
+
+	return r.next.Delete(ctx, id)
+}
+
+func (r *PaymentMethodRepository) Restore(ctx context.Context, paymentMethod entity.PaymentMethod) (_ *entity.PaymentMethod, err error) {
+	defer r.observe("restore", time.Now(), &err)
+
+	return r.next.Restore(ctx, paymentMethod)
+}
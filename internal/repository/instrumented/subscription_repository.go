@@ -0,0 +1,54 @@
+package instrumented
+
+import (
+	"context"
+	"time"
+
+	"git.home/alex/go-subscriptions/internal/domain/entity"
+	"git.home/alex/go-subscriptions/internal/domain/repository"
+)
+
+type SubscriptionRepository struct {
+	next repository.SubscriptionRepository
+	observer
+}
+
+func NewSubscriptionRepository(next repository.SubscriptionRepository, o Observer, backend string) *SubscriptionRepository {
+	return &SubscriptionRepository{next: next, observer: observer{Observer: o, backend: backend, repository: "subscription"}}
+}
+
+func (r *SubscriptionRepository) Create(ctx context.Context, subscription entity.Subscription) (_ *entity.Subscription, err error) {
+	defer r.observe("create", time.Now(), &err)
+
+	return r.next.Create(ctx, subscription)
+}
+
+func (r *SubscriptionRepository) Get(ctx context.Context, id uint) (_ *entity.Subscription, err error) {
+	defer r.observe("get", time.Now(), &err)
+
+	return r.next.Get(ctx, id)
+}
+
+func (r *SubscriptionRepository) GetAll(ctx context.Context) (_ repository.Subscriptions, err error) {
+	defer r.observe("get_all", time.Now(), &err)
+
+	return r.next.GetAll(ctx)
+}
+
+func (r *SubscriptionRepository) Update(ctx context.Context, subscription entity.Subscription) (_ *entity.Subscription, err error) {
+	defer r.observe("update", time.Now(), &err)
+
+	return r.next.Update(ctx, subscription)
+}
+
+func (r *SubscriptionRepository) Delete(ctx context.Context, id uint) (err error) {
+	defer r.observe("delete", time.Now(), &err)
+
+	return r.next.Delete(ctx, id)
+}
+
+func (r *SubscriptionRepository) Restore(ctx context.Context, subscription entity.Subscription) (_ *entity.Subscription, err error) {
+	defer r.observe("restore", time.Now(), &err)
+
+	return r.next.Restore(ctx, subscription)
+}
@@ -0,0 +1,54 @@
+package instrumented
+
+import (
+	"context"
+	"time"
+
+	"git.home/alex/go-subscriptions/internal/domain/entity"
+	"git.home/alex/go-subscriptions/internal/domain/repository"
+)
+
+type TagRepository struct {
+	next repository.TagRepository
+	observer
+}
+
+func NewTagRepository(next repository.TagRepository, o Observer, backend string) *TagRepository {
+	return &TagRepository{next: next, observer: observer{Observer: o, backend: backend, repository: "tag"}}
+}
+
+func (r *TagRepository) Create(ctx context.Context, tag entity.Tag) (_ *entity.Tag, err error) {
+	defer r.observe("create", time.Now(), &err)
+
+	return r.next.Create(ctx, tag)
+}
+
+func (r *TagRepository) Get(ctx context.Context, id uint) (_ *entity.Tag, err error) {
+	defer r.observe("get", time.Now(), &err)
+
+	return r.next.Get(ctx, id)
+}
+
+func (r *TagRepository) GetAll(ctx context.Context) (_ repository.Tags, err error) {
+	defer r.observe("get_all", time.Now(), &err)
+
+	return r.next.GetAll(ctx)
+}
+
+func (r *TagRepository) Update(ctx context.Context, tag entity.Tag) (_ *entity.Tag, err error) {
+	defer r.observe("update", time.Now(), &err)
+
+	return r.next.Update(ctx, tag)
+}
+
+func (r *TagRepository) Delete(ctx context.Context, id uint) (err error) {
+	defer r.observe("delete", time.Now(), &err)
+
+	return r.next.Delete(ctx, id)
+}
+
+func (r *TagRepository) Restore(ctx context.Context, tag entity.Tag) (_ *entity.Tag, err error) {
+	defer r.observe("restore", time.Now(), &err)
+
+	return r.next.Restore(ctx, tag)
+}
@@ -0,0 +1,60 @@
+package instrumented
+
+import (
+	"context"
+	"time"
+
+	"git.home/alex/go-subscriptions/internal/domain/entity"
+	"git.home/alex/go-subscriptions/internal/domain/repository"
+)
+
+type UserRepository struct {
+	next repository.UserRepository
+	observer
+}
+
+func NewUserRepository(next repository.UserRepository, o Observer, backend string) *UserRepository {
+	return &UserRepository{next: next, observer: observer{Observer: o, backend: backend, repository: "user"}}
+}
+
+func (r *UserRepository) Create(ctx context.Context, user entity.User) (_ *entity.User, err error) {
+	defer r.observe("create", time.Now(), &err)
+
+	return r.next.Create(ctx, user)
+}
+
+func (r *UserRepository) Get(ctx context.Context, id uint) (_ *entity.User, err error) {
+	defer r.observe("get", time.Now(), &err)
+
+	return r.next.Get(ctx, id)
+}
+
+func (r *UserRepository) GetByUsername(ctx context.Context, username string) (_ *entity.User, err error) {
+	defer r.observe("get_by_username", time.Now(), &err)
+
+	return r.next.GetByUsername(ctx, username)
+}
+
+func (r *UserRepository) GetAll(ctx context.Context) (_ repository.Users, err error) {
+	defer r.observe("get_all", time.Now(), &err)
+
+	return r.next.GetAll(ctx)
+}
+
+func (r *UserRepository) Update(ctx context.Context, user entity.User) (_ *entity.User, err error) {
+	defer r.observe("update", time.Now(), &err)
+
+	return r.next.Update(ctx, user)
+}
+
+func (r *UserRepository) Delete(ctx context.Context, id uint) (err error) {
+	defer r.observe("delete", time.Now(), &err)
+
+	return r.next.Delete(ctx, id)
+}
+
+func (r *UserRepository) Restore(ctx context.Context, user entity.User) (_ *entity.User, err error) {
+	defer r.observe("restore", time.Now(), &err)
+
+	return r.next.Restore(ctx, user)
+}
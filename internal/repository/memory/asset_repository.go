@@ -0,0 +1,85 @@
+package memory
+
+import (
+	"context"
+	"sort"
+	"sync"
+
+	"git.home/alex/go-subscriptions/internal/domain/entity"
+	"git.home/alex/go-subscriptions/internal/domain/repository"
+)
+
+type AssetRepository struct {
+	assets map[uint]entity.Asset
+	ids    sequence
+	sync.Mutex
+}
+
+func NewAssetRepository() *AssetRepository {
+	return &AssetRepository{
+		assets: make(map[uint]entity.Asset),
+	}
+}
+
+func (r *AssetRepository) Create(_ context.Context, asset entity.Asset) (*entity.Asset, error) {
+	r.Lock()
+	defer r.Unlock()
+
+	asset.ID = r.ids.next()
+	r.assets[asset.ID] = asset
+
+	return &asset, nil
+}
+
+func (r *AssetRepository) Get(_ context.Context, id uint) (*entity.Asset, error) {
+	r.Lock()
+	defer r.Unlock()
+
+	asset, ok := r.assets[id]
+	if !ok {
+		return nil, repository.ErrNotFoundAsset
+	}
+
+	return &asset, nil
+}
+
+func (r *AssetRepository) GetAll(_ context.Context) (repository.Assets, error) {
+	r.Lock()
+	defer r.Unlock()
+
+	var assets repository.Assets
+	for _, asset := range r.assets {
+		assets = append(assets, asset)
+	}
+
+	sort.Slice(assets, func(i, j int) bool { return assets[i].ID < assets[j].ID })
+
+	return assets, nil
+}
+
+func (r *AssetRepository) Delete(_ context.Context, id uint) error {
+	r.Lock()
+	defer r.Unlock()
+
+	if _, ok := r.assets[id]; !ok {
+		return repository.ErrNotFoundAsset
+	}
+
+	delete(r.assets, id)
+
+	return nil
+}
+
+func (r *AssetRepository) Restore(_ context.Context, asset entity.Asset) (*entity.Asset, error) {
+	r.Lock()
+	defer r.Unlock()
+
+	if asset.ID == 0 {
+		return nil, repository.ErrCreateAsset
+	}
+
+	r.ids.observe(asset.ID)
+	r.assets[asset.ID] = asset
+
+	return &asset, nil
+}
@@ -0,0 +1,85 @@
+package memory
+
+import (
+	"context"
+	"sort"
+	"sync"
+
+	"git.home/alex/go-subscriptions/internal/domain/entity"
+	"git.home/alex/go-subscriptions/internal/domain/repository"
+)
+
+type AttachmentRepository struct {
+	attachments map[uint]entity.Attachment
+	ids         sequence
+	sync.Mutex
+}
+
+func NewAttachmentRepository() *AttachmentRepository {
+	return &AttachmentRepository{
+		attachments: make(map[uint]entity.Attachment),
+	}
+}
+
+func (r *AttachmentRepository) Create(_ context.Context, attachment entity.Attachment) (*entity.Attachment, error) {
+	r.Lock()
+	defer r.Unlock()
+
+	attachment.ID = r.ids.next()
+	r.attachments[attachment.ID] = attachment
+
+	return &attachment, nil
+}
+
+func (r *AttachmentRepository) Get(_ context.Context, id uint) (*entity.Attachment, error) {
+	r.Lock()
+	defer r.Unlock()
+
+	attachment, ok := r.attachments[id]
+	if !ok {
+		return nil, repository.ErrNotFoundAttachment
+	}
+
+	return &attachment, nil
+}
+
+func (r *AttachmentRepository) GetAll(_ context.Context) (repository.Attachments, error) {
+	r.Lock()
+	defer r.Unlock()
+
+	var attachments repository.Attachments
+	for _, attachment := range r.attachments {
+		attachments = append(attachments, attachment)
+	}
+
+	sort.Slice(attachments, func(i, j int) bool { return attachments[i].ID < attachments[j].ID })
+
+	return attachments, nil
+}
+
+func (r *AttachmentRepository) Delete(_ context.Context, id uint) error {
+	r.Lock()
+	defer r.Unlock()
+
+	if _, ok := r.attachments[id]; !ok {
+		return repository.ErrNotFoundAttachment
+	}
+
+	delete(r.attachments, id)
+
+	return nil
+}
+
+func (r *AttachmentRepository) Restore(_ context.Context, attachment entity.Attachment) (*entity.Attachment, error) {
+	r.Lock()
+	defer r.Unlock()
+
+	if attachment.ID == 0 {
+		return nil, repository.ErrCreateAttachment
+	}
+
+	r.ids.observe(attachment.ID)
+	r.attachments[attachment.ID] = attachment
+
+	return &attachment, nil
+}
@@ -0,0 +1,98 @@
+package memory
+
+import (
+	"context"
+	"sort"
+	"sync"
+
+	"git.home/alex/go-subscriptions/internal/domain/entity"
+	"git.home/alex/go-subscriptions/internal/domain/repository"
+)
+
+type BudgetRepository struct {
+	budgets map[uint]entity.Budget
+	ids     sequence
+	sync.Mutex
+}
+
+func NewBudgetRepository() *BudgetRepository {
+	return &BudgetRepository{
+		budgets: make(map[uint]entity.Budget),
+	}
+}
+
+func (r *BudgetRepository) Create(_ context.Context, budget entity.Budget) (*entity.Budget, error) {
+	r.Lock()
+	defer r.Unlock()
+
+	budget.ID = r.ids.next()
+	r.budgets[budget.ID] = budget
+
+	return &budget, nil
+}
+
+func (r *BudgetRepository) Get(_ context.Context, id uint) (*entity.Budget, error) {
+	r.Lock()
+	defer r.Unlock()
+
+	budget, ok := r.budgets[id]
+	if !ok {
+		return nil, repository.ErrNotFoundBudget
+	}
+
+	return &budget, nil
+}
+
+func (r *BudgetRepository) GetAll(_ context.Context) (repository.Budgets, error) {
+	r.Lock()
+	defer r.Unlock()
+
+	var budgets repository.Budgets
+	for _, budget := range r.budgets {
+		budgets = append(budgets, budget)
+	}
+
+	sort.Slice(budgets, func(i, j int) bool { return budgets[i].ID < budgets[j].ID })
+
+	return budgets, nil
+}
+
+func (r *BudgetRepository) Update(_ context.Context, budget entity.Budget) (*entity.Budget, error) {
+	r.Lock()
+	defer r.Unlock()
+
+	if _, ok := r.budgets[budget.ID]; !ok {
+		return nil, repository.ErrUpdateBudget
+	}
+
+	r.budgets[budget.ID] = budget
+
+	return &budget, nil
+}
+
+func (r *BudgetRepository) Delete(_ context.Context, id uint) error {
+	r.Lock()
+	defer r.Unlock()
+
+	if _, ok := r.budgets[id]; !ok {
+		return repository.ErrDeleteBudget
+	}
+
+	delete(r.budgets, id)
+
+	return nil
+}
+
+func (r *BudgetRepository) Restore(_ context.Context, budget entity.Budget) (*entity.Budget, error) {
+	r.Lock()
+	defer r.Unlock()
+
+	if budget.ID == 0 {
+		return nil, repository.ErrCreateBudget
+	}
+
+	r.ids.observe(budget.ID)
+	r.budgets[budget.ID] = budget
+
+	return &budget, nil
+}
@@ -2,6 +2,7 @@ package memory
 
 import (
 	"context"
+	"sort"
 	"sync"
 
 	"git.home/alex/go-subscriptions/internal/domain/entity"
@@ -10,6 +11,7 @@ import (
 
 type CategoryRepository struct {
 	categories map[uint]entity.Category
+	ids        sequence
 	sync.Mutex
 }
 
@@ -23,7 +25,7 @@ func (r *CategoryRepository) Create(_ context.Context, category entity.Category)
 	r.Lock()
 	defer r.Unlock()
 
-	category.ID = uint(len(r.categories) + 1)
+	category.ID = r.ids.next()
 	r.categories[category.ID] = category
 
 	return &category, nil
@@ -50,6 +52,8 @@ func (r *CategoryRepository) GetAll(_ context.Context) (repository.Categories, e
 		categories = append(categories, category)
 	}
 
+	sort.Slice(categories, func(i, j int) bool { return categories[i].ID < categories[j].ID })
+
 	return categories, nil
 }
 
@@ -78,3 +82,17 @@ func (r *CategoryRepository) Delete(_ context.Context, id uint) error {
 
 	return nil
 }
+
+func (r *CategoryRepository) Restore(_ context.Context, category entity.Category) (*entity.Category, error) {
+	r.Lock()
+	defer r.Unlock()
+
+	if category.ID == 0 {
+		return nil, repository.ErrCreateCategory
+	}
+
+	r.ids.observe(category.ID)
+	r.categories[category.ID] = category
+
+	return &category, nil
+}
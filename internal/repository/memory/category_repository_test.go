@@ -202,7 +202,7 @@ func TestCategoryRepository_Delete(t *testing.T) {
 		{
 			name:     "Delete an existing category",
 			category: entity.Category{Name: "Category 2"},
-			id:       1,
+			id:       2,
 			wantErr:  nil,
 		},
 		{
@@ -232,3 +232,21 @@ func TestCategoryRepository_Delete(t *testing.T) {
 		})
 	}
 }
+
+func TestCategoryRepository_IDsAreNotReused(t *testing.T) {
+	repo := memory.NewCategoryRepository()
+	ctx := context.Background()
+
+	_, err := repo.Restore(ctx, entity.Category{ID: 5, Name: "Restored"})
+	assert.NoError(t, err)
+
+	created, err := repo.Create(ctx, entity.Category{Name: "Music"})
+	assert.NoError(t, err)
+	assert.Equal(t, uint(6), created.ID)
+
+	assert.NoError(t, repo.Delete(ctx, created.ID))
+
+	created, err = repo.Create(ctx, entity.Category{Name: "Video"})
+	assert.NoError(t, err)
+	assert.Equal(t, uint(7), created.ID)
+}
@@ -2,6 +2,7 @@ package memory
 
 import (
 	"context"
+	"slices"
 	"sync"
 
 	"git.home/alex/go-subscriptions/internal/domain/entity"
@@ -10,6 +11,7 @@ import (
 
 type CurrencyRepository struct {
 	currencies map[string]entity.Currency
+	codes      []string // insertion order
 	sync.Mutex
 }
 
@@ -28,6 +30,7 @@ func (r *CurrencyRepository) Create(_ context.Context, currency entity.Currency)
 	}
 
 	r.currencies[currency.Code] = currency
+	r.codes = append(r.codes, currency.Code)
 
 	return &currency, nil
 }
@@ -49,8 +52,8 @@ func (r *CurrencyRepository) GetAll(_ context.Context) (repository.Currencies, e
 
 	var currencies repository.Currencies
 
-	for _, currency := range r.currencies {
-		currencies = append(currencies, currency)
+	for _, code := range r.codes {
+		currencies = append(currencies, r.currencies[code])
 	}
 
 	return currencies, nil
@@ -78,6 +81,24 @@ func (r *CurrencyRepository) Delete(_ context.Context, code string) error {
 	}
 
 	delete(r.currencies, code)
+	r.codes = slices.DeleteFunc(r.codes, func(c string) bool { return c == code })
 
 	return nil
 }
+
+func (r *CurrencyRepository) Restore(_ context.Context, currency entity.Currency) (*entity.Currency, error) {
+	r.Lock()
+	defer r.Unlock()
+
+	if currency.Code == "" {
+		return nil, repository.ErrCreateCurrency
+	}
+
+	if _, ok := r.currencies[currency.Code]; !ok {
+		r.codes = append(r.codes, currency.Code)
+	}
+
+	r.currencies[currency.Code] = currency
+
+	return &currency, nil
+}
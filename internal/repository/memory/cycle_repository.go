@@ -2,6 +2,7 @@ package memory
 
 import (
 	"context"
+	"sort"
 	"sync"
 
 	"git.home/alex/go-subscriptions/internal/domain/entity"
@@ -10,6 +11,7 @@ import (
 
 type CycleRepository struct {
 	cycles map[uint]entity.Cycle
+	ids    sequence
 	sync.Mutex
 }
 
@@ -23,7 +25,7 @@ func (r *CycleRepository) Create(_ context.Context, cycle entity.Cycle) (*entity
 	r.Lock()
 	defer r.Unlock()
 
-	cycle.ID = uint(len(r.cycles) + 1)
+	cycle.ID = r.ids.next()
 	r.cycles[cycle.ID] = cycle
 
 	return &cycle, nil
@@ -50,6 +52,8 @@ func (r *CycleRepository) GetAll(_ context.Context) (repository.Cycles, error) {
 		cycles = append(cycles, cycle)
 	}
 
+	sort.Slice(cycles, func(i, j int) bool { return cycles[i].ID < cycles[j].ID })
+
 	return cycles, nil
 }
 
@@ -78,3 +82,17 @@ func (r *CycleRepository) Delete(_ context.Context, id uint) error {
 
 	return nil
 }
+
+func (r *CycleRepository) Restore(_ context.Context, cycle entity.Cycle) (*entity.Cycle, error) {
+	r.Lock()
+	defer r.Unlock()
+
+	if cycle.ID == 0 {
+		return nil, repository.ErrCreateCycle
+	}
+
+	r.ids.observe(cycle.ID)
+	r.cycles[cycle.ID] = cycle
+
+	return &cycle, nil
+}
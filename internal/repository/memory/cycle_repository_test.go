@@ -204,7 +204,7 @@ func TestCycleRepository_Delete(t *testing.T) {
 		{
 			name:    "Delete an existing cycle",
 			cycle:   entity.Cycle{Name: "Test Cycle 2"},
-			id:      1,
+			id:      2,
 			wantErr: nil,
 		},
 		{
@@ -0,0 +1,98 @@
+package memory
+
+import (
+	"context"
+	"sort"
+	"sync"
+
+	"git.home/alex/go-subscriptions/internal/domain/entity"
+	"git.home/alex/go-subscriptions/internal/domain/repository"
+)
+
+type HouseholdRepository struct {
+	households map[uint]entity.Household
+	ids        sequence
+	sync.Mutex
+}
+
+func NewHouseholdRepository() *HouseholdRepository {
+	return &HouseholdRepository{
+		households: make(map[uint]entity.Household),
+	}
+}
+
+func (r *HouseholdRepository) Create(_ context.Context, household entity.Household) (*entity.Household, error) {
+	r.Lock()
+	defer r.Unlock()
+
+	household.ID = r.ids.next()
+	r.households[household.ID] = household
+
+	return &household, nil
+}
+
+func (r *HouseholdRepository) Get(_ context.Context, id uint) (*entity.Household, error) {
+	r.Lock()
+	defer r.Unlock()
+
+	household, ok := r.households[id]
+	if !ok {
+		return nil, repository.ErrNotFoundHousehold
+	}
+
+	return &household, nil
+}
+
+func (r *HouseholdRepository) GetAll(_ context.Context) (repository.Households, error) {
+	r.Lock()
+	defer r.Unlock()
+
+	var households repository.Households
+	for _, household := range r.households {
+		households = append(households, household)
+	}
+
+	sort.Slice(households, func(i, j int) bool { return households[i].ID < households[j].ID })
+
+	return households, nil
+}
+
+func (r *HouseholdRepository) Update(_ context.Context, household entity.Household) (*entity.Household, error) {
+	r.Lock()
+	defer r.Unlock()
+
+	if _, ok := r.households[household.ID]; !ok {
+		return nil, repository.ErrUpdateHousehold
+	}
+
+	r.households[household.ID] = household
+
+	return &household, nil
+}
+
+func (r *HouseholdRepository) Delete(_ context.Context, id uint) error {
+	r.Lock()
+	defer r.Unlock()
+
+	if _, ok := r.households[id]; !ok {
+		return repository.ErrDeleteHousehold
+	}
+
+	delete(r.households, id)
+
+	return nil
+}
+
+func (r *HouseholdRepository) Restore(_ context.Context, household entity.Household) (*entity.Household, error) {
+	r.Lock()
+	defer r.Unlock()
+
+	if household.ID == 0 {
+		return nil, repository.ErrCreateHousehold
+	}
+
+	r.ids.observe(household.ID)
+	r.households[household.ID] = household
+
+	return &household, nil
+}
@@ -0,0 +1,17 @@
+package memory
+
+// sequence hands out increasing IDs. It never goes back, so the ID of a deleted
+// entity is not reused, and it skips past restored IDs so new entities never collide with them.
+type sequence struct {
+	last uint
+}
+
+func (s *sequence) next() uint {
+	s.last++
+
+	return s.last
+}
+
+func (s *sequence) observe(id uint) {
+	s.last = max(s.last, id)
+}
@@ -0,0 +1,98 @@
+package memory
+
+import (
+	"context"
+	"sort"
+	"sync"
+
+	"git.home/alex/go-subscriptions/internal/domain/entity"
+	"git.home/alex/go-subscriptions/internal/domain/repository"
+)
+
+type PaymentMethodRepository struct {
+	paymentMethods map[uint]entity.PaymentMethod
+	ids            sequence
+	sync.Mutex
+}
+
+func NewPaymentMethodRepository() *PaymentMethodRepository {
+	return &PaymentMethodRepository{
+		paymentMethods: make(map[uint]entity.PaymentMethod),
+	}
+}
+
+func (r *PaymentMethodRepository) Create(_ context.Context, paymentMethod entity.PaymentMethod) (*entity.PaymentMethod, error) {
+	r.Lock()
+	defer r.Unlock()
+
+	paymentMethod.ID = r.ids.next()
+	r.paymentMethods[paymentMethod.ID] = paymentMethod
+
+	return &paymentMethod, nil
+}
+
+func (r *PaymentMethodRepository) Get(_ context.Context, id uint) (*entity.PaymentMethod, error) {
+	r.Lock()
+	defer r.Unlock()
+
+	paymentMethod, ok := r.paymentMethods[id]
+	if !ok {
+		return nil, repository.ErrNotFoundPaymentMethod
+	}
+
+	return &paymentMethod, nil
+}
+
+func (r *PaymentMethodRepository) GetAll(_ context.Context) (repository.PaymentMethods, error) {
+	r.Lock()
+	defer r.Unlock()
+
+	var paymentMethods repository.PaymentMethods
+	for _, paymentMethod := range r.paymentMethods {
+		paymentMethods = append(paymentMethods, paymentMethod)
+	}
+
+	sort.Slice(paymentMethods, func(i, j int) bool { return paymentMethods[i].ID < paymentMethods[j].ID })
+
+	return paymentMethods, nil
+}
+
+func (r *PaymentMethodRepository) Update(_ context.Context, paymentMethod entity.PaymentMethod) (*entity.PaymentMethod, error) {
+	r.Lock()
+	defer r.Unlock()
+
+	if _, ok := r.paymentMethods[paymentMethod.ID]; !ok {
+		return nil, repository.ErrNotFoundPaymentMethod
+	}
+
+	r.paymentMethods[paymentMethod.ID] = paymentMethod
+
+	return &paymentMethod, nil
+}
+
+func (r *PaymentMethodRepository) Delete(_ context.Context, id uint) error {
+	r.Lock()
+	defer r.Unlock()
+
+	if _, ok := r.paymentMethods[id]; !ok {
+		return repository.ErrNotFoundPaymentMethod
+	}
+
+	delete(r.paymentMethods, id)
+
+	return nil
+}
+
+func (r *PaymentMethodRepository) Restore(_ context.Context, paymentMethod entity.PaymentMethod) (*entity.PaymentMethod, error) {
+	r.Lock()
+	defer r.Unlock()
+
+	if paymentMethod.ID == 0 {
+		return nil, repository.ErrCreatePaymentMethod
+	}
+
+	r.ids.observe(paymentMethod.ID)
+	r.paymentMethods[paymentMethod.ID] = paymentMethod
+
+	return &paymentMethod, nil
+}
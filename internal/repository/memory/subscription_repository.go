@@ -2,6 +2,7 @@ package memory
 
 import (
 	"context"
+	"sort"
 	"sync"
 
 	"git.home/alex/go-subscriptions/internal/domain/entity"
@@ -10,6 +11,7 @@ import (
 
 type SubscriptionRepository struct {
 	subscriptions map[uint]entity.Subscription
+	ids           sequence
 	sync.Mutex
 }
 
@@ -23,7 +25,7 @@ func (r *SubscriptionRepository) Create(_ context.Context, subscription entity.S
 	r.Lock()
 	defer r.Unlock()
 
-	subscription.ID = uint(len(r.subscriptions) + 1)
+	subscription.ID = r.ids.next()
 	r.subscriptions[subscription.ID] = subscription
 
 	return &subscription, nil
@@ -50,6 +52,8 @@ func (r *SubscriptionRepository) GetAll(_ context.Context) (repository.Subscript
 		subscriptions = append(subscriptions, subscription)
 	}
 
+	sort.Slice(subscriptions, func(i, j int) bool { return subscriptions[i].ID < subscriptions[j].ID })
+
 	return subscriptions, nil
 }
 
@@ -78,3 +82,17 @@ func (r *SubscriptionRepository) Delete(_ context.Context, id uint) error {
 
 	return nil
 }
+
+func (r *SubscriptionRepository) Restore(_ context.Context, subscription entity.Subscription) (*entity.Subscription, error) {
+	r.Lock()
+	defer r.Unlock()
+
+	if subscription.ID == 0 {
+		return nil, repository.ErrCreateSubscription
+	}
+
+	r.ids.observe(subscription.ID)
+	r.subscriptions[subscription.ID] = subscription
+
+	return &subscription, nil
+}
@@ -202,7 +202,7 @@ func TestSubscriptionRepository_Delete(t *testing.T) {
 		{
 			name:         "Delete an existing subscription",
 			subscription: entity.Subscription{Name: "Test Subscription"},
-			id:           1,
+			id:           2,
 			wantErr:      nil,
 		},
 		{
@@ -232,3 +232,19 @@ func TestSubscriptionRepository_Delete(t *testing.T) {
 		})
 	}
 }
+
+func TestSubscriptionRepository_Restore(t *testing.T) {
+	repo := memory.NewSubscriptionRepository()
+	ctx := context.Background()
+
+	restored, err := repo.Restore(ctx, entity.Subscription{ID: 5, Name: "Restored"})
+	assert.NoError(t, err)
+	assert.Equal(t, &entity.Subscription{ID: 5, Name: "Restored"}, restored)
+
+	_, err = repo.Restore(ctx, entity.Subscription{Name: "Without ID"})
+	assert.Equal(t, repository.ErrCreateSubscription, err)
+
+	created, err := repo.Create(ctx, entity.Subscription{Name: "Created"})
+	assert.NoError(t, err)
+	assert.Equal(t, uint(6), created.ID)
+}
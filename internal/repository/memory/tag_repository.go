@@ -0,0 +1,98 @@
+package memory
+
+import (
+	"context"
+	"sort"
+	"sync"
+
+	"git.home/alex/go-subscriptions/internal/domain/entity"
+	"git.home/alex/go-subscriptions/internal/domain/repository"
+)
+
+type TagRepository struct {
+	tags map[uint]entity.Tag
+	ids  sequence
+	sync.Mutex
+}
+
+func NewTagRepository() *TagRepository {
+	return &TagRepository{
+		tags: make(map[uint]entity.Tag),
+	}
+}
+
+func (r *TagRepository) Create(_ context.Context, tag entity.Tag) (*entity.Tag, error) {
+	r.Lock()
+	defer r.Unlock()
+
+	tag.ID = r.ids.next()
+	r.tags[tag.ID] = tag
+
+	return &tag, nil
+}
+
+func (r *TagRepository) Get(_ context.Context, id uint) (*entity.Tag, error) {
+	r.Lock()
+	defer r.Unlock()
+
+	tag, ok := r.tags[id]
+	if !ok {
+		return nil, repository.ErrNotFoundTag
+	}
+
+	return &tag, nil
+}
+
+func (r *TagRepository) GetAll(_ context.Context) (repository.Tags, error) {
+	r.Lock()
+	defer r.Unlock()
+
+	var tags repository.Tags
+	for _, tag := range r.tags {
+		tags = append(tags, tag)
+	}
+
+	sort.Slice(tags, func(i, j int) bool { return tags[i].ID < tags[j].ID })
+
+	return tags, nil
+}
+
+func (r *TagRepository) Update(_ context.Context, tag entity.Tag) (*entity.Tag, error) {
+	r.Lock()
+	defer r.Unlock()
+
+	if _, ok := r.tags[tag.ID]; !ok {
+		return nil, repository.ErrNotFoundTag
+	}
+
+	r.tags[tag.ID] = tag
+
+	return &tag, nil
+}
+
+func (r *TagRepository) Delete(_ context.Context, id uint) error {
+	r.Lock()
+	defer r.Unlock()
+
+	if _, ok := r.tags[id]; !ok {
+		return repository.ErrNotFoundTag
+	}
+
+	delete(r.tags, id)
+
+	return nil
+}
+
+func (r *TagRepository) Restore(_ context.Context, tag entity.Tag) (*entity.Tag, error) {
+	r.Lock()
+	defer r.Unlock()
+
+	if tag.ID == 0 {
+		return nil, repository.ErrCreateTag
+	}
+
+	r.ids.observe(tag.ID)
+	r.tags[tag.ID] = tag
+
+	return &tag, nil
+}
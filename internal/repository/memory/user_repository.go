@@ -0,0 +1,117 @@
+package memory
+
+import (
+	"context"
+	"sort"
+	"sync"
+
+	"git.home/alex/go-subscriptions/internal/domain/entity"
+	"git.home/alex/go-subscriptions/internal/domain/repository"
+)
+
+type UserRepository struct {
+	users map[uint]entity.User
+	ids   sequence
+	sync.Mutex
+}
+
+func NewUserRepository() *UserRepository {
+	return &UserRepository{
+		users: make(map[uint]entity.User),
+	}
+}
+
+func (r *UserRepository) Create(_ context.Context, user entity.User) (*entity.User, error) {
+	r.Lock()
+	defer r.Unlock()
+
+	for _, u := range r.users {
+		if u.Username == user.Username {
+			return nil, repository.ErrAlreadyExistsUser
+		}
+	}
+
+	user.ID = r.ids.next()
+	r.users[user.ID] = user
+
+	return &user, nil
+}
+
+func (r *UserRepository) Get(_ context.Context, id uint) (*entity.User, error) {
+	r.Lock()
+	defer r.Unlock()
+
+	user, ok := r.users[id]
+	if !ok {
+		return nil, repository.ErrNotFoundUser
+	}
+
+	return &user, nil
+}
+
+func (r *UserRepository) GetByUsername(_ context.Context, username string) (*entity.User, error) {
+	r.Lock()
+	defer r.Unlock()
+
+	for _, user := range r.users {
+		if user.Username == username {
+			return &user, nil
+		}
+	}
+
+	return nil, repository.ErrNotFoundUser
+}
+
+func (r *UserRepository) GetAll(_ context.Context) (repository.Users, error) {
+	r.Lock()
+	defer r.Unlock()
+
+	var users repository.Users
+	for _, user := range r.users {
+		users = append(users, user)
+	}
+
+	sort.Slice(users, func(i, j int) bool { return users[i].ID < users[j].ID })
+
+	return users, nil
+}
+
+func (r *UserRepository) Update(_ context.Context, user entity.User) (*entity.User, error) {
+	r.Lock()
+	defer r.Unlock()
+
+	if _, ok := r.users[user.ID]; !ok {
+		return nil, repository.ErrUpdateUser
+	}
+
+	r.users[user.ID] = user
+
+	return &user, nil
+}
+
+func (r *UserRepository) Delete(_ context.Context, id uint) error {
+	r.Lock()
+	defer r.Unlock()
+
+	if _, ok := r.users[id]; !ok {
+		return repository.ErrDeleteUser
+	}
+
+	delete(r.users, id)
+
+	return nil
+}
+
+func (r *UserRepository) Restore(_ context.Context, user entity.User) (*entity.User, error) {
+	r.Lock()
+	defer r.Unlock()
+
+	if user.ID == 0 {
+		return nil, repository.ErrCreateUser
+	}
+
+	r.ids.observe(user.ID)
+	r.users[user.ID] = user
+
+	return &user, nil
+}
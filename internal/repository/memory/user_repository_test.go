@@ -0,0 +1,93 @@
+package memory_test
+
+import (
+	"context"
+	"testing"
+
+	"git.home/alex/go-subscriptions/internal/domain/entity"
+	"git.home/alex/go-subscriptions/internal/domain/repository"
+	"git.home/alex/go-subscriptions/internal/repository/memory"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestUserRepository_Create(t *testing.T) {
+	testCases := []struct {
+		name       string
+		user       entity.User
+		wantResult *entity.User
+		wantErr    error
+	}{
+		{
+			name:       "Create a new user",
+			user:       entity.User{Username: "alice", PasswordHash: "hash"},
+			wantResult: &entity.User{ID: 1, Username: "alice", PasswordHash: "hash"},
+		},
+		{
+			name:       "Create another user",
+			user:       entity.User{Username: "bob", PasswordHash: "hash"},
+			wantResult: &entity.User{ID: 2, Username: "bob", PasswordHash: "hash"},
+		},
+		{
+			name:    "Create a duplicate user",
+			user:    entity.User{Username: "alice", PasswordHash: "hash"},
+			wantErr: repository.ErrAlreadyExistsUser,
+		},
+	}
+
+	repo := memory.NewUserRepository()
+	ctx := context.Background()
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			result, err := repo.Create(ctx, tc.user)
+
+			if tc.wantErr != nil {
+				assert.Error(t, err)
+				assert.Equal(t, tc.wantErr, err)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tc.wantResult, result)
+			}
+		})
+	}
+}
+
+func TestUserRepository_GetByUsername(t *testing.T) {
+	testCases := []struct {
+		name       string
+		username   string
+		wantResult *entity.User
+		wantErr    error
+	}{
+		{
+			name:       "Get an existing user",
+			username:   "alice",
+			wantResult: &entity.User{ID: 1, Username: "alice"},
+		},
+		{
+			name:     "Get a non-existing user",
+			username: "bob",
+			wantErr:  repository.ErrNotFoundUser,
+		},
+	}
+
+	repo := memory.NewUserRepository()
+	ctx := context.Background()
+
+	_, err := repo.Create(ctx, entity.User{Username: "alice"})
+	assert.NoError(t, err)
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			result, err := repo.GetByUsername(ctx, tc.username)
+
+			if tc.wantErr != nil {
+				assert.Error(t, err)
+				assert.Equal(t, tc.wantErr, err)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tc.wantResult, result)
+			}
+		})
+	}
+}
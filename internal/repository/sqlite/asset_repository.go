@@ -0,0 +1,110 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"git.home/alex/go-subscriptions/internal/domain/entity"
+	"git.home/alex/go-subscriptions/internal/domain/repository"
+)
+
+const selectAssets = "SELECT id, user_id, content_type, size, checksum, created_at FROM assets"
+
+type AssetRepository struct {
+	db *sql.DB
+}
+
+func NewAssetRepository(db *sql.DB) *AssetRepository {
+	return &AssetRepository{db: db}
+}
+
+func (r *AssetRepository) Create(ctx context.Context, asset entity.Asset) (*entity.Asset, error) {
+	err := inTx(ctx, r.db, func(tx *sql.Tx) error {
+		id, err := nextID(ctx, tx, "assets")
+		if err != nil {
+			return err
+		}
+
+		asset.ID = id
+
+		_, err = tx.ExecContext(ctx, "INSERT INTO assets (id, user_id, content_type, size, checksum, created_at) "+
+			"VALUES (?, ?, ?, ?, ?, ?)", assetArgs(asset)...)
+
+		return err
+	})
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", repository.ErrCreateAsset, err)
+	}
+
+	return &asset, nil
+}
+
+func (r *AssetRepository) Get(ctx context.Context, id uint) (*entity.Asset, error) {
+	asset, err := scanAsset(r.db.QueryRowContext(ctx, selectAssets+" WHERE id = ?", id))
+	if err != nil {
+		return nil, notFound(err, repository.ErrNotFoundAsset)
+	}
+
+	return &asset, nil
+}
+
+func (r *AssetRepository) GetAll(ctx context.Context) (repository.Assets, error) {
+	rows, err := r.db.QueryContext(ctx, selectAssets+" ORDER BY id")
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var assets repository.Assets
+
+	for rows.Next() {
+		asset, err := scanAsset(rows)
+		if err != nil {
+			return nil, err
+		}
+
+		assets = append(assets, asset)
+	}
+
+	return assets, rows.Err()
+}
+
+// Delete fails while a subscription still uses the asset as its logo.
+func (r *AssetRepository) Delete(ctx context.Context, id uint) error {
+	res, err := r.db.ExecContext(ctx, "DELETE FROM assets WHERE id = ?", id)
+	if err != nil {
+		return fmt.Errorf("%w: %w", repository.ErrDeleteAsset, err)
+	}
+
+	return affected(res, repository.ErrNotFoundAsset)
+}
+
+func (r *AssetRepository) Restore(ctx context.Context, asset entity.Asset) (*entity.Asset, error) {
+	if asset.ID == 0 {
+		return nil, repository.ErrCreateAsset
+	}
+
+	_, err := r.db.ExecContext(ctx, "INSERT INTO assets (id, user_id, content_type, size, checksum, created_at) "+
+		"VALUES (?, ?, ?, ?, ?, ?) ON CONFLICT (id) DO UPDATE SET user_id = excluded.user_id, content_type = excluded.content_type, "+
+		"size = excluded.size, checksum = excluded.checksum, created_at = excluded.created_at",
+		assetArgs(asset)...)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", repository.ErrCreateAsset, err)
+	}
+
+	return &asset, nil
+}
+
+// assetArgs lists the columns in the order of selectAssets.
+func assetArgs(a entity.Asset) []any {
+	return []any{a.ID, a.UserID, a.ContentType, a.Size, a.Checksum, formatTime(a.CreatedAt)}
+}
+
+func scanAsset(row scanner) (entity.Asset, error) {
+	var a entity.Asset
+
+	err := row.Scan(&a.ID, &a.UserID, &a.ContentType, &a.Size, &a.Checksum, scanTime(&a.CreatedAt))
+
+	return a, err
+}
@@ -0,0 +1,114 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"git.home/alex/go-subscriptions/internal/domain/entity"
+	"git.home/alex/go-subscriptions/internal/domain/repository"
+)
+
+const selectAttachments = "SELECT id, user_id, subscription_id, payment_date, name, content_type, size, checksum, created_at " +
+	"FROM attachments"
+
+type AttachmentRepository struct {
+	db *sql.DB
+}
+
+func NewAttachmentRepository(db *sql.DB) *AttachmentRepository {
+	return &AttachmentRepository{db: db}
+}
+
+func (r *AttachmentRepository) Create(ctx context.Context, attachment entity.Attachment) (*entity.Attachment, error) {
+	err := inTx(ctx, r.db, func(tx *sql.Tx) error {
+		id, err := nextID(ctx, tx, "attachments")
+		if err != nil {
+			return err
+		}
+
+		attachment.ID = id
+
+		_, err = tx.ExecContext(ctx, "INSERT INTO attachments (id, user_id, subscription_id, payment_date, name, content_type, "+
+			"size, checksum, created_at) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?)", attachmentArgs(attachment)...)
+
+		return err
+	})
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", repository.ErrCreateAttachment, err)
+	}
+
+	return &attachment, nil
+}
+
+func (r *AttachmentRepository) Get(ctx context.Context, id uint) (*entity.Attachment, error) {
+	attachment, err := scanAttachment(r.db.QueryRowContext(ctx, selectAttachments+" WHERE id = ?", id))
+	if err != nil {
+		return nil, notFound(err, repository.ErrNotFoundAttachment)
+	}
+
+	return &attachment, nil
+}
+
+func (r *AttachmentRepository) GetAll(ctx context.Context) (repository.Attachments, error) {
+	rows, err := r.db.QueryContext(ctx, selectAttachments+" ORDER BY id")
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var attachments repository.Attachments
+
+	for rows.Next() {
+		attachment, err := scanAttachment(rows)
+		if err != nil {
+			return nil, err
+		}
+
+		attachments = append(attachments, attachment)
+	}
+
+	return attachments, rows.Err()
+}
+
+func (r *AttachmentRepository) Delete(ctx context.Context, id uint) error {
+	res, err := r.db.ExecContext(ctx, "DELETE FROM attachments WHERE id = ?", id)
+	if err != nil {
+		return fmt.Errorf("%w: %w", repository.ErrDeleteAttachment, err)
+	}
+
+	return affected(res, repository.ErrNotFoundAttachment)
+}
+
+func (r *AttachmentRepository) Restore(ctx context.Context, attachment entity.Attachment) (*entity.Attachment, error) {
+	if attachment.ID == 0 {
+		return nil, repository.ErrCreateAttachment
+	}
+
+	_, err := r.db.ExecContext(ctx, "INSERT INTO attachments (id, user_id, subscription_id, payment_date, name, content_type, "+
+		"size, checksum, created_at) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?) ON CONFLICT (id) DO UPDATE SET user_id = excluded.user_id, "+
+		"subscription_id = excluded.subscription_id, payment_date = excluded.payment_date, name = excluded.name, "+
+		"content_type = excluded.content_type, size = excluded.size, checksum = excluded.checksum, created_at = excluded.created_at",
+		attachmentArgs(attachment)...)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", repository.ErrCreateAttachment, err)
+	}
+
+	return &attachment, nil
+}
+
+// attachmentArgs lists the columns in the order of selectAttachments.
+func attachmentArgs(a entity.Attachment) []any {
+	return []any{a.ID, a.UserID, a.SubscriptionID, formatTime(time.Time(a.PaymentDate)), a.Name, a.ContentType, a.Size,
+		a.Checksum, formatTime(a.CreatedAt)}
+}
+
+func scanAttachment(row scanner) (entity.Attachment, error) {
+	var a entity.Attachment
+
+	err := row.Scan(&a.ID, &a.UserID, &a.SubscriptionID, scanTime((*time.Time)(&a.PaymentDate)), &a.Name, &a.ContentType,
+		&a.Size, &a.Checksum, scanTime(&a.CreatedAt))
+
+	return a, err
+}
@@ -0,0 +1,124 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"git.home/alex/go-subscriptions/internal/domain/entity"
+	"git.home/alex/go-subscriptions/internal/domain/repository"
+)
+
+const selectBudgets = "SELECT id, user_id, coalesce(category_id, 0), period, amount, currency_code FROM budgets"
+
+type BudgetRepository struct {
+	db *sql.DB
+}
+
+func NewBudgetRepository(db *sql.DB) *BudgetRepository {
+	return &BudgetRepository{db: db}
+}
+
+func (r *BudgetRepository) Create(ctx context.Context, budget entity.Budget) (*entity.Budget, error) {
+	err := inTx(ctx, r.db, func(tx *sql.Tx) error {
+		id, err := nextID(ctx, tx, "budgets")
+		if err != nil {
+			return err
+		}
+
+		budget.ID = id
+
+		_, err = tx.ExecContext(ctx, "INSERT INTO budgets (id, user_id, category_id, period, amount, currency_code) "+
+			"VALUES (?, ?, ?, ?, ?, ?)", budgetArgs(budget)...)
+
+		return err
+	})
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", repository.ErrCreateBudget, err)
+	}
+
+	return &budget, nil
+}
+
+func (r *BudgetRepository) Get(ctx context.Context, id uint) (*entity.Budget, error) {
+	budget, err := scanBudget(r.db.QueryRowContext(ctx, selectBudgets+" WHERE id = ?", id))
+	if err != nil {
+		return nil, notFound(err, repository.ErrNotFoundBudget)
+	}
+
+	return &budget, nil
+}
+
+func (r *BudgetRepository) GetAll(ctx context.Context) (repository.Budgets, error) {
+	rows, err := r.db.QueryContext(ctx, selectBudgets+" ORDER BY id")
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var budgets repository.Budgets
+
+	for rows.Next() {
+		budget, err := scanBudget(rows)
+		if err != nil {
+			return nil, err
+		}
+
+		budgets = append(budgets, budget)
+	}
+
+	return budgets, rows.Err()
+}
+
+func (r *BudgetRepository) Update(ctx context.Context, budget entity.Budget) (*entity.Budget, error) {
+	res, err := r.db.ExecContext(ctx, "UPDATE budgets SET user_id = ?, category_id = ?, period = ?, amount = ?, currency_code = ? "+
+		"WHERE id = ?", append(budgetArgs(budget)[1:], budget.ID)...)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", repository.ErrUpdateBudget, err)
+	}
+
+	err = affected(res, repository.ErrUpdateBudget)
+	if err != nil {
+		return nil, err
+	}
+
+	return &budget, nil
+}
+
+func (r *BudgetRepository) Delete(ctx context.Context, id uint) error {
+	res, err := r.db.ExecContext(ctx, "DELETE FROM budgets WHERE id = ?", id)
+	if err != nil {
+		return fmt.Errorf("%w: %w", repository.ErrDeleteBudget, err)
+	}
+
+	return affected(res, repository.ErrDeleteBudget)
+}
+
+func (r *BudgetRepository) Restore(ctx context.Context, budget entity.Budget) (*entity.Budget, error) {
+	if budget.ID == 0 {
+		return nil, repository.ErrCreateBudget
+	}
+
+	_, err := r.db.ExecContext(ctx, "INSERT INTO budgets (id, user_id, category_id, period, amount, currency_code) "+
+		"VALUES (?, ?, ?, ?, ?, ?) ON CONFLICT (id) DO UPDATE SET user_id = excluded.user_id, category_id = excluded.category_id, "+
+		"period = excluded.period, amount = excluded.amount, currency_code = excluded.currency_code",
+		budgetArgs(budget)...)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", repository.ErrCreateBudget, err)
+	}
+
+	return &budget, nil
+}
+
+// budgetArgs lists the columns in the order of selectBudgets.
+func budgetArgs(b entity.Budget) []any {
+	return []any{b.ID, b.UserID, nullID(b.CategoryID), string(b.Period), b.Amount, b.CurrencyCode}
+}
+
+func scanBudget(row scanner) (entity.Budget, error) {
+	var b entity.Budget
+
+	err := row.Scan(&b.ID, &b.UserID, &b.CategoryID, &b.Period, &b.Amount, &b.CurrencyCode)
+
+	return b, err
+}
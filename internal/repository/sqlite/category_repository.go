@@ -0,0 +1,119 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"git.home/alex/go-subscriptions/internal/domain/entity"
+	"git.home/alex/go-subscriptions/internal/domain/repository"
+)
+
+const selectCategories = "SELECT id, name, user_id, coalesce(parent_id, 0) FROM categories"
+
+type CategoryRepository struct {
+	db *sql.DB
+}
+
+func NewCategoryRepository(db *sql.DB) *CategoryRepository {
+	return &CategoryRepository{db: db}
+}
+
+func (r *CategoryRepository) Create(ctx context.Context, category entity.Category) (*entity.Category, error) {
+	err := inTx(ctx, r.db, func(tx *sql.Tx) error {
+		id, err := nextID(ctx, tx, "categories")
+		if err != nil {
+			return err
+		}
+
+		category.ID = id
+
+		_, err = tx.ExecContext(ctx, "INSERT INTO categories (id, name, user_id, parent_id) VALUES (?, ?, ?, ?)",
+			category.ID, category.Name, category.UserID, nullID(category.ParentID))
+
+		return err
+	})
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", repository.ErrCreateCategory, err)
+	}
+
+	return &category, nil
+}
+
+func (r *CategoryRepository) Get(ctx context.Context, id uint) (*entity.Category, error) {
+	category, err := scanCategory(r.db.QueryRowContext(ctx, selectCategories+" WHERE id = ?", id))
+	if err != nil {
+		return nil, notFound(err, repository.ErrNotFoundCategory)
+	}
+
+	return &category, nil
+}
+
+func (r *CategoryRepository) GetAll(ctx context.Context) (repository.Categories, error) {
+	rows, err := r.db.QueryContext(ctx, selectCategories+" ORDER BY id")
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var categories repository.Categories
+
+	for rows.Next() {
+		category, err := scanCategory(rows)
+		if err != nil {
+			return nil, err
+		}
+
+		categories = append(categories, category)
+	}
+
+	return categories, rows.Err()
+}
+
+func (r *CategoryRepository) Update(ctx context.Context, category entity.Category) (*entity.Category, error) {
+	res, err := r.db.ExecContext(ctx, "UPDATE categories SET name = ?, user_id = ?, parent_id = ? WHERE id = ?",
+		category.Name, category.UserID, nullID(category.ParentID), category.ID)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", repository.ErrUpdateCategory, err)
+	}
+
+	err = affected(res, repository.ErrNotFoundCategory)
+	if err != nil {
+		return nil, err
+	}
+
+	return &category, nil
+}
+
+// Delete fails while a subscription or budget still references the category.
+func (r *CategoryRepository) Delete(ctx context.Context, id uint) error {
+	res, err := r.db.ExecContext(ctx, "DELETE FROM categories WHERE id = ?", id)
+	if err != nil {
+		return fmt.Errorf("%w: %w", repository.ErrDeleteCategory, err)
+	}
+
+	return affected(res, repository.ErrNotFoundCategory)
+}
+
+func (r *CategoryRepository) Restore(ctx context.Context, category entity.Category) (*entity.Category, error) {
+	if category.ID == 0 {
+		return nil, repository.ErrCreateCategory
+	}
+
+	_, err := r.db.ExecContext(ctx, "INSERT INTO categories (id, name, user_id, parent_id) VALUES (?, ?, ?, ?) "+
+		"ON CONFLICT (id) DO UPDATE SET name = excluded.name, user_id = excluded.user_id, parent_id = excluded.parent_id",
+		category.ID, category.Name, category.UserID, nullID(category.ParentID))
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", repository.ErrCreateCategory, err)
+	}
+
+	return &category, nil
+}
+
+func scanCategory(row scanner) (entity.Category, error) {
+	var c entity.Category
+
+	err := row.Scan(&c.ID, &c.Name, &c.UserID, &c.ParentID)
+
+	return c, err
+}
@@ -0,0 +1,64 @@
+package sqlite_test
+
+import (
+	"context"
+	"testing"
+
+	"git.home/alex/go-subscriptions/internal/domain/entity"
+	"git.home/alex/go-subscriptions/internal/domain/repository"
+	"git.home/alex/go-subscriptions/internal/repository/sqlite"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCategoryRepository(t *testing.T) {
+	ctx := context.Background()
+	repo := sqlite.NewCategoryRepository(newDB(t))
+
+	parent, err := repo.Create(ctx, entity.Category{Name: "Video", UserID: 1})
+	require.NoError(t, err)
+	assert.Equal(t, &entity.Category{ID: 1, Name: "Video", UserID: 1}, parent)
+
+	child, err := repo.Create(ctx, entity.Category{Name: "Movies", UserID: 1, ParentID: parent.ID})
+	require.NoError(t, err)
+
+	child.Name = "Films"
+	_, err = repo.Update(ctx, *child)
+	require.NoError(t, err)
+
+	got, err := repo.Get(ctx, child.ID)
+	require.NoError(t, err)
+	assert.Equal(t, &entity.Category{ID: 2, Name: "Films", UserID: 1, ParentID: 1}, got)
+
+	all, err := repo.GetAll(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, repository.Categories{*parent, *child}, all)
+
+	_, err = repo.Create(ctx, entity.Category{Name: "Unknown parent", ParentID: 9})
+	assert.ErrorIs(t, err, repository.ErrCreateCategory)
+
+	assert.ErrorIs(t, repo.Delete(ctx, parent.ID), repository.ErrDeleteCategory, "the child still references it")
+	require.NoError(t, repo.Delete(ctx, child.ID))
+
+	_, err = repo.Get(ctx, child.ID)
+	assert.Equal(t, repository.ErrNotFoundCategory, err)
+	_, err = repo.Update(ctx, *child)
+	assert.Equal(t, repository.ErrNotFoundCategory, err)
+	assert.Equal(t, repository.ErrNotFoundCategory, repo.Delete(ctx, child.ID))
+
+	// The ID of the deleted category isn't handed out again.
+	category, err := repo.Create(ctx, entity.Category{Name: "Music"})
+	require.NoError(t, err)
+	assert.Equal(t, uint(3), category.ID)
+
+	restored, err := repo.Restore(ctx, entity.Category{ID: 10, Name: "Restored"})
+	require.NoError(t, err)
+	assert.Equal(t, uint(10), restored.ID)
+
+	category, err = repo.Create(ctx, entity.Category{Name: "Games"})
+	require.NoError(t, err)
+	assert.Equal(t, uint(11), category.ID)
+
+	_, err = repo.Restore(ctx, entity.Category{Name: "No ID"})
+	assert.Equal(t, repository.ErrCreateCategory, err)
+}
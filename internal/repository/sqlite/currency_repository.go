@@ -0,0 +1,113 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"git.home/alex/go-subscriptions/internal/domain/entity"
+	"git.home/alex/go-subscriptions/internal/domain/repository"
+)
+
+const selectCurrencies = "SELECT code, symbol, name, user_id FROM currencies"
+
+type CurrencyRepository struct {
+	db *sql.DB
+}
+
+func NewCurrencyRepository(db *sql.DB) *CurrencyRepository {
+	return &CurrencyRepository{db: db}
+}
+
+func (r *CurrencyRepository) Create(ctx context.Context, currency entity.Currency) (*entity.Currency, error) {
+	_, err := r.db.ExecContext(ctx, "INSERT INTO currencies (code, symbol, name, user_id) VALUES (?, ?, ?, ?)",
+		currency.Code, currency.Symbol, currency.Name, currency.UserID)
+
+	switch {
+	case isConstraint(err):
+		return nil, repository.ErrAlreadyExistsCurrency
+	case err != nil:
+		return nil, fmt.Errorf("%w: %w", repository.ErrCreateCurrency, err)
+	}
+
+	return &currency, nil
+}
+
+func (r *CurrencyRepository) Get(ctx context.Context, code string) (*entity.Currency, error) {
+	currency, err := scanCurrency(r.db.QueryRowContext(ctx, selectCurrencies+" WHERE code = ?", code))
+	if err != nil {
+		return nil, notFound(err, repository.ErrNotFoundCurrency)
+	}
+
+	return &currency, nil
+}
+
+// GetAll returns the currencies in the order they were added.
+func (r *CurrencyRepository) GetAll(ctx context.Context) (repository.Currencies, error) {
+	rows, err := r.db.QueryContext(ctx, selectCurrencies+" ORDER BY rowid")
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var currencies repository.Currencies
+
+	for rows.Next() {
+		currency, err := scanCurrency(rows)
+		if err != nil {
+			return nil, err
+		}
+
+		currencies = append(currencies, currency)
+	}
+
+	return currencies, rows.Err()
+}
+
+func (r *CurrencyRepository) Update(ctx context.Context, currency entity.Currency) (*entity.Currency, error) {
+	res, err := r.db.ExecContext(ctx, "UPDATE currencies SET symbol = ?, name = ?, user_id = ? WHERE code = ?",
+		currency.Symbol, currency.Name, currency.UserID, currency.Code)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", repository.ErrUpdateCurrency, err)
+	}
+
+	err = affected(res, repository.ErrNotFoundCurrency)
+	if err != nil {
+		return nil, err
+	}
+
+	return &currency, nil
+}
+
+// Delete fails while a subscription is still billed in the currency.
+func (r *CurrencyRepository) Delete(ctx context.Context, code string) error {
+	res, err := r.db.ExecContext(ctx, "DELETE FROM currencies WHERE code = ?", code)
+	if err != nil {
+		return fmt.Errorf("%w: %w", repository.ErrDeleteCurrency, err)
+	}
+
+	return affected(res, repository.ErrNotFoundCurrency)
+}
+
+func (r *CurrencyRepository) Restore(ctx context.Context, currency entity.Currency) (*entity.Currency, error) {
+	if currency.Code == "" {
+		return nil, repository.ErrCreateCurrency
+	}
+
+	_, err := r.db.ExecContext(ctx, "INSERT INTO currencies (code, symbol, name, user_id) VALUES (?, ?, ?, ?) "+
+		"ON CONFLICT (code) DO UPDATE SET symbol = excluded.symbol, name = excluded.name, user_id = excluded.user_id",
+		currency.Code, currency.Symbol, currency.Name, currency.UserID)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", repository.ErrCreateCurrency, err)
+	}
+
+	return &currency, nil
+}
+
+func scanCurrency(row scanner) (entity.Currency, error) {
+	var c entity.Currency
+
+	err := row.Scan(&c.Code, &c.Symbol, &c.Name, &c.UserID)
+
+	return c, err
+}
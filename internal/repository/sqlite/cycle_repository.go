@@ -0,0 +1,120 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"git.home/alex/go-subscriptions/internal/domain/entity"
+	"git.home/alex/go-subscriptions/internal/domain/repository"
+)
+
+const selectCycles = "SELECT id, name, days, rule, calendar, user_id FROM cycles"
+
+type CycleRepository struct {
+	db *sql.DB
+}
+
+func NewCycleRepository(db *sql.DB) *CycleRepository {
+	return &CycleRepository{db: db}
+}
+
+func (r *CycleRepository) Create(ctx context.Context, cycle entity.Cycle) (*entity.Cycle, error) {
+	err := inTx(ctx, r.db, func(tx *sql.Tx) error {
+		id, err := nextID(ctx, tx, "cycles")
+		if err != nil {
+			return err
+		}
+
+		cycle.ID = id
+
+		_, err = tx.ExecContext(ctx, "INSERT INTO cycles (id, name, days, rule, calendar, user_id) VALUES (?, ?, ?, ?, ?, ?)",
+			cycle.ID, cycle.Name, cycle.Days, cycle.Rule, cycle.Calendar, cycle.UserID)
+
+		return err
+	})
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", repository.ErrCreateCycle, err)
+	}
+
+	return &cycle, nil
+}
+
+func (r *CycleRepository) Get(ctx context.Context, id uint) (*entity.Cycle, error) {
+	cycle, err := scanCycle(r.db.QueryRowContext(ctx, selectCycles+" WHERE id = ?", id))
+	if err != nil {
+		return nil, notFound(err, repository.ErrNotFoundCycle)
+	}
+
+	return &cycle, nil
+}
+
+func (r *CycleRepository) GetAll(ctx context.Context) (repository.Cycles, error) {
+	rows, err := r.db.QueryContext(ctx, selectCycles+" ORDER BY id")
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var cycles repository.Cycles
+
+	for rows.Next() {
+		cycle, err := scanCycle(rows)
+		if err != nil {
+			return nil, err
+		}
+
+		cycles = append(cycles, cycle)
+	}
+
+	return cycles, rows.Err()
+}
+
+func (r *CycleRepository) Update(ctx context.Context, cycle entity.Cycle) (*entity.Cycle, error) {
+	res, err := r.db.ExecContext(ctx, "UPDATE cycles SET name = ?, days = ?, rule = ?, calendar = ?, user_id = ? WHERE id = ?",
+		cycle.Name, cycle.Days, cycle.Rule, cycle.Calendar, cycle.UserID, cycle.ID)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", repository.ErrUpdateCycle, err)
+	}
+
+	err = affected(res, repository.ErrNotFoundCycle)
+	if err != nil {
+		return nil, err
+	}
+
+	return &cycle, nil
+}
+
+// Delete fails while a subscription still bills on the cycle.
+func (r *CycleRepository) Delete(ctx context.Context, id uint) error {
+	res, err := r.db.ExecContext(ctx, "DELETE FROM cycles WHERE id = ?", id)
+	if err != nil {
+		return fmt.Errorf("%w: %w", repository.ErrDeleteCycle, err)
+	}
+
+	return affected(res, repository.ErrNotFoundCycle)
+}
+
+func (r *CycleRepository) Restore(ctx context.Context, cycle entity.Cycle) (*entity.Cycle, error) {
+	if cycle.ID == 0 {
+		return nil, repository.ErrCreateCycle
+	}
+
+	_, err := r.db.ExecContext(ctx, "INSERT INTO cycles (id, name, days, rule, calendar, user_id) VALUES (?, ?, ?, ?, ?, ?) "+
+		"ON CONFLICT (id) DO UPDATE SET name = excluded.name, days = excluded.days, rule = excluded.rule, "+
+		"calendar = excluded.calendar, user_id = excluded.user_id",
+		cycle.ID, cycle.Name, cycle.Days, cycle.Rule, cycle.Calendar, cycle.UserID)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", repository.ErrCreateCycle, err)
+	}
+
+	return &cycle, nil
+}
+
+func scanCycle(row scanner) (entity.Cycle, error) {
+	var c entity.Cycle
+
+	err := row.Scan(&c.ID, &c.Name, &c.Days, &c.Rule, &c.Calendar, &c.UserID)
+
+	return c, err
+}
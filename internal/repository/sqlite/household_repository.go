@@ -0,0 +1,190 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"git.home/alex/go-subscriptions/internal/domain/entity"
+	"git.home/alex/go-subscriptions/internal/domain/repository"
+)
+
+type HouseholdRepository struct {
+	db *sql.DB
+}
+
+func NewHouseholdRepository(db *sql.DB) *HouseholdRepository {
+	return &HouseholdRepository{db: db}
+}
+
+func (r *HouseholdRepository) Create(ctx context.Context, household entity.Household) (*entity.Household, error) {
+	err := inTx(ctx, r.db, func(tx *sql.Tx) error {
+		id, err := nextID(ctx, tx, "households")
+		if err != nil {
+			return err
+		}
+
+		household.ID = id
+
+		_, err = tx.ExecContext(ctx, "INSERT INTO households (id, name, user_id) VALUES (?, ?, ?)",
+			household.ID, household.Name, household.UserID)
+		if err != nil {
+			return err
+		}
+
+		return writeMembers(ctx, tx, household)
+	})
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", repository.ErrCreateHousehold, err)
+	}
+
+	return &household, nil
+}
+
+func (r *HouseholdRepository) Get(ctx context.Context, id uint) (*entity.Household, error) {
+	households, err := r.query(ctx, "WHERE id = ?", id)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(households) == 0 {
+		return nil, repository.ErrNotFoundHousehold
+	}
+
+	return &households[0], nil
+}
+
+func (r *HouseholdRepository) GetAll(ctx context.Context) (repository.Households, error) {
+	return r.query(ctx, "")
+}
+
+func (r *HouseholdRepository) Update(ctx context.Context, household entity.Household) (*entity.Household, error) {
+	err := inTx(ctx, r.db, func(tx *sql.Tx) error {
+		res, err := tx.ExecContext(ctx, "UPDATE households SET name = ?, user_id = ? WHERE id = ?",
+			household.Name, household.UserID, household.ID)
+		if err != nil {
+			return fmt.Errorf("%w: %w", repository.ErrUpdateHousehold, err)
+		}
+
+		err = affected(res, repository.ErrUpdateHousehold)
+		if err != nil {
+			return err
+		}
+
+		err = writeMembers(ctx, tx, household)
+		if err != nil {
+			return fmt.Errorf("%w: %w", repository.ErrUpdateHousehold, err)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &household, nil
+}
+
+// Delete removes the members with the household and fails while a subscription is still shared with it.
+func (r *HouseholdRepository) Delete(ctx context.Context, id uint) error {
+	res, err := r.db.ExecContext(ctx, "DELETE FROM households WHERE id = ?", id)
+	if err != nil {
+		return fmt.Errorf("%w: %w", repository.ErrDeleteHousehold, err)
+	}
+
+	return affected(res, repository.ErrDeleteHousehold)
+}
+
+func (r *HouseholdRepository) Restore(ctx context.Context, household entity.Household) (*entity.Household, error) {
+	if household.ID == 0 {
+		return nil, repository.ErrCreateHousehold
+	}
+
+	err := inTx(ctx, r.db, func(tx *sql.Tx) error {
+		_, err := tx.ExecContext(ctx, "INSERT INTO households (id, name, user_id) VALUES (?, ?, ?) "+
+			"ON CONFLICT (id) DO UPDATE SET name = excluded.name, user_id = excluded.user_id",
+			household.ID, household.Name, household.UserID)
+		if err != nil {
+			return err
+		}
+
+		return writeMembers(ctx, tx, household)
+	})
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", repository.ErrCreateHousehold, err)
+	}
+
+	return &household, nil
+}
+
+// query returns the households matching where, which may only reference columns of households.
+func (r *HouseholdRepository) query(ctx context.Context, where string, args ...any) (repository.Households, error) {
+	rows, err := r.db.QueryContext(ctx, "SELECT id, name, user_id FROM households "+where+" ORDER BY id", args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var households repository.Households
+
+	index := make(map[uint]int)
+
+	for rows.Next() {
+		var h entity.Household
+
+		err = rows.Scan(&h.ID, &h.Name, &h.UserID)
+		if err != nil {
+			return nil, err
+		}
+
+		index[h.ID] = len(households)
+		households = append(households, h)
+	}
+
+	err = rows.Err()
+	if err != nil {
+		return nil, err
+	}
+
+	members, err := r.db.QueryContext(ctx, "SELECT household_id, id, name, user_id FROM household_members "+
+		"WHERE household_id IN (SELECT id FROM households "+where+") ORDER BY rowid", args...)
+	if err != nil {
+		return nil, err
+	}
+	defer members.Close()
+
+	for members.Next() {
+		var (
+			householdID uint
+			m           entity.HouseholdMember
+		)
+
+		err = members.Scan(&householdID, &m.ID, &m.Name, &m.UserID)
+		if err != nil {
+			return nil, err
+		}
+
+		h := &households[index[householdID]]
+		h.Members = append(h.Members, m)
+	}
+
+	return households, members.Err()
+}
+
+// writeMembers replaces the stored members of the household with its current ones.
+func writeMembers(ctx context.Context, tx *sql.Tx, household entity.Household) error {
+	_, err := tx.ExecContext(ctx, "DELETE FROM household_members WHERE household_id = ?", household.ID)
+	if err != nil {
+		return err
+	}
+
+	for _, m := range household.Members {
+		_, err = tx.ExecContext(ctx, "INSERT INTO household_members (household_id, id, name, user_id) VALUES (?, ?, ?, ?)",
+			household.ID, m.ID, m.Name, m.UserID)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,9 @@
+DROP INDEX subscriptions_user_id;
+DROP TABLE subscription_share_parts;
+DROP TABLE subscriptions;
+DROP TABLE household_members;
+DROP TABLE households;
+DROP TABLE categories;
+DROP TABLE cycles;
+DROP TABLE currencies;
+DROP TABLE users;
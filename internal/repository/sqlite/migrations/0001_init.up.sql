@@ -0,0 +1,63 @@
+CREATE TABLE users (
+    id            INTEGER PRIMARY KEY,
+    username      TEXT    NOT NULL UNIQUE,
+    password_hash TEXT    NOT NULL
+);
+
+CREATE TABLE currencies (
+    code    TEXT    PRIMARY KEY,
+    symbol  TEXT    NOT NULL DEFAULT '',
+    name    TEXT    NOT NULL DEFAULT '',
+    user_id INTEGER NOT NULL DEFAULT 0
+);
+
+CREATE TABLE cycles (
+    id      INTEGER PRIMARY KEY,
+    name    TEXT    NOT NULL,
+    days    INTEGER NOT NULL,
+    user_id INTEGER NOT NULL DEFAULT 0
+);
+
+CREATE TABLE categories (
+    id      INTEGER PRIMARY KEY,
+    name    TEXT    NOT NULL,
+    user_id INTEGER NOT NULL DEFAULT 0
+);
+
+CREATE TABLE households (
+    id      INTEGER PRIMARY KEY,
+    name    TEXT    NOT NULL,
+    user_id INTEGER NOT NULL DEFAULT 0
+);
+
+CREATE TABLE household_members (
+    household_id INTEGER NOT NULL REFERENCES households (id) ON DELETE CASCADE,
+    id           INTEGER NOT NULL,
+    name         TEXT    NOT NULL,
+    user_id      INTEGER NOT NULL DEFAULT 0,
+    PRIMARY KEY (household_id, id)
+);
+
+CREATE TABLE subscriptions (
+    id                INTEGER PRIMARY KEY,
+    user_id           INTEGER NOT NULL DEFAULT 0,
+    name              TEXT    NOT NULL,
+    note              TEXT    NOT NULL DEFAULT '',
+    logo              TEXT    NOT NULL DEFAULT '',
+    price             REAL    NOT NULL,
+    category_id       INTEGER REFERENCES categories (id),
+    cycle_id          INTEGER NOT NULL REFERENCES cycles (id),
+    currency_code     TEXT    NOT NULL REFERENCES currencies (code),
+    next_payment_date TEXT    NOT NULL DEFAULT '',
+    share_household   INTEGER REFERENCES households (id),
+    share_rule        TEXT    NOT NULL DEFAULT ''
+);
+
+CREATE TABLE subscription_share_parts (
+    subscription_id INTEGER NOT NULL REFERENCES subscriptions (id) ON DELETE CASCADE,
+    member_id       INTEGER NOT NULL,
+    value           REAL    NOT NULL,
+    PRIMARY KEY (subscription_id, member_id)
+);
+
+CREATE INDEX subscriptions_user_id ON subscriptions (user_id);
@@ -0,0 +1,4 @@
+ALTER TABLE subscriptions DROP COLUMN cancel_date;
+ALTER TABLE subscriptions DROP COLUMN resume_date;
+ALTER TABLE subscriptions DROP COLUMN trial_end_date;
+ALTER TABLE subscriptions DROP COLUMN status;
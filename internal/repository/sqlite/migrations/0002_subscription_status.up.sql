@@ -0,0 +1,4 @@
+ALTER TABLE subscriptions ADD COLUMN status TEXT NOT NULL DEFAULT '';
+ALTER TABLE subscriptions ADD COLUMN trial_end_date TEXT NOT NULL DEFAULT '';
+ALTER TABLE subscriptions ADD COLUMN resume_date TEXT NOT NULL DEFAULT '';
+ALTER TABLE subscriptions ADD COLUMN cancel_date TEXT NOT NULL DEFAULT '';
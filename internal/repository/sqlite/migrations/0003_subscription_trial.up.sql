@@ -0,0 +1 @@
+ALTER TABLE subscriptions ADD COLUMN trial_start_date TEXT NOT NULL DEFAULT '';
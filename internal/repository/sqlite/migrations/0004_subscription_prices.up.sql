@@ -0,0 +1,7 @@
+-- The initial price is stored with an empty effective date.
+CREATE TABLE subscription_prices (
+    subscription_id INTEGER NOT NULL REFERENCES subscriptions (id) ON DELETE CASCADE,
+    effective_date  TEXT    NOT NULL DEFAULT '',
+    price           REAL    NOT NULL,
+    PRIMARY KEY (subscription_id, effective_date)
+);
@@ -0,0 +1,10 @@
+-- A budget without a category caps all subscriptions of the user.
+CREATE TABLE budgets (
+    id            INTEGER PRIMARY KEY,
+    user_id       INTEGER NOT NULL DEFAULT 0,
+    category_id   INTEGER REFERENCES categories (id),
+    period        TEXT    NOT NULL,
+    amount        REAL    NOT NULL,
+    currency_code TEXT    NOT NULL,
+    UNIQUE (user_id, category_id)
+);
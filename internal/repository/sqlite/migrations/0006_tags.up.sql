@@ -0,0 +1,11 @@
+CREATE TABLE tags (
+    id      INTEGER PRIMARY KEY,
+    name    TEXT    NOT NULL,
+    user_id INTEGER NOT NULL DEFAULT 0
+);
+
+CREATE TABLE subscription_tags (
+    subscription_id INTEGER NOT NULL REFERENCES subscriptions (id) ON DELETE CASCADE,
+    tag_id          INTEGER NOT NULL REFERENCES tags (id) ON DELETE CASCADE,
+    PRIMARY KEY (subscription_id, tag_id)
+);
@@ -0,0 +1,2 @@
+-- A category without a parent is a top level category.
+ALTER TABLE categories ADD COLUMN parent_id INTEGER REFERENCES categories (id);
@@ -0,0 +1,2 @@
+ALTER TABLE subscriptions DROP COLUMN payment_method_id;
+DROP TABLE payment_methods;
@@ -0,0 +1,12 @@
+-- A payment method without an expiry has a zero expiry year and month.
+CREATE TABLE payment_methods (
+    id           INTEGER PRIMARY KEY,
+    user_id      INTEGER NOT NULL DEFAULT 0,
+    label        TEXT    NOT NULL,
+    type         TEXT    NOT NULL,
+    last_four    TEXT    NOT NULL DEFAULT '',
+    expiry_year  INTEGER NOT NULL DEFAULT 0,
+    expiry_month INTEGER NOT NULL DEFAULT 0
+);
+
+ALTER TABLE subscriptions ADD COLUMN payment_method_id INTEGER REFERENCES payment_methods (id);
@@ -0,0 +1,4 @@
+ALTER TABLE subscriptions DROP COLUMN auto_renew;
+ALTER TABLE subscriptions DROP COLUMN notice_days;
+ALTER TABLE subscriptions DROP COLUMN contract_end_date;
+ALTER TABLE subscriptions DROP COLUMN contract_start_date;
@@ -0,0 +1,4 @@
+ALTER TABLE subscriptions ADD COLUMN contract_start_date TEXT NOT NULL DEFAULT '';
+ALTER TABLE subscriptions ADD COLUMN contract_end_date TEXT NOT NULL DEFAULT '';
+ALTER TABLE subscriptions ADD COLUMN notice_days INTEGER NOT NULL DEFAULT 0;
+ALTER TABLE subscriptions ADD COLUMN auto_renew INTEGER NOT NULL DEFAULT 0;
@@ -0,0 +1 @@
+ALTER TABLE cycles ADD COLUMN rule TEXT NOT NULL DEFAULT '';
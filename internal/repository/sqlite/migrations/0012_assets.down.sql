@@ -0,0 +1,2 @@
+ALTER TABLE subscriptions DROP COLUMN logo_asset_id;
+DROP TABLE assets;
@@ -0,0 +1,11 @@
+-- The image content lives in the blob store; created_at is an RFC 3339 timestamp.
+CREATE TABLE assets (
+    id           INTEGER PRIMARY KEY,
+    user_id      INTEGER NOT NULL DEFAULT 0,
+    content_type TEXT    NOT NULL,
+    size         INTEGER NOT NULL,
+    checksum     TEXT    NOT NULL,
+    created_at   TEXT    NOT NULL
+);
+
+ALTER TABLE subscriptions ADD COLUMN logo_asset_id INTEGER REFERENCES assets (id);
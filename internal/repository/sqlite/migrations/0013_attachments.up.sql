@@ -0,0 +1,14 @@
+-- The file content lives in the blob store. payment_date is empty for a file about the whole subscription.
+CREATE TABLE attachments (
+    id              INTEGER PRIMARY KEY,
+    user_id         INTEGER NOT NULL DEFAULT 0,
+    subscription_id INTEGER NOT NULL REFERENCES subscriptions (id) ON DELETE CASCADE,
+    payment_date    TEXT    NOT NULL DEFAULT '',
+    name            TEXT    NOT NULL,
+    content_type    TEXT    NOT NULL,
+    size            INTEGER NOT NULL,
+    checksum        TEXT    NOT NULL,
+    created_at      TEXT    NOT NULL
+);
+
+CREATE INDEX attachments_subscription_id ON attachments (subscription_id);
@@ -0,0 +1,5 @@
+-- The last ID handed out per table, so the ID of a deleted row is never reused.
+CREATE TABLE id_sequences (
+    name TEXT    PRIMARY KEY,
+    last INTEGER NOT NULL
+);
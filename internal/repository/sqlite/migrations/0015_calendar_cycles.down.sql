@@ -0,0 +1 @@
+ALTER TABLE cycles DROP COLUMN calendar;
@@ -0,0 +1,5 @@
+-- Cycles that follow the calendar rather than count days. The cycles of 30 and 365 days were
+-- treated as monthly and yearly before the flag existed, so they keep doing so.
+ALTER TABLE cycles ADD COLUMN calendar INTEGER NOT NULL DEFAULT 0;
+
+UPDATE cycles SET calendar = 1 WHERE rule = '' AND days IN (30, 365);
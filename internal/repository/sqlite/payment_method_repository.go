@@ -0,0 +1,125 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"git.home/alex/go-subscriptions/internal/domain/entity"
+	"git.home/alex/go-subscriptions/internal/domain/repository"
+)
+
+const selectPaymentMethods = "SELECT id, user_id, label, type, last_four, expiry_year, expiry_month FROM payment_methods"
+
+type PaymentMethodRepository struct {
+	db *sql.DB
+}
+
+func NewPaymentMethodRepository(db *sql.DB) *PaymentMethodRepository {
+	return &PaymentMethodRepository{db: db}
+}
+
+func (r *PaymentMethodRepository) Create(ctx context.Context, paymentMethod entity.PaymentMethod) (*entity.PaymentMethod, error) {
+	err := inTx(ctx, r.db, func(tx *sql.Tx) error {
+		id, err := nextID(ctx, tx, "payment_methods")
+		if err != nil {
+			return err
+		}
+
+		paymentMethod.ID = id
+
+		_, err = tx.ExecContext(ctx, "INSERT INTO payment_methods (id, user_id, label, type, last_four, expiry_year, expiry_month) "+
+			"VALUES (?, ?, ?, ?, ?, ?, ?)", paymentMethodArgs(paymentMethod)...)
+
+		return err
+	})
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", repository.ErrCreatePaymentMethod, err)
+	}
+
+	return &paymentMethod, nil
+}
+
+func (r *PaymentMethodRepository) Get(ctx context.Context, id uint) (*entity.PaymentMethod, error) {
+	paymentMethod, err := scanPaymentMethod(r.db.QueryRowContext(ctx, selectPaymentMethods+" WHERE id = ?", id))
+	if err != nil {
+		return nil, notFound(err, repository.ErrNotFoundPaymentMethod)
+	}
+
+	return &paymentMethod, nil
+}
+
+func (r *PaymentMethodRepository) GetAll(ctx context.Context) (repository.PaymentMethods, error) {
+	rows, err := r.db.QueryContext(ctx, selectPaymentMethods+" ORDER BY id")
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var paymentMethods repository.PaymentMethods
+
+	for rows.Next() {
+		paymentMethod, err := scanPaymentMethod(rows)
+		if err != nil {
+			return nil, err
+		}
+
+		paymentMethods = append(paymentMethods, paymentMethod)
+	}
+
+	return paymentMethods, rows.Err()
+}
+
+func (r *PaymentMethodRepository) Update(ctx context.Context, paymentMethod entity.PaymentMethod) (*entity.PaymentMethod, error) {
+	res, err := r.db.ExecContext(ctx, "UPDATE payment_methods SET user_id = ?, label = ?, type = ?, last_four = ?, "+
+		"expiry_year = ?, expiry_month = ? WHERE id = ?", append(paymentMethodArgs(paymentMethod)[1:], paymentMethod.ID)...)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", repository.ErrUpdatePaymentMethod, err)
+	}
+
+	err = affected(res, repository.ErrNotFoundPaymentMethod)
+	if err != nil {
+		return nil, err
+	}
+
+	return &paymentMethod, nil
+}
+
+// Delete fails while a subscription is still charged to the payment method.
+func (r *PaymentMethodRepository) Delete(ctx context.Context, id uint) error {
+	res, err := r.db.ExecContext(ctx, "DELETE FROM payment_methods WHERE id = ?", id)
+	if err != nil {
+		return fmt.Errorf("%w: %w", repository.ErrDeletePaymentMethod, err)
+	}
+
+	return affected(res, repository.ErrNotFoundPaymentMethod)
+}
+
+func (r *PaymentMethodRepository) Restore(ctx context.Context, paymentMethod entity.PaymentMethod) (*entity.PaymentMethod, error) {
+	if paymentMethod.ID == 0 {
+		return nil, repository.ErrCreatePaymentMethod
+	}
+
+	_, err := r.db.ExecContext(ctx, "INSERT INTO payment_methods (id, user_id, label, type, last_four, expiry_year, expiry_month) "+
+		"VALUES (?, ?, ?, ?, ?, ?, ?) ON CONFLICT (id) DO UPDATE SET user_id = excluded.user_id, label = excluded.label, "+
+		"type = excluded.type, last_four = excluded.last_four, expiry_year = excluded.expiry_year, expiry_month = excluded.expiry_month",
+		paymentMethodArgs(paymentMethod)...)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", repository.ErrCreatePaymentMethod, err)
+	}
+
+	return &paymentMethod, nil
+}
+
+// paymentMethodArgs lists the columns in the order of selectPaymentMethods.
+func paymentMethodArgs(m entity.PaymentMethod) []any {
+	return []any{m.ID, m.UserID, m.Label, string(m.Type), m.LastFour, m.ExpiryYear, int(m.ExpiryMonth)}
+}
+
+func scanPaymentMethod(row scanner) (entity.PaymentMethod, error) {
+	var m entity.PaymentMethod
+
+	err := row.Scan(&m.ID, &m.UserID, &m.Label, &m.Type, &m.LastFour, &m.ExpiryYear, &m.ExpiryMonth)
+
+	return m, err
+}
@@ -0,0 +1,83 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"embed"
+	"errors"
+	"fmt"
+	"io/fs"
+
+	"git.home/alex/go-subscriptions/internal/migration"
+	_ "modernc.org/sqlite"
+)
+
+var (
+	ErrPendingMigrations = errors.New("the database schema is not up to date, run `subscriptions db migrate`")
+)
+
+//go:embed migrations/*.sql
+var migrations embed.FS
+
+// Migrations returns the schema migrations of the SQLite storage.
+func Migrations() fs.FS {
+	sub, _ := fs.Sub(migrations, "migrations")
+
+	return sub
+}
+
+// Open opens the database file, creating it when missing.
+func Open(path string) (*sql.DB, error) {
+	db, err := sql.Open("sqlite", "file:"+path+"?_pragma=foreign_keys(1)&_pragma=busy_timeout(5000)")
+	if err != nil {
+		return nil, err
+	}
+
+	// SQLite allows one writer at a time; a single connection avoids "database is locked" errors.
+	db.SetMaxOpenConns(1)
+
+	err = db.Ping()
+	if err != nil {
+		_ = db.Close()
+		return nil, err
+	}
+
+	return db, nil
+}
+
+func NewMigrator(db *sql.DB, cfgs ...migration.Configuration) (*migration.Migrator, error) {
+	return migration.New(db, Migrations(), cfgs...)
+}
+
+// Migrate applies the pending migrations.
+func Migrate(ctx context.Context, db *sql.DB) error {
+	m, err := NewMigrator(db)
+	if err != nil {
+		return err
+	}
+
+	_, err = m.Up(ctx)
+
+	return err
+}
+
+// CheckSchema fails when a migration hasn't been applied, so the repositories never run against an older schema.
+func CheckSchema(ctx context.Context, db *sql.DB) error {
+	m, err := NewMigrator(db)
+	if err != nil {
+		return err
+	}
+
+	statuses, err := m.Status(ctx)
+	if err != nil {
+		return err
+	}
+
+	for _, s := range statuses {
+		if !s.Applied {
+			return fmt.Errorf("%w: %04d %s is pending", ErrPendingMigrations, s.Version, s.Name)
+		}
+	}
+
+	return nil
+}
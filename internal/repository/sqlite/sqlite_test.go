@@ -0,0 +1,67 @@
+package sqlite_test
+
+import (
+	"context"
+	"database/sql"
+	"path/filepath"
+	"testing"
+
+	"git.home/alex/go-subscriptions/internal/repository/sqlite"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newDB returns a migrated database that is removed after the test.
+func newDB(t *testing.T) *sql.DB {
+	t.Helper()
+
+	db, err := sqlite.Open(filepath.Join(t.TempDir(), "subscriptions.db"))
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = db.Close() })
+
+	require.NoError(t, sqlite.Migrate(context.Background(), db))
+
+	return db
+}
+
+func TestCheckSchema(t *testing.T) {
+	ctx := context.Background()
+	db := newDB(t)
+
+	require.NoError(t, sqlite.CheckSchema(ctx, db))
+
+	m, err := sqlite.NewMigrator(db)
+	require.NoError(t, err)
+	_, err = m.Down(ctx, 1)
+	require.NoError(t, err)
+
+	assert.ErrorIs(t, sqlite.CheckSchema(ctx, db), sqlite.ErrPendingMigrations)
+}
+
+func TestMigrations(t *testing.T) {
+	ctx := context.Background()
+
+	db, err := sqlite.Open(filepath.Join(t.TempDir(), "subscriptions.db"))
+	require.NoError(t, err)
+	defer db.Close()
+
+	require.NoError(t, sqlite.Migrate(ctx, db))
+
+	var tables int
+	require.NoError(t, db.QueryRowContext(ctx,
+		"SELECT count(*) FROM sqlite_master WHERE type = 'table' AND name NOT LIKE 'schema_migrations%'").Scan(&tables))
+	assert.Equal(t, 16, tables)
+
+	m, err := sqlite.NewMigrator(db)
+	require.NoError(t, err)
+
+	statuses, err := m.Status(ctx)
+	require.NoError(t, err)
+
+	_, err = m.Down(ctx, len(statuses))
+	require.NoError(t, err)
+
+	require.NoError(t, db.QueryRowContext(ctx,
+		"SELECT count(*) FROM sqlite_master WHERE type = 'table' AND name NOT LIKE 'schema_migrations%'").Scan(&tables))
+	assert.Zero(t, tables)
+}
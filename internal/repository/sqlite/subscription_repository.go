@@ -0,0 +1,334 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"git.home/alex/go-subscriptions/internal/domain/entity"
+	"git.home/alex/go-subscriptions/internal/domain/repository"
+)
+
+// selectSubscriptions reads the category, cycle and currency as they are now rather than as they were
+// when the subscription was saved.
+const selectSubscriptions = `SELECT s.id, s.user_id, s.name, s.note, s.logo, s.price,
+	coalesce(s.category_id, 0), coalesce(c.name, ''), coalesce(c.user_id, 0), coalesce(c.parent_id, 0),
+	s.cycle_id, coalesce(cy.name, ''), coalesce(cy.days, 0), coalesce(cy.rule, ''), coalesce(cy.calendar, 0), coalesce(cy.user_id, 0),
+	s.currency_code, coalesce(cu.symbol, ''), coalesce(cu.name, ''), coalesce(cu.user_id, 0),
+	s.next_payment_date, coalesce(s.share_household, 0), s.share_rule, s.status,
+	s.trial_start_date, s.trial_end_date, s.resume_date, s.cancel_date, coalesce(s.payment_method_id, 0),
+	s.contract_start_date, s.contract_end_date, s.notice_days, s.auto_renew, coalesce(s.logo_asset_id, 0)
+FROM subscriptions s
+	LEFT JOIN categories c ON c.id = s.category_id
+	LEFT JOIN cycles cy ON cy.id = s.cycle_id
+	LEFT JOIN currencies cu ON cu.code = s.currency_code`
+
+const subscriptionColumns = `id, user_id, name, note, logo, price, category_id, cycle_id, currency_code, next_payment_date,
+	share_household, share_rule, status, trial_start_date, trial_end_date, resume_date, cancel_date, payment_method_id,
+	contract_start_date, contract_end_date, notice_days, auto_renew, logo_asset_id`
+
+type SubscriptionRepository struct {
+	db *sql.DB
+}
+
+func NewSubscriptionRepository(db *sql.DB) *SubscriptionRepository {
+	return &SubscriptionRepository{db: db}
+}
+
+func (r *SubscriptionRepository) Create(ctx context.Context, subscription entity.Subscription) (*entity.Subscription, error) {
+	err := inTx(ctx, r.db, func(tx *sql.Tx) error {
+		id, err := nextID(ctx, tx, "subscriptions")
+		if err != nil {
+			return err
+		}
+
+		subscription.ID = id
+
+		_, err = tx.ExecContext(ctx, "INSERT INTO subscriptions ("+subscriptionColumns+") "+
+			"VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)", subscriptionArgs(subscription)...)
+		if err != nil {
+			return err
+		}
+
+		return writeSubscriptionDetails(ctx, tx, subscription)
+	})
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", repository.ErrCreateSubscription, err)
+	}
+
+	return &subscription, nil
+}
+
+func (r *SubscriptionRepository) Get(ctx context.Context, id uint) (*entity.Subscription, error) {
+	subscriptions, err := r.query(ctx, "WHERE s.id = ?", id)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(subscriptions) == 0 {
+		return nil, repository.ErrNotFoundSubscription
+	}
+
+	return &subscriptions[0], nil
+}
+
+func (r *SubscriptionRepository) GetAll(ctx context.Context) (repository.Subscriptions, error) {
+	return r.query(ctx, "")
+}
+
+func (r *SubscriptionRepository) Update(ctx context.Context, subscription entity.Subscription) (*entity.Subscription, error) {
+	err := inTx(ctx, r.db, func(tx *sql.Tx) error {
+		res, err := tx.ExecContext(ctx, `UPDATE subscriptions SET user_id = ?, name = ?, note = ?, logo = ?, price = ?,
+			category_id = ?, cycle_id = ?, currency_code = ?, next_payment_date = ?, share_household = ?, share_rule = ?,
+			status = ?, trial_start_date = ?, trial_end_date = ?, resume_date = ?, cancel_date = ?, payment_method_id = ?,
+			contract_start_date = ?, contract_end_date = ?, notice_days = ?, auto_renew = ?, logo_asset_id = ?
+			WHERE id = ?`, append(subscriptionArgs(subscription)[1:], subscription.ID)...)
+		if err != nil {
+			return fmt.Errorf("%w: %w", repository.ErrUpdateSubscription, err)
+		}
+
+		err = affected(res, repository.ErrUpdateSubscription)
+		if err != nil {
+			return err
+		}
+
+		err = writeSubscriptionDetails(ctx, tx, subscription)
+		if err != nil {
+			return fmt.Errorf("%w: %w", repository.ErrUpdateSubscription, err)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &subscription, nil
+}
+
+// Delete removes the price history, tags, share and attachment records with the subscription.
+func (r *SubscriptionRepository) Delete(ctx context.Context, id uint) error {
+	res, err := r.db.ExecContext(ctx, "DELETE FROM subscriptions WHERE id = ?", id)
+	if err != nil {
+		return fmt.Errorf("%w: %w", repository.ErrDeleteSubscription, err)
+	}
+
+	return affected(res, repository.ErrDeleteSubscription)
+}
+
+func (r *SubscriptionRepository) Restore(ctx context.Context, subscription entity.Subscription) (*entity.Subscription, error) {
+	if subscription.ID == 0 {
+		return nil, repository.ErrCreateSubscription
+	}
+
+	err := inTx(ctx, r.db, func(tx *sql.Tx) error {
+		_, err := tx.ExecContext(ctx, "INSERT INTO subscriptions ("+subscriptionColumns+") "+
+			"VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?) ON CONFLICT (id) DO UPDATE SET "+
+			`user_id = excluded.user_id, name = excluded.name, note = excluded.note, logo = excluded.logo,
+			price = excluded.price, category_id = excluded.category_id, cycle_id = excluded.cycle_id,
+			currency_code = excluded.currency_code, next_payment_date = excluded.next_payment_date,
+			share_household = excluded.share_household, share_rule = excluded.share_rule, status = excluded.status,
+			trial_start_date = excluded.trial_start_date, trial_end_date = excluded.trial_end_date,
+			resume_date = excluded.resume_date, cancel_date = excluded.cancel_date,
+			payment_method_id = excluded.payment_method_id, contract_start_date = excluded.contract_start_date,
+			contract_end_date = excluded.contract_end_date, notice_days = excluded.notice_days,
+			auto_renew = excluded.auto_renew, logo_asset_id = excluded.logo_asset_id`, subscriptionArgs(subscription)...)
+		if err != nil {
+			return err
+		}
+
+		return writeSubscriptionDetails(ctx, tx, subscription)
+	})
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", repository.ErrCreateSubscription, err)
+	}
+
+	return &subscription, nil
+}
+
+// query returns the subscriptions matching where, which references the subscriptions table as s.
+func (r *SubscriptionRepository) query(ctx context.Context, where string, args ...any) (repository.Subscriptions, error) {
+	rows, err := r.db.QueryContext(ctx, selectSubscriptions+" "+where+" ORDER BY s.id", args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var subscriptions repository.Subscriptions
+
+	for rows.Next() {
+		subscription, err := scanSubscription(rows)
+		if err != nil {
+			return nil, err
+		}
+
+		subscriptions = append(subscriptions, subscription)
+	}
+
+	err = rows.Err()
+	if err != nil {
+		return nil, err
+	}
+
+	index := make(map[uint]*entity.Subscription, len(subscriptions))
+	for i := range subscriptions {
+		index[subscriptions[i].ID] = &subscriptions[i]
+	}
+
+	ids := "SELECT s.id FROM subscriptions s " + where
+
+	var (
+		subscriptionID uint
+		change         entity.PriceChange
+		tagID          uint
+		part           entity.SharePart
+	)
+
+	err = r.each(ctx, "SELECT subscription_id, effective_date, price FROM subscription_prices WHERE subscription_id IN ("+ids+")",
+		args, []any{&subscriptionID, scanTime((*time.Time)(&change.EffectiveDate)), &change.Price}, func() {
+			s := index[subscriptionID]
+			s.Prices = append(s.Prices, change)
+		})
+	if err != nil {
+		return nil, err
+	}
+
+	err = r.each(ctx, "SELECT subscription_id, tag_id FROM subscription_tags WHERE subscription_id IN ("+ids+")",
+		args, []any{&subscriptionID, &tagID}, func() {
+			s := index[subscriptionID]
+			s.TagIDs = append(s.TagIDs, tagID)
+		})
+	if err != nil {
+		return nil, err
+	}
+
+	err = r.each(ctx, "SELECT subscription_id, member_id, value FROM subscription_share_parts WHERE subscription_id IN ("+ids+")",
+		args, []any{&subscriptionID, &part.MemberID, &part.Value}, func() {
+			if s := index[subscriptionID]; s.Share != nil {
+				s.Share.Parts = append(s.Share.Parts, part)
+			}
+		})
+	if err != nil {
+		return nil, err
+	}
+
+	return subscriptions, nil
+}
+
+// each scans every row of query into dest and calls add after each one. The rows come in the order
+// they were written, which keeps the price history, tags and share parts in their original order.
+func (r *SubscriptionRepository) each(ctx context.Context, query string, args []any, dest []any, add func()) error {
+	rows, err := r.db.QueryContext(ctx, query+" ORDER BY rowid", args...)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		err = rows.Scan(dest...)
+		if err != nil {
+			return err
+		}
+
+		add()
+	}
+
+	return rows.Err()
+}
+
+// subscriptionArgs lists the values in the order of subscriptionColumns.
+func subscriptionArgs(s entity.Subscription) []any {
+	var share struct {
+		householdID uint
+		rule        string
+	}
+
+	if s.Share != nil {
+		share.householdID, share.rule = s.Share.HouseholdID, string(s.Share.Rule)
+	}
+
+	var contract entity.Contract
+	if s.Contract != nil {
+		contract = *s.Contract
+	}
+
+	return []any{
+		s.ID, s.UserID, s.Name, s.Note, s.Logo, s.Price, nullID(s.Category.ID), s.Cycle.ID, s.Currency.Code,
+		formatTime(time.Time(s.NextPaymentDate)), nullID(share.householdID), share.rule, string(s.Status),
+		formatTime(time.Time(s.TrialStartDate)), formatTime(time.Time(s.TrialEndDate)), formatTime(time.Time(s.ResumeDate)),
+		formatTime(time.Time(s.CancelDate)), nullID(s.PaymentMethodID), formatTime(time.Time(contract.StartDate)),
+		formatTime(time.Time(contract.EndDate)), contract.NoticeDays, contract.AutoRenew, nullID(s.LogoAssetID),
+	}
+}
+
+func scanSubscription(row scanner) (entity.Subscription, error) {
+	var (
+		s           entity.Subscription
+		householdID uint
+		rule        string
+		contract    entity.Contract
+	)
+
+	err := row.Scan(&s.ID, &s.UserID, &s.Name, &s.Note, &s.Logo, &s.Price,
+		&s.Category.ID, &s.Category.Name, &s.Category.UserID, &s.Category.ParentID,
+		&s.Cycle.ID, &s.Cycle.Name, &s.Cycle.Days, &s.Cycle.Rule, &s.Cycle.Calendar, &s.Cycle.UserID,
+		&s.Currency.Code, &s.Currency.Symbol, &s.Currency.Name, &s.Currency.UserID,
+		scanTime((*time.Time)(&s.NextPaymentDate)), &householdID, &rule, &s.Status,
+		scanTime((*time.Time)(&s.TrialStartDate)), scanTime((*time.Time)(&s.TrialEndDate)),
+		scanTime((*time.Time)(&s.ResumeDate)), scanTime((*time.Time)(&s.CancelDate)), &s.PaymentMethodID,
+		scanTime((*time.Time)(&contract.StartDate)), scanTime((*time.Time)(&contract.EndDate)),
+		&contract.NoticeDays, &contract.AutoRenew, &s.LogoAssetID)
+	if err != nil {
+		return s, err
+	}
+
+	if householdID != 0 {
+		s.Share = &entity.SubscriptionShare{HouseholdID: householdID, Rule: entity.SplitRule(rule)}
+	}
+
+	if contract != (entity.Contract{}) {
+		s.Contract = &contract
+	}
+
+	return s, nil
+}
+
+// writeSubscriptionDetails replaces the stored price history, tags and share parts with the current ones.
+func writeSubscriptionDetails(ctx context.Context, tx *sql.Tx, s entity.Subscription) error {
+	for _, table := range []string{"subscription_prices", "subscription_tags", "subscription_share_parts"} {
+		_, err := tx.ExecContext(ctx, "DELETE FROM "+table+" WHERE subscription_id = ?", s.ID)
+		if err != nil {
+			return err
+		}
+	}
+
+	for _, change := range s.Prices {
+		_, err := tx.ExecContext(ctx, "INSERT INTO subscription_prices (subscription_id, effective_date, price) VALUES (?, ?, ?)",
+			s.ID, formatTime(time.Time(change.EffectiveDate)), change.Price)
+		if err != nil {
+			return err
+		}
+	}
+
+	for _, tagID := range s.TagIDs {
+		_, err := tx.ExecContext(ctx, "INSERT INTO subscription_tags (subscription_id, tag_id) VALUES (?, ?)", s.ID, tagID)
+		if err != nil {
+			return err
+		}
+	}
+
+	if s.Share == nil {
+		return nil
+	}
+
+	for _, part := range s.Share.Parts {
+		_, err := tx.ExecContext(ctx, "INSERT INTO subscription_share_parts (subscription_id, member_id, value) VALUES (?, ?, ?)",
+			s.ID, part.MemberID, part.Value)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
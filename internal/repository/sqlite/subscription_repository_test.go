@@ -0,0 +1,99 @@
+package sqlite_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"git.home/alex/go-subscriptions/internal/domain/entity"
+	"git.home/alex/go-subscriptions/internal/domain/repository"
+	"git.home/alex/go-subscriptions/internal/factory"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func date(year int, month time.Month, day int) entity.PaymentDate {
+	return entity.PaymentDate(time.Date(year, month, day, 0, 0, 0, 0, time.UTC))
+}
+
+func TestSubscriptionRepository(t *testing.T) {
+	ctx := context.Background()
+
+	rf, err := factory.NewRepositoryFactory(factory.WithSqliteRepository(newDB(t)))
+	require.NoError(t, err)
+
+	category, err := rf.CategoryRepository.Create(ctx, entity.Category{Name: "Video", UserID: 1})
+	require.NoError(t, err)
+	cycle, err := rf.CycleRepository.Create(ctx, entity.Monthly)
+	require.NoError(t, err)
+	currency, err := rf.CurrencyRepository.Create(ctx, entity.USD)
+	require.NoError(t, err)
+	tag, err := rf.TagRepository.Create(ctx, entity.Tag{Name: "Work", UserID: 1})
+	require.NoError(t, err)
+	household, err := rf.HouseholdRepository.Create(ctx, entity.Household{
+		Name:    "Home",
+		UserID:  1,
+		Members: []entity.HouseholdMember{{ID: 1, Name: "Alice", UserID: 1}, {ID: 2, Name: "Bob"}},
+	})
+	require.NoError(t, err)
+
+	subscription := entity.Subscription{
+		UserID:          1,
+		Name:            "Streaming",
+		Price:           12,
+		Category:        *category,
+		Cycle:           *cycle,
+		Currency:        *currency,
+		NextPaymentDate: date(2024, time.May, 1),
+		Status:          entity.StatusTrial,
+		TrialStartDate:  date(2024, time.April, 1),
+		TrialEndDate:    date(2024, time.May, 1),
+		Prices:          []entity.PriceChange{{Price: 10}, {Price: 12, EffectiveDate: date(2024, time.March, 1)}},
+		TagIDs:          []uint{tag.ID},
+		Share: &entity.SubscriptionShare{
+			HouseholdID: household.ID,
+			Rule:        entity.SplitFixed,
+			Parts:       []entity.SharePart{{MemberID: 2, Value: 4}, {MemberID: 1, Value: 8}},
+		},
+		Contract: &entity.Contract{StartDate: date(2024, time.January, 1), EndDate: date(2025, time.January, 1), AutoRenew: true},
+	}
+
+	created, err := rf.SubscriptionRepository.Create(ctx, subscription)
+	require.NoError(t, err)
+	assert.Equal(t, uint(1), created.ID)
+
+	got, err := rf.SubscriptionRepository.Get(ctx, created.ID)
+	require.NoError(t, err)
+	assert.Equal(t, created, got)
+
+	// The category is read as it is now.
+	category.Name = "Films"
+	_, err = rf.CategoryRepository.Update(ctx, *category)
+	require.NoError(t, err)
+
+	got.Share, got.Contract, got.TagIDs = nil, nil, nil
+	got.Status, got.TrialStartDate, got.TrialEndDate = entity.StatusActive, entity.PaymentDate{}, entity.PaymentDate{}
+	_, err = rf.SubscriptionRepository.Update(ctx, *got)
+	require.NoError(t, err)
+
+	all, err := rf.SubscriptionRepository.GetAll(ctx)
+	require.NoError(t, err)
+	require.Len(t, all, 1)
+	assert.Equal(t, "Films", all[0].Category.Name)
+	assert.Nil(t, all[0].Share)
+	assert.Nil(t, all[0].Contract)
+	assert.Empty(t, all[0].TagIDs)
+	assert.Equal(t, subscription.Prices, all[0].Prices)
+
+	assert.ErrorIs(t, rf.CycleRepository.Delete(ctx, cycle.ID), repository.ErrDeleteCycle, "the subscription bills on it")
+
+	require.NoError(t, rf.SubscriptionRepository.Delete(ctx, created.ID))
+
+	_, err = rf.SubscriptionRepository.Get(ctx, created.ID)
+	assert.Equal(t, repository.ErrNotFoundSubscription, err)
+	_, err = rf.SubscriptionRepository.Update(ctx, *got)
+	assert.Equal(t, repository.ErrUpdateSubscription, err)
+	assert.Equal(t, repository.ErrDeleteSubscription, rf.SubscriptionRepository.Delete(ctx, created.ID))
+
+	require.NoError(t, rf.CycleRepository.Delete(ctx, cycle.ID))
+}
@@ -0,0 +1,117 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"git.home/alex/go-subscriptions/internal/domain/entity"
+	"git.home/alex/go-subscriptions/internal/domain/repository"
+)
+
+const selectTags = "SELECT id, name, user_id FROM tags"
+
+type TagRepository struct {
+	db *sql.DB
+}
+
+func NewTagRepository(db *sql.DB) *TagRepository {
+	return &TagRepository{db: db}
+}
+
+func (r *TagRepository) Create(ctx context.Context, tag entity.Tag) (*entity.Tag, error) {
+	err := inTx(ctx, r.db, func(tx *sql.Tx) error {
+		id, err := nextID(ctx, tx, "tags")
+		if err != nil {
+			return err
+		}
+
+		tag.ID = id
+
+		_, err = tx.ExecContext(ctx, "INSERT INTO tags (id, name, user_id) VALUES (?, ?, ?)", tag.ID, tag.Name, tag.UserID)
+
+		return err
+	})
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", repository.ErrCreateTag, err)
+	}
+
+	return &tag, nil
+}
+
+func (r *TagRepository) Get(ctx context.Context, id uint) (*entity.Tag, error) {
+	tag, err := scanTag(r.db.QueryRowContext(ctx, selectTags+" WHERE id = ?", id))
+	if err != nil {
+		return nil, notFound(err, repository.ErrNotFoundTag)
+	}
+
+	return &tag, nil
+}
+
+func (r *TagRepository) GetAll(ctx context.Context) (repository.Tags, error) {
+	rows, err := r.db.QueryContext(ctx, selectTags+" ORDER BY id")
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var tags repository.Tags
+
+	for rows.Next() {
+		tag, err := scanTag(rows)
+		if err != nil {
+			return nil, err
+		}
+
+		tags = append(tags, tag)
+	}
+
+	return tags, rows.Err()
+}
+
+func (r *TagRepository) Update(ctx context.Context, tag entity.Tag) (*entity.Tag, error) {
+	res, err := r.db.ExecContext(ctx, "UPDATE tags SET name = ?, user_id = ? WHERE id = ?", tag.Name, tag.UserID, tag.ID)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", repository.ErrUpdateTag, err)
+	}
+
+	err = affected(res, repository.ErrNotFoundTag)
+	if err != nil {
+		return nil, err
+	}
+
+	return &tag, nil
+}
+
+// Delete removes the tag from the subscriptions carrying it as well.
+func (r *TagRepository) Delete(ctx context.Context, id uint) error {
+	res, err := r.db.ExecContext(ctx, "DELETE FROM tags WHERE id = ?", id)
+	if err != nil {
+		return fmt.Errorf("%w: %w", repository.ErrDeleteTag, err)
+	}
+
+	return affected(res, repository.ErrNotFoundTag)
+}
+
+func (r *TagRepository) Restore(ctx context.Context, tag entity.Tag) (*entity.Tag, error) {
+	if tag.ID == 0 {
+		return nil, repository.ErrCreateTag
+	}
+
+	_, err := r.db.ExecContext(ctx, "INSERT INTO tags (id, name, user_id) VALUES (?, ?, ?) "+
+		"ON CONFLICT (id) DO UPDATE SET name = excluded.name, user_id = excluded.user_id",
+		tag.ID, tag.Name, tag.UserID)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", repository.ErrCreateTag, err)
+	}
+
+	return &tag, nil
+}
+
+func scanTag(row scanner) (entity.Tag, error) {
+	var t entity.Tag
+
+	err := row.Scan(&t.ID, &t.Name, &t.UserID)
+
+	return t, err
+}
@@ -0,0 +1,131 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"git.home/alex/go-subscriptions/internal/domain/entity"
+	"git.home/alex/go-subscriptions/internal/domain/repository"
+)
+
+const selectUsers = "SELECT id, username, password_hash, timezone FROM users"
+
+type UserRepository struct {
+	db *sql.DB
+}
+
+func NewUserRepository(db *sql.DB) *UserRepository {
+	return &UserRepository{db: db}
+}
+
+func (r *UserRepository) Create(ctx context.Context, user entity.User) (*entity.User, error) {
+	err := inTx(ctx, r.db, func(tx *sql.Tx) error {
+		id, err := nextID(ctx, tx, "users")
+		if err != nil {
+			return err
+		}
+
+		user.ID = id
+
+		_, err = tx.ExecContext(ctx, "INSERT INTO users (id, username, password_hash, timezone) VALUES (?, ?, ?, ?)",
+			user.ID, user.Username, user.PasswordHash, user.Timezone)
+
+		return err
+	})
+
+	switch {
+	case isConstraint(err):
+		return nil, repository.ErrAlreadyExistsUser
+	case err != nil:
+		return nil, fmt.Errorf("%w: %w", repository.ErrCreateUser, err)
+	}
+
+	return &user, nil
+}
+
+func (r *UserRepository) Get(ctx context.Context, id uint) (*entity.User, error) {
+	user, err := scanUser(r.db.QueryRowContext(ctx, selectUsers+" WHERE id = ?", id))
+	if err != nil {
+		return nil, notFound(err, repository.ErrNotFoundUser)
+	}
+
+	return &user, nil
+}
+
+func (r *UserRepository) GetByUsername(ctx context.Context, username string) (*entity.User, error) {
+	user, err := scanUser(r.db.QueryRowContext(ctx, selectUsers+" WHERE username = ?", username))
+	if err != nil {
+		return nil, notFound(err, repository.ErrNotFoundUser)
+	}
+
+	return &user, nil
+}
+
+func (r *UserRepository) GetAll(ctx context.Context) (repository.Users, error) {
+	rows, err := r.db.QueryContext(ctx, selectUsers+" ORDER BY id")
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var users repository.Users
+
+	for rows.Next() {
+		user, err := scanUser(rows)
+		if err != nil {
+			return nil, err
+		}
+
+		users = append(users, user)
+	}
+
+	return users, rows.Err()
+}
+
+func (r *UserRepository) Update(ctx context.Context, user entity.User) (*entity.User, error) {
+	res, err := r.db.ExecContext(ctx, "UPDATE users SET username = ?, password_hash = ?, timezone = ? WHERE id = ?",
+		user.Username, user.PasswordHash, user.Timezone, user.ID)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", repository.ErrUpdateUser, err)
+	}
+
+	err = affected(res, repository.ErrUpdateUser)
+	if err != nil {
+		return nil, err
+	}
+
+	return &user, nil
+}
+
+func (r *UserRepository) Delete(ctx context.Context, id uint) error {
+	res, err := r.db.ExecContext(ctx, "DELETE FROM users WHERE id = ?", id)
+	if err != nil {
+		return fmt.Errorf("%w: %w", repository.ErrDeleteUser, err)
+	}
+
+	return affected(res, repository.ErrDeleteUser)
+}
+
+func (r *UserRepository) Restore(ctx context.Context, user entity.User) (*entity.User, error) {
+	if user.ID == 0 {
+		return nil, repository.ErrCreateUser
+	}
+
+	_, err := r.db.ExecContext(ctx, "INSERT INTO users (id, username, password_hash, timezone) VALUES (?, ?, ?, ?) "+
+		"ON CONFLICT (id) DO UPDATE SET username = excluded.username, password_hash = excluded.password_hash, timezone = excluded.timezone",
+		user.ID, user.Username, user.PasswordHash, user.Timezone)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", repository.ErrCreateUser, err)
+	}
+
+	return &user, nil
+}
+
+func scanUser(row scanner) (entity.User, error) {
+	var u entity.User
+
+	err := row.Scan(&u.ID, &u.Username, &u.PasswordHash, &u.Timezone)
+
+	return u, err
+}
@@ -0,0 +1,33 @@
+package sqlite_test
+
+import (
+	"context"
+	"testing"
+
+	"git.home/alex/go-subscriptions/internal/domain/entity"
+	"git.home/alex/go-subscriptions/internal/domain/repository"
+	"git.home/alex/go-subscriptions/internal/repository/sqlite"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUserRepository(t *testing.T) {
+	ctx := context.Background()
+	repo := sqlite.NewUserRepository(newDB(t))
+
+	user, err := repo.Create(ctx, entity.User{Username: "alice", PasswordHash: "hash", Timezone: "Europe/Berlin"})
+	require.NoError(t, err)
+
+	_, err = repo.Create(ctx, entity.User{Username: "alice", PasswordHash: "other"})
+	assert.Equal(t, repository.ErrAlreadyExistsUser, err)
+
+	got, err := repo.GetByUsername(ctx, "alice")
+	require.NoError(t, err)
+	assert.Equal(t, user, got)
+
+	_, err = repo.GetByUsername(ctx, "bob")
+	assert.Equal(t, repository.ErrNotFoundUser, err)
+
+	require.NoError(t, repo.Delete(ctx, user.ID))
+	assert.Equal(t, repository.ErrDeleteUser, repo.Delete(ctx, user.ID))
+}
@@ -0,0 +1,130 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	driver "modernc.org/sqlite"
+)
+
+// sqliteConstraint is the primary result code of every constraint violation.
+const sqliteConstraint = 19
+
+// isConstraint reports whether err is a violated UNIQUE, FOREIGN KEY or other constraint.
+func isConstraint(err error) bool {
+	var e *driver.Error
+
+	return errors.As(err, &e) && e.Code()&0xff == sqliteConstraint
+}
+
+// scanner is a *sql.Row or *sql.Rows.
+type scanner interface {
+	Scan(dest ...any) error
+}
+
+// inTx runs fn in a transaction that is committed when fn succeeds and rolled back otherwise.
+func inTx(ctx context.Context, db *sql.DB, fn func(tx *sql.Tx) error) error {
+	tx, err := db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+
+	err = fn(tx)
+	if err != nil {
+		_ = tx.Rollback()
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// nextID reserves an ID of table greater than every one handed out before, so the ID of a deleted
+// row, whose files may still be referenced somewhere, is never reused.
+func nextID(ctx context.Context, tx *sql.Tx, table string) (uint, error) {
+	maxID := "(SELECT coalesce(max(id), 0) FROM " + table + ")"
+
+	var id uint
+
+	err := tx.QueryRowContext(ctx,
+		"INSERT INTO id_sequences (name, last) VALUES (?, "+maxID+" + 1) "+
+			"ON CONFLICT (name) DO UPDATE SET last = max(last, "+maxID+") + 1 RETURNING last", table).Scan(&id)
+
+	return id, err
+}
+
+// notFound turns a missing row into errNotFound and returns any other failure as is.
+func notFound(err, errNotFound error) error {
+	if errors.Is(err, sql.ErrNoRows) {
+		return errNotFound
+	}
+
+	return err
+}
+
+// affected returns errMissing when res changed no row.
+func affected(res sql.Result, errMissing error) error {
+	n, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if n == 0 {
+		return errMissing
+	}
+
+	return nil
+}
+
+// nullID stores a zero reference as NULL, so it doesn't have to satisfy the foreign key.
+func nullID(id uint) sql.NullInt64 {
+	return sql.NullInt64{Int64: int64(id), Valid: id != 0}
+}
+
+// formatTime stores t as RFC 3339 text and the zero time as an empty string.
+func formatTime(t time.Time) string {
+	if t.IsZero() {
+		return ""
+	}
+
+	return t.Format(time.RFC3339Nano)
+}
+
+// timeValue scans the text written by formatTime.
+type timeValue struct {
+	t *time.Time
+}
+
+func scanTime(t *time.Time) timeValue {
+	return timeValue{t: t}
+}
+
+func (v timeValue) Scan(src any) error {
+	var s string
+
+	switch src := src.(type) {
+	case string:
+		s = src
+	case []byte:
+		s = string(src)
+	case nil:
+	default:
+		return fmt.Errorf("unsupported time value %T", src)
+	}
+
+	if s == "" {
+		*v.t = time.Time{}
+		return nil
+	}
+
+	t, err := time.Parse(time.RFC3339Nano, s)
+	if err != nil {
+		return err
+	}
+
+	*v.t = t
+
+	return nil
+}
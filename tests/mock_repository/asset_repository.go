@@ -0,0 +1,50 @@
+package mock_repository
+
+import (
+	"context"
+
+	"git.home/alex/go-subscriptions/internal/domain/entity"
+	"git.home/alex/go-subscriptions/internal/domain/repository"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockAssetRepository struct {
+	mock.Mock
+}
+
+func (m *MockAssetRepository) Create(ctx context.Context, asset entity.Asset) (*entity.Asset, error) {
+	args := m.Called(ctx, asset)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entity.Asset), args.Error(1)
+}
+
+func (m *MockAssetRepository) Get(ctx context.Context, id uint) (*entity.Asset, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entity.Asset), args.Error(1)
+}
+
+func (m *MockAssetRepository) GetAll(ctx context.Context) (repository.Assets, error) {
+	args := m.Called(ctx)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(repository.Assets), args.Error(1)
+}
+
+func (m *MockAssetRepository) Delete(ctx context.Context, id uint) error {
+	args := m.Called(ctx, id)
+	return args.Error(0)
+}
+
+func (m *MockAssetRepository) Restore(ctx context.Context, asset entity.Asset) (*entity.Asset, error) {
+	args := m.Called(ctx, asset)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entity.Asset), args.Error(1)
+}
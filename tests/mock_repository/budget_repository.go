@@ -0,0 +1,58 @@
+package mock_repository
+
+import (
+	"context"
+
+	"git.home/alex/go-subscriptions/internal/domain/entity"
+	"git.home/alex/go-subscriptions/internal/domain/repository"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockBudgetRepository struct {
+	mock.Mock
+}
+
+func (m *MockBudgetRepository) Create(ctx context.Context, budget entity.Budget) (*entity.Budget, error) {
+	args := m.Called(ctx, budget)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entity.Budget), args.Error(1)
+}
+
+func (m *MockBudgetRepository) Get(ctx context.Context, id uint) (*entity.Budget, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entity.Budget), args.Error(1)
+}
+
+func (m *MockBudgetRepository) GetAll(ctx context.Context) (repository.Budgets, error) {
+	args := m.Called(ctx)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(repository.Budgets), args.Error(1)
+}
+
+func (m *MockBudgetRepository) Update(ctx context.Context, budget entity.Budget) (*entity.Budget, error) {
+	args := m.Called(ctx, budget)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entity.Budget), args.Error(1)
+}
+
+func (m *MockBudgetRepository) Delete(ctx context.Context, id uint) error {
+	args := m.Called(ctx, id)
+	return args.Error(0)
+}
+
+func (m *MockBudgetRepository) Restore(ctx context.Context, budget entity.Budget) (*entity.Budget, error) {
+	args := m.Called(ctx, budget)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entity.Budget), args.Error(1)
+}
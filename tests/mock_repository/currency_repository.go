@@ -48,3 +48,11 @@ func (m *MockCurrencyRepository) Delete(ctx context.Context, code string) error
 	args := m.Called(ctx, code)
 	return args.Error(0)
 }
+
+func (m *MockCurrencyRepository) Restore(ctx context.Context, currency entity.Currency) (*entity.Currency, error) {
+	args := m.Called(ctx, currency)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entity.Currency), args.Error(1)
+}
@@ -48,3 +48,11 @@ func (m *MockCycleRepository) Delete(ctx context.Context, id uint) error {
 	args := m.Called(ctx, id)
 	return args.Error(0)
 }
+
+func (m *MockCycleRepository) Restore(ctx context.Context, cycle entity.Cycle) (*entity.Cycle, error) {
+	args := m.Called(ctx, cycle)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entity.Cycle), args.Error(1)
+}
@@ -0,0 +1,58 @@
+package mock_repository
+
+import (
+	"context"
+
+	"git.home/alex/go-subscriptions/internal/domain/entity"
+	"git.home/alex/go-subscriptions/internal/domain/repository"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockHouseholdRepository struct {
+	mock.Mock
+}
+
+func (m *MockHouseholdRepository) Create(ctx context.Context, household entity.Household) (*entity.Household, error) {
+	args := m.Called(ctx, household)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entity.Household), args.Error(1)
+}
+
+func (m *MockHouseholdRepository) Get(ctx context.Context, id uint) (*entity.Household, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entity.Household), args.Error(1)
+}
+
+func (m *MockHouseholdRepository) GetAll(ctx context.Context) (repository.Households, error) {
+	args := m.Called(ctx)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(repository.Households), args.Error(1)
+}
+
+func (m *MockHouseholdRepository) Update(ctx context.Context, household entity.Household) (*entity.Household, error) {
+	args := m.Called(ctx, household)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entity.Household), args.Error(1)
+}
+
+func (m *MockHouseholdRepository) Delete(ctx context.Context, id uint) error {
+	args := m.Called(ctx, id)
+	return args.Error(0)
+}
+
+func (m *MockHouseholdRepository) Restore(ctx context.Context, household entity.Household) (*entity.Household, error) {
+	args := m.Called(ctx, household)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entity.Household), args.Error(1)
+}
@@ -0,0 +1,58 @@
+package mock_repository
+
+import (
+	"context"
+
+	"git.home/alex/go-subscriptions/internal/domain/entity"
+	"git.home/alex/go-subscriptions/internal/domain/repository"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockPaymentMethodRepository struct {
+	mock.Mock
+}
+
+func (m *MockPaymentMethodRepository) Create(ctx context.Context, paymentMethod entity.PaymentMethod) (*entity.PaymentMethod, error) {
+	args := m.Called(ctx, paymentMethod)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entity.PaymentMethod), args.Error(1)
+}
+
+func (m *MockPaymentMethodRepository) Get(ctx context.Context, id uint) (*entity.PaymentMethod, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entity.PaymentMethod), args.Error(1)
+}
+
+func (m *MockPaymentMethodRepository) GetAll(ctx context.Context) (repository.PaymentMethods, error) {
+	args := m.Called(ctx)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(repository.PaymentMethods), args.Error(1)
+}
+
+func (m *MockPaymentMethodRepository) Update(ctx context.Context, paymentMethod entity.PaymentMethod) (*entity.PaymentMethod, error) {
+	args := m.Called(ctx, paymentMethod)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entity.PaymentMethod), args.Error(1)
+}
+
+func (m *MockPaymentMethodRepository) Delete(ctx context.Context, id uint) error {
+	args := m.Called(ctx, id)
+	return args.Error(0)
+}
+
+func (m *MockPaymentMethodRepository) Restore(ctx context.Context, paymentMethod entity.PaymentMethod) (*entity.PaymentMethod, error) {
+	args := m.Called(ctx, paymentMethod)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entity.PaymentMethod), args.Error(1)
+}
@@ -48,3 +48,11 @@ func (m *MockSubscriptionRepository) Delete(ctx context.Context, id uint) error
 	args := m.Called(ctx, id)
 	return args.Error(0)
 }
+
+func (m *MockSubscriptionRepository) Restore(ctx context.Context, subscription entity.Subscription) (*entity.Subscription, error) {
+	args := m.Called(ctx, subscription)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entity.Subscription), args.Error(1)
+}